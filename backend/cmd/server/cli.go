@@ -0,0 +1,700 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/api"
+	"github.com/bpg/swimstats/backend/internal/domain/backup"
+	"github.com/bpg/swimstats/backend/internal/domain/importer"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
+	"github.com/bpg/swimstats/backend/internal/migrate"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// command is an administrative subcommand of the server binary. Subcommands
+// run the domain services directly against DATABASE_URL, without the API.
+type command struct {
+	name  string
+	args  string
+	short string
+	run   func(ctx context.Context, c *cli, args []string) error
+}
+
+var commands = []command{
+	{"migrate", "", "Run the database migrations", nil},
+	{"check", "[--json]", "Check the database connection, migrations and data", runCheck},
+	{"export", "[--swimmer ID] [--owner ID] [--output FILE]", "Export a swimmer's data as JSON", runExport},
+	{"import", "[--swimmer ID] [--owner ID] [--mode MODE] [--update] [--dry-run] [--json] FILE", "Import a JSON data file (- for stdin)", runImport},
+	{"standards import", "[--mode MODE] [--dry-run] [--json] FILE...", "Import time standards from JSON files", runStandardsImport},
+	{"standards list", "[--course-type COURSE] [--gender GENDER] [--owner ID] [--json]", "List time standards", runStandardsList},
+	{"swimmer show", "[--json] [ID]", "Show a swimmer, or list the swimmers of all users", runSwimmerShow},
+	{"claim", "--owner ID [--json]", "Assign data recorded before multi-user support to a user", runClaim},
+}
+
+// errUsage reports invalid command line arguments.
+var errUsage = errors.New("usage")
+
+// cli holds the state of a subcommand run.
+type cli struct {
+	cfg      Config
+	logger   *slog.Logger
+	stdin    io.Reader
+	stdout   io.Writer
+	stderr   io.Writer
+	db       *postgres.DB
+	services *api.Services
+}
+
+// runCommand runs the subcommand named by the arguments and returns the
+// exit code: 0 on success, 1 on failure and 2 for invalid arguments.
+func runCommand(cfg Config, args []string) int {
+	return run(cfg, args, os.Stdin, os.Stdout, os.Stderr)
+}
+
+// run runs a subcommand as runCommand does, with the given standard streams.
+func run(cfg Config, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	cmd, rest := findCommand(args)
+	if cmd == nil {
+		if len(args) > 0 && args[0] != "help" && args[0] != "--help" && args[0] != "-h" {
+			fmt.Fprintf(stderr, "unknown command: %s\n\n", strings.Join(args, " "))
+			printUsage(stderr)
+			return 2
+		}
+		printUsage(stdout)
+		return 0
+	}
+
+	// Logs go to stderr so that output can be piped
+	c := &cli{
+		cfg:    cfg,
+		logger: slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: slog.LevelWarn})),
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+	}
+	defer c.close()
+
+	if err := cmd.run(context.Background(), c, rest); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			if !errors.Is(err, flag.ErrHelp) {
+				fmt.Fprintf(stderr, "usage: %s %s %s\n", os.Args[0], cmd.name, cmd.args)
+			}
+			return 2
+		}
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// isCommand reports whether the arguments name a subcommand, or ask for help.
+// Other arguments are left to the server.
+func isCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "help", "--help", "-h":
+		return true
+	}
+	for _, cmd := range commands {
+		if cmd.run != nil && strings.Fields(cmd.name)[0] == args[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// findCommand returns the subcommand named by the first one or two
+// arguments, with the remaining arguments.
+func findCommand(args []string) (*command, []string) {
+	for i := range commands {
+		cmd := &commands[i]
+		words := strings.Fields(cmd.name)
+		if cmd.run == nil || len(args) < len(words) {
+			continue
+		}
+		if strings.Join(args[:len(words)], " ") == cmd.name {
+			return cmd, args[len(words):]
+		}
+	}
+	return nil, nil
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "usage: %s [command]\n\nWithout a command, the API server is started.\n\nCommands:\n", os.Args[0])
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %s\n        %s\n", strings.TrimSpace(cmd.name+" "+cmd.args), cmd.short)
+	}
+}
+
+// connect opens the database and creates the services.
+func (c *cli) connect(ctx context.Context) error {
+	db, err := postgres.NewFromDSN(ctx, c.cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	c.db = db
+	c.services = api.NewServices(db.Pool, backup.DefaultConfig())
+	return nil
+}
+
+func (c *cli) close() {
+	if c.db != nil {
+		c.db.Close()
+	}
+}
+
+// flags returns the flag set of a subcommand with the --json flag.
+func (c *cli) flags(name string) (*flag.FlagSet, *bool) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	asJSON := fs.Bool("json", false, "print JSON instead of text")
+	return fs, asJSON
+}
+
+// parseFlags parses the arguments of a subcommand, reporting invalid flags
+// as usage errors.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	return err
+}
+
+// print writes v as indented JSON, or as text with the human function.
+func (c *cli) print(asJSON bool, v any, human func(w io.Writer)) error {
+	if asJSON {
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	tw := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	human(tw)
+	return tw.Flush()
+}
+
+// checkResult reports the state of the installation.
+type checkResult struct {
+	OK               bool          `json:"ok"`
+	Database         string        `json:"database"`
+	MigrationVersion uint          `json:"migration_version"`
+	LatestMigration  uint          `json:"latest_migration"`
+	Dirty            bool          `json:"dirty"`
+	Swimmers         int           `json:"swimmers"`
+	Standards        int           `json:"standards"`
+	Backups          *backupStatus `json:"backups,omitempty"`
+	Problems         []string      `json:"problems"`
+}
+
+type backupStatus struct {
+	Dir    string       `json:"dir"`
+	Count  int          `json:"count"`
+	Latest *backup.Info `json:"latest,omitempty"`
+}
+
+// runCheck checks that the database is reachable and migrated, failing
+// otherwise, and reports the amount of data and the backups.
+func runCheck(ctx context.Context, c *cli, args []string) error {
+	fs, asJSON := c.flags("check")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errUsage
+	}
+
+	result := &checkResult{Database: "ok", Problems: []string{}}
+	c.check(ctx, result)
+	result.OK = len(result.Problems) == 0
+
+	if err := c.print(*asJSON, result, func(w io.Writer) {
+		fmt.Fprintf(w, "Database:\t%s\n", result.Database)
+		if result.LatestMigration > 0 {
+			fmt.Fprintf(w, "Migrations:\t%d of %d\n", result.MigrationVersion, result.LatestMigration)
+			fmt.Fprintf(w, "Swimmers:\t%d\n", result.Swimmers)
+			fmt.Fprintf(w, "Standards:\t%d\n", result.Standards)
+		}
+		if b := result.Backups; b != nil {
+			latest := "none"
+			if b.Latest != nil {
+				latest = b.Latest.Name
+			}
+			fmt.Fprintf(w, "Backups:\t%d in %s, latest %s\n", b.Count, b.Dir, latest)
+		}
+		for _, problem := range result.Problems {
+			fmt.Fprintf(w, "Problem:\t%s\n", problem)
+		}
+	}); err != nil {
+		return err
+	}
+	if !result.OK {
+		return fmt.Errorf("check failed: %s", strings.Join(result.Problems, "; "))
+	}
+	return nil
+}
+
+// check fills in the result, stopping at the first problem.
+func (c *cli) check(ctx context.Context, result *checkResult) {
+	if err := c.connect(ctx); err != nil {
+		result.Database = err.Error()
+		result.Problems = append(result.Problems, "database is not reachable")
+		return
+	}
+
+	version, latest, dirty, err := migrate.Status(c.cfg.DatabaseURL, c.logger)
+	if err != nil {
+		result.Problems = append(result.Problems, fmt.Sprintf("cannot read migrations: %v", err))
+		return
+	}
+	result.MigrationVersion, result.LatestMigration, result.Dirty = version, latest, dirty
+	switch {
+	case dirty:
+		result.Problems = append(result.Problems, fmt.Sprintf("migration %d failed halfway, manual intervention required", version))
+		return
+	case version < latest:
+		result.Problems = append(result.Problems, fmt.Sprintf("%d migration(s) pending, run migrate", latest-version))
+		return
+	}
+
+	swimmers, err := c.services.Swimmers.ListAll(ctx)
+	if err != nil {
+		result.Problems = append(result.Problems, fmt.Sprintf("cannot list swimmers: %v", err))
+		return
+	}
+	result.Swimmers = len(swimmers)
+	unowned := 0
+	for _, sw := range swimmers {
+		if sw.OwnerID == "" {
+			unowned++
+		}
+	}
+	if unowned > 0 {
+		result.Problems = append(result.Problems, fmt.Sprintf("%d swimmer(s) have no owner, run claim --owner ID", unowned))
+	}
+	standards, err := c.services.Standards.List(ctx, "", standard.ListParams{})
+	if err != nil {
+		result.Problems = append(result.Problems, fmt.Sprintf("cannot list standards: %v", err))
+		return
+	}
+	result.Standards = len(standards.Standards)
+
+	if cfg := backup.DefaultConfig(); cfg.Enabled() {
+		result.Backups = &backupStatus{Dir: cfg.Dir}
+		list, err := c.services.Backups.List()
+		if err != nil {
+			result.Problems = append(result.Problems, fmt.Sprintf("cannot list backups: %v", err))
+			return
+		}
+		result.Backups.Count = list.Total
+		if list.Total > 0 {
+			result.Backups.Latest = &list.Backups[0]
+		}
+	}
+}
+
+// runExport writes the JSON export of a swimmer to stdout or a file.
+func runExport(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	swimmerFlag := fs.String("swimmer", "", "ID of the swimmer, required if there are several")
+	ownerFlag := fs.String("owner", "", "user ID whose default swimmer to export")
+	output := fs.String("output", "", "file to write instead of stdout")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errUsage
+	}
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+
+	sw, err := c.exportedSwimmer(ctx, *swimmerFlag, *ownerFlag)
+	if err != nil {
+		return err
+	}
+	data, err := c.services.Exports.ExportAll(ctx, sw.OwnerID, sw.ID)
+	if err != nil {
+		return err
+	}
+
+	out := c.stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+
+	if *output != "" {
+		times := 0
+		for _, m := range data.Meets {
+			times += len(m.Times)
+		}
+		fmt.Fprintf(c.stdout, "Exported %s: %d meet(s), %d time(s), %d standard(s) to %s\n",
+			data.Swimmer.Name, len(data.Meets), times, len(data.Standards), *output)
+	}
+	return nil
+}
+
+// exportedSwimmer returns the swimmer with the given ID, the owner's default
+// swimmer, or the only swimmer there is. Exports only read, so the lookup
+// never changes any data.
+func (c *cli) exportedSwimmer(ctx context.Context, swimmerFlag, ownerFlag string) (*swimmer.OwnedSwimmer, error) {
+	swimmers, err := c.services.Swimmers.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if swimmerFlag == "" && ownerFlag != "" {
+		// Each owner's default swimmer is listed first
+		for i := range swimmers {
+			if swimmers[i].OwnerID == ownerFlag {
+				return &swimmers[i], nil
+			}
+		}
+		return nil, fmt.Errorf("user %s has no swimmer", ownerFlag)
+	}
+	if swimmerFlag == "" {
+		if len(swimmers) != 1 {
+			return nil, fmt.Errorf("there are %d swimmers, choose one with --swimmer (see swimmer show)", len(swimmers))
+		}
+		return &swimmers[0], nil
+	}
+	return findSwimmer(swimmers, swimmerFlag)
+}
+
+// findSwimmer returns the swimmer of an ID.
+func findSwimmer(swimmers []swimmer.OwnedSwimmer, id string) (*swimmer.OwnedSwimmer, error) {
+	swimmerID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid swimmer ID: %s", id)
+	}
+	for i := range swimmers {
+		if swimmers[i].ID == swimmerID {
+			return &swimmers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("swimmer %s not found", id)
+}
+
+// runImport imports a JSON data file as the API's data import does.
+func runImport(ctx context.Context, c *cli, args []string) error {
+	fs, asJSON := c.flags("import")
+	swimmerFlag := fs.String("swimmer", "", "ID of the swimmer to import into")
+	ownerFlag := fs.String("owner", "", "user ID whose default swimmer to import into, created from the file if needed")
+	mode := fs.String("mode", string(importer.ModeReplace), "replace, append or merge")
+	update := fs.Bool("update", false, "in merge mode, update records that differ")
+	dryRun := fs.Bool("dry-run", false, "import and roll back, reporting what the import would do")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	raw, err := c.readFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	var data importer.ImportData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("invalid data file: %w", err)
+	}
+
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+	ownerID, swimmerID, err := c.importTarget(ctx, *swimmerFlag, *ownerFlag)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.services.Imports.ImportSwimmerData(ctx, ownerID, swimmerID, &data, importer.ImportOptions{
+		Mode:   importer.Mode(*mode),
+		DryRun: *dryRun,
+		Update: *update,
+	})
+	if printErr := c.print(*asJSON, result, func(w io.Writer) {
+		printImportResult(w, result)
+	}); printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+// importTarget returns the owner and swimmer an import goes to: the given
+// swimmer, the owner's default swimmer, or the default swimmer of the only
+// user with swimmers.
+func (c *cli) importTarget(ctx context.Context, swimmerFlag, ownerFlag string) (string, *uuid.UUID, error) {
+	if swimmerFlag == "" && ownerFlag != "" {
+		return ownerFlag, nil, nil
+	}
+
+	swimmers, err := c.services.Swimmers.ListAll(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	if swimmerFlag != "" {
+		sw, err := findSwimmer(swimmers, swimmerFlag)
+		if err != nil {
+			return "", nil, err
+		}
+		return sw.OwnerID, &sw.ID, nil
+	}
+
+	owners := make(map[string]bool)
+	for _, sw := range swimmers {
+		owners[sw.OwnerID] = true
+	}
+	if len(owners) != 1 {
+		return "", nil, fmt.Errorf("%d users have swimmers, choose the target with --swimmer or --owner (see swimmer show)", len(owners))
+	}
+	return swimmers[0].OwnerID, nil, nil
+}
+
+func printImportResult(w io.Writer, result *importer.ImportResult) {
+	if result == nil {
+		return
+	}
+	if result.DryRun {
+		fmt.Fprintln(w, "Dry run, nothing was changed")
+	}
+	if result.SwimmerName != "" {
+		fmt.Fprintf(w, "Swimmer:\t%s (%s)\n", result.SwimmerName, result.SwimmerID)
+	}
+	fmt.Fprintf(w, "Meets:\t%d created, %d deleted\n", result.MeetsCreated, result.MeetsDeleted)
+	fmt.Fprintf(w, "Times:\t%d created, %d skipped\n", result.TimesCreated, result.SkippedTimes)
+	fmt.Fprintf(w, "Standards:\t%d created, %d deleted\n", result.StandardsCreated, result.StandardsDeleted)
+	if m := result.Merge; m != nil {
+		fmt.Fprintf(w, "Merged meets:\t%d matched, %d updated, %d conflicting\n", m.Meets.Matched, m.Meets.Updated, m.Meets.Conflicting)
+		fmt.Fprintf(w, "Merged times:\t%d matched, %d updated, %d conflicting\n", m.Times.Matched, m.Times.Updated, m.Times.Conflicting)
+		fmt.Fprintf(w, "Merged standards:\t%d matched, %d updated, %d conflicting\n", m.Standards.Matched, m.Standards.Updated, m.Standards.Conflicting)
+		for _, conflict := range m.Conflicts {
+			fmt.Fprintf(w, "Conflict:\t%s\n", conflict)
+		}
+	}
+	for _, reason := range result.SkippedReason {
+		fmt.Fprintf(w, "Skipped:\t%s\n", reason)
+	}
+	for _, e := range result.Errors {
+		fmt.Fprintf(w, "Error:\t%s\n", e)
+	}
+}
+
+// standardsImport is the result of importing a standards file.
+type standardsImport struct {
+	File   string                     `json:"file"`
+	Result *standard.JSONImportResult `json:"result,omitempty"`
+	Error  string                     `json:"error,omitempty"`
+}
+
+// runStandardsImport imports standards files as the API's JSON standards
+// import does, each in its own transaction. Failing files do not stop the
+// others but fail the command.
+func runStandardsImport(ctx context.Context, c *cli, args []string) error {
+	fs, asJSON := c.flags("standards import")
+	mode := fs.String("mode", string(standard.ImportModeSkip), "skip, replace or merge existing standards")
+	dryRun := fs.Bool("dry-run", false, "report the changes without making them")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errUsage
+	}
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+
+	imports := make([]standardsImport, 0, fs.NArg())
+	failed := 0
+	for _, file := range fs.Args() {
+		imported := standardsImport{File: file}
+		result, err := c.importStandards(ctx, file, standard.JSONImportOptions{
+			Mode:   standard.ImportMode(*mode),
+			DryRun: *dryRun,
+		})
+		if err != nil {
+			imported.Error = err.Error()
+			failed++
+		}
+		imported.Result = result
+		imports = append(imports, imported)
+	}
+
+	if err := c.print(*asJSON, imports, func(w io.Writer) {
+		for _, imported := range imports {
+			if imported.Error != "" {
+				fmt.Fprintf(w, "%s:\tfailed: %s\n", imported.File, imported.Error)
+				continue
+			}
+			r := imported.Result
+			fmt.Fprintf(w, "%s:\t%d imported, %d updated, %d unchanged, %d skipped\n",
+				imported.File, r.Imported, r.Updated, r.Unchanged, r.Skipped)
+			for _, e := range r.Errors {
+				fmt.Fprintf(w, "\terror: %s\n", e)
+			}
+		}
+		if *dryRun {
+			fmt.Fprintln(w, "Dry run, nothing was changed")
+		}
+	}); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d file(s) failed to import", failed, len(imports))
+	}
+	return nil
+}
+
+func (c *cli) importStandards(ctx context.Context, file string, opts standard.JSONImportOptions) (*standard.JSONImportResult, error) {
+	raw, err := c.readFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var input standard.JSONFileInput
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return nil, fmt.Errorf("invalid JSON file format: %w", err)
+	}
+	return c.services.Standards.ImportFromJSON(ctx, "", input, opts)
+}
+
+// runStandardsList lists the time standards.
+func runStandardsList(ctx context.Context, c *cli, args []string) error {
+	fs, asJSON := c.flags("standards list")
+	courseType := fs.String("course-type", "", "only standards of this course (25m, 50m or 25y)")
+	gender := fs.String("gender", "", "only standards of this gender (female or male)")
+	owner := fs.String("owner", "", "only shared standards and those of this user")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errUsage
+	}
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+
+	var params standard.ListParams
+	if *courseType != "" {
+		params.CourseType = courseType
+	}
+	if *gender != "" {
+		params.Gender = gender
+	}
+	list, err := c.services.Standards.List(ctx, *owner, params)
+	if err != nil {
+		return err
+	}
+
+	return c.print(*asJSON, list, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tCOURSE\tGENDER\tPRELOADED\tOWNER")
+		for _, std := range list.Standards {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%s\n", std.ID, std.Name, std.CourseType, std.Gender, std.IsPreloaded, std.OwnerID)
+		}
+	})
+}
+
+// ownedSwimmerJSON is a swimmer with its owner as printed.
+type ownedSwimmerJSON struct {
+	OwnerID string `json:"owner_id"`
+	swimmer.Swimmer
+}
+
+// runSwimmerShow shows a swimmer, or lists the swimmers of all users.
+func runSwimmerShow(ctx context.Context, c *cli, args []string) error {
+	fs, asJSON := c.flags("swimmer show")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return errUsage
+	}
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+
+	swimmers, err := c.services.Swimmers.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		list := make([]ownedSwimmerJSON, len(swimmers))
+		for i, sw := range swimmers {
+			list[i] = ownedSwimmerJSON{OwnerID: sw.OwnerID, Swimmer: sw.Swimmer}
+		}
+		return c.print(*asJSON, list, func(w io.Writer) {
+			fmt.Fprintln(w, "ID\tNAME\tBIRTH DATE\tGENDER\tAGE GROUP\tOWNER")
+			for _, sw := range swimmers {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", sw.ID, sw.Name, sw.BirthDate, sw.Gender, sw.CurrentAgeGroup, sw.OwnerID)
+			}
+		})
+	}
+
+	sw, err := findSwimmer(swimmers, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return c.print(*asJSON, ownedSwimmerJSON{OwnerID: sw.OwnerID, Swimmer: sw.Swimmer}, func(w io.Writer) {
+		fmt.Fprintf(w, "ID:\t%s\n", sw.ID)
+		fmt.Fprintf(w, "Name:\t%s\n", sw.Name)
+		fmt.Fprintf(w, "Birth date:\t%s\n", sw.BirthDate)
+		fmt.Fprintf(w, "Gender:\t%s\n", sw.Gender)
+		fmt.Fprintf(w, "Age:\t%d (%s)\n", sw.CurrentAge, sw.CurrentAgeGroup)
+		fmt.Fprintf(w, "Threshold:\t%.1f%%\n", sw.ThresholdPercent)
+		fmt.Fprintf(w, "Season start:\t%s\n", sw.SeasonStart)
+		fmt.Fprintf(w, "Owner:\t%s\n", sw.OwnerID)
+	})
+}
+
+// runClaim assigns the swimmers and meets that have no owner to a user.
+func runClaim(ctx context.Context, c *cli, args []string) error {
+	fs, asJSON := c.flags("claim")
+	owner := fs.String("owner", "", "user ID (OIDC subject) to assign the data to")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 || *owner == "" {
+		return errUsage
+	}
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+
+	result, err := c.services.Swimmers.ClaimUnowned(ctx, *owner)
+	if err != nil {
+		return err
+	}
+	return c.print(*asJSON, result, func(w io.Writer) {
+		fmt.Fprintf(w, "Assigned %d swimmer(s) and %d meet(s) to %s\n", result.Swimmers, result.Meets, result.OwnerID)
+	})
+}
+
+// readFile reads a file, or stdin for "-".
+func (c *cli) readFile(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(c.stdin)
+	}
+	return os.ReadFile(name)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/swimstats/backend/internal/domain/importer"
+	"github.com/bpg/swimstats/backend/tests/integration"
+)
+
+// cliRun is the outcome of a subcommand run.
+type cliRun struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+// runCLI runs a subcommand against the database of cfg with stdin as input.
+func runCLI(cfg Config, stdin string, args ...string) cliRun {
+	var stdout, stderr bytes.Buffer
+	code := run(cfg, args, strings.NewReader(stdin), &stdout, &stderr)
+	return cliRun{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+func TestIsCommand(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{nil, false},
+		{[]string{"serve"}, false},
+		{[]string{"--port=8080"}, false},
+		{[]string{"help"}, true},
+		{[]string{"-h"}, true},
+		{[]string{"check"}, true},
+		{[]string{"standards"}, true},
+		{[]string{"standards", "bogus"}, true},
+		{[]string{"swimmer", "show"}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			assert.Equal(t, tc.want, isCommand(tc.args))
+		})
+	}
+}
+
+func TestCommandArguments(t *testing.T) {
+	// None of these reach the database
+	cfg := Config{DatabaseURL: "postgres://invalid"}
+
+	t.Run("help lists the commands", func(t *testing.T) {
+		r := runCLI(cfg, "", "help")
+		assert.Equal(t, 0, r.code)
+		assert.Contains(t, r.stdout, "Commands:")
+		assert.Contains(t, r.stdout, "standards import")
+	})
+
+	t.Run("unknown commands are usage errors", func(t *testing.T) {
+		r := runCLI(cfg, "", "standards", "bogus")
+		assert.Equal(t, 2, r.code)
+		assert.Contains(t, r.stderr, "unknown command: standards bogus")
+	})
+
+	usageErrors := []struct {
+		name string
+		args []string
+	}{
+		{"export takes no arguments", []string{"export", "extra"}},
+		{"import needs a file", []string{"import"}},
+		{"import takes one file", []string{"import", "a.json", "b.json"}},
+		{"import flags need values", []string{"import", "--mode"}},
+		{"standards import needs files", []string{"standards", "import"}},
+		{"standards list takes no arguments", []string{"standards", "list", "extra"}},
+		{"swimmer show takes one ID", []string{"swimmer", "show", "a", "b"}},
+		{"claim needs an owner", []string{"claim"}},
+		{"unknown flags", []string{"check", "--bogus"}},
+	}
+	for _, tc := range usageErrors {
+		t.Run(tc.name, func(t *testing.T) {
+			r := runCLI(cfg, "", tc.args...)
+			assert.Equal(t, 2, r.code)
+			assert.Contains(t, r.stderr, "usage:")
+		})
+	}
+
+	t.Run("invalid data files fail", func(t *testing.T) {
+		r := runCLI(cfg, "not json", "import", "-")
+		assert.Equal(t, 1, r.code)
+		assert.Contains(t, r.stderr, "invalid data file")
+	})
+}
+
+const cliData = `{
+	"swimmer": {"name": "CLI Swimmer", "birth_date": "2012-05-15", "gender": "female"},
+	"meets": [{
+		"name": "CLI Meet", "city": "Toronto", "country": "Canada",
+		"start_date": "2026-01-10", "end_date": "2026-01-10", "course_type": "25m",
+		"times": [{"event": "50FR", "time": "30.10", "event_date": "2026-01-10"}]
+	}]
+}`
+
+func TestCommands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	testDB := integration.NewTestDB(t)
+	defer testDB.Close()
+	testDB.CleanTables(t)
+
+	cfg := Config{DatabaseURL: testDB.DSN}
+	const owner = "cli-owner"
+
+	swimmers := func(t *testing.T) []ownedSwimmerJSON {
+		t.Helper()
+		r := runCLI(cfg, "", "swimmer", "show", "--json")
+		require.Equal(t, 0, r.code, r.stderr)
+		var list []ownedSwimmerJSON
+		require.NoError(t, json.Unmarshal([]byte(r.stdout), &list))
+		return list
+	}
+
+	t.Run("import dry run changes nothing", func(t *testing.T) {
+		r := runCLI(cfg, cliData, "import", "--owner", owner, "--dry-run", "--json", "-")
+		require.Equal(t, 0, r.code, r.stderr)
+
+		var result importer.ImportResult
+		require.NoError(t, json.Unmarshal([]byte(r.stdout), &result))
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 1, result.MeetsCreated)
+		assert.Equal(t, 1, result.TimesCreated)
+
+		assert.Empty(t, swimmers(t))
+	})
+
+	t.Run("import imports for the owner", func(t *testing.T) {
+		r := runCLI(cfg, cliData, "import", "--owner", owner, "--json", "-")
+		require.Equal(t, 0, r.code, r.stderr)
+
+		list := swimmers(t)
+		require.Len(t, list, 1)
+		assert.Equal(t, "CLI Swimmer", list[0].Name)
+		assert.Equal(t, owner, list[0].OwnerID)
+	})
+
+	t.Run("export writes the owner's swimmer", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "export.json")
+		r := runCLI(cfg, "", "export", "--owner", owner, "--output", output)
+		require.Equal(t, 0, r.code, r.stderr)
+		assert.Contains(t, r.stdout, "Exported CLI Swimmer: 1 meet(s), 1 time(s)")
+
+		raw, err := os.ReadFile(output)
+		require.NoError(t, err)
+		var data struct {
+			Swimmer struct {
+				Name string `json:"name"`
+			} `json:"swimmer"`
+		}
+		require.NoError(t, json.Unmarshal(raw, &data))
+		assert.Equal(t, "CLI Swimmer", data.Swimmer.Name)
+	})
+
+	t.Run("export never claims legacy data", func(t *testing.T) {
+		testDB.ExecSQL(t, `INSERT INTO swimmers (name, birth_date, gender) VALUES ('Legacy', '2012-05-15', 'female')`)
+
+		r := runCLI(cfg, "", "export", "--owner", "cli-other")
+		assert.Equal(t, 1, r.code)
+		assert.Contains(t, r.stderr, "user cli-other has no swimmer")
+
+		var unowned int
+		require.NoError(t, testDB.Pool.QueryRow(context.Background(),
+			`SELECT COUNT(*) FROM swimmers WHERE owner_id = ''`).Scan(&unowned))
+		assert.Equal(t, 1, unowned)
+	})
+
+	t.Run("claim assigns legacy data", func(t *testing.T) {
+		r := runCLI(cfg, "", "claim", "--owner", "cli-other", "--json")
+		require.Equal(t, 0, r.code, r.stderr)
+
+		var result struct {
+			Swimmers int `json:"swimmers"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(r.stdout), &result))
+		assert.Equal(t, 1, result.Swimmers)
+	})
+
+	t.Run("standards import reads each file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "standards.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{
+			"source": "CLI Test", "course_type": "25m", "gender": "female",
+			"standards": {"PROV": {"name": "CLI Standard"}},
+			"times": {"50FR": {"OPEN": {"PROV": "0:28.00"}}}
+		}`), 0o600))
+
+		r := runCLI(cfg, "", "standards", "import", "--json", file, filepath.Join(t.TempDir(), "missing.json"))
+		assert.Equal(t, 1, r.code)
+		assert.Contains(t, r.stderr, "1 of 2 file(s) failed to import")
+
+		var imports []standardsImport
+		require.NoError(t, json.Unmarshal([]byte(r.stdout), &imports))
+		require.Len(t, imports, 2)
+		assert.Empty(t, imports[0].Error)
+		assert.Equal(t, 1, imports[0].Result.Imported)
+		assert.NotEmpty(t, imports[1].Error)
+
+		r = runCLI(cfg, "", "standards", "list", "--course-type", "25m")
+		require.Equal(t, 0, r.code, r.stderr)
+		assert.Contains(t, r.stdout, "CLI Standard")
+	})
+}
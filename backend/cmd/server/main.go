@@ -13,6 +13,7 @@ import (
 
 	"github.com/bpg/swimstats/backend/internal/api"
 	"github.com/bpg/swimstats/backend/internal/auth"
+	"github.com/bpg/swimstats/backend/internal/domain/backup"
 	"github.com/bpg/swimstats/backend/internal/migrate"
 	"github.com/bpg/swimstats/backend/internal/store/postgres"
 )
@@ -92,6 +93,12 @@ func main() {
 		runMigrations(cfg, logger)
 		return
 	}
+
+	// Handle administrative subcommands
+	if isCommand(os.Args[1:]) {
+		os.Exit(runCommand(cfg, os.Args[1:]))
+	}
+
 	logger.Info("starting server",
 		"port", cfg.Port,
 		"environment", cfg.Environment,
@@ -125,7 +132,10 @@ func main() {
 	}
 
 	// Create router with dependencies
-	router := api.NewRouter(logger, authProvider, db.Pool)
+	router := api.NewRouter(logger, authProvider, db.Pool, backup.DefaultConfig())
+
+	// Take scheduled backups if a backup directory is configured
+	go router.Services().Backups.Run(ctx, logger)
 
 	// Create HTTP server
 	server := &http.Server{
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/api/middleware"
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// AgeGroupHandler handles age-group scheme API requests.
+type AgeGroupHandler struct {
+	service *agegroup.Service
+	logger  *slog.Logger
+}
+
+// NewAgeGroupHandler creates a new age-group scheme handler.
+func NewAgeGroupHandler(service *agegroup.Service, logger *slog.Logger) *AgeGroupHandler {
+	return &AgeGroupHandler{service: service, logger: logger}
+}
+
+// ListSchemes handles GET /age-group-schemes requests.
+func (h *AgeGroupHandler) ListSchemes(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.List(r.Context())
+	if err != nil {
+		middleware.WriteInternalError(w, h.logger, err, "failed to list age group schemes")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, result)
+}
+
+// GetScheme handles GET /age-group-schemes/{id} requests.
+func (h *AgeGroupHandler) GetScheme(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid age group scheme ID", "INVALID_INPUT")
+		return
+	}
+
+	scheme, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "age group scheme not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get age group scheme")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, scheme)
+}
+
+// CreateScheme handles POST /age-group-schemes requests.
+func (h *AgeGroupHandler) CreateScheme(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	var input agegroup.Input
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
+		return
+	}
+
+	scheme, err := h.service.Create(ctx, input)
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to create age group scheme")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusCreated, scheme)
+}
+
+// DeleteScheme handles DELETE /age-group-schemes/{id} requests.
+func (h *AgeGroupHandler) DeleteScheme(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid age group scheme ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.Delete(ctx, id); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "age group scheme not found", "NOT_FOUND")
+			return
+		}
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to delete age group scheme")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -13,6 +13,8 @@ type CurrentUserResponse struct {
 	Email       string `json:"email"`
 	Name        string `json:"name,omitempty"`
 	AccessLevel string `json:"access_level"`
+	OwnerID     string `json:"owner_id"`
+	Admin       bool   `json:"admin,omitempty"`
 }
 
 // AuthHandler handles authentication-related requests.
@@ -38,6 +40,8 @@ func (h *AuthHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 		Email:       user.Email,
 		Name:        user.Name,
 		AccessLevel: string(user.AccessLevel),
+		OwnerID:     user.OwnerID,
+		Admin:       user.Admin,
 	}
 
 	middleware.WriteJSON(w, http.StatusOK, resp)
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bpg/swimstats/backend/internal/api/middleware"
+	"github.com/bpg/swimstats/backend/internal/domain/backup"
+)
+
+// BackupHandler handles the administrative backup API requests.
+// Routes act on the data of all users and require an operator.
+type BackupHandler struct {
+	service *backup.Service
+	logger  *slog.Logger
+}
+
+// NewBackupHandler creates a new backup handler.
+func NewBackupHandler(service *backup.Service, logger *slog.Logger) *BackupHandler {
+	return &BackupHandler{service: service, logger: logger}
+}
+
+// RestoreRequest confirms restoring a backup. Dry runs change nothing and
+// need no confirmation.
+type RestoreRequest struct {
+	Confirmed bool `json:"confirmed"`
+	DryRun    bool `json:"dry_run,omitempty"`
+}
+
+// ListBackups handles GET /admin/backups requests.
+func (h *BackupHandler) ListBackups(w http.ResponseWriter, r *http.Request) {
+	list, err := h.service.List()
+	if err != nil {
+		h.writeError(w, err, "failed to list backups")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, list)
+}
+
+// CreateBackup handles POST /admin/backups requests, taking a snapshot now.
+func (h *BackupHandler) CreateBackup(w http.ResponseWriter, r *http.Request) {
+	info, err := h.service.Create(r.Context())
+	if err != nil && info == nil {
+		h.writeError(w, err, "failed to create backup")
+		return
+	}
+	if err != nil {
+		// The snapshot was written, only removing old ones failed
+		h.logger.Error("failed to prune backups", "error", err)
+	}
+
+	h.logger.Info("backup created", "name", info.Name, "size", info.Size)
+	middleware.WriteJSON(w, http.StatusCreated, info)
+}
+
+// RestoreBackup handles POST /admin/backups/{name}/restore requests.
+func (h *BackupHandler) RestoreBackup(w http.ResponseWriter, r *http.Request) {
+	var req RestoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
+		return
+	}
+	if !req.Confirmed && !req.DryRun {
+		middleware.WriteError(w, http.StatusBadRequest, "restore requires confirmation, set 'confirmed: true' after a dry run", "VALIDATION_ERROR")
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	result, err := h.service.Restore(r.Context(), name, req.DryRun)
+	if err != nil {
+		h.writeError(w, err, "failed to restore backup")
+		return
+	}
+
+	h.logger.Info("backup restored",
+		"name", name,
+		"dry_run", req.DryRun,
+		"swimmers", len(result.Swimmers),
+	)
+	middleware.WriteJSON(w, http.StatusOK, result)
+}
+
+// writeError writes the response for a backup service error.
+func (h *BackupHandler) writeError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, backup.ErrDisabled):
+		middleware.WriteError(w, http.StatusNotFound, "backups are not configured, set BACKUP_DIR to enable them", "NOT_FOUND")
+	case errors.Is(err, backup.ErrNotFound):
+		middleware.WriteError(w, http.StatusNotFound, "backup not found", "NOT_FOUND")
+	case isValidationError(err):
+		middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+	default:
+		middleware.WriteInternalError(w, h.logger, err, message)
+	}
+}
@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/bpg/swimstats/backend/internal/api/middleware"
+	"github.com/bpg/swimstats/backend/internal/domain"
 	"github.com/bpg/swimstats/backend/internal/domain/comparison"
 	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
 	"github.com/bpg/swimstats/backend/internal/store/postgres"
@@ -32,8 +35,12 @@ func NewComparisonHandler(comparisonService *comparison.ComparisonService, swimm
 // GetComparison handles GET /comparisons requests.
 // Query parameters:
 //   - standard_id (required): UUID of the time standard to compare against
-//   - course_type (optional): "25m" or "50m", defaults to "25m"
+//   - course_type (optional): "25m", "50m" or "25y", defaults to "25m"
 //   - threshold (optional): "almost there" threshold percentage, defaults to 3.0
+//   - mode (optional): "current" compares PBs against the current age group (default),
+//     "at_swim" compares each swim against the age group at the time of the swim
+//   - date (optional): evaluation date (YYYY-MM-DD) selecting the version of a
+//     standard family to compare against, defaults to today
 func (h *ComparisonHandler) GetComparison(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -55,8 +62,8 @@ func (h *ComparisonHandler) GetComparison(w http.ResponseWriter, r *http.Request
 	if courseType == "" {
 		courseType = "25m"
 	}
-	if courseType != "25m" && courseType != "50m" {
-		middleware.WriteError(w, http.StatusBadRequest, "course_type must be '25m' or '50m'", "INVALID_INPUT")
+	if !domain.CourseType(courseType).IsValid() {
+		middleware.WriteError(w, http.StatusBadRequest, "course_type must be '25m', '50m' or '25y'", "INVALID_INPUT")
 		return
 	}
 
@@ -71,10 +78,31 @@ func (h *ComparisonHandler) GetComparison(w http.ResponseWriter, r *http.Request
 		threshold = &t
 	}
 
+	// Get mode parameter (optional, defaults to "current")
+	mode := comparison.Mode(r.URL.Query().Get("mode"))
+	if mode == "" {
+		mode = comparison.ModeCurrent
+	}
+	if !mode.IsValid() {
+		middleware.WriteError(w, http.StatusBadRequest, "mode must be 'current' or 'at_swim'", "INVALID_INPUT")
+		return
+	}
+
+	// Get date parameter (optional, defaults to today)
+	date := time.Now()
+	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			middleware.WriteError(w, http.StatusBadRequest, "invalid date format (expected YYYY-MM-DD)", "VALIDATION_ERROR")
+			return
+		}
+		date = parsed
+	}
+
 	// Get swimmer profile
-	swimmerProfile, err := h.swimmerService.Get(ctx)
+	swimmerProfile, err := resolveSwimmer(r, h.swimmerService)
 	if err != nil {
-		if err == postgres.ErrNotFound {
+		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "swimmer profile not found - please set up your profile first", "NOT_FOUND")
 			return
 		}
@@ -88,9 +116,9 @@ func (h *ComparisonHandler) GetComparison(w http.ResponseWriter, r *http.Request
 	}
 
 	// Perform comparison
-	result, err := h.comparisonService.Compare(ctx, swimmerProfile.ID, standardID, courseType, threshold)
+	result, err := h.comparisonService.Compare(ctx, swimmerProfile.ID, standardID, courseType, threshold, mode, date)
 	if err != nil {
-		if err == postgres.ErrNotFound {
+		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "standard not found", "NOT_FOUND")
 			return
 		}
@@ -100,3 +128,83 @@ func (h *ComparisonHandler) GetComparison(w http.ResponseWriter, r *http.Request
 
 	middleware.WriteJSON(w, http.StatusOK, result)
 }
+
+// GetAttainment handles GET /attainment requests.
+// Query parameters:
+//   - course_type (optional): "25m", "50m" or "25y", defaults to "25m"
+func (h *ComparisonHandler) GetAttainment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	courseType := r.URL.Query().Get("course_type")
+	if courseType == "" {
+		courseType = "25m"
+	}
+	if !domain.CourseType(courseType).IsValid() {
+		middleware.WriteError(w, http.StatusBadRequest, "course_type must be '25m', '50m' or '25y'", "INVALID_INPUT")
+		return
+	}
+
+	// Get swimmer profile
+	swimmerProfile, err := resolveSwimmer(r, h.swimmerService)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "swimmer profile not found - please set up your profile first", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get swimmer profile")
+		return
+	}
+
+	result, err := h.comparisonService.Attainment(ctx, swimmerProfile.ID, courseType)
+	if err != nil {
+		middleware.WriteInternalError(w, h.logger, err, "failed to evaluate standards")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, result)
+}
+
+// GetLadderComparison handles GET /ladder-comparisons requests.
+// Query parameters:
+//   - ladder_id (required): UUID of the standard ladder to place PBs on
+func (h *ComparisonHandler) GetLadderComparison(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ladderIDStr := r.URL.Query().Get("ladder_id")
+	if ladderIDStr == "" {
+		middleware.WriteError(w, http.StatusBadRequest, "ladder_id is required", "INVALID_INPUT")
+		return
+	}
+	ladderID, err := uuid.Parse(ladderIDStr)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid ladder_id", "INVALID_INPUT")
+		return
+	}
+
+	// Get swimmer profile
+	swimmerProfile, err := resolveSwimmer(r, h.swimmerService)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "swimmer profile not found - please set up your profile first", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get swimmer profile")
+		return
+	}
+
+	result, err := h.comparisonService.CompareLadder(ctx, swimmerProfile.ID, ladderID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "ladder not found", "NOT_FOUND")
+			return
+		}
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to compare with ladder")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, result)
+}
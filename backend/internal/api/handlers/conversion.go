@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/api/middleware"
+	"github.com/bpg/swimstats/backend/internal/domain/conversion"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// ConversionHandler handles course conversion API requests.
+type ConversionHandler struct {
+	service *conversion.Service
+	logger  *slog.Logger
+}
+
+// NewConversionHandler creates a new conversion handler.
+func NewConversionHandler(service *conversion.Service, logger *slog.Logger) *ConversionHandler {
+	return &ConversionHandler{service: service, logger: logger}
+}
+
+// Convert handles GET /conversions requests.
+// Query parameters:
+//   - event (required): event code swum in the from course
+//   - time_ms (required): time in milliseconds
+//   - from (required): course of the swim ("25m", "50m" or "25y")
+//   - to (required): course to estimate the time in
+func (h *ConversionHandler) Convert(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	timeMS, err := strconv.Atoi(query.Get("time_ms"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "time_ms must be a number", "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.Convert(ctx, query.Get("event"), query.Get("from"), query.Get("to"), timeMS)
+	if err != nil {
+		if errors.Is(err, conversion.ErrNoFactor) {
+			middleware.WriteError(w, http.StatusNotFound, err.Error(), "NOT_FOUND")
+			return
+		}
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to convert time")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, result)
+}
+
+// ListFactors handles GET /conversions/factors requests.
+func (h *ConversionHandler) ListFactors(w http.ResponseWriter, r *http.Request) {
+	factors, err := h.service.ListFactors(r.Context())
+	if err != nil {
+		middleware.WriteInternalError(w, h.logger, err, "failed to list conversion factors")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, conversion.FactorList{Factors: factors})
+}
+
+// SetFactor handles PUT /conversions/factors requests.
+func (h *ConversionHandler) SetFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	var input conversion.FactorInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
+		return
+	}
+
+	factor, err := h.service.SetFactor(ctx, input)
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to set conversion factor")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, factor)
+}
+
+// DeleteFactor handles DELETE /conversions/factors/{id} requests.
+func (h *ConversionHandler) DeleteFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid conversion factor ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.DeleteFactor(ctx, id); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "conversion factor not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to delete conversion factor")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,31 +1,68 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
+	"github.com/google/uuid"
+
 	"github.com/bpg/swimstats/backend/internal/domain/exporter"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
 )
 
 // ExportHandler handles data export operations.
 type ExportHandler struct {
-	service *exporter.Service
-	logger  *slog.Logger
+	service        *exporter.Service
+	swimmerService *swimmer.Service
+	logger         *slog.Logger
 }
 
 // NewExportHandler creates a new export handler.
-func NewExportHandler(service *exporter.Service, logger *slog.Logger) *ExportHandler {
+func NewExportHandler(service *exporter.Service, swimmerService *swimmer.Service, logger *slog.Logger) *ExportHandler {
 	return &ExportHandler{
-		service: service,
-		logger:  logger,
+		service:        service,
+		swimmerService: swimmerService,
+		logger:         logger,
 	}
 }
 
 // ExportAllData handles GET /api/v1/data/export
 // Exports all swimmer data including meets, times, and custom standards to JSON.
+// Query params: format (json (default), csv for one row per swim, or xlsx for
+// a workbook of meets, times, personal bests and comparisons)
 func (h *ExportHandler) ExportAllData(w http.ResponseWriter, r *http.Request) {
-	exportData, err := h.service.ExportAll(r.Context())
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" && format != "xlsx" {
+		http.Error(w, "format must be 'json', 'csv' or 'xlsx'", http.StatusBadRequest)
+		return
+	}
+
+	sw, err := resolveSwimmer(r, h.swimmerService)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			http.Error(w, "Swimmer profile not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get swimmer", "error", err)
+		http.Error(w, "Failed to export data", http.StatusInternalServerError)
+		return
+	}
+
+	switch format {
+	case "csv":
+		h.exportFile(w, r, sw, "text/csv; charset=utf-8", "swimstats-export.csv", h.service.ExportCSV)
+		return
+	case "xlsx":
+		h.exportFile(w, r, sw, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "swimstats-export.xlsx", h.service.ExportXLSX)
+		return
+	}
+
+	exportData, err := h.service.ExportAll(r.Context(), ownerID(r), sw.ID)
 	if err != nil {
 		h.logger.Error("Failed to export data", "error", err)
 		http.Error(w, "Failed to export data", http.StatusInternalServerError)
@@ -44,3 +81,28 @@ func (h *ExportHandler) ExportAllData(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("Failed to encode export data", "error", err)
 	}
 }
+
+// exportFile writes the swimmer's data exported by export as a file download.
+func (h *ExportHandler) exportFile(
+	w http.ResponseWriter,
+	r *http.Request,
+	sw *swimmer.Swimmer,
+	contentType, filename string,
+	export func(ctx context.Context, ownerID string, swimmerID uuid.UUID) ([]byte, error),
+) {
+	data, err := export(r.Context(), ownerID(r), sw.ID)
+	if err != nil {
+		h.logger.Error("Failed to export data", "error", err, "file", filename)
+		http.Error(w, "Failed to export data", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("Data export successful", "file", filename, "swimmer", sw.Name)
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		h.logger.Error("Failed to write export data", "error", err)
+	}
+}
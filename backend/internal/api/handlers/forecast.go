@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bpg/swimstats/backend/internal/api/middleware"
+	"github.com/bpg/swimstats/backend/internal/domain/comparison"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// ForecastHandler handles time-to-standard forecast API requests.
+type ForecastHandler struct {
+	forecastService *comparison.ForecastService
+	swimmerService  *swimmer.Service
+	logger          *slog.Logger
+}
+
+// NewForecastHandler creates a new forecast handler.
+func NewForecastHandler(forecastService *comparison.ForecastService, swimmerService *swimmer.Service, logger *slog.Logger) *ForecastHandler {
+	return &ForecastHandler{
+		forecastService: forecastService,
+		swimmerService:  swimmerService,
+		logger:          logger,
+	}
+}
+
+// GetForecast handles GET /forecast/{event} requests.
+// Query parameters:
+//   - course_type (required): "25m", "50m" or "25y"
+func (h *ForecastHandler) GetForecast(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get swimmer profile
+	sw, err := resolveSwimmer(r, h.swimmerService)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "swimmer profile not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get swimmer")
+		return
+	}
+
+	// Get event from URL path
+	event := chi.URLParam(r, "event")
+	if event == "" {
+		middleware.WriteError(w, http.StatusBadRequest, "event is required", "VALIDATION_ERROR")
+		return
+	}
+
+	// Get course_type from query (required)
+	courseType := r.URL.Query().Get("course_type")
+	if courseType == "" {
+		middleware.WriteError(w, http.StatusBadRequest, "course_type is required", "VALIDATION_ERROR")
+		return
+	}
+
+	forecast, err := h.forecastService.GetForecast(ctx, sw.ID, courseType, event)
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get forecast")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, forecast)
+}
@@ -2,10 +2,18 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/api/middleware"
+	"github.com/bpg/swimstats/backend/internal/domain/dataformat"
 	"github.com/bpg/swimstats/backend/internal/domain/importer"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
 )
 
 // ImportHandler handles data import operations.
@@ -22,25 +30,76 @@ func NewImportHandler(service *importer.Service, logger *slog.Logger) *ImportHan
 	}
 }
 
+// maxResultsFileSize limits the size of uploaded meet results files.
+const maxResultsFileSize = 20 << 20
+
 // PreviewImport handles POST /api/v1/data/import/preview
 // Analyzes import data and returns what will be deleted/replaced.
+// With ?format=lenex the body is a Lenex results file (.lef or .lxf); the
+// swimmer's results are converted and returned in the preview's data field.
+// With ?format=csv the body is a CSV file with one row per swim, converted
+// the same way; rows that cannot be imported are listed in row_errors.
+// The optional ?mode=replace|append|merge selects how JSON data is combined with existing data.
 func (h *ImportHandler) PreviewImport(w http.ResponseWriter, r *http.Request) {
-	var importData importer.ImportData
+	swimmerID, err := swimmerIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid swimmer ID", http.StatusBadRequest)
+		return
+	}
+
+	var preview *importer.PreviewResult
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		var importData importer.ImportData
+
+		if err := json.NewDecoder(r.Body).Decode(&importData); err != nil {
+			h.logger.Error("Failed to decode import data", "error", err)
+			writeDecodeError(w, err)
+			return
+		}
+
+		mode := importer.Mode(r.URL.Query().Get("mode"))
+		preview, err = h.service.Preview(r.Context(), ownerID(r), swimmerID, &importData, mode)
+	case "lenex":
+		raw, readErr := io.ReadAll(http.MaxBytesReader(w, r.Body, maxResultsFileSize))
+		if readErr != nil {
+			h.logger.Error("Failed to read Lenex file", "error", readErr)
+			http.Error(w, "Failed to read Lenex file", http.StatusBadRequest)
+			return
+		}
+
+		preview, err = h.service.PreviewLenex(r.Context(), ownerID(r), swimmerID, raw)
+		if errors.Is(err, postgres.ErrNotFound) {
+			http.Error(w, "Swimmer profile not found", http.StatusNotFound)
+			return
+		}
+	case "csv":
+		raw, readErr := io.ReadAll(http.MaxBytesReader(w, r.Body, maxResultsFileSize))
+		if readErr != nil {
+			h.logger.Error("Failed to read CSV file", "error", readErr)
+			http.Error(w, "Failed to read CSV file", http.StatusBadRequest)
+			return
+		}
 
-	if err := json.NewDecoder(r.Body).Decode(&importData); err != nil {
-		h.logger.Error("Failed to decode import data", "error", err)
-		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		preview, err = h.service.PreviewCSV(r.Context(), ownerID(r), swimmerID, raw)
+	default:
+		http.Error(w, "Unsupported import format: "+format, http.StatusBadRequest)
 		return
 	}
 
-	preview, err := h.service.Preview(r.Context(), &importData)
 	if err != nil {
+		if isValidationError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		h.logger.Error("Failed to preview import", "error", err)
 		http.Error(w, "Failed to analyze import data", http.StatusInternalServerError)
 		return
 	}
 
 	h.logger.Info("Import preview generated",
+		"mode", preview.Mode,
 		"will_replace_swimmer", preview.WillReplaceSwimmer,
 		"current_meets", preview.CurrentMeetsCount,
 		"new_meets", preview.NewMeetsCount,
@@ -55,22 +114,26 @@ func (h *ImportHandler) PreviewImport(w http.ResponseWriter, r *http.Request) {
 }
 
 // ImportSwimmerData handles POST /api/v1/data/import
-// Imports a complete swimmer dataset from JSON.
-// Requires confirmed=true in the request after previewing.
+// Imports a complete swimmer dataset from JSON in a single transaction.
+// Requires confirmed=true in the request after previewing, unless dry_run=true
+// asks for the result of the import without keeping any of it.
 func (h *ImportHandler) ImportSwimmerData(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Data      importer.ImportData `json:"data"`
-		Confirmed bool                `json:"confirmed"`
+	swimmerID, err := swimmerIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid swimmer ID", http.StatusBadRequest)
+		return
 	}
 
+	var req importer.ImportRequest
+
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.logger.Error("Failed to decode import request", "error", err)
-		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		writeDecodeError(w, err)
 		return
 	}
 
 	// Require confirmation for destructive operations
-	if !req.Confirmed {
+	if !req.Confirmed && !req.DryRun {
 		// Check if any sections are present that would require confirmation
 		if req.Data.Swimmer != nil || len(req.Data.Meets) > 0 || len(req.Data.Standards) > 0 {
 			http.Error(w, "Import requires confirmation. Set 'confirmed: true' after previewing.", http.StatusBadRequest)
@@ -78,7 +141,11 @@ func (h *ImportHandler) ImportSwimmerData(w http.ResponseWriter, r *http.Request
 		}
 	}
 
-	result, err := h.service.ImportSwimmerData(r.Context(), &req.Data)
+	result, err := h.service.ImportSwimmerData(r.Context(), ownerID(r), swimmerID, &req.Data, importer.ImportOptions{
+		Mode:   req.Mode,
+		DryRun: req.DryRun,
+		Update: req.Update,
+	})
 	if err != nil && !result.Success {
 		h.logger.Error("Import failed completely", "error", err, "errors", result.Errors)
 		w.Header().Set("Content-Type", "application/json")
@@ -97,6 +164,7 @@ func (h *ImportHandler) ImportSwimmerData(w http.ResponseWriter, r *http.Request
 			"errors", result.Errors)
 	} else {
 		h.logger.Info("Import successful",
+			"dry_run", result.DryRun,
 			"swimmer_id", result.SwimmerID,
 			"swimmer_replaced", result.SwimmerReplaced,
 			"meets_deleted", result.MeetsDeleted,
@@ -104,12 +172,117 @@ func (h *ImportHandler) ImportSwimmerData(w http.ResponseWriter, r *http.Request
 			"times_created", result.TimesCreated,
 			"standards_deleted", result.StandardsDeleted,
 			"standards_created", result.StandardsCreated,
-			"skipped_times", result.SkippedTimes)
+			"skipped_times", result.SkippedTimes,
+			"merge", result.Merge)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Error("Failed to encode import result", "error", err)
+	}
+}
+
+// ImportSDIF handles POST /api/v1/data/import/sdif
+// Imports the swimmer's individual results from an SDIF (.cl2/.sd3) results
+// file sent as the request body. Existing data is kept and duplicate events are skipped.
+func (h *ImportHandler) ImportSDIF(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUser(r.Context())
+	if user != nil && !user.AccessLevel.CanWrite() {
+		http.Error(w, "Write access required", http.StatusForbidden)
+		return
+	}
+
+	swimmerID, err := swimmerIDParam(r)
+	if err != nil {
+		http.Error(w, "Invalid swimmer ID", http.StatusBadRequest)
+		return
 	}
 
+	raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxResultsFileSize))
+	if err != nil {
+		h.logger.Error("Failed to read SDIF file", "error", err)
+		http.Error(w, "Failed to read SDIF file", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.ImportSDIF(r.Context(), ownerID(r), swimmerID, raw)
+	if err != nil {
+		switch {
+		case errors.Is(err, postgres.ErrNotFound):
+			http.Error(w, "Swimmer profile not found", http.StatusNotFound)
+		case isValidationError(err):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			h.logger.Error("Failed to import SDIF file", "error", err)
+			http.Error(w, "Failed to import SDIF file", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.logger.Info("SDIF import completed",
+		"swimmer_id", result.SwimmerID,
+		"meets_created", result.MeetsCreated,
+		"times_created", result.TimesCreated,
+		"skipped_times", result.SkippedTimes,
+		"errors", len(result.Errors))
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(result); err != nil {
 		h.logger.Error("Failed to encode import result", "error", err)
 	}
 }
+
+// swimmerIDParam returns the swimmer addressed by routes nested under
+// /swimmers/{swimmerID}, or nil on the single-swimmer routes.
+func swimmerIDParam(r *http.Request) (*uuid.UUID, error) {
+	idStr := chi.URLParam(r, "swimmerID")
+	if idStr == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// ListFormatVersions handles GET /api/v1/data/schema
+// Lists the versions of the data format that can be imported.
+func (h *ImportHandler) ListFormatVersions(w http.ResponseWriter, r *http.Request) {
+	middleware.WriteJSON(w, http.StatusOK, map[string]any{
+		"current":  dataformat.Current,
+		"versions": dataformat.Versions(),
+	})
+}
+
+// GetFormatSchema handles GET /api/v1/data/schema/{version}
+// Returns the JSON Schema of a version of the data format.
+func (h *ImportHandler) GetFormatSchema(w http.ResponseWriter, r *http.Request) {
+	schema, err := dataformat.Schema(chi.URLParam(r, "version"))
+	if errors.Is(err, dataformat.ErrUnknownVersion) {
+		middleware.WriteError(w, http.StatusNotFound, "Unknown format version", "NOT_FOUND")
+		return
+	}
+	if err != nil {
+		middleware.WriteInternalError(w, h.logger, err, "Failed to get schema")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(schema)
+}
+
+// writeDecodeError reports import data that cannot be decoded, naming
+// format versions that cannot be imported.
+func writeDecodeError(w http.ResponseWriter, err error) {
+	var versionErr *dataformat.VersionError
+	if errors.As(err, &versionErr) {
+		http.Error(w, versionErr.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/api/middleware"
+	"github.com/bpg/swimstats/backend/internal/domain/ladder"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// LadderHandler handles standard ladder API requests.
+type LadderHandler struct {
+	service *ladder.Service
+	logger  *slog.Logger
+}
+
+// NewLadderHandler creates a new ladder handler.
+func NewLadderHandler(service *ladder.Service, logger *slog.Logger) *LadderHandler {
+	return &LadderHandler{service: service, logger: logger}
+}
+
+// ListLadders handles GET /standard-ladders requests.
+// Query parameters:
+//   - course_type (optional): only ladders of this course
+//   - gender (optional): only ladders of this gender
+func (h *LadderHandler) ListLadders(w http.ResponseWriter, r *http.Request) {
+	list, err := h.service.List(r.Context(), ownerID(r), ladder.ListParams{
+		CourseType: r.URL.Query().Get("course_type"),
+		Gender:     r.URL.Query().Get("gender"),
+	})
+	if err != nil {
+		middleware.WriteInternalError(w, h.logger, err, "failed to list ladders")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, list)
+}
+
+// GetLadder handles GET /standard-ladders/{id} requests.
+func (h *LadderHandler) GetLadder(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid ladder ID", "INVALID_INPUT")
+		return
+	}
+
+	l, err := h.service.Get(r.Context(), ownerID(r), id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "ladder not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get ladder")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, l)
+}
+
+// CreateLadder handles POST /standard-ladders requests.
+func (h *LadderHandler) CreateLadder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	var input ladder.Input
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
+		return
+	}
+
+	l, err := h.service.Create(ctx, ownerID(r), input)
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to create ladder")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusCreated, l)
+}
+
+// UpdateLadder handles PUT /standard-ladders/{id} requests.
+func (h *LadderHandler) UpdateLadder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid ladder ID", "INVALID_INPUT")
+		return
+	}
+
+	var input ladder.Input
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
+		return
+	}
+
+	l, err := h.service.Update(ctx, ownerID(r), id, input)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "ladder not found", "NOT_FOUND")
+			return
+		}
+		if errors.Is(err, ladder.ErrShared) {
+			middleware.WriteError(w, http.StatusForbidden, err.Error(), "FORBIDDEN")
+			return
+		}
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to update ladder")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, l)
+}
+
+// DeleteLadder handles DELETE /standard-ladders/{id} requests.
+func (h *LadderHandler) DeleteLadder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid ladder ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.Delete(ctx, ownerID(r), id); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "ladder not found", "NOT_FOUND")
+			return
+		}
+		if errors.Is(err, ladder.ErrShared) {
+			middleware.WriteError(w, http.StatusForbidden, err.Error(), "FORBIDDEN")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to delete ladder")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegradeLadder handles POST /standard-ladders/{id}/regrade requests.
+func (h *LadderHandler) RegradeLadder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid ladder ID", "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.Regrade(ctx, ownerID(r), id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "ladder not found", "NOT_FOUND")
+			return
+		}
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to regrade swims")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, result)
+}
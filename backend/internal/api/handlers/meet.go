@@ -49,7 +49,7 @@ func (h *MeetHandler) ListMeets(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	list, err := h.service.List(ctx, params)
+	list, err := h.service.List(ctx, ownerID(r), params)
 	if err != nil {
 		middleware.WriteInternalError(w, h.logger, err, "failed to list meets")
 		return
@@ -69,7 +69,7 @@ func (h *MeetHandler) GetMeet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	m, err := h.service.Get(ctx, id)
+	m, err := h.service.Get(ctx, ownerID(r), id)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "meet not found", "NOT_FOUND")
@@ -99,7 +99,7 @@ func (h *MeetHandler) CreateMeet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	m, err := h.service.Create(ctx, input)
+	m, err := h.service.Create(ctx, ownerID(r), input)
 	if err != nil {
 		if isValidationError(err) {
 			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
@@ -136,7 +136,7 @@ func (h *MeetHandler) UpdateMeet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	m, err := h.service.Update(ctx, id, input)
+	m, err := h.service.Update(ctx, ownerID(r), id, input)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "meet not found", "NOT_FOUND")
@@ -171,7 +171,7 @@ func (h *MeetHandler) DeleteMeet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.Delete(ctx, id)
+	err = h.service.Delete(ctx, ownerID(r), id)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "meet not found", "NOT_FOUND")
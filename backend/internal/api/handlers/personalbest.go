@@ -32,7 +32,7 @@ func (h *PersonalBestHandler) GetPersonalBests(w http.ResponseWriter, r *http.Re
 	ctx := r.Context()
 
 	// Get swimmer profile
-	sw, err := h.swimmerService.Get(ctx)
+	sw, err := resolveSwimmer(r, h.swimmerService)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "swimmer profile not found", "NOT_FOUND")
@@ -61,3 +61,33 @@ func (h *PersonalBestHandler) GetPersonalBests(w http.ResponseWriter, r *http.Re
 
 	middleware.WriteJSON(w, http.StatusOK, pbs)
 }
+
+// GetBestEvents handles GET /best-events requests.
+// Query parameters:
+//   - course_type (optional): "25m", "50m" or "25y", defaults to all courses
+func (h *PersonalBestHandler) GetBestEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get swimmer profile
+	sw, err := resolveSwimmer(r, h.swimmerService)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "swimmer profile not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get swimmer")
+		return
+	}
+
+	events, err := h.pbService.GetBestEvents(ctx, sw.ID, r.URL.Query().Get("course_type"))
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get best events")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, events)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/bpg/swimstats/backend/internal/api/middleware"
+	"github.com/bpg/swimstats/backend/internal/domain/points"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// PointsHandler handles World Aquatics points base time API requests.
+type PointsHandler struct {
+	service *points.Service
+	logger  *slog.Logger
+}
+
+// NewPointsHandler creates a new points handler.
+func NewPointsHandler(service *points.Service, logger *slog.Logger) *PointsHandler {
+	return &PointsHandler{service: service, logger: logger}
+}
+
+// ListTables handles GET /points/base-times requests.
+func (h *PointsHandler) ListTables(w http.ResponseWriter, r *http.Request) {
+	tables, err := h.service.ListTables(r.Context())
+	if err != nil {
+		middleware.WriteInternalError(w, h.logger, err, "failed to list points base times")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, tables)
+}
+
+// SetTable handles PUT /points/base-times requests.
+func (h *PointsHandler) SetTable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	var input points.Table
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
+		return
+	}
+
+	table, err := h.service.SetTable(ctx, input)
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to set points base times")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, table)
+}
+
+// DeleteTable handles DELETE /points/base-times/{year}/{course_type}/{gender} requests.
+func (h *PointsHandler) DeleteTable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "year must be a number", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.DeleteTable(ctx, year, chi.URLParam(r, "course_type"), chi.URLParam(r, "gender")); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "points base times not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to delete points base times")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
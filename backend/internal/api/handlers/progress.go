@@ -35,7 +35,7 @@ func (h *ProgressHandler) GetProgressData(w http.ResponseWriter, r *http.Request
 	ctx := r.Context()
 
 	// Get swimmer profile
-	sw, err := h.swimmerService.Get(ctx)
+	sw, err := resolveSwimmer(r, h.swimmerService)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "swimmer profile not found", "NOT_FOUND")
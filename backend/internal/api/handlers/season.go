@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/bpg/swimstats/backend/internal/api/middleware"
+	"github.com/bpg/swimstats/backend/internal/domain/season"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// SeasonHandler handles season API requests.
+type SeasonHandler struct {
+	seasonService  *season.Service
+	swimmerService *swimmer.Service
+	logger         *slog.Logger
+}
+
+// NewSeasonHandler creates a new season handler.
+func NewSeasonHandler(seasonService *season.Service, swimmerService *swimmer.Service, logger *slog.Logger) *SeasonHandler {
+	return &SeasonHandler{
+		seasonService:  seasonService,
+		swimmerService: swimmerService,
+		logger:         logger,
+	}
+}
+
+// ListSeasons handles GET /seasons requests.
+func (h *SeasonHandler) ListSeasons(w http.ResponseWriter, r *http.Request) {
+	sw, ok := h.swimmer(w, r)
+	if !ok {
+		return
+	}
+
+	seasons, err := h.seasonService.List(r.Context(), sw.ID)
+	if err != nil {
+		middleware.WriteInternalError(w, h.logger, err, "failed to list seasons")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, seasons)
+}
+
+// GetSeasonBests handles GET /season-bests requests.
+// Query parameters:
+//   - course_type (required): "25m", "50m" or "25y"
+//   - season (optional): season label such as "2025-2026", defaults to the current season
+func (h *SeasonHandler) GetSeasonBests(w http.ResponseWriter, r *http.Request) {
+	sw, ok := h.swimmer(w, r)
+	if !ok {
+		return
+	}
+
+	courseType := r.URL.Query().Get("course_type")
+	if courseType == "" {
+		middleware.WriteError(w, http.StatusBadRequest, "course_type is required", "VALIDATION_ERROR")
+		return
+	}
+
+	bests, err := h.seasonService.Bests(r.Context(), sw.ID, courseType, r.URL.Query().Get("season"))
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get season bests")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, bests)
+}
+
+// GetSeasonSummary handles GET /season-summary requests.
+// Query parameters:
+//   - course_type (required): "25m", "50m" or "25y"
+//   - season (optional): season label such as "2025-2026", defaults to the current season
+func (h *SeasonHandler) GetSeasonSummary(w http.ResponseWriter, r *http.Request) {
+	sw, ok := h.swimmer(w, r)
+	if !ok {
+		return
+	}
+
+	courseType := r.URL.Query().Get("course_type")
+	if courseType == "" {
+		middleware.WriteError(w, http.StatusBadRequest, "course_type is required", "VALIDATION_ERROR")
+		return
+	}
+
+	summary, err := h.seasonService.Summary(r.Context(), sw.ID, courseType, r.URL.Query().Get("season"))
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get season summary")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, summary)
+}
+
+// swimmer resolves the swimmer of the request, writing an error response if
+// there is none.
+func (h *SeasonHandler) swimmer(w http.ResponseWriter, r *http.Request) (*swimmer.Swimmer, bool) {
+	sw, err := resolveSwimmer(r, h.swimmerService)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "swimmer profile not found", "NOT_FOUND")
+			return nil, false
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get swimmer")
+		return nil, false
+	}
+	return sw, true
+}
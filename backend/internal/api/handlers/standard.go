@@ -3,8 +3,11 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -39,7 +42,7 @@ func (h *StandardHandler) ListStandards(w http.ResponseWriter, r *http.Request)
 		params.Gender = &gender
 	}
 
-	list, err := h.service.List(ctx, params)
+	list, err := h.service.List(ctx, ownerID(r), params)
 	if err != nil {
 		middleware.WriteInternalError(w, h.logger, err, "failed to list standards")
 		return
@@ -59,7 +62,7 @@ func (h *StandardHandler) GetStandard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	std, err := h.service.GetWithTimes(ctx, id)
+	std, err := h.service.GetWithTimes(ctx, ownerID(r), id)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "standard not found", "NOT_FOUND")
@@ -89,7 +92,7 @@ func (h *StandardHandler) CreateStandard(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	std, err := h.service.Create(ctx, input)
+	std, err := h.service.Create(ctx, ownerID(r), input)
 	if err != nil {
 		if isValidationError(err) {
 			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
@@ -126,12 +129,16 @@ func (h *StandardHandler) UpdateStandard(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	std, err := h.service.Update(ctx, id, input)
+	std, err := h.service.Update(ctx, ownerID(r), id, input)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "standard not found", "NOT_FOUND")
 			return
 		}
+		if errors.Is(err, standard.ErrShared) {
+			middleware.WriteError(w, http.StatusForbidden, err.Error(), "FORBIDDEN")
+			return
+		}
 		if isValidationError(err) {
 			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
 			return
@@ -161,12 +168,16 @@ func (h *StandardHandler) DeleteStandard(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.service.Delete(ctx, id)
+	err = h.service.Delete(ctx, ownerID(r), id)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "standard not found", "NOT_FOUND")
 			return
 		}
+		if errors.Is(err, standard.ErrShared) {
+			middleware.WriteError(w, http.StatusForbidden, err.Error(), "FORBIDDEN")
+			return
+		}
 		if isValidationError(err) {
 			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
 			return
@@ -204,12 +215,16 @@ func (h *StandardHandler) SetStandardTimes(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	std, err := h.service.SetTimes(ctx, id, body.Times)
+	std, err := h.service.SetTimes(ctx, ownerID(r), id, body.Times)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "standard not found", "NOT_FOUND")
 			return
 		}
+		if errors.Is(err, standard.ErrShared) {
+			middleware.WriteError(w, http.StatusForbidden, err.Error(), "FORBIDDEN")
+			return
+		}
 		if isValidationError(err) {
 			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
 			return
@@ -238,7 +253,7 @@ func (h *StandardHandler) ImportStandard(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	std, err := h.service.Import(ctx, input)
+	std, err := h.service.Import(ctx, ownerID(r), input)
 	if err != nil {
 		if isValidationError(err) {
 			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
@@ -253,6 +268,10 @@ func (h *StandardHandler) ImportStandard(w http.ResponseWriter, r *http.Request)
 
 // ImportStandardsFromJSON handles POST /standards/import/json requests.
 // This endpoint accepts the JSON file format with multiple standards.
+// Query parameters:
+//   - mode (optional): skip (default), replace or merge, for standards that
+//     already exist
+//   - dry_run (optional): when true, only reports the changes of the import
 func (h *StandardHandler) ImportStandardsFromJSON(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -269,7 +288,16 @@ func (h *StandardHandler) ImportStandardsFromJSON(w http.ResponseWriter, r *http
 		return
 	}
 
-	result, err := h.service.ImportFromJSON(ctx, input)
+	opts := standard.JSONImportOptions{Mode: standard.ImportMode(r.URL.Query().Get("mode"))}
+	if dryRun := r.URL.Query().Get("dry_run"); dryRun != "" {
+		var err error
+		if opts.DryRun, err = strconv.ParseBool(dryRun); err != nil {
+			middleware.WriteError(w, http.StatusBadRequest, "dry_run must be true or false", "INVALID_INPUT")
+			return
+		}
+	}
+
+	result, err := h.service.ImportFromJSON(ctx, ownerID(r), input, opts)
 	if err != nil {
 		if isValidationError(err) {
 			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
@@ -279,5 +307,128 @@ func (h *StandardHandler) ImportStandardsFromJSON(w http.ResponseWriter, r *http
 		return
 	}
 
+	if opts.DryRun {
+		middleware.WriteJSON(w, http.StatusOK, result)
+		return
+	}
 	middleware.WriteJSON(w, http.StatusCreated, result)
 }
+
+// ImportStandardsFromCSV handles POST /standards/import/csv requests.
+// This endpoint accepts a CSV file with a description of its columns.
+func (h *StandardHandler) ImportStandardsFromCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	var input standard.CSVImportInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.ImportFromCSV(ctx, ownerID(r), input)
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to import standards")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusCreated, result)
+}
+
+// ExportStandardCSV handles GET /standards/{id}/csv requests.
+func (h *StandardHandler) ExportStandardCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid standard ID", "INVALID_INPUT")
+		return
+	}
+
+	std, data, err := h.service.ExportCSV(ctx, ownerID(r), id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "standard not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to export standard")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", csvFilename(std.Name)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		h.logger.Error("Failed to write standard CSV", "error", err)
+	}
+}
+
+// csvFilename returns a file name for the CSV export of a standard.
+func csvFilename(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteRune('-')
+		}
+	}
+	filename := strings.TrimSuffix(b.String(), "-")
+	if filename == "" {
+		filename = "standard"
+	}
+	return filename + ".csv"
+}
+
+// GetStandardDiff handles GET /standards/{id}/diff requests.
+// Query parameters:
+//   - from (optional): UUID of the standard to compare with, defaults to the
+//     previous version of the standard's family
+func (h *StandardHandler) GetStandardDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid standard ID", "INVALID_INPUT")
+		return
+	}
+
+	var fromID *uuid.UUID
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		parsed, err := uuid.Parse(fromStr)
+		if err != nil {
+			middleware.WriteError(w, http.StatusBadRequest, "invalid from standard ID", "INVALID_INPUT")
+			return
+		}
+		fromID = &parsed
+	}
+
+	diff, err := h.service.Diff(ctx, ownerID(r), id, fromID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "standard not found", "NOT_FOUND")
+			return
+		}
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to compare standards")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, diff)
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/api/middleware"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// ListStandardFamilies handles GET /standard-families requests.
+func (h *StandardHandler) ListStandardFamilies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := standard.ListParams{}
+
+	if courseType := r.URL.Query().Get("course_type"); courseType != "" {
+		params.CourseType = &courseType
+	}
+
+	if gender := r.URL.Query().Get("gender"); gender != "" {
+		params.Gender = &gender
+	}
+
+	list, err := h.service.ListFamilies(ctx, ownerID(r), params)
+	if err != nil {
+		middleware.WriteInternalError(w, h.logger, err, "failed to list standard families")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, list)
+}
+
+// GetStandardFamily handles GET /standard-families/{id} requests.
+func (h *StandardHandler) GetStandardFamily(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid standard family ID", "INVALID_INPUT")
+		return
+	}
+
+	family, err := h.service.GetFamily(ctx, ownerID(r), id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "standard family not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get standard family")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, family)
+}
+
+// CreateStandardFamily handles POST /standard-families requests.
+func (h *StandardHandler) CreateStandardFamily(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	var input standard.FamilyInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
+		return
+	}
+
+	family, err := h.service.CreateFamily(ctx, ownerID(r), input)
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to create standard family")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusCreated, family)
+}
+
+// UpdateStandardFamily handles PUT /standard-families/{id} requests.
+func (h *StandardHandler) UpdateStandardFamily(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid standard family ID", "INVALID_INPUT")
+		return
+	}
+
+	var input standard.FamilyInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
+		return
+	}
+
+	family, err := h.service.UpdateFamily(ctx, ownerID(r), id, input)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "standard family not found", "NOT_FOUND")
+			return
+		}
+		if errors.Is(err, standard.ErrShared) {
+			middleware.WriteError(w, http.StatusForbidden, err.Error(), "FORBIDDEN")
+			return
+		}
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to update standard family")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, family)
+}
+
+// DeleteStandardFamily handles DELETE /standard-families/{id} requests.
+// The versions of the family are kept as standalone standards.
+func (h *StandardHandler) DeleteStandardFamily(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid standard family ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.DeleteFamily(ctx, ownerID(r), id); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "standard family not found", "NOT_FOUND")
+			return
+		}
+		if errors.Is(err, standard.ErrShared) {
+			middleware.WriteError(w, http.StatusForbidden, err.Error(), "FORBIDDEN")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to delete standard family")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
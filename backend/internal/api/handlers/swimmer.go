@@ -6,6 +6,9 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
 	"github.com/bpg/swimstats/backend/internal/api/middleware"
 	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
 	"github.com/bpg/swimstats/backend/internal/store/postgres"
@@ -26,7 +29,7 @@ func NewSwimmerHandler(service *swimmer.Service, logger *slog.Logger) *SwimmerHa
 func (h *SwimmerHandler) GetSwimmer(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	sw, err := h.service.Get(ctx)
+	sw, err := h.service.Get(ctx, ownerID(r))
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "swimmer profile not found", "NOT_FOUND")
@@ -56,7 +59,7 @@ func (h *SwimmerHandler) PutSwimmer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sw, created, err := h.service.CreateOrUpdate(ctx, input)
+	sw, created, err := h.service.CreateOrUpdate(ctx, ownerID(r), input)
 	if err != nil {
 		// Check if validation error
 		if isValidationError(err) {
@@ -75,6 +78,158 @@ func (h *SwimmerHandler) PutSwimmer(w http.ResponseWriter, r *http.Request) {
 	middleware.WriteJSON(w, status, sw)
 }
 
+// ListSwimmers handles GET /swimmers requests.
+func (h *SwimmerHandler) ListSwimmers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	list, err := h.service.List(ctx, ownerID(r))
+	if err != nil {
+		middleware.WriteInternalError(w, h.logger, err, "failed to list swimmers")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, list)
+}
+
+// CreateSwimmer handles POST /swimmers requests.
+func (h *SwimmerHandler) CreateSwimmer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	var input swimmer.Input
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
+		return
+	}
+
+	sw, err := h.service.Create(ctx, ownerID(r), input)
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to create swimmer")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusCreated, sw)
+}
+
+// GetSwimmerByID handles GET /swimmers/{swimmerID} requests.
+func (h *SwimmerHandler) GetSwimmerByID(w http.ResponseWriter, r *http.Request) {
+	sw, err := resolveSwimmer(r, h.service)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "swimmer not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get swimmer")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, sw)
+}
+
+// UpdateSwimmer handles PUT /swimmers/{swimmerID} requests.
+func (h *SwimmerHandler) UpdateSwimmer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "swimmerID"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid swimmer ID", "INVALID_INPUT")
+		return
+	}
+
+	var input swimmer.Input
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
+		return
+	}
+
+	sw, err := h.service.Update(ctx, ownerID(r), id, input)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "swimmer not found", "NOT_FOUND")
+			return
+		}
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to update swimmer")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, sw)
+}
+
+// DeleteSwimmer handles DELETE /swimmers/{swimmerID} requests.
+func (h *SwimmerHandler) DeleteSwimmer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Check write access
+	user := middleware.GetUser(ctx)
+	if user != nil && !user.AccessLevel.CanWrite() {
+		middleware.WriteError(w, http.StatusForbidden, "write access required", "FORBIDDEN")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "swimmerID"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid swimmer ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.Delete(ctx, ownerID(r), id); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "swimmer not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to delete swimmer")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ownerID returns the user whose swimmers and meets the authenticated user
+// works with, see auth.User.OwnerID.
+func ownerID(r *http.Request) string {
+	if user := middleware.GetUser(r.Context()); user != nil {
+		return user.OwnerID
+	}
+	return ""
+}
+
+// resolveSwimmer returns the swimmer a request applies to. Routes nested under
+// /swimmers/{swimmerID} address that swimmer; the single-swimmer routes use the
+// user's default swimmer. Swimmers of other users are reported as postgres.ErrNotFound.
+func resolveSwimmer(r *http.Request, service *swimmer.Service) (*swimmer.Swimmer, error) {
+	idStr := chi.URLParam(r, "swimmerID")
+	if idStr == "" {
+		return service.Get(r.Context(), ownerID(r))
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, postgres.ErrNotFound
+	}
+	return service.GetForOwner(r.Context(), ownerID(r), id)
+}
+
 func isValidationError(err error) bool {
 	return err != nil && (errors.Is(err, errors.New("validation")) ||
 		len(err.Error()) > 0 && err.Error()[:10] == "validation")
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -42,7 +43,7 @@ func NewTimeHandler(timeService *timeservice.Service, swimmerService *swimmer.Se
 func (h *TimeHandler) ListTimes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	sw, err := h.swimmerService.Get(ctx)
+	sw, err := resolveSwimmer(r, h.swimmerService)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "swimmer profile not found", "NOT_FOUND")
@@ -92,10 +93,59 @@ func (h *TimeHandler) ListTimes(w http.ResponseWriter, r *http.Request) {
 	middleware.WriteJSON(w, http.StatusOK, list)
 }
 
-// GetTime handles GET /times/{id} requests.
-func (h *TimeHandler) GetTime(w http.ResponseWriter, r *http.Request) {
+// GetStats handles GET /stats requests.
+// Query parameters:
+//   - course_type (optional): "25m", "50m" or "25y"
+//   - start_date, end_date (optional): YYYY-MM-DD date range
+func (h *TimeHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	sw, err := resolveSwimmer(r, h.swimmerService)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "swimmer profile not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get swimmer")
+		return
+	}
+
+	params := timeservice.StatsParams{
+		SwimmerID:  sw.ID,
+		CourseType: r.URL.Query().Get("course_type"),
+	}
+	if startStr := r.URL.Query().Get("start_date"); startStr != "" {
+		parsed, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			middleware.WriteError(w, http.StatusBadRequest, "invalid start_date format (expected YYYY-MM-DD)", "VALIDATION_ERROR")
+			return
+		}
+		params.StartDate = &parsed
+	}
+	if endStr := r.URL.Query().Get("end_date"); endStr != "" {
+		parsed, err := time.Parse("2006-01-02", endStr)
+		if err != nil {
+			middleware.WriteError(w, http.StatusBadRequest, "invalid end_date format (expected YYYY-MM-DD)", "VALIDATION_ERROR")
+			return
+		}
+		params.EndDate = &parsed
+	}
+
+	stats, err := h.timeService.Stats(ctx, params)
+	if err != nil {
+		if isValidationError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get stats")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, stats)
+}
+
+// GetTime handles GET /times/{id} requests.
+func (h *TimeHandler) GetTime(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -103,7 +153,7 @@ func (h *TimeHandler) GetTime(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	t, err := h.timeService.Get(ctx, id)
+	t, err := h.getOwnedTime(r, id)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "time not found", "NOT_FOUND")
@@ -127,7 +177,7 @@ func (h *TimeHandler) CreateTime(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sw, err := h.swimmerService.Get(ctx)
+	sw, err := resolveSwimmer(r, h.swimmerService)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusBadRequest, "swimmer profile required", "PRECONDITION_FAILED")
@@ -143,7 +193,7 @@ func (h *TimeHandler) CreateTime(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	t, err := h.timeService.Create(ctx, sw.ID, input)
+	t, err := h.timeService.Create(ctx, ownerID(r), sw.ID, input)
 	if err != nil {
 		if errors.Is(err, postgres.ErrDuplicateEvent) {
 			middleware.WriteError(w, http.StatusConflict, "event already exists for this meet", "DUPLICATE_EVENT")
@@ -175,7 +225,7 @@ func (h *TimeHandler) CreateBatchTimes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sw, err := h.swimmerService.Get(ctx)
+	sw, err := resolveSwimmer(r, h.swimmerService)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusBadRequest, "swimmer profile required", "PRECONDITION_FAILED")
@@ -191,7 +241,7 @@ func (h *TimeHandler) CreateBatchTimes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.timeService.CreateBatch(ctx, sw.ID, input)
+	result, err := h.timeService.CreateBatch(ctx, ownerID(r), sw.ID, input)
 	if err != nil {
 		if errors.Is(err, postgres.ErrDuplicateEvent) {
 			middleware.WriteError(w, http.StatusConflict, err.Error(), "DUPLICATE_EVENT")
@@ -230,13 +280,22 @@ func (h *TimeHandler) UpdateTime(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, err := h.getOwnedTime(r, id); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "time not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get time")
+		return
+	}
+
 	var input timeservice.Input
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		middleware.WriteError(w, http.StatusBadRequest, "invalid request body", "INVALID_INPUT")
 		return
 	}
 
-	t, err := h.timeService.Update(ctx, id, input)
+	t, err := h.timeService.Update(ctx, ownerID(r), id, input)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			middleware.WriteError(w, http.StatusNotFound, "time not found", "NOT_FOUND")
@@ -271,6 +330,15 @@ func (h *TimeHandler) DeleteTime(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, err := h.getOwnedTime(r, id); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			middleware.WriteError(w, http.StatusNotFound, "time not found", "NOT_FOUND")
+			return
+		}
+		middleware.WriteInternalError(w, h.logger, err, "failed to get time")
+		return
+	}
+
 	err = h.timeService.Delete(ctx, id)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
@@ -283,3 +351,24 @@ func (h *TimeHandler) DeleteTime(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// getOwnedTime retrieves a time if it belongs to one of the user's swimmers
+// and, on routes nested under /swimmers/{swimmerID}, to that swimmer.
+// Times of other swimmers are reported as postgres.ErrNotFound.
+func (h *TimeHandler) getOwnedTime(r *http.Request, id uuid.UUID) (*timeservice.TimeRecord, error) {
+	t, err := h.timeService.Get(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if idStr := chi.URLParam(r, "swimmerID"); idStr != "" {
+		swimmerID, err := uuid.Parse(idStr)
+		if err != nil || swimmerID != t.SwimmerID {
+			return nil, postgres.ErrNotFound
+		}
+	}
+	if _, err := h.swimmerService.GetForOwner(r.Context(), ownerID(r), t.SwimmerID); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
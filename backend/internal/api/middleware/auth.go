@@ -84,6 +84,31 @@ func RequireWriteAccess(logger *slog.Logger) func(http.Handler) http.Handler {
 	}
 }
 
+// RequireAdmin creates middleware that requires an operator, see auth.User.Admin.
+func RequireAdmin(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := GetUser(r.Context())
+			if user == nil {
+				WriteError(w, http.StatusUnauthorized, "authentication required", "UNAUTHORIZED")
+				return
+			}
+
+			if !user.Admin {
+				logger.Warn("admin access denied",
+					"user_id", user.ID,
+					"path", r.URL.Path,
+					"method", r.Method,
+				)
+				WriteError(w, http.StatusForbidden, "admin access required", "FORBIDDEN")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // GetUser returns the authenticated user from the context.
 func GetUser(ctx context.Context) *auth.User {
 	user, ok := ctx.Value(UserContextKey).(*auth.User)
@@ -4,6 +4,7 @@ package api
 import (
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -11,33 +12,19 @@ import (
 	"github.com/bpg/swimstats/backend/internal/api/handlers"
 	"github.com/bpg/swimstats/backend/internal/api/middleware"
 	"github.com/bpg/swimstats/backend/internal/auth"
-	"github.com/bpg/swimstats/backend/internal/domain/comparison"
-	"github.com/bpg/swimstats/backend/internal/domain/exporter"
-	"github.com/bpg/swimstats/backend/internal/domain/importer"
-	"github.com/bpg/swimstats/backend/internal/domain/meet"
-	"github.com/bpg/swimstats/backend/internal/domain/standard"
-	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
-	timeservice "github.com/bpg/swimstats/backend/internal/domain/time"
-	"github.com/bpg/swimstats/backend/internal/store/db"
-	"github.com/bpg/swimstats/backend/internal/store/postgres"
+	"github.com/bpg/swimstats/backend/internal/domain/backup"
 )
 
+// adminWriteTimeout is the time administrative requests, such as taking or
+// restoring a backup, have to respond.
+const adminWriteTimeout = 10 * time.Minute
+
 // Router holds dependencies for the API router.
 type Router struct {
 	logger       *slog.Logger
 	authProvider *auth.Provider
 	pool         *pgxpool.Pool
-
-	// Services
-	swimmerService    *swimmer.Service
-	meetService       *meet.Service
-	timeService       *timeservice.Service
-	pbService         *comparison.PersonalBestService
-	comparisonService *comparison.ComparisonService
-	progressService   *comparison.ProgressService
-	standardService   *standard.Service
-	importService     *importer.Service
-	exportService     *exporter.Service
+	services     *Services
 
 	// Handlers
 	authHandler       *handlers.AuthHandler
@@ -47,58 +34,46 @@ type Router struct {
 	pbHandler         *handlers.PersonalBestHandler
 	comparisonHandler *handlers.ComparisonHandler
 	progressHandler   *handlers.ProgressHandler
+	forecastHandler   *handlers.ForecastHandler
+	seasonHandler     *handlers.SeasonHandler
 	standardHandler   *handlers.StandardHandler
+	ladderHandler     *handlers.LadderHandler
+	conversionHandler *handlers.ConversionHandler
+	pointsHandler     *handlers.PointsHandler
+	ageGroupHandler   *handlers.AgeGroupHandler
 	importHandler     *handlers.ImportHandler
 	exportHandler     *handlers.ExportHandler
+	backupHandler     *handlers.BackupHandler
 }
 
 // NewRouter creates a new API router with all dependencies.
-func NewRouter(logger *slog.Logger, authProvider *auth.Provider, pool *pgxpool.Pool) *Router {
-	// Create queries instance
-	queries := db.New(pool)
-
-	// Create repositories
-	swimmerRepo := postgres.NewSwimmerRepository(queries)
-	meetRepo := postgres.NewMeetRepository(queries)
-	timeRepo := postgres.NewTimeRepository(queries)
-	standardRepo := postgres.NewStandardRepository(queries)
-
-	// Create services
-	swimmerService := swimmer.NewService(swimmerRepo)
-	meetService := meet.NewService(meetRepo)
-	timeService := timeservice.NewService(timeRepo, meetRepo)
-	pbService := comparison.NewPersonalBestService(timeRepo)
-	comparisonService := comparison.NewComparisonService(timeRepo, standardRepo, swimmerRepo)
-	progressService := comparison.NewProgressService(timeRepo)
-	standardService := standard.NewService(standardRepo)
-	importService := importer.NewService(swimmerService, meetService, timeService, standardService)
-	exportService := exporter.NewService(swimmerService, meetService, timeService, standardService)
+func NewRouter(logger *slog.Logger, authProvider *auth.Provider, pool *pgxpool.Pool, backupConfig backup.Config) *Router {
+	services := NewServices(pool, backupConfig)
 
 	// Create handlers
 	authHandler := handlers.NewAuthHandler(authProvider)
-	swimmerHandler := handlers.NewSwimmerHandler(swimmerService, logger)
-	meetHandler := handlers.NewMeetHandler(meetService, logger)
-	timeHandler := handlers.NewTimeHandler(timeService, swimmerService, logger)
-	pbHandler := handlers.NewPersonalBestHandler(pbService, swimmerService, logger)
-	comparisonHandler := handlers.NewComparisonHandler(comparisonService, swimmerService, logger)
-	progressHandler := handlers.NewProgressHandler(progressService, swimmerService, logger)
-	standardHandler := handlers.NewStandardHandler(standardService, logger)
-	importHandler := handlers.NewImportHandler(importService, logger)
-	exportHandler := handlers.NewExportHandler(exportService, logger)
+	swimmerHandler := handlers.NewSwimmerHandler(services.Swimmers, logger)
+	meetHandler := handlers.NewMeetHandler(services.Meets, logger)
+	timeHandler := handlers.NewTimeHandler(services.Times, services.Swimmers, logger)
+	pbHandler := handlers.NewPersonalBestHandler(services.PersonalBests, services.Swimmers, logger)
+	comparisonHandler := handlers.NewComparisonHandler(services.Comparisons, services.Swimmers, logger)
+	progressHandler := handlers.NewProgressHandler(services.Progress, services.Swimmers, logger)
+	forecastHandler := handlers.NewForecastHandler(services.Forecasts, services.Swimmers, logger)
+	seasonHandler := handlers.NewSeasonHandler(services.Seasons, services.Swimmers, logger)
+	standardHandler := handlers.NewStandardHandler(services.Standards, logger)
+	ladderHandler := handlers.NewLadderHandler(services.Ladders, logger)
+	conversionHandler := handlers.NewConversionHandler(services.Conversions, logger)
+	pointsHandler := handlers.NewPointsHandler(services.Points, logger)
+	ageGroupHandler := handlers.NewAgeGroupHandler(services.AgeGroups, logger)
+	importHandler := handlers.NewImportHandler(services.Imports, logger)
+	exportHandler := handlers.NewExportHandler(services.Exports, services.Swimmers, logger)
+	backupHandler := handlers.NewBackupHandler(services.Backups, logger)
 
 	return &Router{
 		logger:            logger,
 		authProvider:      authProvider,
 		pool:              pool,
-		swimmerService:    swimmerService,
-		meetService:       meetService,
-		timeService:       timeService,
-		pbService:         pbService,
-		comparisonService: comparisonService,
-		progressService:   progressService,
-		standardService:   standardService,
-		importService:     importService,
-		exportService:     exportService,
+		services:          services,
 		authHandler:       authHandler,
 		swimmerHandler:    swimmerHandler,
 		meetHandler:       meetHandler,
@@ -106,12 +81,24 @@ func NewRouter(logger *slog.Logger, authProvider *auth.Provider, pool *pgxpool.P
 		pbHandler:         pbHandler,
 		comparisonHandler: comparisonHandler,
 		progressHandler:   progressHandler,
+		forecastHandler:   forecastHandler,
+		seasonHandler:     seasonHandler,
 		standardHandler:   standardHandler,
+		ladderHandler:     ladderHandler,
+		conversionHandler: conversionHandler,
+		pointsHandler:     pointsHandler,
+		ageGroupHandler:   ageGroupHandler,
 		importHandler:     importHandler,
 		exportHandler:     exportHandler,
+		backupHandler:     backupHandler,
 	}
 }
 
+// Services returns the domain services of the router.
+func (rt *Router) Services() *Services {
+	return rt.services
+}
+
 // Handler returns the configured HTTP handler with all routes.
 func (rt *Router) Handler() http.Handler {
 	r := chi.NewRouter()
@@ -137,13 +124,52 @@ func (rt *Router) Handler() http.Handler {
 			// Add auth middleware
 			r.Use(middleware.AuthMiddleware(rt.authProvider, rt.logger))
 
+			// Data shared by all users is changed by operators only
+			requireAdmin := middleware.RequireAdmin(rt.logger)
+
 			// Auth endpoints
 			r.Get("/auth/me", rt.authHandler.GetCurrentUser)
 
-			// Swimmer profile
+			// Swimmer profile (the user's default swimmer)
 			r.Get("/swimmer", rt.swimmerHandler.GetSwimmer)
 			r.Put("/swimmer", rt.swimmerHandler.PutSwimmer)
 
+			// Swimmers owned by the user, with per-swimmer data
+			r.Route("/swimmers", func(r chi.Router) {
+				r.Get("/", rt.swimmerHandler.ListSwimmers)
+				r.Post("/", rt.swimmerHandler.CreateSwimmer)
+
+				r.Route("/{swimmerID}", func(r chi.Router) {
+					r.Get("/", rt.swimmerHandler.GetSwimmerByID)
+					r.Put("/", rt.swimmerHandler.UpdateSwimmer)
+					r.Delete("/", rt.swimmerHandler.DeleteSwimmer)
+
+					r.Get("/times", rt.timeHandler.ListTimes)
+					r.Post("/times", rt.timeHandler.CreateTime)
+					r.Post("/times/batch", rt.timeHandler.CreateBatchTimes)
+					r.Get("/times/{id}", rt.timeHandler.GetTime)
+					r.Put("/times/{id}", rt.timeHandler.UpdateTime)
+					r.Delete("/times/{id}", rt.timeHandler.DeleteTime)
+					r.Get("/stats", rt.timeHandler.GetStats)
+
+					r.Get("/personal-bests", rt.pbHandler.GetPersonalBests)
+					r.Get("/best-events", rt.pbHandler.GetBestEvents)
+					r.Get("/seasons", rt.seasonHandler.ListSeasons)
+					r.Get("/season-bests", rt.seasonHandler.GetSeasonBests)
+					r.Get("/season-summary", rt.seasonHandler.GetSeasonSummary)
+					r.Get("/comparisons", rt.comparisonHandler.GetComparison)
+					r.Get("/attainment", rt.comparisonHandler.GetAttainment)
+					r.Get("/ladder-comparisons", rt.comparisonHandler.GetLadderComparison)
+					r.Get("/progress/{event}", rt.progressHandler.GetProgressData)
+					r.Get("/forecast/{event}", rt.forecastHandler.GetForecast)
+
+					r.Get("/data/export", rt.exportHandler.ExportAllData)
+					r.Post("/data/import/preview", rt.importHandler.PreviewImport)
+					r.Post("/data/import", rt.importHandler.ImportSwimmerData)
+					r.Post("/data/import/sdif", rt.importHandler.ImportSDIF)
+				})
+			})
+
 			// Meets
 			r.Get("/meets", rt.meetHandler.ListMeets)
 			r.Post("/meets", rt.meetHandler.CreateMeet)
@@ -158,30 +184,89 @@ func (rt *Router) Handler() http.Handler {
 			r.Get("/times/{id}", rt.timeHandler.GetTime)
 			r.Put("/times/{id}", rt.timeHandler.UpdateTime)
 			r.Delete("/times/{id}", rt.timeHandler.DeleteTime)
+			r.Get("/stats", rt.timeHandler.GetStats)
 
 			// Personal Bests
 			r.Get("/personal-bests", rt.pbHandler.GetPersonalBests)
+			r.Get("/best-events", rt.pbHandler.GetBestEvents)
+
+			// Seasons
+			r.Get("/seasons", rt.seasonHandler.ListSeasons)
+			r.Get("/season-bests", rt.seasonHandler.GetSeasonBests)
+			r.Get("/season-summary", rt.seasonHandler.GetSeasonSummary)
 
 			// Standards
 			r.Get("/standards", rt.standardHandler.ListStandards)
 			r.Post("/standards", rt.standardHandler.CreateStandard)
 			r.Post("/standards/import", rt.standardHandler.ImportStandard)
 			r.Post("/standards/import/json", rt.standardHandler.ImportStandardsFromJSON)
+			r.Post("/standards/import/csv", rt.standardHandler.ImportStandardsFromCSV)
 			r.Get("/standards/{id}", rt.standardHandler.GetStandard)
 			r.Put("/standards/{id}", rt.standardHandler.UpdateStandard)
 			r.Delete("/standards/{id}", rt.standardHandler.DeleteStandard)
 			r.Put("/standards/{id}/times", rt.standardHandler.SetStandardTimes)
+			r.Get("/standards/{id}/diff", rt.standardHandler.GetStandardDiff)
+			r.Get("/standards/{id}/csv", rt.standardHandler.ExportStandardCSV)
+
+			// Standard families
+			r.Get("/standard-families", rt.standardHandler.ListStandardFamilies)
+			r.Post("/standard-families", rt.standardHandler.CreateStandardFamily)
+			r.Get("/standard-families/{id}", rt.standardHandler.GetStandardFamily)
+			r.Put("/standard-families/{id}", rt.standardHandler.UpdateStandardFamily)
+			r.Delete("/standard-families/{id}", rt.standardHandler.DeleteStandardFamily)
+
+			// Standard ladders
+			r.Get("/standard-ladders", rt.ladderHandler.ListLadders)
+			r.Post("/standard-ladders", rt.ladderHandler.CreateLadder)
+			r.Get("/standard-ladders/{id}", rt.ladderHandler.GetLadder)
+			r.Put("/standard-ladders/{id}", rt.ladderHandler.UpdateLadder)
+			r.Delete("/standard-ladders/{id}", rt.ladderHandler.DeleteLadder)
+			r.Post("/standard-ladders/{id}/regrade", rt.ladderHandler.RegradeLadder)
+
+			// Course conversions
+			r.Get("/conversions", rt.conversionHandler.Convert)
+			r.Get("/conversions/factors", rt.conversionHandler.ListFactors)
+			r.With(requireAdmin).Put("/conversions/factors", rt.conversionHandler.SetFactor)
+			r.With(requireAdmin).Delete("/conversions/factors/{id}", rt.conversionHandler.DeleteFactor)
+
+			// World Aquatics points
+			r.Get("/points/base-times", rt.pointsHandler.ListTables)
+			r.With(requireAdmin).Put("/points/base-times", rt.pointsHandler.SetTable)
+			r.With(requireAdmin).Delete("/points/base-times/{year}/{course_type}/{gender}", rt.pointsHandler.DeleteTable)
+
+			// Age-group schemes
+			r.Get("/age-group-schemes", rt.ageGroupHandler.ListSchemes)
+			r.With(requireAdmin).Post("/age-group-schemes", rt.ageGroupHandler.CreateScheme)
+			r.Get("/age-group-schemes/{id}", rt.ageGroupHandler.GetScheme)
+			r.With(requireAdmin).Delete("/age-group-schemes/{id}", rt.ageGroupHandler.DeleteScheme)
 
 			// Comparisons
 			r.Get("/comparisons", rt.comparisonHandler.GetComparison)
+			r.Get("/attainment", rt.comparisonHandler.GetAttainment)
+			r.Get("/ladder-comparisons", rt.comparisonHandler.GetLadderComparison)
 
 			// Progress
 			r.Get("/progress/{event}", rt.progressHandler.GetProgressData)
+			r.Get("/forecast/{event}", rt.forecastHandler.GetForecast)
 
 			// Data export/import
+			r.Get("/data/schema", rt.importHandler.ListFormatVersions)
+			r.Get("/data/schema/{version}", rt.importHandler.GetFormatSchema)
 			r.Get("/data/export", rt.exportHandler.ExportAllData)
 			r.Post("/data/import/preview", rt.importHandler.PreviewImport)
 			r.Post("/data/import", rt.importHandler.ImportSwimmerData)
+			r.Post("/data/import/sdif", rt.importHandler.ImportSDIF)
+
+			// Administration (operators only); backups of all data may take
+			// longer than the server's write timeout
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(requireAdmin)
+				r.Use(middleware.WriteTimeout(adminWriteTimeout))
+
+				r.Get("/backups", rt.backupHandler.ListBackups)
+				r.Post("/backups", rt.backupHandler.CreateBackup)
+				r.Post("/backups/{name}/restore", rt.backupHandler.RestoreBackup)
+			})
 		})
 	})
 
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/domain/backup"
+	"github.com/bpg/swimstats/backend/internal/domain/comparison"
+	"github.com/bpg/swimstats/backend/internal/domain/conversion"
+	"github.com/bpg/swimstats/backend/internal/domain/exporter"
+	"github.com/bpg/swimstats/backend/internal/domain/importer"
+	"github.com/bpg/swimstats/backend/internal/domain/ladder"
+	"github.com/bpg/swimstats/backend/internal/domain/meet"
+	"github.com/bpg/swimstats/backend/internal/domain/points"
+	"github.com/bpg/swimstats/backend/internal/domain/season"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
+	timeservice "github.com/bpg/swimstats/backend/internal/domain/time"
+	"github.com/bpg/swimstats/backend/internal/store/db"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// Services holds the domain services, shared by the API and the command line.
+type Services struct {
+	Swimmers      *swimmer.Service
+	Meets         *meet.Service
+	Times         *timeservice.Service
+	PersonalBests *comparison.PersonalBestService
+	Comparisons   *comparison.ComparisonService
+	Progress      *comparison.ProgressService
+	Forecasts     *comparison.ForecastService
+	Seasons       *season.Service
+	Standards     *standard.Service
+	Ladders       *ladder.Service
+	Conversions   *conversion.Service
+	Points        *points.Service
+	AgeGroups     *agegroup.Service
+	Imports       *importer.Service
+	Exports       *exporter.Service
+	Backups       *backup.Service
+}
+
+// NewServices creates the domain services with their repositories.
+func NewServices(pool *pgxpool.Pool, backupConfig backup.Config) *Services {
+	// Create queries instance
+	queries := db.New(pool)
+
+	// Create repositories
+	swimmerRepo := postgres.NewSwimmerRepository(queries)
+	meetRepo := postgres.NewMeetRepository(queries)
+	timeRepo := postgres.NewTimeRepository(queries)
+	standardRepo := postgres.NewStandardRepository(queries)
+	conversionRepo := postgres.NewConversionRepository(queries)
+	pointsRepo := postgres.NewPointsRepository(queries)
+	ladderRepo := postgres.NewLadderRepository(queries)
+	ageGroupRepo := postgres.NewAgeGroupRepository(queries)
+
+	// Create services
+	swimmerService := swimmer.NewService(swimmerRepo, pool)
+	meetService := meet.NewService(meetRepo)
+	pointsService := points.NewService(pointsRepo, pool)
+	ageGroupService := agegroup.NewService(ageGroupRepo)
+	ladderService := ladder.NewService(ladderRepo, standardRepo, timeRepo, ageGroupService, pool)
+	timeService := timeservice.NewService(timeRepo, meetRepo, swimmerRepo, pointsService, ladderService, pool)
+	pbService := comparison.NewPersonalBestService(timeRepo, swimmerRepo, pointsService)
+	conversionService := conversion.NewService(conversionRepo)
+	comparisonService := comparison.NewComparisonService(timeRepo, standardRepo, swimmerRepo, ladderRepo, conversionService, ageGroupService)
+	progressService := comparison.NewProgressService(timeRepo, swimmerRepo, pointsService)
+	forecastService := comparison.NewForecastService(timeRepo, standardRepo, swimmerRepo, ageGroupService)
+	standardService := standard.NewService(standardRepo, ageGroupService, ladderService, pool)
+	seasonService := season.NewService(timeRepo, swimmerRepo, standardRepo, ageGroupService)
+	importService := importer.NewService(swimmerService, meetService, timeService, standardService, ageGroupService, pool)
+	exportService := exporter.NewService(swimmerService, meetService, timeService, standardService, ageGroupService, pbService, comparisonService)
+	backupService := backup.NewService(backupConfig, swimmerService, meetService, standardService, ageGroupService, conversionService, pointsService, ladderService, exportService, importService, pool)
+
+	return &Services{
+		Swimmers:      swimmerService,
+		Meets:         meetService,
+		Times:         timeService,
+		PersonalBests: pbService,
+		Comparisons:   comparisonService,
+		Progress:      progressService,
+		Forecasts:     forecastService,
+		Seasons:       seasonService,
+		Standards:     standardService,
+		Ladders:       ladderService,
+		Conversions:   conversionService,
+		Points:        pointsService,
+		AgeGroups:     ageGroupService,
+		Imports:       importService,
+		Exports:       exportService,
+		Backups:       backupService,
+	}
+}
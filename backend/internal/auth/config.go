@@ -4,6 +4,8 @@ package auth
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 )
 
 // AccessLevel represents the user's access level.
@@ -32,6 +34,11 @@ type User struct {
 	Email       string      `json:"email"`
 	Name        string      `json:"name,omitempty"`
 	AccessLevel AccessLevel `json:"access_level"`
+	// OwnerID is the user whose data the user works with: the user itself,
+	// or for a view-only user mapped to another user, that user.
+	OwnerID string `json:"owner_id"`
+	// Admin is set for operators, who may access the data of all users.
+	Admin bool `json:"admin,omitempty"`
 }
 
 // Config holds OIDC authentication configuration.
@@ -52,6 +59,14 @@ type Config struct {
 	// Users without this claim get view-only access
 	FullAccessClaim string
 
+	// ViewerOwners maps view-only users (OIDC subjects) to the user whose
+	// data they see. Unmapped view-only users see only their own data.
+	ViewerOwners map[string]string
+
+	// AdminSubjects are the OIDC subjects of operators, who may back up and
+	// restore the data of all users. Operators also need full access.
+	AdminSubjects []string
+
 	// Scopes are the OAuth2 scopes to request
 	Scopes []string
 
@@ -67,6 +82,8 @@ func DefaultConfig() Config {
 		ClientSecret:    getEnv("OIDC_CLIENT_SECRET", ""),
 		RedirectURL:     getEnv("OIDC_REDIRECT_URL", "http://localhost:5173/auth/callback"),
 		FullAccessClaim: getEnv("OIDC_FULL_ACCESS_CLAIM", "swimstats_admin"),
+		ViewerOwners:    parseViewerOwners(getEnv("OIDC_VIEWER_OWNERS", "")),
+		AdminSubjects:   parseList(getEnv("OIDC_ADMIN_SUBJECTS", "")),
 		Scopes:          []string{"openid", "email", "profile"},
 		SkipValidation:  getEnv("ENV", "production") == "development",
 	}
@@ -87,6 +104,46 @@ func (c Config) Validate() error {
 	return nil
 }
 
+// ownerOf returns the user whose data the user works with.
+func (c Config) ownerOf(user *User) string {
+	if user.AccessLevel == AccessLevelViewOnly {
+		if owner, ok := c.ViewerOwners[user.ID]; ok {
+			return owner
+		}
+	}
+	return user.ID
+}
+
+// isAdmin reports whether the user is an operator.
+func (c Config) isAdmin(user *User) bool {
+	return user.AccessLevel.CanWrite() && slices.Contains(c.AdminSubjects, user.ID)
+}
+
+// parseList parses a comma-separated list, dropping empty entries.
+func parseList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// parseViewerOwners parses comma-separated viewer=owner pairs of subjects.
+// Incomplete pairs are ignored.
+func parseViewerOwners(s string) map[string]string {
+	owners := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		viewer, owner, ok := strings.Cut(pair, "=")
+		viewer, owner = strings.TrimSpace(viewer), strings.TrimSpace(owner)
+		if ok && viewer != "" && owner != "" {
+			owners[viewer] = owner
+		}
+	}
+	return owners
+}
+
 // getEnv returns environment variable or default.
 func getEnv(key, defaultVal string) string {
 	if val := os.Getenv(key); val != "" {
@@ -95,12 +95,15 @@ func (p *Provider) VerifyToken(ctx context.Context, rawToken string) (*User, err
 		}
 	}
 
-	return &User{
+	user := &User{
 		ID:          token.Subject,
 		Email:       claims.Email,
 		Name:        claims.Name,
 		AccessLevel: accessLevel,
-	}, nil
+	}
+	user.OwnerID = p.config.ownerOf(user)
+	user.Admin = p.config.isAdmin(user)
+	return user, nil
 }
 
 // parseDevToken parses a mock token for development.
@@ -128,6 +131,7 @@ func (p *Provider) parseDevToken(rawToken string) (*User, error) {
 			Email:       "dev@swimstats.local",
 			Name:        "Developer",
 			AccessLevel: AccessLevelFull,
+			OwnerID:     "dev-user",
 		}, nil
 	}
 
@@ -136,12 +140,15 @@ func (p *Provider) parseDevToken(rawToken string) (*User, error) {
 		accessLevel = AccessLevelViewOnly
 	}
 
-	return &User{
+	user := &User{
 		ID:          "mock-" + mockUser.Email,
 		Email:       mockUser.Email,
 		Name:        mockUser.Name,
 		AccessLevel: accessLevel,
-	}, nil
+	}
+	user.OwnerID = p.config.ownerOf(user)
+	user.Admin = p.config.isAdmin(user)
+	return user, nil
 }
 
 // AuthCodeURL returns the URL to redirect the user for authentication.
@@ -1,6 +1,271 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AgeRule determines how a swimmer's competition age is calculated.
+type AgeRule string
+
+const (
+	// AgeRuleDec31 uses the age on December 31 of the competition year (Swimming Canada).
+	AgeRuleDec31 AgeRule = "dec31"
+	// AgeRuleMeetStart uses the age on the first day of the meet (USA Swimming).
+	AgeRuleMeetStart AgeRule = "meet_start"
+)
+
+// IsValid checks if the age rule is valid.
+func (r AgeRule) IsValid() bool {
+	return r == AgeRuleDec31 || r == AgeRuleMeetStart
+}
+
+// Age returns the competition age of a swimmer for a meet starting on meetDate.
+func (r AgeRule) Age(birthDate, meetDate time.Time) int {
+	if r == AgeRuleMeetStart {
+		return AgeAtDate(birthDate, meetDate)
+	}
+	return AgeAtCompetition(birthDate, meetDate)
+}
+
+// Window returns the period in which a swimmer born on birthDate competes at
+// ages minAge to maxAge, as meet start dates. The end is nil when maxAge is nil.
+func (r AgeRule) Window(birthDate time.Time, minAge int, maxAge *int) (time.Time, *time.Time) {
+	if r == AgeRuleMeetStart {
+		start := birthDate.AddDate(minAge, 0, 0)
+		if maxAge == nil {
+			return start, nil
+		}
+		end := birthDate.AddDate(*maxAge+1, 0, -1)
+		return start, &end
+	}
+
+	start := time.Date(birthDate.Year()+minAge, 1, 1, 0, 0, 0, 0, time.UTC)
+	if maxAge == nil {
+		return start, nil
+	}
+	end := time.Date(birthDate.Year()+*maxAge, 12, 31, 0, 0, 0, 0, time.UTC)
+	return start, &end
+}
+
+// AgeRange is an age group of a scheme. A nil MaxAge is open-ended.
+type AgeRange struct {
+	Code   AgeGroup `json:"code"`
+	MinAge int      `json:"min_age"`
+	MaxAge *int     `json:"max_age,omitempty"`
+}
+
+// Contains reports whether the age falls within the range.
+func (r AgeRange) Contains(age int) bool {
+	return age >= r.MinAge && (r.MaxAge == nil || age <= *r.MaxAge)
+}
+
+// sameAges reports whether both ranges cover the same ages.
+func (r AgeRange) sameAges(other AgeRange) bool {
+	if r.MinAge != other.MinAge || (r.MaxAge == nil) != (other.MaxAge == nil) {
+		return false
+	}
+	return r.MaxAge == nil || *r.MaxAge == *other.MaxAge
+}
+
+// ParseAgeRange parses an age group label as used by governing bodies and
+// results files: "10U" or "10&U" (10 and under), "11-12", "13" (single year),
+// "17O", "17&O" or "17+" (17 and over). OPEN is not a range and is not parsed.
+func ParseAgeRange(label string) (AgeRange, bool) {
+	s := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(label), " ", ""))
+	s = strings.NewReplacer("&UNDER", "U", "&U", "U", "&OVER", "O", "&O", "O", "+", "O").Replace(s)
+	if s == "" {
+		return AgeRange{}, false
+	}
+
+	atoi := func(v string) (int, bool) {
+		n, err := strconv.Atoi(v)
+		return n, err == nil && n >= 0
+	}
+
+	r := AgeRange{Code: AgeGroup(strings.TrimSpace(label))}
+	switch {
+	case strings.HasSuffix(s, "U"):
+		max, ok := atoi(strings.TrimSuffix(s, "U"))
+		if !ok {
+			return AgeRange{}, false
+		}
+		r.MaxAge = &max
+	case strings.HasSuffix(s, "O"):
+		min, ok := atoi(strings.TrimSuffix(s, "O"))
+		if !ok {
+			return AgeRange{}, false
+		}
+		r.MinAge = min
+	case strings.Contains(s, "-"):
+		parts := strings.SplitN(s, "-", 2)
+		min, ok1 := atoi(parts[0])
+		max, ok2 := atoi(parts[1])
+		if !ok1 || !ok2 || max < min {
+			return AgeRange{}, false
+		}
+		r.MinAge, r.MaxAge = min, &max
+	default:
+		age, ok := atoi(s)
+		if !ok {
+			return AgeRange{}, false
+		}
+		r.MinAge, r.MaxAge = age, &age
+	}
+	return r, true
+}
+
+// AgeGroupScheme is a governing body's set of age groups together with the
+// rule used to determine a swimmer's competition age. Groups are ordered
+// youngest first. OPEN times apply to all ages in every scheme.
+type AgeGroupScheme struct {
+	Name    string     `json:"name"`
+	AgeRule AgeRule    `json:"age_rule"`
+	Groups  []AgeRange `json:"groups"`
+}
+
+// DefaultAgeGroupScheme is the name of the scheme used when none is given.
+const DefaultAgeGroupScheme = "Swimming Canada"
+
+func intPtr(v int) *int { return &v }
+
+// SwimmingCanadaAgeGroups is the Swimming Canada age-group scheme.
+var SwimmingCanadaAgeGroups = AgeGroupScheme{
+	Name:    DefaultAgeGroupScheme,
+	AgeRule: AgeRuleDec31,
+	Groups: []AgeRange{
+		{Code: AgeGroup10U, MinAge: 0, MaxAge: intPtr(10)},
+		{Code: AgeGroup11_12, MinAge: 11, MaxAge: intPtr(12)},
+		{Code: AgeGroup13_14, MinAge: 13, MaxAge: intPtr(14)},
+		{Code: AgeGroup15_17, MinAge: 15, MaxAge: intPtr(17)},
+		{Code: AgeGroupOpen, MinAge: 18},
+	},
+}
+
+// maxSchemeAge is the highest age accepted in an age group.
+const maxSchemeAge = 99
+
+// Validate checks that the scheme has a valid rule and ordered,
+// non-overlapping groups with unique codes.
+func (s AgeGroupScheme) Validate() error {
+	if !s.AgeRule.IsValid() {
+		return errors.New("age_rule must be 'dec31' or 'meet_start'")
+	}
+	if len(s.Groups) == 0 {
+		return errors.New("at least one age group is required")
+	}
+
+	seen := make(map[AgeGroup]bool, len(s.Groups))
+	for i, g := range s.Groups {
+		if g.Code == "" {
+			return fmt.Errorf("group %d: code is required", i+1)
+		}
+		if len(g.Code) > 20 {
+			return fmt.Errorf("group %s: code must be at most 20 characters", g.Code)
+		}
+		if seen[g.Code] {
+			return fmt.Errorf("group %s: duplicate code", g.Code)
+		}
+		seen[g.Code] = true
+
+		if g.MinAge < 0 || g.MinAge > maxSchemeAge {
+			return fmt.Errorf("group %s: min_age must be between 0 and %d", g.Code, maxSchemeAge)
+		}
+		if g.MaxAge != nil && (*g.MaxAge < g.MinAge || *g.MaxAge > maxSchemeAge) {
+			return fmt.Errorf("group %s: max_age must be between min_age and %d", g.Code, maxSchemeAge)
+		}
+		if i > 0 {
+			prev := s.Groups[i-1]
+			if prev.MaxAge == nil || g.MinAge <= *prev.MaxAge {
+				return fmt.Errorf("group %s: overlaps group %s", g.Code, prev.Code)
+			}
+		}
+	}
+	return nil
+}
+
+// Age returns the competition age of a swimmer for a meet starting on meetDate.
+func (s AgeGroupScheme) Age(birthDate, meetDate time.Time) int {
+	return s.AgeRule.Age(birthDate, meetDate)
+}
+
+// GroupForAge returns the age group containing the age, or OPEN if no group does.
+func (s AgeGroupScheme) GroupForAge(age int) AgeGroup {
+	for _, g := range s.Groups {
+		if g.Contains(age) {
+			return g.Code
+		}
+	}
+	return AgeGroupOpen
+}
+
+// Has reports whether the scheme defines the age group. OPEN is always accepted.
+func (s AgeGroupScheme) Has(ag AgeGroup) bool {
+	return s.index(ag) >= 0 || ag == AgeGroupOpen
+}
+
+// Normalize maps an age group label to the scheme's code: an exact code,
+// OPEN, or a label such as "10&U" or "17+" covering the same ages as one of
+// the scheme's groups. Labels that do not match a group are rejected rather
+// than collapsed into a wider group.
+func (s AgeGroupScheme) Normalize(label string) (AgeGroup, bool) {
+	label = strings.TrimSpace(label)
+	if s.Has(AgeGroup(label)) {
+		return AgeGroup(label), true
+	}
+	if strings.EqualFold(label, string(AgeGroupOpen)) {
+		return AgeGroupOpen, true
+	}
+	r, ok := ParseAgeRange(label)
+	if !ok {
+		return "", false
+	}
+	for _, g := range s.Groups {
+		if g.sameAges(r) {
+			return g.Code, true
+		}
+	}
+	return "", false
+}
+
+// Window returns the period in which a swimmer born on birthDate competes in
+// the age group, as meet start dates. The end is nil for an open-ended group.
+func (s AgeGroupScheme) Window(birthDate time.Time, ag AgeGroup) (start time.Time, end *time.Time, ok bool) {
+	i := s.index(ag)
+	if i < 0 {
+		return time.Time{}, nil, false
+	}
+	start, end = s.AgeRule.Window(birthDate, s.Groups[i].MinAge, s.Groups[i].MaxAge)
+	return start, end, true
+}
+
+// Previous returns the age group before the given one, or "" if there is none.
+func (s AgeGroupScheme) Previous(ag AgeGroup) AgeGroup {
+	if i := s.index(ag); i > 0 {
+		return s.Groups[i-1].Code
+	}
+	return ""
+}
+
+// Next returns the age group after the given one, or "" if there is none.
+func (s AgeGroupScheme) Next(ag AgeGroup) AgeGroup {
+	if i := s.index(ag); i >= 0 && i < len(s.Groups)-1 {
+		return s.Groups[i+1].Code
+	}
+	return ""
+}
+
+func (s AgeGroupScheme) index(ag AgeGroup) int {
+	for i, g := range s.Groups {
+		if g.Code == ag {
+			return i
+		}
+	}
+	return -1
+}
 
 // AgeAtCompetition calculates swimmer's age using Swimming Canada rules:
 // Age as of December 31 of the competition year.
@@ -20,20 +285,9 @@ func AgeAtCompetition(birthDate, meetDate time.Time) int {
 	return age
 }
 
-// AgeGroupFromAge determines the age group from a swimmer's age.
+// AgeGroupFromAge determines the Swimming Canada age group from a swimmer's age.
 func AgeGroupFromAge(age int) AgeGroup {
-	switch {
-	case age <= 10:
-		return AgeGroup10U
-	case age <= 12:
-		return AgeGroup11_12
-	case age <= 14:
-		return AgeGroup13_14
-	case age <= 17:
-		return AgeGroup15_17
-	default:
-		return AgeGroupOpen
-	}
+	return SwimmingCanadaAgeGroups.GroupForAge(age)
 }
 
 // AgeGroupAtCompetition combines both calculations.
@@ -47,63 +301,41 @@ func CurrentAgeGroup(birthDate time.Time) AgeGroup {
 	return AgeGroupAtCompetition(birthDate, time.Now())
 }
 
-// AgeGroupBounds returns the min and max ages for an age group.
+// AgeGroupBounds returns the min and max ages for a Swimming Canada age group.
 func AgeGroupBounds(ag AgeGroup) (min, max int) {
-	switch ag {
-	case AgeGroup10U:
-		return 0, 10
-	case AgeGroup11_12:
-		return 11, 12
-	case AgeGroup13_14:
-		return 13, 14
-	case AgeGroup15_17:
-		return 15, 17
-	case AgeGroupOpen:
-		return 18, 99
-	default:
+	i := SwimmingCanadaAgeGroups.index(ag)
+	if i < 0 {
 		return 0, 0
 	}
+	g := SwimmingCanadaAgeGroups.Groups[i]
+	if g.MaxAge == nil {
+		return g.MinAge, 99
+	}
+	return g.MinAge, *g.MaxAge
 }
 
-// AgeAtDate calculates age at a given date (standard calculation).
+// AgeAtDate calculates age at a given date (standard calculation). Birthdays
+// fall on the same month and day each year, and on March 1 for February 29
+// births in common years, as in AgeRule.Window.
 func AgeAtDate(birthDate, date time.Time) int {
+	birthDate = time.Date(birthDate.Year(), birthDate.Month(), birthDate.Day(), 0, 0, 0, 0, time.UTC)
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
 	years := date.Year() - birthDate.Year()
-	if date.YearDay() < birthDate.YearDay() {
+	if birthDate.AddDate(years, 0, 0).After(date) {
 		years--
 	}
 	return years
 }
 
-// PreviousAgeGroup returns the age group before the given age group.
+// PreviousAgeGroup returns the Swimming Canada age group before the given age group.
 // Returns empty string if there is no previous age group.
 func PreviousAgeGroup(ag AgeGroup) AgeGroup {
-	switch ag {
-	case AgeGroup11_12:
-		return AgeGroup10U
-	case AgeGroup13_14:
-		return AgeGroup11_12
-	case AgeGroup15_17:
-		return AgeGroup13_14
-	case AgeGroupOpen:
-		return AgeGroup15_17
-	default:
-		return "" // No previous age group for 10U
-	}
+	return SwimmingCanadaAgeGroups.Previous(ag)
 }
 
-// NextAgeGroup returns the age group after the given age group.
+// NextAgeGroup returns the Swimming Canada age group after the given age group.
 // Returns empty string if there is no next age group.
 func NextAgeGroup(ag AgeGroup) AgeGroup {
-	switch ag {
-	case AgeGroup10U:
-		return AgeGroup11_12
-	case AgeGroup11_12:
-		return AgeGroup13_14
-	case AgeGroup13_14:
-		return AgeGroup15_17
-	case AgeGroup15_17:
-		return AgeGroupOpen
-	default:
-		return "" // No next age group for OPEN
-	}
+	return SwimmingCanadaAgeGroups.Next(ag)
 }
@@ -0,0 +1,269 @@
+// Package agegroup provides the age-group schemes of governing bodies.
+package agegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/store/db"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// Service provides age-group scheme business logic.
+type Service struct {
+	repo *postgres.AgeGroupRepository
+}
+
+// NewService creates a new age-group scheme service.
+func NewService(repo *postgres.AgeGroupRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// WithTx returns a service that runs its queries in the transaction.
+func (s *Service) WithTx(tx pgx.Tx) *Service {
+	return &Service{repo: s.repo.WithTx(tx)}
+}
+
+// Scheme is a stored age-group scheme.
+type Scheme struct {
+	ID          uuid.UUID `json:"id"`
+	Description string    `json:"description,omitempty"`
+	IsPreloaded bool      `json:"is_preloaded"`
+	domain.AgeGroupScheme
+}
+
+// SchemeList represents a list of schemes.
+type SchemeList struct {
+	Schemes []Scheme `json:"schemes"`
+}
+
+// Input represents input for creating a scheme.
+type Input struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	AgeRule     string            `json:"age_rule"`
+	Groups      []domain.AgeRange `json:"groups"`
+}
+
+// Sanitize trims whitespace from string fields.
+func (i *Input) Sanitize() {
+	i.Name = strings.TrimSpace(i.Name)
+	i.Description = strings.TrimSpace(i.Description)
+	i.AgeRule = strings.TrimSpace(i.AgeRule)
+	for j := range i.Groups {
+		i.Groups[j].Code = domain.AgeGroup(strings.TrimSpace(string(i.Groups[j].Code)))
+	}
+}
+
+// Validate validates the scheme input. Call Sanitize() first.
+func (i Input) Validate() error {
+	if i.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(i.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	return i.scheme().Validate()
+}
+
+func (i Input) scheme() domain.AgeGroupScheme {
+	return domain.AgeGroupScheme{
+		Name:    i.Name,
+		AgeRule: domain.AgeRule(i.AgeRule),
+		Groups:  i.Groups,
+	}
+}
+
+// List retrieves all schemes with their age groups.
+func (s *Service) List(ctx context.Context) (*SchemeList, error) {
+	dbSchemes, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRanges, err := s.repo.ListAllRanges(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rangesByScheme := make(map[uuid.UUID][]db.AgeGroupRange)
+	for _, r := range dbRanges {
+		rangesByScheme[r.SchemeID] = append(rangesByScheme[r.SchemeID], r)
+	}
+
+	schemes := make([]Scheme, len(dbSchemes))
+	for i, dbScheme := range dbSchemes {
+		schemes[i] = toScheme(&dbScheme, rangesByScheme[dbScheme.ID])
+	}
+	return &SchemeList{Schemes: schemes}, nil
+}
+
+// Get retrieves a scheme by ID.
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*Scheme, error) {
+	dbScheme, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.withRanges(ctx, dbScheme)
+}
+
+// GetByName retrieves a scheme by its name, ignoring case.
+func (s *Service) GetByName(ctx context.Context, name string) (*Scheme, error) {
+	dbScheme, err := s.repo.GetByName(ctx, name)
+	if errors.Is(err, postgres.ErrNotFound) {
+		// Fall back to a case-insensitive match
+		list, listErr := s.List(ctx)
+		if listErr != nil {
+			return nil, listErr
+		}
+		for _, scheme := range list.Schemes {
+			if strings.EqualFold(scheme.Name, name) {
+				return &scheme, nil
+			}
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.withRanges(ctx, dbScheme)
+}
+
+// Default retrieves the scheme used when a standard does not name one.
+func (s *Service) Default(ctx context.Context) (*Scheme, error) {
+	scheme, err := s.GetByName(ctx, domain.DefaultAgeGroupScheme)
+	if err != nil {
+		return nil, fmt.Errorf("get default age group scheme: %w", err)
+	}
+	return scheme, nil
+}
+
+// Resolve returns the scheme with the given name, or the default scheme if
+// the name is empty.
+func (s *Service) Resolve(ctx context.Context, name string) (*Scheme, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return s.Default(ctx)
+	}
+	scheme, err := s.GetByName(ctx, name)
+	if errors.Is(err, postgres.ErrNotFound) {
+		return nil, fmt.Errorf("validation: unknown age group scheme: %s", name)
+	}
+	return scheme, err
+}
+
+// Create creates a new scheme with its age groups.
+func (s *Service) Create(ctx context.Context, input Input) (*Scheme, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+
+	exists, err := s.repo.NameExists(ctx, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("validation: an age group scheme with this name already exists")
+	}
+
+	var description pgtype.Text
+	if input.Description != "" {
+		description = pgtype.Text{String: input.Description, Valid: true}
+	}
+
+	dbScheme, err := s.repo.Create(ctx, db.CreateAgeGroupSchemeParams{
+		Name:        input.Name,
+		Description: description,
+		AgeRule:     input.AgeRule,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dbRanges := make([]db.AgeGroupRange, 0, len(input.Groups))
+	for _, g := range input.Groups {
+		var maxAge pgtype.Int2
+		if g.MaxAge != nil {
+			maxAge = pgtype.Int2{Int16: int16(*g.MaxAge), Valid: true}
+		}
+		dbRange, err := s.repo.CreateRange(ctx, db.CreateAgeGroupRangeParams{
+			SchemeID: dbScheme.ID,
+			Code:     string(g.Code),
+			MinAge:   int16(g.MinAge),
+			MaxAge:   maxAge,
+		})
+		if err != nil {
+			return nil, err
+		}
+		dbRanges = append(dbRanges, *dbRange)
+	}
+
+	scheme := toScheme(dbScheme, dbRanges)
+	return &scheme, nil
+}
+
+// Delete deletes a scheme. Preloaded schemes and schemes used by a time
+// standard cannot be deleted.
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	existing, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing.IsPreloaded {
+		return errors.New("validation: preloaded age group schemes cannot be deleted")
+	}
+
+	inUse, err := s.repo.InUse(ctx, id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errors.New("validation: age group scheme is used by a time standard")
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *Service) withRanges(ctx context.Context, dbScheme *db.AgeGroupScheme) (*Scheme, error) {
+	dbRanges, err := s.repo.ListRanges(ctx, dbScheme.ID)
+	if err != nil {
+		return nil, err
+	}
+	scheme := toScheme(dbScheme, dbRanges)
+	return &scheme, nil
+}
+
+func toScheme(dbScheme *db.AgeGroupScheme, dbRanges []db.AgeGroupRange) Scheme {
+	groups := make([]domain.AgeRange, len(dbRanges))
+	for i, r := range dbRanges {
+		groups[i] = domain.AgeRange{
+			Code:   domain.AgeGroup(r.Code),
+			MinAge: int(r.MinAge),
+		}
+		if r.MaxAge.Valid {
+			maxAge := int(r.MaxAge.Int16)
+			groups[i].MaxAge = &maxAge
+		}
+	}
+
+	scheme := Scheme{
+		ID:          dbScheme.ID,
+		IsPreloaded: dbScheme.IsPreloaded,
+		AgeGroupScheme: domain.AgeGroupScheme{
+			Name:    dbScheme.Name,
+			AgeRule: domain.AgeRule(dbScheme.AgeRule),
+			Groups:  groups,
+		},
+	}
+	if dbScheme.Description.Valid {
+		scheme.Description = dbScheme.Description.String
+	}
+	return scheme
+}
@@ -0,0 +1,68 @@
+package backup
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config holds the backup schedule and retention rules.
+type Config struct {
+	// Dir is the directory snapshots are written to. Backups are disabled
+	// when it is empty.
+	Dir string
+
+	// Interval is the time between scheduled snapshots. Snapshots are only
+	// taken on request when it is zero.
+	Interval time.Duration
+
+	// KeepLast is the number of most recent snapshots to keep.
+	KeepLast int
+
+	// KeepDaily, KeepWeekly and KeepMonthly are the numbers of most recent
+	// days, weeks and months for which the newest snapshot is kept.
+	KeepDaily   int
+	KeepWeekly  int
+	KeepMonthly int
+}
+
+// DefaultConfig returns the backup configuration from the environment.
+func DefaultConfig() Config {
+	return Config{
+		Dir:         getEnv("BACKUP_DIR", ""),
+		Interval:    getEnvDuration("BACKUP_INTERVAL", 24*time.Hour),
+		KeepLast:    getEnvInt("BACKUP_KEEP_LAST", 7),
+		KeepDaily:   getEnvInt("BACKUP_KEEP_DAILY", 7),
+		KeepWeekly:  getEnvInt("BACKUP_KEEP_WEEKLY", 4),
+		KeepMonthly: getEnvInt("BACKUP_KEEP_MONTHLY", 12),
+	}
+}
+
+// Enabled reports whether backups are configured.
+func (c Config) Enabled() bool {
+	return c.Dir != ""
+}
+
+// getEnv returns environment variable or default.
+func getEnv(key, defaultVal string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return defaultVal
+}
+
+// getEnvInt returns environment variable as int or default.
+func getEnvInt(key string, defaultVal int) int {
+	if val, err := strconv.Atoi(os.Getenv(key)); err == nil && val >= 0 {
+		return val
+	}
+	return defaultVal
+}
+
+// getEnvDuration returns environment variable as duration (e.g. 12h) or default.
+func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
+	if val, err := time.ParseDuration(os.Getenv(key)); err == nil && val >= 0 {
+		return val
+	}
+	return defaultVal
+}
@@ -0,0 +1,238 @@
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/bpg/swimstats/backend/internal/domain/importer"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// RestoreResult reports what restoring a snapshot imported.
+type RestoreResult struct {
+	Backup             string                            `json:"backup"`
+	DryRun             bool                              `json:"dry_run,omitempty"`
+	Swimmers           []*importer.ImportResult          `json:"swimmers"`
+	OwnerStandards     map[string]*importer.ImportResult `json:"owner_standards"`
+	Standards          *importer.ImportResult            `json:"standards"`
+	PreloadedStandards *importer.ImportResult            `json:"preloaded_standards"`
+	AgeGroupSchemes    int                               `json:"age_group_schemes"`
+	ConversionFactors  int                               `json:"conversion_factors"`
+	PointsTables       int                               `json:"points_tables"`
+	Meets              int                               `json:"meets"`
+	Ladders            int                               `json:"ladders"`
+}
+
+// storedSnapshot is a snapshot file as read for restoring. Standards are
+// decoded with the format version of the snapshot, so that older snapshots
+// are upgraded as imports are.
+type storedSnapshot struct {
+	FormatVersion string `json:"format_version"`
+	Swimmers      []struct {
+		OwnerID string              `json:"owner_id"`
+		Data    importer.ImportData `json:"data"`
+	} `json:"swimmers"`
+	OwnerStandards     map[string]json.RawMessage `json:"owner_standards"`
+	Standards          json.RawMessage            `json:"standards"`
+	PreloadedStandards json.RawMessage            `json:"preloaded_standards"`
+	tables
+}
+
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+// Restore imports a snapshot in a single transaction. Missing custom age-group
+// schemes are created and the conversion factors and points base time tables
+// are set first. Each swimmer of the snapshot is matched by name among its
+// owner's swimmers, or created, and its profile, meets and times are
+// replaced; meets without times are then recreated. The custom standards of
+// each owner and the shared ones are replaced and preloaded standards are
+// merged, updating their times. Ladders are replaced last, as their rungs
+// refer to the restored standards. Swimmers that are not in the snapshot are
+// left as they are. A dry run performs the whole restore and rolls it back.
+func (s *Service) Restore(ctx context.Context, name string, dryRun bool) (*RestoreResult, error) {
+	if !s.cfg.Enabled() {
+		return nil, ErrDisabled
+	}
+
+	snap, err := s.read(name)
+	if err != nil {
+		return nil, err
+	}
+	standards, err := standardsData(snap.FormatVersion, snap.Standards)
+	if err != nil {
+		return nil, fmt.Errorf("validation: invalid standards in backup: %w", err)
+	}
+	preloaded, err := standardsData(snap.FormatVersion, snap.PreloadedStandards)
+	if err != nil {
+		return nil, fmt.Errorf("validation: invalid preloaded standards in backup: %w", err)
+	}
+	owners := make([]string, 0, len(snap.OwnerStandards))
+	ownerStandards := make(map[string]*importer.ImportData, len(snap.OwnerStandards))
+	for ownerID, raw := range snap.OwnerStandards {
+		if ownerID == "" {
+			return nil, errors.New("validation: standards of the backup have an empty owner")
+		}
+		if ownerStandards[ownerID], err = standardsData(snap.FormatVersion, raw); err != nil {
+			return nil, fmt.Errorf("validation: invalid standards of %s in backup: %w", ownerID, err)
+		}
+		owners = append(owners, ownerID)
+	}
+	sort.Strings(owners)
+
+	var result *RestoreResult
+	err = postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		result = &RestoreResult{
+			Backup:         name,
+			DryRun:         dryRun,
+			Swimmers:       []*importer.ImportResult{},
+			OwnerStandards: make(map[string]*importer.ImportResult, len(owners)),
+		}
+		swimmers := s.swimmerService.WithTx(tx)
+		imports := s.importService.WithTx(tx)
+
+		if err := s.restoreSettings(ctx, tx, &snap.tables, result); err != nil {
+			return err
+		}
+
+		for i := range snap.Swimmers {
+			owned := &snap.Swimmers[i]
+			if owned.Data.Swimmer == nil {
+				return fmt.Errorf("validation: swimmer %d of the backup has no profile", i+1)
+			}
+
+			swimmerID, err := restoreTarget(ctx, swimmers, owned.OwnerID, owned.Data.Swimmer)
+			if err != nil {
+				return err
+			}
+			imported, err := imports.ImportSwimmerData(ctx, owned.OwnerID, &swimmerID, &owned.Data, importer.ImportOptions{
+				Mode: importer.ModeReplace,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to restore swimmer %s: %w", owned.Data.Swimmer.Name, err)
+			}
+			result.Swimmers = append(result.Swimmers, imported)
+		}
+		if err := s.restoreMeets(ctx, tx, snap.Meets, result); err != nil {
+			return err
+		}
+
+		for _, ownerID := range owners {
+			imported, err := imports.ImportSwimmerData(ctx, ownerID, nil, ownerStandards[ownerID], importer.ImportOptions{
+				Mode: importer.ModeReplace,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to restore standards of %s: %w", ownerID, err)
+			}
+			result.OwnerStandards[ownerID] = imported
+		}
+
+		if result.Standards, err = imports.ImportSwimmerData(ctx, "", nil, standards, importer.ImportOptions{
+			Mode: importer.ModeReplace,
+		}); err != nil {
+			return fmt.Errorf("failed to restore standards: %w", err)
+		}
+		if result.PreloadedStandards, err = imports.ImportSwimmerData(ctx, "", nil, preloaded, importer.ImportOptions{
+			Mode:   importer.ModeMerge,
+			Update: true,
+		}); err != nil {
+			return fmt.Errorf("failed to restore preloaded standards: %w", err)
+		}
+		if snap.Ladders != nil {
+			if err := s.restoreLadders(ctx, tx, snap.Ladders, result); err != nil {
+				return err
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return result, nil
+}
+
+// read decodes a snapshot file.
+func (s *Service) read(name string) (*storedSnapshot, error) {
+	if _, ok := parseName(name); !ok {
+		return nil, ErrNotFound
+	}
+
+	f, err := os.Open(filepath.Join(s.cfg.Dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open backup %s: %w", name, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("validation: backup %s is not a gzip file: %w", name, err)
+	}
+	var snap storedSnapshot
+	if err := json.NewDecoder(zr).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("validation: invalid backup %s: %w", name, err)
+	}
+	return &snap, nil
+}
+
+// standardsData decodes standards of a snapshot as import data of the
+// snapshot's format version.
+func standardsData(formatVersion string, raw json.RawMessage) (*importer.ImportData, error) {
+	if len(raw) == 0 {
+		raw = json.RawMessage("null")
+	}
+	doc, err := json.Marshal(map[string]any{
+		"format_version": formatVersion,
+		"standards":      raw,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var data importer.ImportData
+	if err := json.Unmarshal(doc, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// restoreTarget returns the owner's swimmer with the name of the snapshot
+// profile, creating it if the owner has none.
+func restoreTarget(ctx context.Context, swimmers *swimmer.Service, ownerID string, profile *importer.SwimmerData) (uuid.UUID, error) {
+	list, err := swimmers.List(ctx, ownerID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to list swimmers: %w", err)
+	}
+	for _, sw := range list.Swimmers {
+		if sw.Name == profile.Name {
+			return sw.ID, nil
+		}
+	}
+
+	created, err := swimmers.Create(ctx, ownerID, swimmer.Input{
+		Name:             profile.Name,
+		BirthDate:        profile.BirthDate,
+		Gender:           profile.Gender,
+		ThresholdPercent: profile.ThresholdPercent,
+		SeasonStart:      profile.SeasonStart,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create swimmer %s: %w", profile.Name, err)
+	}
+	return created.ID, nil
+}
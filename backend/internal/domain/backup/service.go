@@ -0,0 +1,386 @@
+// Package backup takes scheduled snapshots of all data to a local directory
+// and restores them through the importer.
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/domain/conversion"
+	"github.com/bpg/swimstats/backend/internal/domain/exporter"
+	"github.com/bpg/swimstats/backend/internal/domain/importer"
+	"github.com/bpg/swimstats/backend/internal/domain/ladder"
+	"github.com/bpg/swimstats/backend/internal/domain/meet"
+	"github.com/bpg/swimstats/backend/internal/domain/points"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+var (
+	// ErrDisabled is returned when no backup directory is configured.
+	ErrDisabled = errors.New("backups are not configured")
+
+	// ErrNotFound is returned for a snapshot that does not exist.
+	ErrNotFound = errors.New("backup not found")
+)
+
+// nameLayout is the time layout of snapshot file names, in UTC.
+const nameLayout = "20060102T150405.000Z"
+
+// namePattern matches snapshot file names, capturing the time.
+var namePattern = regexp.MustCompile(`^swimstats-(\d{8}T\d{6}\.\d{3}Z)\.json\.gz$`)
+
+// Service takes, lists and restores snapshots.
+type Service struct {
+	cfg               Config
+	swimmerService    *swimmer.Service
+	meetService       *meet.Service
+	standardService   *standard.Service
+	schemes           *agegroup.Service
+	conversionService *conversion.Service
+	pointsService     *points.Service
+	ladderService     *ladder.Service
+	exportService     *exporter.Service
+	importService     *importer.Service
+	txs               postgres.SnapshotBeginner
+
+	mu sync.Mutex // serializes changes to the backup directory
+}
+
+// NewService creates a new backup service.
+func NewService(
+	cfg Config,
+	swimmerService *swimmer.Service,
+	meetService *meet.Service,
+	standardService *standard.Service,
+	schemes *agegroup.Service,
+	conversionService *conversion.Service,
+	pointsService *points.Service,
+	ladderService *ladder.Service,
+	exportService *exporter.Service,
+	importService *importer.Service,
+	txs postgres.SnapshotBeginner,
+) *Service {
+	return &Service{
+		cfg:               cfg,
+		swimmerService:    swimmerService,
+		meetService:       meetService,
+		standardService:   standardService,
+		schemes:           schemes,
+		conversionService: conversionService,
+		pointsService:     pointsService,
+		ladderService:     ladderService,
+		exportService:     exportService,
+		importService:     importService,
+		txs:               txs,
+	}
+}
+
+// Info describes a snapshot file.
+type Info struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+}
+
+// BackupList represents the snapshots in the backup directory.
+type BackupList struct {
+	Backups []Info `json:"backups"`
+	Total   int    `json:"total"`
+}
+
+// snapshot is the content of a snapshot file: the export of every swimmer
+// with its owner, the custom standards of each owner, the shared custom
+// standards and the preloaded standards, which may have been edited, and the
+// data outside the swimmer exports: custom age-group schemes, conversion
+// factors, points base time tables, each owner's meets without times and the
+// standard ladders.
+type snapshot struct {
+	FormatVersion      string                               `json:"format_version"`
+	CreatedAt          time.Time                            `json:"created_at"`
+	Swimmers           []swimmerSnapshot                    `json:"swimmers"`
+	OwnerStandards     map[string][]exporter.StandardExport `json:"owner_standards"`
+	Standards          []exporter.StandardExport            `json:"standards"`
+	PreloadedStandards []exporter.StandardExport            `json:"preloaded_standards"`
+	tables
+}
+
+// swimmerSnapshot is the export of a swimmer without standards.
+type swimmerSnapshot struct {
+	OwnerID string               `json:"owner_id"`
+	Data    *exporter.ExportData `json:"data"`
+}
+
+// Create writes a snapshot of all data and removes the snapshots the
+// retention rules no longer keep. The snapshot is written to a temporary
+// file and renamed, so the directory never holds a partial snapshot.
+func (s *Service) Create(ctx context.Context) (*Info, error) {
+	if !s.cfg.Enabled() {
+		return nil, ErrDisabled
+	}
+
+	snap, err := s.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.cfg.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create backup directory: %w", err)
+	}
+	name := "swimstats-" + snap.CreatedAt.Format(nameLayout) + ".json.gz"
+	size, err := writeSnapshot(s.cfg.Dir, name, snap)
+	if err != nil {
+		return nil, err
+	}
+	info := &Info{Name: name, CreatedAt: snap.CreatedAt, Size: size}
+
+	if err := s.prune(); err != nil {
+		return info, err
+	}
+	return info, nil
+}
+
+// snapshot exports the data of all owners, all read in one read-only
+// transaction so that changes made meanwhile are not half included.
+func (s *Service) snapshot(ctx context.Context) (*snapshot, error) {
+	snap := &snapshot{
+		FormatVersion: exporter.CurrentFormatVersion,
+		CreatedAt:     time.Now().UTC().Truncate(time.Millisecond),
+		Swimmers:      []swimmerSnapshot{},
+	}
+
+	err := postgres.InSnapshot(ctx, s.txs, func(tx pgx.Tx) error {
+		exports := s.exportService.WithTx(tx)
+
+		swimmers, err := s.swimmerService.WithTx(tx).ListAll(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list swimmers: %w", err)
+		}
+		for _, sw := range swimmers {
+			data, err := exports.ExportAll(ctx, sw.OwnerID, sw.ID)
+			if err != nil {
+				return fmt.Errorf("failed to export swimmer %s: %w", sw.Name, err)
+			}
+			data.Standards = nil // Saved once for each owner
+			snap.Swimmers = append(snap.Swimmers, swimmerSnapshot{OwnerID: sw.OwnerID, Data: data})
+		}
+
+		if snap.OwnerStandards, err = exports.ExportOwnedStandards(ctx); err != nil {
+			return err
+		}
+		if snap.Standards, err = exports.ExportStandards(ctx, "", false); err != nil {
+			return err
+		}
+		if snap.PreloadedStandards, err = exports.ExportStandards(ctx, "", true); err != nil {
+			return err
+		}
+		return s.snapshotTables(ctx, tx, &snap.tables)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// writeSnapshot writes a compressed snapshot file, returning its size.
+func writeSnapshot(dir, name string, snap *snapshot) (int64, error) {
+	tmp, err := os.CreateTemp(dir, ".swimstats-*.tmp")
+	if err != nil {
+		return 0, fmt.Errorf("create backup file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	defer func() { _ = tmp.Close() }()
+
+	zw := gzip.NewWriter(tmp)
+	if err := json.NewEncoder(zw).Encode(snap); err != nil {
+		return 0, fmt.Errorf("write backup file: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return 0, fmt.Errorf("write backup file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return 0, fmt.Errorf("write backup file: %w", err)
+	}
+	stat, err := tmp.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("write backup file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, fmt.Errorf("write backup file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		return 0, fmt.Errorf("write backup file: %w", err)
+	}
+	return stat.Size(), nil
+}
+
+// List lists the snapshots in the backup directory, newest first.
+func (s *Service) List() (*BackupList, error) {
+	if !s.cfg.Enabled() {
+		return nil, ErrDisabled
+	}
+
+	backups, err := s.list()
+	if err != nil {
+		return nil, err
+	}
+	return &BackupList{Backups: backups, Total: len(backups)}, nil
+}
+
+func (s *Service) list() ([]Info, error) {
+	entries, err := os.ReadDir(s.cfg.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Info{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read backup directory: %w", err)
+	}
+
+	backups := []Info{}
+	for _, entry := range entries {
+		createdAt, ok := parseName(entry.Name())
+		if !ok || !entry.Type().IsRegular() {
+			continue
+		}
+		fileInfo, err := entry.Info()
+		if err != nil {
+			continue // Removed meanwhile
+		}
+		backups = append(backups, Info{Name: entry.Name(), CreatedAt: createdAt, Size: fileInfo.Size()})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	return backups, nil
+}
+
+// parseName returns the time of a snapshot from its file name.
+func parseName(name string) (time.Time, bool) {
+	match := namePattern.FindStringSubmatch(name)
+	if match == nil {
+		return time.Time{}, false
+	}
+	createdAt, err := time.Parse(nameLayout, match[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return createdAt, true
+}
+
+// prune removes the snapshots that no retention rule keeps. Every snapshot
+// is kept when no rule is set.
+func (s *Service) prune() error {
+	if s.cfg.KeepLast == 0 && s.cfg.KeepDaily == 0 && s.cfg.KeepWeekly == 0 && s.cfg.KeepMonthly == 0 {
+		return nil
+	}
+
+	backups, err := s.list()
+	if err != nil {
+		return err
+	}
+	keep := retained(backups, s.cfg)
+	for _, b := range backups {
+		if keep[b.Name] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.cfg.Dir, b.Name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove backup %s: %w", b.Name, err)
+		}
+	}
+	return nil
+}
+
+// retained returns the names of the snapshots, sorted newest first, that the
+// retention rules keep: the KeepLast newest ones, and the newest one of each
+// of the KeepDaily, KeepWeekly and KeepMonthly most recent days, ISO weeks
+// and months that have snapshots.
+func retained(backups []Info, cfg Config) map[string]bool {
+	keep := make(map[string]bool)
+	for i := 0; i < len(backups) && i < cfg.KeepLast; i++ {
+		keep[backups[i].Name] = true
+	}
+
+	newestOf := func(periods int, period func(time.Time) string) {
+		seen := make(map[string]bool)
+		for _, b := range backups {
+			key := period(b.CreatedAt)
+			if seen[key] {
+				continue
+			}
+			if len(seen) == periods {
+				return
+			}
+			seen[key] = true
+			keep[b.Name] = true
+		}
+	}
+	newestOf(cfg.KeepDaily, func(t time.Time) string {
+		return t.Format("2006-01-02")
+	})
+	newestOf(cfg.KeepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+	newestOf(cfg.KeepMonthly, func(t time.Time) string {
+		return t.Format("2006-01")
+	})
+	return keep
+}
+
+// Run takes a snapshot every interval until the context is done. The first
+// one is taken when the interval has passed since the newest snapshot, right
+// away if there is none. Failures are logged and retried at the next interval.
+func (s *Service) Run(ctx context.Context, logger *slog.Logger) {
+	if !s.cfg.Enabled() || s.cfg.Interval == 0 {
+		return
+	}
+
+	var wait time.Duration
+	backups, err := s.list()
+	if err != nil {
+		logger.Error("failed to list backups", "error", err)
+	} else if len(backups) > 0 {
+		wait = max(time.Until(backups[0].CreatedAt.Add(s.cfg.Interval)), 0)
+	}
+	logger.Info("backups scheduled",
+		"dir", s.cfg.Dir,
+		"interval", s.cfg.Interval.String(),
+		"next", time.Now().Add(wait).UTC(),
+	)
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			info, err := s.Create(ctx)
+			if err != nil {
+				logger.Error("scheduled backup failed", "error", err)
+			}
+			if info != nil {
+				logger.Info("backup created", "name", info.Name, "size", info.Size)
+			}
+			timer.Reset(s.cfg.Interval)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/domain/conversion"
+	"github.com/bpg/swimstats/backend/internal/domain/ladder"
+	"github.com/bpg/swimstats/backend/internal/domain/meet"
+	"github.com/bpg/swimstats/backend/internal/domain/points"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// tables is the data of a snapshot outside the swimmer and standard exports.
+// Fields are nil in snapshots taken before they were saved, and restoring
+// such a snapshot leaves that data as it is.
+type tables struct {
+	AgeGroupSchemes   []agegroup.Input         `json:"age_group_schemes"`
+	ConversionFactors []conversion.FactorInput `json:"conversion_factors"`
+	PointsTables      []points.Table           `json:"points_tables"`
+	Meets             map[string][]meet.Input  `json:"meets"`
+	Ladders           []ladderSnapshot         `json:"ladders"`
+}
+
+// ladderSnapshot is a ladder with its owner. Rungs name their standards,
+// which are restored with new IDs.
+type ladderSnapshot struct {
+	OwnerID     string         `json:"owner_id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	CourseType  string         `json:"course_type"`
+	Gender      string         `json:"gender"`
+	Grading     bool           `json:"grading"`
+	Rungs       []rungSnapshot `json:"rungs"`
+}
+
+// rungSnapshot is a rung of a ladder. Shared rungs use a shared or preloaded
+// standard, the others a standard of the ladder's owner.
+type rungSnapshot struct {
+	Standard string `json:"standard"`
+	Shared   bool   `json:"shared,omitempty"`
+	Label    string `json:"label,omitempty"`
+}
+
+// snapshotTables reads the custom age-group schemes, conversion factors,
+// points base time tables, meets without times and ladders.
+func (s *Service) snapshotTables(ctx context.Context, tx pgx.Tx, t *tables) error {
+	schemes, err := s.schemes.WithTx(tx).List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list age group schemes: %w", err)
+	}
+	t.AgeGroupSchemes = []agegroup.Input{}
+	for _, scheme := range schemes.Schemes {
+		if scheme.IsPreloaded {
+			continue
+		}
+		t.AgeGroupSchemes = append(t.AgeGroupSchemes, agegroup.Input{
+			Name:        scheme.Name,
+			Description: scheme.Description,
+			AgeRule:     string(scheme.AgeRule),
+			Groups:      scheme.Groups,
+		})
+	}
+
+	factors, err := s.conversionService.WithTx(tx).ListFactors(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list conversion factors: %w", err)
+	}
+	t.ConversionFactors = make([]conversion.FactorInput, 0, len(factors))
+	for _, f := range factors {
+		t.ConversionFactors = append(t.ConversionFactors, conversion.FactorInput{
+			FromCourse: f.FromCourse,
+			ToCourse:   f.ToCourse,
+			Event:      f.Event,
+			Factor:     f.Factor,
+		})
+	}
+
+	pointsTables, err := s.pointsService.WithTx(tx).ListTables(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list points tables: %w", err)
+	}
+	t.PointsTables = pointsTables.Tables
+
+	meets, err := s.meetService.WithTx(tx).ListEmpty(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list meets: %w", err)
+	}
+	t.Meets = make(map[string][]meet.Input)
+	for _, m := range meets {
+		sanctioned := m.Sanctioned
+		t.Meets[m.OwnerID] = append(t.Meets[m.OwnerID], meet.Input{
+			Name:       m.Name,
+			City:       m.City,
+			Country:    m.Country,
+			StartDate:  m.StartDate,
+			EndDate:    m.EndDate,
+			CourseType: m.CourseType,
+			Sanctioned: &sanctioned,
+		})
+	}
+
+	standards, err := s.standardService.WithTx(tx).List(ctx, "", standard.ListParams{})
+	if err != nil {
+		return fmt.Errorf("failed to list standards: %w", err)
+	}
+	standardOwners := make(map[uuid.UUID]string, len(standards.Standards))
+	for _, std := range standards.Standards {
+		standardOwners[std.ID] = std.OwnerID
+	}
+
+	ladders, err := s.ladderService.WithTx(tx).List(ctx, "", ladder.ListParams{})
+	if err != nil {
+		return fmt.Errorf("failed to list ladders: %w", err)
+	}
+	t.Ladders = make([]ladderSnapshot, 0, len(ladders.Ladders))
+	for _, l := range ladders.Ladders {
+		saved := ladderSnapshot{
+			OwnerID:     l.OwnerID,
+			Name:        l.Name,
+			Description: l.Description,
+			CourseType:  l.CourseType,
+			Gender:      l.Gender,
+			Grading:     l.Grading,
+			Rungs:       make([]rungSnapshot, 0, len(l.Rungs)),
+		}
+		for _, r := range l.Rungs {
+			saved.Rungs = append(saved.Rungs, rungSnapshot{
+				Standard: r.StandardName,
+				Shared:   standardOwners[r.StandardID] == "",
+				Label:    r.Label,
+			})
+		}
+		t.Ladders = append(t.Ladders, saved)
+	}
+	return nil
+}
+
+// restoreSettings creates the custom age-group schemes of the snapshot that
+// are missing and sets its conversion factors and points base time tables,
+// ahead of the standards and times that use them.
+func (s *Service) restoreSettings(ctx context.Context, tx pgx.Tx, t *tables, result *RestoreResult) error {
+	schemes := s.schemes.WithTx(tx)
+	for _, input := range t.AgeGroupSchemes {
+		_, err := schemes.GetByName(ctx, input.Name)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, postgres.ErrNotFound) {
+			return fmt.Errorf("failed to get age group scheme %s: %w", input.Name, err)
+		}
+		if _, err := schemes.Create(ctx, input); err != nil {
+			return fmt.Errorf("failed to restore age group scheme %s: %w", input.Name, err)
+		}
+		result.AgeGroupSchemes++
+	}
+
+	conversions := s.conversionService.WithTx(tx)
+	for _, input := range t.ConversionFactors {
+		if _, err := conversions.SetFactor(ctx, input); err != nil {
+			return fmt.Errorf("failed to restore conversion factor %s to %s: %w", input.FromCourse, input.ToCourse, err)
+		}
+		result.ConversionFactors++
+	}
+
+	pointsTables := s.pointsService.WithTx(tx)
+	for _, table := range t.PointsTables {
+		if _, err := pointsTables.SetTable(ctx, table); err != nil {
+			return fmt.Errorf("failed to restore points table %d %s %s: %w", table.Year, table.CourseType, table.Gender, err)
+		}
+		result.PointsTables++
+	}
+	return nil
+}
+
+// restoreMeets creates the meets of the snapshot that have no times. They are
+// restored after the swimmers, whose replacement removes meets without times.
+func (s *Service) restoreMeets(ctx context.Context, tx pgx.Tx, meets map[string][]meet.Input, result *RestoreResult) error {
+	owners := make([]string, 0, len(meets))
+	for ownerID := range meets {
+		owners = append(owners, ownerID)
+	}
+	sort.Strings(owners)
+
+	meetService := s.meetService.WithTx(tx)
+	for _, ownerID := range owners {
+		for _, input := range meets[ownerID] {
+			if _, created, err := meetService.FindOrCreate(ctx, ownerID, input); err != nil {
+				return fmt.Errorf("failed to restore meet %s: %w", input.Name, err)
+			} else if created {
+				result.Meets++
+			}
+		}
+	}
+	return nil
+}
+
+// restoreLadders replaces all ladders with those of the snapshot. Rungs are
+// matched to the restored standards by owner and name.
+func (s *Service) restoreLadders(ctx context.Context, tx pgx.Tx, ladders []ladderSnapshot, result *RestoreResult) error {
+	ladderService := s.ladderService.WithTx(tx)
+	existing, err := ladderService.List(ctx, "", ladder.ListParams{})
+	if err != nil {
+		return fmt.Errorf("failed to list ladders: %w", err)
+	}
+	for _, l := range existing.Ladders {
+		if err := ladderService.Delete(ctx, "", l.ID); err != nil {
+			return fmt.Errorf("failed to delete ladder %s: %w", l.Name, err)
+		}
+	}
+
+	standards, err := s.standardService.WithTx(tx).List(ctx, "", standard.ListParams{})
+	if err != nil {
+		return fmt.Errorf("failed to list standards: %w", err)
+	}
+	type standardKey struct{ ownerID, name string }
+	standardIDs := make(map[standardKey]uuid.UUID, len(standards.Standards))
+	for _, std := range standards.Standards {
+		standardIDs[standardKey{std.OwnerID, std.Name}] = std.ID
+	}
+
+	for _, l := range ladders {
+		input := ladder.Input{
+			Name:        l.Name,
+			Description: l.Description,
+			CourseType:  l.CourseType,
+			Gender:      l.Gender,
+			Grading:     l.Grading,
+			Rungs:       make([]ladder.RungInput, 0, len(l.Rungs)),
+		}
+		for _, r := range l.Rungs {
+			ownerID := l.OwnerID
+			if r.Shared {
+				ownerID = ""
+			}
+			id, ok := standardIDs[standardKey{ownerID, r.Standard}]
+			if !ok {
+				return fmt.Errorf("validation: standard %s of ladder %s is not in the backup", r.Standard, l.Name)
+			}
+			input.Rungs = append(input.Rungs, ladder.RungInput{StandardID: id, Label: r.Label})
+		}
+		if _, err := ladderService.Create(ctx, l.OwnerID, input); err != nil {
+			return fmt.Errorf("failed to restore ladder %s: %w", l.Name, err)
+		}
+		result.Ladders++
+	}
+	return nil
+}
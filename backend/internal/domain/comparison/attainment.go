@@ -0,0 +1,313 @@
+package comparison
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
+	"github.com/bpg/swimstats/backend/internal/store/db"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// AttainmentStandard is a standard evaluated in an attainment matrix, with
+// the swimmer's age and age group under the standard's scheme.
+type AttainmentStandard struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	AgeGroupScheme string    `json:"age_group_scheme"`
+	SwimmerAge     int       `json:"swimmer_age"`
+	AgeGroup       string    `json:"age_group"`
+}
+
+// StandardLevel is the time of one standard in an event.
+type StandardLevel struct {
+	StandardID    uuid.UUID `json:"standard_id"`
+	StandardName  string    `json:"standard_name"`
+	AgeGroup      string    `json:"age_group"`
+	TimeMS        int       `json:"time_ms"`
+	TimeFormatted string    `json:"time_formatted"`
+	Achieved      bool      `json:"achieved"`
+}
+
+// NextStandard is the next standard up from the personal best of an event.
+// The gap is only set when the event has a personal best.
+type NextStandard struct {
+	StandardLevel
+	GapMS        *int     `json:"gap_ms"`
+	GapFormatted *string  `json:"gap_formatted"`
+	GapPercent   *float64 `json:"gap_percent"`
+}
+
+// EventAttainment is the row of an event in an attainment matrix.
+type EventAttainment struct {
+	Event                string          `json:"event"`
+	SwimmerTimeMS        *int            `json:"swimmer_time_ms"`
+	SwimmerTimeFormatted *string         `json:"swimmer_time_formatted"`
+	MeetName             *string         `json:"meet_name"`
+	Date                 *string         `json:"date"`
+	Standards            []StandardLevel `json:"standards"`
+	HighestAchieved      *StandardLevel  `json:"highest_achieved"`
+	Next                 *NextStandard   `json:"next"`
+}
+
+// AttainmentMatrix represents a swimmer's personal bests evaluated against
+// every standard of a course.
+type AttainmentMatrix struct {
+	CourseType  string               `json:"course_type"`
+	SwimmerName string               `json:"swimmer_name"`
+	Standards   []AttainmentStandard `json:"standards"`
+	Events      []EventAttainment    `json:"events"`
+}
+
+// Attainment evaluates the swimmer's personal bests in a course against every
+// standard of the course and the swimmer's gender in effect today, i.e. only
+// the current version of each standard family. Each standard is judged in
+// the swimmer's current age group of its scheme, falling back to OPEN times.
+// Per event it reports the fastest standard achieved and the slowest standard
+// not yet achieved, with the gap to it. Qualifying periods of the standards
+// are not applied; use Compare for a single standard's rules.
+func (s *ComparisonService) Attainment(ctx context.Context, swimmerID uuid.UUID, courseType string) (*AttainmentMatrix, error) {
+	if !domain.CourseType(courseType).IsValid() {
+		return nil, errors.New("validation: course_type must be '25m', '50m' or '25y'")
+	}
+
+	swimmer, err := s.swimmerRepo.Get(ctx, swimmerID)
+	if err != nil {
+		return nil, fmt.Errorf("get swimmer: %w", err)
+	}
+
+	set, err := s.loadStandards(ctx, swimmer, courseType)
+	if err != nil {
+		return nil, err
+	}
+
+	pbMap, err := s.personalBests(ctx, swimmerID, courseType)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := &AttainmentMatrix{
+		CourseType:  courseType,
+		SwimmerName: swimmer.Name,
+		Standards:   set.info,
+		Events:      []EventAttainment{},
+	}
+
+	for _, event := range domain.EventsForCourse(domain.CourseType(courseType)) {
+		row := EventAttainment{
+			Event:     string(event),
+			Standards: []StandardLevel{},
+		}
+
+		pb, hasPB := pbMap[string(event)]
+		if hasPB {
+			row.SwimmerTimeMS, row.SwimmerTimeFormatted, row.MeetName, row.Date = swimmerTimeFields(pb)
+		}
+
+		for _, std := range set.standards {
+			level, ok := set.level(std, string(event), pb, hasPB)
+			if !ok {
+				continue
+			}
+			row.Standards = append(row.Standards, level)
+
+			// The highest standard achieved is the fastest one, the next
+			// standard up the slowest one not achieved
+			if level.Achieved {
+				if row.HighestAchieved == nil || level.TimeMS < row.HighestAchieved.TimeMS {
+					highest := level
+					row.HighestAchieved = &highest
+				}
+			} else if row.Next == nil || level.TimeMS > row.Next.TimeMS {
+				row.Next = &NextStandard{StandardLevel: level}
+			}
+		}
+
+		// Events without a personal best or a standard time are left out
+		if !hasPB && len(row.Standards) == 0 {
+			continue
+		}
+
+		if row.Next != nil && hasPB {
+			row.Next.GapMS, row.Next.GapFormatted, row.Next.GapPercent = gapFields(int(pb.TimeMs), row.Next.TimeMS)
+		}
+
+		matrix.Events = append(matrix.Events, row)
+	}
+
+	return matrix, nil
+}
+
+// standardSet holds standards of a course with all their times, loaded at
+// once for evaluating personal bests against each of them.
+type standardSet struct {
+	standards []db.TimeStandard
+	info      []AttainmentStandard
+	ageGroups map[uuid.UUID]string                      // standard -> current age group
+	times     map[uuid.UUID]map[string]map[string]int32 // standard -> event -> age_group -> time_ms
+	effective map[uuid.UUID]uuid.UUID                   // version -> effective version of its family
+}
+
+// loadStandards loads the standards of a course and the swimmer's gender in
+// effect today with their times and the swimmer's current age group under
+// each one's scheme, using a fixed number of queries.
+func (s *ComparisonService) loadStandards(ctx context.Context, swimmer *db.Swimmer, courseType string) (*standardSet, error) {
+	allStandards, err := s.standardRepo.List(ctx, postgres.ListStandardsParams{
+		CourseType: &courseType,
+		Gender:     &swimmer.Gender,
+		OwnerID:    swimmer.OwnerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list standards: %w", err)
+	}
+	now := time.Now()
+	standards := standard.EffectiveVersions(allStandards, now)
+
+	standardTimes, err := s.standardRepo.ListTimesForCourse(ctx, courseType, swimmer.Gender)
+	if err != nil {
+		return nil, fmt.Errorf("get standard times: %w", err)
+	}
+
+	schemeList, err := s.schemes.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list age group schemes: %w", err)
+	}
+	schemes := make(map[uuid.UUID]agegroup.Scheme, len(schemeList.Schemes))
+	for _, scheme := range schemeList.Schemes {
+		schemes[scheme.ID] = scheme
+	}
+
+	set := &standardSet{
+		standards: standards,
+		info:      make([]AttainmentStandard, 0, len(standards)),
+		ageGroups: make(map[uuid.UUID]string, len(standards)),
+		times:     make(map[uuid.UUID]map[string]map[string]int32),
+		effective: make(map[uuid.UUID]uuid.UUID),
+	}
+	familyVersion := make(map[pgtype.UUID]uuid.UUID, len(standards))
+	for _, std := range standards {
+		if std.FamilyID.Valid {
+			familyVersion[std.FamilyID] = std.ID
+		}
+	}
+	for _, std := range allStandards {
+		if std.FamilyID.Valid {
+			set.effective[std.ID] = familyVersion[std.FamilyID]
+		}
+	}
+	for _, st := range standardTimes {
+		stdTimesMap, ok := set.times[st.StandardID]
+		if !ok {
+			stdTimesMap = make(map[string]map[string]int32)
+			set.times[st.StandardID] = stdTimesMap
+		}
+		if stdTimesMap[st.Event] == nil {
+			stdTimesMap[st.Event] = make(map[string]int32)
+		}
+		stdTimesMap[st.Event][st.AgeGroup] = st.TimeMs
+	}
+
+	for _, std := range standards {
+		scheme, ok := schemes[std.AgeGroupSchemeID]
+		if !ok {
+			return nil, fmt.Errorf("get age group scheme: %w", postgres.ErrNotFound)
+		}
+		age := scheme.Age(swimmer.BirthDate.Time, now)
+		ageGroup := string(scheme.GroupForAge(age))
+		set.ageGroups[std.ID] = ageGroup
+		set.info = append(set.info, AttainmentStandard{
+			ID:             std.ID,
+			Name:           std.Name,
+			AgeGroupScheme: scheme.Name,
+			SwimmerAge:     age,
+			AgeGroup:       ageGroup,
+		})
+	}
+	return set, nil
+}
+
+// resolve returns the standard in effect for a standard of the set's course:
+// the effective version of its family, or the standard itself.
+func (set *standardSet) resolve(id uuid.UUID) uuid.UUID {
+	if effective, ok := set.effective[id]; ok {
+		return effective
+	}
+	return id
+}
+
+// effectiveVersion returns the version of a standard's family effective on
+// the date, or the standard itself when it is not part of a family.
+func (s *ComparisonService) effectiveVersion(ctx context.Context, std *db.TimeStandard, date time.Time) (*db.TimeStandard, error) {
+	if !std.FamilyID.Valid {
+		return std, nil
+	}
+	versions, err := s.standardRepo.ListVersions(ctx, uuid.UUID(std.FamilyID.Bytes))
+	if err != nil {
+		return nil, err
+	}
+	effective := standard.EffectiveVersions(versions, date)
+	if len(effective) == 0 {
+		return std, nil
+	}
+	return &effective[0], nil
+}
+
+// level returns the time of a standard in an event for the swimmer's current
+// age group, falling back to OPEN, and whether the personal best achieves it.
+func (set *standardSet) level(std db.TimeStandard, event string, pb db.GetPersonalBestsRow, hasPB bool) (StandardLevel, bool) {
+	stdTimeMS, ageGroup, ok := getStandardTime(set.times[std.ID], event, set.ageGroups[std.ID])
+	if !ok {
+		return StandardLevel{}, false
+	}
+	return StandardLevel{
+		StandardID:    std.ID,
+		StandardName:  std.Name,
+		AgeGroup:      ageGroup,
+		TimeMS:        int(stdTimeMS),
+		TimeFormatted: domain.FormatTime(int(stdTimeMS)),
+		Achieved:      hasPB && pb.TimeMs <= stdTimeMS,
+	}, true
+}
+
+// personalBests returns the swimmer's personal bests in a course by event.
+func (s *ComparisonService) personalBests(ctx context.Context, swimmerID uuid.UUID, courseType string) (map[string]db.GetPersonalBestsRow, error) {
+	pbs, err := s.timeRepo.GetPersonalBests(ctx, swimmerID, courseType)
+	if err != nil {
+		return nil, fmt.Errorf("get personal bests: %w", err)
+	}
+	pbMap := make(map[string]db.GetPersonalBestsRow, len(pbs))
+	for _, pb := range pbs {
+		pbMap[pb.Event] = pb
+	}
+	return pbMap, nil
+}
+
+// swimmerTimeFields returns the time, formatted time, meet name and date of a
+// personal best.
+func swimmerTimeFields(pb db.GetPersonalBestsRow) (*int, *string, *string, *string) {
+	swimmerTime := int(pb.TimeMs)
+	swimmerTimeFormatted := domain.FormatTime(swimmerTime)
+	meetName := pb.MeetName
+	var date *string
+	if pb.MeetDate.Valid {
+		d := pb.MeetDate.Time.Format("Jan 2, 2006")
+		date = &d
+	}
+	return &swimmerTime, &swimmerTimeFormatted, &meetName, date
+}
+
+// gapFields returns the gap from a swimmer's time to a standard time, formatted
+// and as a percentage of the standard time.
+func gapFields(swimmerTimeMS, standardTimeMS int) (*int, *string, *float64) {
+	gap := swimmerTimeMS - standardTimeMS
+	gapFormatted := formatDifference(gap)
+	gapPercent := float64(gap) / float64(standardTimeMS) * 100
+	return &gap, &gapFormatted, &gapPercent
+}
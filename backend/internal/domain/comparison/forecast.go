@@ -0,0 +1,406 @@
+package comparison
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+const (
+	// forecastHorizonDays is how far ahead standards are forecast.
+	forecastHorizonDays = 730
+	// forecastDayOffset is added to the days since the first swim before
+	// taking the logarithm, so that the first weeks do not dominate the fit.
+	forecastDayOffset = 30
+	// minForecastSwims is the number of swims needed to fit a trend.
+	minForecastSwims = 3
+	// thinHistorySwims and thinHistoryDays are the history below which a
+	// forecast is flagged as unreliable.
+	thinHistorySwims = 6
+	thinHistoryDays  = 180
+)
+
+// ForecastStatus is the outlook for reaching a standard time.
+type ForecastStatus string
+
+const (
+	// ForecastAchieved means the personal best already meets the standard.
+	ForecastAchieved ForecastStatus = "achieved"
+	// ForecastProjected means the trend reaches the standard within the horizon.
+	ForecastProjected ForecastStatus = "projected"
+	// ForecastBeyondHorizon means the trend is improving but does not reach
+	// the standard within the horizon.
+	ForecastBeyondHorizon ForecastStatus = "beyond_horizon"
+	// ForecastNotImproving means the trend is flat or getting slower.
+	ForecastNotImproving ForecastStatus = "not_improving"
+	// ForecastInsufficientData means there are too few swims to fit a trend.
+	ForecastInsufficientData ForecastStatus = "insufficient_data"
+)
+
+// ForecastService forecasts when a swimmer will reach time standards.
+type ForecastService struct {
+	timeRepo     *postgres.TimeRepository
+	standardRepo *postgres.StandardRepository
+	swimmerRepo  *postgres.SwimmerRepository
+	schemes      *agegroup.Service
+}
+
+// NewForecastService creates a new forecast service.
+func NewForecastService(
+	timeRepo *postgres.TimeRepository,
+	standardRepo *postgres.StandardRepository,
+	swimmerRepo *postgres.SwimmerRepository,
+	schemes *agegroup.Service,
+) *ForecastService {
+	return &ForecastService{
+		timeRepo:     timeRepo,
+		standardRepo: standardRepo,
+		swimmerRepo:  swimmerRepo,
+		schemes:      schemes,
+	}
+}
+
+// Trend is a logarithmic improvement curve fitted to a swimmer's history:
+// time = intercept + slope * ln(days since the first swim + 30). A negative
+// slope means the swimmer is getting faster, with gains slowing over time.
+type Trend struct {
+	Model             string  `json:"model"`
+	InterceptMS       float64 `json:"intercept_ms"`
+	Slope             float64 `json:"slope"`
+	RSquared          float64 `json:"r_squared"`
+	ResidualMS        float64 `json:"residual_ms"`
+	ConfidenceLevel   float64 `json:"confidence_level"`
+	CurrentEstimateMS int     `json:"current_estimate_ms"`
+}
+
+// ProjectionPoint is a point of the projected trend with its confidence band.
+type ProjectionPoint struct {
+	Date   string `json:"date"`
+	TimeMS int    `json:"time_ms"`
+	LowMS  int    `json:"low_ms"`
+	HighMS int    `json:"high_ms"`
+}
+
+// StandardForecast is the outlook for one standard. ETA is when the trend
+// reaches the standard time; the earliest and latest dates are when the
+// faster and slower edges of the confidence band reach it.
+type StandardForecast struct {
+	StandardID            uuid.UUID      `json:"standard_id"`
+	StandardName          string         `json:"standard_name"`
+	AgeGroup              string         `json:"age_group"`
+	StandardTimeMS        int            `json:"standard_time_ms"`
+	StandardTimeFormatted string         `json:"standard_time_formatted"`
+	GapMS                 *int           `json:"gap_ms,omitempty"`
+	Status                ForecastStatus `json:"status"`
+	ETA                   *string        `json:"eta,omitempty"`
+	ETAEarliest           *string        `json:"eta_earliest,omitempty"`
+	ETALatest             *string        `json:"eta_latest,omitempty"`
+}
+
+// Forecast is the time-to-standard forecast of a swimmer for an event.
+type Forecast struct {
+	SwimmerID      string             `json:"swimmer_id"`
+	Event          string             `json:"event"`
+	CourseType     string             `json:"course_type"`
+	Season         string             `json:"season"`
+	Swims          int                `json:"swims"`
+	HistoryStart   *string            `json:"history_start,omitempty"`
+	HistoryEnd     *string            `json:"history_end,omitempty"`
+	ThinHistory    bool               `json:"thin_history"`
+	PersonalBestMS *int               `json:"personal_best_ms,omitempty"`
+	Trend          *Trend             `json:"trend,omitempty"`
+	Projection     []ProjectionPoint  `json:"projection"`
+	Forecasts      []StandardForecast `json:"forecasts"`
+}
+
+// GetForecast fits a trend to the swimmer's official swims of an event in a
+// course and estimates when each standard of the swimmer's gender and course
+// will be reached. Standards are taken for the age group the swimmer will be
+// in at the start of the upcoming season, falling back to OPEN times. Of a
+// standard family, only the version in effect today is forecast.
+func (s *ForecastService) GetForecast(ctx context.Context, swimmerID uuid.UUID, courseType, event string) (*Forecast, error) {
+	if !domain.CourseType(courseType).IsValid() {
+		return nil, errors.New("validation: course_type must be '25m', '50m' or '25y'")
+	}
+	if !domain.EventCode(event).IsValid() {
+		return nil, fmt.Errorf("validation: invalid event: %s", event)
+	}
+
+	dbSwimmer, err := s.swimmerRepo.Get(ctx, swimmerID)
+	if err != nil {
+		return nil, fmt.Errorf("get swimmer: %w", err)
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	def := swimmer.SeasonDefinition(dbSwimmer)
+	upcoming := def.SeasonOf(def.SeasonOf(today).EndDate.AddDate(0, 0, 1))
+
+	swims, err := s.timeRepo.ListOfficialSwims(ctx, swimmerID, courseType, postgres.SwimWindow{})
+	if err != nil {
+		return nil, err
+	}
+
+	var history []trendPoint
+	for _, swim := range swims {
+		if swim.Event == event && swim.Date.Valid {
+			history = append(history, trendPoint{date: swim.Date.Time, timeMS: float64(swim.TimeMs)})
+		}
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].date.Before(history[j].date) })
+
+	forecast := &Forecast{
+		SwimmerID:   swimmerID.String(),
+		Event:       event,
+		CourseType:  courseType,
+		Season:      upcoming.Label,
+		Swims:       len(history),
+		ThinHistory: true,
+		Projection:  []ProjectionPoint{},
+		Forecasts:   []StandardForecast{},
+	}
+
+	var pb *int
+	if len(history) > 0 {
+		first := history[0].date.Format("2006-01-02")
+		last := history[len(history)-1].date.Format("2006-01-02")
+		forecast.HistoryStart, forecast.HistoryEnd = &first, &last
+
+		best := int(history[0].timeMS)
+		for _, p := range history {
+			best = min(best, int(p.timeMS))
+		}
+		pb = &best
+		forecast.PersonalBestMS = pb
+
+		span := history[len(history)-1].date.Sub(history[0].date)
+		forecast.ThinHistory = len(history) < thinHistorySwims || span < thinHistoryDays*24*time.Hour
+	}
+
+	fit := fitTrend(history)
+	if fit != nil {
+		forecast.Trend = &Trend{
+			Model:             "logarithmic",
+			InterceptMS:       fit.intercept,
+			Slope:             fit.slope,
+			RSquared:          fit.rSquared,
+			ResidualMS:        fit.residual,
+			ConfidenceLevel:   0.9,
+			CurrentEstimateMS: int(math.Round(fit.predict(today))),
+		}
+		for month := 0; month*30 <= forecastHorizonDays; month += 3 {
+			date := today.AddDate(0, month, 0)
+			value, low, high := fit.band(date)
+			forecast.Projection = append(forecast.Projection, ProjectionPoint{
+				Date:   date.Format("2006-01-02"),
+				TimeMS: int(math.Round(value)),
+				LowMS:  int(math.Round(low)),
+				HighMS: int(math.Round(high)),
+			})
+		}
+	}
+
+	gender := dbSwimmer.Gender
+	standards, err := s.standardRepo.List(ctx, postgres.ListStandardsParams{CourseType: &courseType, Gender: &gender, OwnerID: dbSwimmer.OwnerID})
+	if err != nil {
+		return nil, fmt.Errorf("list standards: %w", err)
+	}
+	standards = standard.EffectiveVersions(standards, today)
+
+	schemes := make(map[uuid.UUID]*agegroup.Scheme)
+	for _, std := range standards {
+		scheme, ok := schemes[std.AgeGroupSchemeID]
+		if !ok {
+			scheme, err = s.schemes.Get(ctx, std.AgeGroupSchemeID)
+			if err != nil {
+				return nil, fmt.Errorf("get age group scheme: %w", err)
+			}
+			schemes[std.AgeGroupSchemeID] = scheme
+		}
+		ageGroup := string(scheme.GroupForAge(scheme.Age(dbSwimmer.BirthDate.Time, upcoming.StartDate)))
+
+		stdTimes, err := s.standardRepo.ListTimes(ctx, std.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get standard times: %w", err)
+		}
+		stdTimesMap := make(map[string]map[string]int32)
+		for _, st := range stdTimes {
+			if stdTimesMap[st.Event] == nil {
+				stdTimesMap[st.Event] = make(map[string]int32)
+			}
+			stdTimesMap[st.Event][st.AgeGroup] = st.TimeMs
+		}
+		stdTime, usedAgeGroup, ok := getStandardTime(stdTimesMap, event, ageGroup)
+		if !ok {
+			continue
+		}
+
+		forecast.Forecasts = append(forecast.Forecasts, forecastStandard(std.ID, std.Name, usedAgeGroup, int(stdTime), pb, fit, today))
+	}
+
+	return forecast, nil
+}
+
+// forecastStandard estimates when the trend reaches a standard time.
+func forecastStandard(id uuid.UUID, name, ageGroup string, stdTime int, pb *int, fit *trendFit, today time.Time) StandardForecast {
+	f := StandardForecast{
+		StandardID:            id,
+		StandardName:          name,
+		AgeGroup:              ageGroup,
+		StandardTimeMS:        stdTime,
+		StandardTimeFormatted: domain.FormatTime(stdTime),
+	}
+	if pb != nil {
+		gap := *pb - stdTime
+		f.GapMS = &gap
+		if gap <= 0 {
+			f.Status = ForecastAchieved
+			return f
+		}
+	}
+
+	switch {
+	case fit == nil:
+		f.Status = ForecastInsufficientData
+		return f
+	case fit.slope >= 0:
+		f.Status = ForecastNotImproving
+		return f
+	}
+
+	target := float64(stdTime)
+	for day := 0; day <= forecastHorizonDays; day++ {
+		date := today.AddDate(0, 0, day)
+		value, low, high := fit.band(date)
+		formatted := date.Format("2006-01-02")
+		if f.ETAEarliest == nil && low <= target {
+			f.ETAEarliest = &formatted
+		}
+		if f.ETA == nil && value <= target {
+			f.ETA = &formatted
+		}
+		if f.ETALatest == nil && high <= target {
+			f.ETALatest = &formatted
+			break
+		}
+	}
+
+	f.Status = ForecastProjected
+	if f.ETA == nil {
+		f.Status = ForecastBeyondHorizon
+	}
+	return f
+}
+
+// trendPoint is a swim in the history being fitted.
+type trendPoint struct {
+	date   time.Time
+	timeMS float64
+}
+
+// trendFit is a least-squares fit of time against ln(days + offset).
+type trendFit struct {
+	origin    time.Time
+	intercept float64
+	slope     float64
+	rSquared  float64
+	residual  float64 // residual standard error
+	n         int
+	meanX     float64
+	sxx       float64
+	tValue    float64
+}
+
+// fitTrend fits a logarithmic trend to the history, or returns nil if there
+// are too few swims or they were all swum on the same day.
+func fitTrend(history []trendPoint) *trendFit {
+	n := len(history)
+	if n < minForecastSwims {
+		return nil
+	}
+
+	fit := &trendFit{origin: history[0].date, n: n}
+	xs := make([]float64, n)
+	var sumX, sumY float64
+	for i, p := range history {
+		xs[i] = fit.x(p.date)
+		sumX += xs[i]
+		sumY += p.timeMS
+	}
+	fit.meanX = sumX / float64(n)
+	meanY := sumY / float64(n)
+
+	var sxy, syy float64
+	for i, p := range history {
+		dx, dy := xs[i]-fit.meanX, p.timeMS-meanY
+		fit.sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+	if fit.sxx == 0 {
+		return nil
+	}
+
+	fit.slope = sxy / fit.sxx
+	fit.intercept = meanY - fit.slope*fit.meanX
+
+	var sse float64
+	for i, p := range history {
+		r := p.timeMS - (fit.intercept + fit.slope*xs[i])
+		sse += r * r
+	}
+	if syy > 0 {
+		fit.rSquared = 1 - sse/syy
+	}
+	fit.residual = math.Sqrt(sse / float64(n-2))
+	fit.tValue = tCritical90(n - 2)
+	return fit
+}
+
+// x returns the fitted variable for a date.
+func (f *trendFit) x(date time.Time) float64 {
+	days := date.Sub(f.origin).Hours() / 24
+	return math.Log(math.Max(days, 0) + forecastDayOffset)
+}
+
+// predict returns the trend time at a date.
+func (f *trendFit) predict(date time.Time) float64 {
+	return f.intercept + f.slope*f.x(date)
+}
+
+// band returns the trend time at a date with the 90% prediction interval
+// for a single swim.
+func (f *trendFit) band(date time.Time) (value, low, high float64) {
+	x := f.x(date)
+	value = f.intercept + f.slope*x
+	width := f.tValue * f.residual * math.Sqrt(1+1/float64(f.n)+(x-f.meanX)*(x-f.meanX)/f.sxx)
+	return value, value - width, value + width
+}
+
+// tTable90 holds the two-sided 90% critical values of Student's t
+// distribution for 1 to 30 degrees of freedom.
+var tTable90 = []float64{
+	6.314, 2.920, 2.353, 2.132, 2.015, 1.943, 1.895, 1.860, 1.833, 1.812,
+	1.796, 1.782, 1.771, 1.761, 1.753, 1.746, 1.740, 1.734, 1.729, 1.725,
+	1.721, 1.717, 1.714, 1.711, 1.708, 1.706, 1.703, 1.701, 1.699, 1.697,
+}
+
+// tCritical90 returns the two-sided 90% critical value of Student's t
+// distribution, using the normal value above 30 degrees of freedom.
+func tCritical90(df int) float64 {
+	if df >= 1 && df <= len(tTable90) {
+		return tTable90[df-1]
+	}
+	return 1.645
+}
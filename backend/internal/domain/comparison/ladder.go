@@ -0,0 +1,162 @@
+package comparison
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// LadderRung is the time of a ladder's rung in an event.
+type LadderRung struct {
+	Position int    `json:"position"`
+	Label    string `json:"label,omitempty"`
+	StandardLevel
+}
+
+// NextRung is the next rung up from the current rung of an event. The gap is
+// only set when the event has a personal best.
+type NextRung struct {
+	LadderRung
+	GapMS        *int     `json:"gap_ms"`
+	GapFormatted *string  `json:"gap_formatted"`
+	GapPercent   *float64 `json:"gap_percent"`
+}
+
+// EventLadder is the standing of an event on a ladder.
+type EventLadder struct {
+	Event                string       `json:"event"`
+	SwimmerTimeMS        *int         `json:"swimmer_time_ms"`
+	SwimmerTimeFormatted *string      `json:"swimmer_time_formatted"`
+	MeetName             *string      `json:"meet_name"`
+	Date                 *string      `json:"date"`
+	Rungs                []LadderRung `json:"rungs"`
+	CurrentRung          *LadderRung  `json:"current_rung"`
+	NextRung             *NextRung    `json:"next_rung"`
+}
+
+// LadderComparison represents a swimmer's personal bests placed on a ladder.
+type LadderComparison struct {
+	LadderID    uuid.UUID            `json:"ladder_id"`
+	LadderName  string               `json:"ladder_name"`
+	CourseType  string               `json:"course_type"`
+	SwimmerName string               `json:"swimmer_name"`
+	Standards   []AttainmentStandard `json:"standards"`
+	Events      []EventLadder        `json:"events"`
+}
+
+// CompareLadder places the swimmer's personal bests in a ladder's course on
+// its rungs. The current rung of an event is the highest one achieved in the
+// swimmer's current age group, falling back to OPEN times, and the next rung
+// the first one above it with a time for the event. Events without a time on
+// any rung are left out. A rung that is a version of a standard family uses
+// the version in effect today.
+func (s *ComparisonService) CompareLadder(ctx context.Context, swimmerID, ladderID uuid.UUID) (*LadderComparison, error) {
+	swimmer, err := s.swimmerRepo.Get(ctx, swimmerID)
+	if err != nil {
+		return nil, fmt.Errorf("get swimmer: %w", err)
+	}
+
+	ladder, err := s.ladderRepo.Get(ctx, ladderID)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' ladders are not compared against
+	if ladder.OwnerID != "" && ladder.OwnerID != swimmer.OwnerID {
+		return nil, postgres.ErrNotFound
+	}
+	if ladder.Gender != swimmer.Gender {
+		return nil, fmt.Errorf("validation: %s is a ladder of %s standards", ladder.Name, ladder.Gender)
+	}
+
+	rungs, err := s.ladderRepo.ListRungs(ctx, ladderID)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := s.loadStandards(ctx, swimmer, ladder.CourseType)
+	if err != nil {
+		return nil, err
+	}
+
+	pbMap, err := s.personalBests(ctx, swimmerID, ladder.CourseType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LadderComparison{
+		LadderID:    ladder.ID,
+		LadderName:  ladder.Name,
+		CourseType:  ladder.CourseType,
+		SwimmerName: swimmer.Name,
+		Standards:   []AttainmentStandard{},
+		Events:      []EventLadder{},
+	}
+
+	// Rungs of standards no longer in the ladder's course and gender are skipped
+	stdIndex := make(map[uuid.UUID]int, len(set.standards))
+	for i, std := range set.standards {
+		stdIndex[std.ID] = i
+	}
+	for _, rung := range rungs {
+		if i, ok := stdIndex[set.resolve(rung.StandardID)]; ok {
+			result.Standards = append(result.Standards, set.info[i])
+		}
+	}
+
+	for _, event := range domain.EventsForCourse(domain.CourseType(ladder.CourseType)) {
+		row := EventLadder{
+			Event: string(event),
+			Rungs: []LadderRung{},
+		}
+
+		pb, hasPB := pbMap[string(event)]
+		if hasPB {
+			row.SwimmerTimeMS, row.SwimmerTimeFormatted, row.MeetName, row.Date = swimmerTimeFields(pb)
+		}
+
+		for _, rung := range rungs {
+			i, ok := stdIndex[set.resolve(rung.StandardID)]
+			if !ok {
+				continue
+			}
+			level, ok := set.level(set.standards[i], string(event), pb, hasPB)
+			if !ok {
+				continue
+			}
+			row.Rungs = append(row.Rungs, LadderRung{
+				Position:      int(rung.Position),
+				Label:         rung.Label.String,
+				StandardLevel: level,
+			})
+		}
+		if len(row.Rungs) == 0 {
+			continue
+		}
+
+		for i := len(row.Rungs) - 1; i >= 0; i-- {
+			if row.Rungs[i].Achieved {
+				current := row.Rungs[i]
+				row.CurrentRung = &current
+				break
+			}
+		}
+		for _, rung := range row.Rungs {
+			if row.CurrentRung != nil && rung.Position <= row.CurrentRung.Position {
+				continue
+			}
+			row.NextRung = &NextRung{LadderRung: rung}
+			if hasPB {
+				row.NextRung.GapMS, row.NextRung.GapFormatted, row.NextRung.GapPercent = gapFields(int(pb.TimeMs), rung.TimeMS)
+			}
+			break
+		}
+
+		result.Events = append(result.Events, row)
+	}
+
+	return result, nil
+}
@@ -3,23 +3,30 @@ package comparison
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/google/uuid"
 
 	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/points"
 	"github.com/bpg/swimstats/backend/internal/store/postgres"
 )
 
 // PersonalBestService provides personal best business logic.
 type PersonalBestService struct {
-	timeRepo *postgres.TimeRepository
+	timeRepo    *postgres.TimeRepository
+	swimmerRepo *postgres.SwimmerRepository
+	points      *points.Service
 }
 
 // NewPersonalBestService creates a new personal best service.
-func NewPersonalBestService(timeRepo *postgres.TimeRepository) *PersonalBestService {
+func NewPersonalBestService(timeRepo *postgres.TimeRepository, swimmerRepo *postgres.SwimmerRepository, pointsService *points.Service) *PersonalBestService {
 	return &PersonalBestService{
-		timeRepo: timeRepo,
+		timeRepo:    timeRepo,
+		swimmerRepo: swimmerRepo,
+		points:      pointsService,
 	}
 }
 
@@ -31,6 +38,8 @@ type PersonalBest struct {
 	TimeID        string `json:"time_id"`
 	MeetName      string `json:"meet"`
 	Date          string `json:"date"`
+	RelayEvent    string `json:"relay_event,omitempty"` // Set when the PB is a relay lead-off leg
+	Points        *int   `json:"points,omitempty"`
 }
 
 // PersonalBestList represents a list of personal bests.
@@ -51,6 +60,11 @@ func (s *PersonalBestService) GetPersonalBests(ctx context.Context, swimmerID uu
 		return nil, fmt.Errorf("get personal bests: %w", err)
 	}
 
+	calculator, err := pointsCalculator(ctx, s.swimmerRepo, s.points, swimmerID)
+	if err != nil {
+		return nil, err
+	}
+
 	pbs := make([]PersonalBest, len(rows))
 	for i, row := range rows {
 		date := ""
@@ -66,6 +80,14 @@ func (s *PersonalBestService) GetPersonalBests(ctx context.Context, swimmerID uu
 			MeetName:      row.MeetName,
 			Date:          date,
 		}
+		if row.RelayLeg.Valid {
+			pbs[i].RelayEvent = row.SwumEvent
+		}
+		swumOn := row.MeetDate.Time
+		if row.EventDate.Valid {
+			swumOn = row.EventDate.Time
+		}
+		pbs[i].Points = calculator.PointsPtr(courseType, row.Event, swumOn, int(row.TimeMs))
 	}
 
 	return &PersonalBestList{
@@ -91,6 +113,62 @@ func (s *PersonalBestService) GetPersonalBestsByStroke(ctx context.Context, swim
 	return byStroke, nil
 }
 
+// BestEvent is a personal best ranked by World Aquatics points.
+type BestEvent struct {
+	Rank       int    `json:"rank"`
+	CourseType string `json:"course_type"`
+	PersonalBest
+}
+
+// BestEventList represents a swimmer's personal bests ranked by points.
+type BestEventList struct {
+	CourseType string      `json:"course_type,omitempty"`
+	Events     []BestEvent `json:"events"`
+}
+
+// GetBestEvents ranks a swimmer's personal bests by World Aquatics points,
+// best first, in one course or, if courseType is empty, in all courses.
+// Events without a base time are left out.
+func (s *PersonalBestService) GetBestEvents(ctx context.Context, swimmerID uuid.UUID, courseType string) (*BestEventList, error) {
+	courses := []string{courseType}
+	if courseType == "" {
+		courses = []string{domain.Course25m.String(), domain.Course50m.String(), domain.Course25y.String()}
+	} else if !domain.CourseType(courseType).IsValid() {
+		return nil, errors.New("validation: course_type must be '25m', '50m' or '25y'")
+	}
+
+	events := []BestEvent{}
+	for _, course := range courses {
+		list, err := s.GetPersonalBests(ctx, swimmerID, course)
+		if err != nil {
+			return nil, err
+		}
+		for _, pb := range list.PersonalBests {
+			if pb.Points != nil {
+				events = append(events, BestEvent{CourseType: course, PersonalBest: pb})
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return *events[i].Points > *events[j].Points
+	})
+	for i := range events {
+		events[i].Rank = i + 1
+	}
+
+	return &BestEventList{CourseType: courseType, Events: events}, nil
+}
+
+// pointsCalculator loads the points base times for the swimmer's gender.
+func pointsCalculator(ctx context.Context, swimmerRepo *postgres.SwimmerRepository, pointsService *points.Service, swimmerID uuid.UUID) (*points.Calculator, error) {
+	dbSwimmer, err := swimmerRepo.Get(ctx, swimmerID)
+	if err != nil {
+		return nil, fmt.Errorf("get swimmer: %w", err)
+	}
+	return pointsService.Calculator(ctx, dbSwimmer.Gender)
+}
+
 // IsPersonalBest checks if a given time would be a new personal best.
 func (s *PersonalBestService) IsPersonalBest(ctx context.Context, swimmerID uuid.UUID, courseType, event string, timeMS int, excludeTimeID *uuid.UUID) (bool, error) {
 	return s.timeRepo.IsPersonalBest(ctx, swimmerID, courseType, event, int32(timeMS), excludeTimeID)
@@ -8,18 +8,23 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/points"
 	"github.com/bpg/swimstats/backend/internal/store/postgres"
 )
 
 // ProgressService provides time progression business logic.
 type ProgressService struct {
-	timeRepo *postgres.TimeRepository
+	timeRepo    *postgres.TimeRepository
+	swimmerRepo *postgres.SwimmerRepository
+	points      *points.Service
 }
 
 // NewProgressService creates a new progress service.
-func NewProgressService(timeRepo *postgres.TimeRepository) *ProgressService {
+func NewProgressService(timeRepo *postgres.TimeRepository, swimmerRepo *postgres.SwimmerRepository, pointsService *points.Service) *ProgressService {
 	return &ProgressService{
-		timeRepo: timeRepo,
+		timeRepo:    timeRepo,
+		swimmerRepo: swimmerRepo,
+		points:      pointsService,
 	}
 }
 
@@ -33,6 +38,7 @@ type ProgressDataPoint struct {
 	MeetName       string `json:"meet_name"`
 	Event          string `json:"event"`
 	IsPersonalBest bool   `json:"is_pb"`
+	Points         *int   `json:"points,omitempty"`
 }
 
 // ProgressData represents the complete progress data for an event.
@@ -68,6 +74,11 @@ func (s *ProgressService) GetProgressData(
 		return nil, fmt.Errorf("get progress data: %w", err)
 	}
 
+	calculator, err := pointsCalculator(ctx, s.swimmerRepo, s.points, swimmerID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert to domain objects
 	dataPoints := make([]ProgressDataPoint, len(rows))
 	for i, row := range rows {
@@ -85,6 +96,7 @@ func (s *ProgressService) GetProgressData(
 			MeetName:       row.MeetName,
 			Event:          row.Event,
 			IsPersonalBest: row.IsPb,
+			Points:         calculator.PointsPtr(courseType, event, row.Date.Time, int(row.TimeMs)),
 		}
 	}
 
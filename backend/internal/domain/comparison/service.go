@@ -8,6 +8,8 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/domain/conversion"
 	"github.com/bpg/swimstats/backend/internal/store/db"
 	"github.com/bpg/swimstats/backend/internal/store/postgres"
 )
@@ -17,6 +19,9 @@ type ComparisonService struct {
 	timeRepo     *postgres.TimeRepository
 	standardRepo *postgres.StandardRepository
 	swimmerRepo  *postgres.SwimmerRepository
+	ladderRepo   *postgres.LadderRepository
+	conversions  *conversion.Service
+	schemes      *agegroup.Service
 }
 
 // NewComparisonService creates a new comparison service.
@@ -24,11 +29,17 @@ func NewComparisonService(
 	timeRepo *postgres.TimeRepository,
 	standardRepo *postgres.StandardRepository,
 	swimmerRepo *postgres.SwimmerRepository,
+	ladderRepo *postgres.LadderRepository,
+	conversions *conversion.Service,
+	schemes *agegroup.Service,
 ) *ComparisonService {
 	return &ComparisonService{
 		timeRepo:     timeRepo,
 		standardRepo: standardRepo,
 		swimmerRepo:  swimmerRepo,
+		ladderRepo:   ladderRepo,
+		conversions:  conversions,
+		schemes:      schemes,
 	}
 }
 
@@ -43,6 +54,50 @@ const (
 	StatusNoStandard  ComparisonStatus = "no_standard"
 )
 
+// Mode selects how swims are compared against a standard.
+type Mode string
+
+const (
+	// ModeCurrent compares personal bests against the swimmer's current age group.
+	ModeCurrent Mode = "current"
+	// ModeAtSwim compares each swim against the standard of the age group the
+	// swimmer was in on the meet date, as championship qualification is judged.
+	ModeAtSwim Mode = "at_swim"
+)
+
+// IsValid checks if the mode is valid.
+func (m Mode) IsValid() bool {
+	return m == ModeCurrent || m == ModeAtSwim
+}
+
+// QualifyingWindow is the period of meet dates in which the swimmer competes
+// in an age group. EndDate is nil for an open-ended age group.
+type QualifyingWindow struct {
+	AgeGroup  string  `json:"age_group"`
+	StartDate string  `json:"start_date"`
+	EndDate   *string `json:"end_date,omitempty"`
+}
+
+// QualificationStatus is the standing of an event within the qualifying
+// period of a standard.
+type QualificationStatus string
+
+const (
+	QualificationQualified    QualificationStatus = "qualified"
+	QualificationNotYet       QualificationStatus = "not_yet"
+	QualificationWindowClosed QualificationStatus = "window_closed"
+)
+
+// QualifyingPeriod describes the rules limiting the swims that count toward a
+// standard. Closed is set once the end date has passed.
+type QualifyingPeriod struct {
+	StartDate      *string `json:"start_date,omitempty"`
+	EndDate        *string `json:"end_date,omitempty"`
+	RequiredCourse *string `json:"required_course,omitempty"`
+	SanctionedOnly bool    `json:"sanctioned_only"`
+	Closed         bool    `json:"closed"`
+}
+
 // EventComparison represents a single event's comparison.
 type EventComparison struct {
 	Event                 string           `json:"event"`
@@ -58,6 +113,22 @@ type EventComparison struct {
 	MeetName              *string          `json:"meet_name"`
 	Date                  *string          `json:"date"`
 
+	// Course conversion, set when the swim was converted to the standard's course
+	Converted             bool     `json:"converted"`
+	ConvertedFrom         *string  `json:"converted_from,omitempty"`
+	OriginalEvent         *string  `json:"original_event,omitempty"`
+	OriginalTimeMS        *int     `json:"original_time_ms,omitempty"`
+	OriginalTimeFormatted *string  `json:"original_time_formatted,omitempty"`
+	ConversionFactor      *float64 `json:"conversion_factor,omitempty"`
+
+	// Evaluation at the time of the swim, set in at_swim mode
+	AchievedAtTime   *bool             `json:"achieved_at_time,omitempty"`
+	SwimAge          *int              `json:"swim_age,omitempty"`
+	QualifyingWindow *QualifyingWindow `json:"qualifying_window,omitempty"`
+
+	// Standing within the standard's qualifying period, set when it has one
+	Qualification QualificationStatus `json:"qualification,omitempty"`
+
 	// Adjacent age groups
 	PrevAgeGroup              *string `json:"prev_age_group,omitempty"`
 	PrevStandardTimeMS        *int    `json:"prev_standard_time_ms,omitempty"`
@@ -83,9 +154,18 @@ type ComparisonSummary struct {
 type ComparisonResult struct {
 	StandardID       uuid.UUID         `json:"standard_id"`
 	StandardName     string            `json:"standard_name"`
+	Version          string            `json:"version,omitempty"`
+	EffectiveFrom    string            `json:"effective_from,omitempty"`
+	EvaluationDate   string            `json:"evaluation_date"`
 	CourseType       string            `json:"course_type"`
+	StandardCourse   string            `json:"standard_course_type"`
+	Converted        bool              `json:"converted"`
+	Mode             Mode              `json:"mode"`
 	SwimmerName      string            `json:"swimmer_name"`
+	SwimmerAge       int               `json:"swimmer_age"`
 	SwimmerAgeGroup  string            `json:"swimmer_age_group"`
+	AgeGroupScheme   string            `json:"age_group_scheme"`
+	QualifyingPeriod *QualifyingPeriod `json:"qualifying_period,omitempty"`
 	ThresholdPercent float64           `json:"threshold_percent"`
 	Comparisons      []EventComparison `json:"comparisons"`
 	Summary          ComparisonSummary `json:"summary"`
@@ -94,8 +174,17 @@ type ComparisonResult struct {
 // DefaultThresholdPercent is the default "almost there" threshold.
 const DefaultThresholdPercent = 3.0
 
-// Compare compares a swimmer's personal bests against a standard.
-func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID uuid.UUID, courseType string, thresholdPercent *float64) (*ComparisonResult, error) {
+// Compare compares a swimmer's swims against a standard as of the evaluation
+// date. In ModeCurrent the personal bests are compared against the swimmer's
+// age group on that date. In ModeAtSwim each event reports the most recent
+// swim that achieved the standard of the swimmer's age group on its meet
+// date, or else the swim that came closest; adjacent age groups are still
+// relative to the age group on the evaluation date.
+// When the standard has qualifying rules only the swims meeting them count.
+// For a version of a standard family, the version effective on the evaluation
+// date is compared against instead.
+func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID uuid.UUID, courseType string, thresholdPercent *float64, mode Mode, date time.Time) (*ComparisonResult, error) {
+
 	// Get swimmer
 	swimmer, err := s.swimmerRepo.Get(ctx, swimmerID)
 	if err != nil {
@@ -107,9 +196,23 @@ func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID u
 	if err != nil {
 		return nil, fmt.Errorf("get standard: %w", err)
 	}
+	// Other users' standards are not compared against
+	if standard.OwnerID != "" && standard.OwnerID != swimmer.OwnerID {
+		return nil, fmt.Errorf("get standard: %w", postgres.ErrNotFound)
+	}
+	standard, err = s.effectiveVersion(ctx, standard, date)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ages and age groups follow the standard's scheme
+	scheme, err := s.schemes.Get(ctx, standard.AgeGroupSchemeID)
+	if err != nil {
+		return nil, fmt.Errorf("get age group scheme: %w", err)
+	}
 
 	// Get standard times
-	standardTimes, err := s.standardRepo.ListTimes(ctx, standardID)
+	standardTimes, err := s.standardRepo.ListTimes(ctx, standard.ID)
 	if err != nil {
 		return nil, fmt.Errorf("get standard times: %w", err)
 	}
@@ -123,16 +226,49 @@ func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID u
 		stdTimesMap[st.Event][st.AgeGroup] = st.TimeMs
 	}
 
+	// Only swims meeting the standard's qualifying rules count. Swims in
+	// another course than a required one never do.
+	period := qualifyingPeriod(standard, date)
+	window := postgres.SwimWindow{
+		Start:          standard.QualifyingStart,
+		End:            standard.QualifyingEnd,
+		SanctionedOnly: standard.SanctionedOnly,
+	}
+	courseCounts := !standard.RequiredCourse.Valid || standard.RequiredCourse.String == courseType
+
 	// Get swimmer's personal bests for this course type
-	pbs, err := s.timeRepo.GetPersonalBests(ctx, swimmerID, courseType)
-	if err != nil {
-		return nil, fmt.Errorf("get personal bests: %w", err)
+	var pbs []db.GetPersonalBestsRow
+	if courseCounts {
+		pbs, err = s.timeRepo.GetPersonalBestsInWindow(ctx, swimmerID, courseType, window)
+		if err != nil {
+			return nil, fmt.Errorf("get personal bests: %w", err)
+		}
 	}
 
-	// Build PB map: event -> PB row
+	// Build PB map: event -> PB row. Times swum in another course than the
+	// standard's are converted to estimated equivalents when a factor exists.
 	pbMap := make(map[string]db.GetPersonalBestsRow)
-	for _, pb := range pbs {
-		pbMap[pb.Event] = pb
+	convMap := make(map[string]*conversion.Conversion)
+	var converter *conversion.Converter
+	if courseType != standard.CourseType {
+		converter, err = s.conversions.Converter(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get conversion factors: %w", err)
+		}
+		for _, pb := range pbs {
+			conv, ok := converter.Convert(pb.Event, courseType, standard.CourseType, int(pb.TimeMs))
+			if !ok {
+				pbMap[pb.Event] = pb
+				continue
+			}
+			pb.TimeMs = int32(conv.ConvertedTimeMS)
+			pbMap[conv.ConvertedEvent] = pb
+			convMap[conv.ConvertedEvent] = conv
+		}
+	} else {
+		for _, pb := range pbs {
+			pbMap[pb.Event] = pb
+		}
 	}
 
 	// Determine threshold
@@ -141,12 +277,34 @@ func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID u
 		threshold = *thresholdPercent
 	}
 
-	// Calculate swimmer's current age group for display
-	currentAge := domain.AgeAtDate(swimmer.BirthDate.Time, time.Now())
-	currentAgeGroup := string(domain.AgeGroupFromAge(currentAge))
+	// Calculate swimmer's age group on the evaluation date using the scheme's age rule
+	currentAge := scheme.Age(swimmer.BirthDate.Time, date)
+	currentAgeGroup := string(scheme.GroupForAge(currentAge))
+
+	// Evaluate every swim against the age group at the time of the swim
+	var atSwim map[string]*swimEvaluation
+	if mode == ModeAtSwim {
+		var swims []db.ListOfficialSwimsRow
+		if courseCounts {
+			swims, err = s.timeRepo.ListOfficialSwims(ctx, swimmerID, courseType, window)
+			if err != nil {
+				return nil, fmt.Errorf("list swims: %w", err)
+			}
+		}
+		atSwim = evaluateSwims(swims, swimmer.BirthDate.Time, scheme.AgeGroupScheme, stdTimesMap, func(event string, timeMS int) (string, int, *conversion.Conversion) {
+			if converter == nil {
+				return event, timeMS, nil
+			}
+			conv, ok := converter.Convert(event, courseType, standard.CourseType, timeMS)
+			if !ok {
+				return event, timeMS, nil
+			}
+			return conv.ConvertedEvent, conv.ConvertedTimeMS, conv
+		})
+	}
 
 	// Build comparisons for all events
-	allEvents := domain.ValidEventCodes
+	allEvents := domain.EventsForCourse(domain.CourseType(standard.CourseType))
 	comparisons := make([]EventComparison, 0, len(allEvents))
 	summary := ComparisonSummary{}
 
@@ -172,6 +330,15 @@ func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID u
 				comp.Date = &date
 			}
 
+			if conv, ok := convMap[string(event)]; ok {
+				comp.Converted = true
+				comp.ConvertedFrom = &conv.FromCourse
+				comp.OriginalEvent = &conv.Event
+				comp.OriginalTimeMS = &conv.TimeMS
+				comp.OriginalTimeFormatted = &conv.TimeFormatted
+				comp.ConversionFactor = &conv.Factor
+			}
+
 			// Get standard time for this event using swimmer's CURRENT age group
 			// (not the age when the PB was achieved)
 			stdTimeMS, actualAgeGroup, hasStandard := getStandardTime(stdTimesMap, string(event), currentAgeGroup)
@@ -196,23 +363,13 @@ func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID u
 				comp.DifferencePercent = &diffPercent
 
 				// Determine status
-				switch {
-				case diff <= 0:
-					comp.Status = StatusAchieved
-					summary.Achieved++
-				case diffPercent <= threshold:
-					comp.Status = StatusAlmost
-					summary.Almost++
-				default:
-					comp.Status = StatusNotAchieved
-					summary.NotAchieved++
-				}
+				comp.Status = statusFor(diff, diffPercent, threshold)
 			} else {
 				comp.Status = StatusNoStandard
 			}
 
 			// Check previous age group (relative to current age)
-			prevAG := domain.PreviousAgeGroup(domain.AgeGroup(currentAgeGroup))
+			prevAG := scheme.Previous(domain.AgeGroup(currentAgeGroup))
 			if prevAG != "" {
 				prevStdTimeMS, hasPrevStandard := getStandardTimeExact(stdTimesMap, string(event), string(prevAG))
 				if hasPrevStandard {
@@ -228,7 +385,7 @@ func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID u
 			}
 
 			// Check next age group (relative to current age)
-			nextAG := domain.NextAgeGroup(domain.AgeGroup(currentAgeGroup))
+			nextAG := scheme.Next(domain.AgeGroup(currentAgeGroup))
 			if nextAG != "" {
 				nextStdTimeMS, hasNextStandard := getStandardTimeExact(stdTimesMap, string(event), string(nextAG))
 				if hasNextStandard {
@@ -257,7 +414,7 @@ func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID u
 			}
 
 			// Check previous age group (even without PB)
-			prevAG := domain.PreviousAgeGroup(domain.AgeGroup(currentAgeGroup))
+			prevAG := scheme.Previous(domain.AgeGroup(currentAgeGroup))
 			if prevAG != "" {
 				prevStdTimeMS, hasPrevStandard := getStandardTimeExact(stdTimesMap, string(event), string(prevAG))
 				if hasPrevStandard {
@@ -271,7 +428,7 @@ func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID u
 			}
 
 			// Check next age group (even without PB)
-			nextAG := domain.NextAgeGroup(domain.AgeGroup(currentAgeGroup))
+			nextAG := scheme.Next(domain.AgeGroup(currentAgeGroup))
 			if nextAG != "" {
 				nextStdTimeMS, hasNextStandard := getStandardTimeExact(stdTimesMap, string(event), string(nextAG))
 				if hasNextStandard {
@@ -285,6 +442,24 @@ func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID u
 			}
 
 			comp.Status = StatusNoTime
+		}
+
+		if mode == ModeAtSwim && comp.Status != StatusNoTime {
+			applySwimEvaluation(&comp, atSwim[string(event)], swimmer.BirthDate.Time, scheme.AgeGroupScheme, threshold)
+		}
+
+		if period != nil && comp.StandardTimeMS != nil {
+			comp.Qualification = qualificationFor(comp.Status, period.Closed)
+		}
+
+		switch comp.Status {
+		case StatusAchieved:
+			summary.Achieved++
+		case StatusAlmost:
+			summary.Almost++
+		case StatusNotAchieved:
+			summary.NotAchieved++
+		case StatusNoTime:
 			summary.NoTime++
 		}
 
@@ -292,16 +467,212 @@ func (s *ComparisonService) Compare(ctx context.Context, swimmerID, standardID u
 		summary.TotalEvents++
 	}
 
-	return &ComparisonResult{
-		StandardID:       standardID,
+	result := &ComparisonResult{
+		StandardID:       standard.ID,
 		StandardName:     standard.Name,
+		EvaluationDate:   date.Format("2006-01-02"),
 		CourseType:       courseType,
+		StandardCourse:   standard.CourseType,
+		Converted:        len(convMap) > 0,
+		Mode:             mode,
 		SwimmerName:      swimmer.Name,
+		SwimmerAge:       currentAge,
 		SwimmerAgeGroup:  currentAgeGroup,
+		AgeGroupScheme:   scheme.Name,
+		QualifyingPeriod: period,
 		ThresholdPercent: threshold,
 		Comparisons:      comparisons,
 		Summary:          summary,
-	}, nil
+	}
+	if standard.Version.Valid {
+		result.Version = standard.Version.String
+	}
+	if standard.EffectiveFrom.Valid {
+		result.EffectiveFrom = standard.EffectiveFrom.Time.Format("2006-01-02")
+	}
+	return result, nil
+}
+
+// statusFor determines the status from the difference to the standard time.
+func statusFor(diff int, diffPercent, threshold float64) ComparisonStatus {
+	switch {
+	case diff <= 0:
+		return StatusAchieved
+	case diffPercent <= threshold:
+		return StatusAlmost
+	default:
+		return StatusNotAchieved
+	}
+}
+
+// swimEvaluation is a swim evaluated against the standard of the age group
+// the swimmer was in on the meet date.
+type swimEvaluation struct {
+	timeMS         int
+	date           time.Time
+	meetName       string
+	age            int
+	ageGroup       string
+	standardTimeMS int
+	conv           *conversion.Conversion
+}
+
+func (e *swimEvaluation) diffPercent() float64 {
+	return float64(e.timeMS-e.standardTimeMS) / float64(e.standardTimeMS) * 100
+}
+
+// evaluateSwims picks, per event, the most recent swim that achieved the
+// standard of the swimmer's age group at the time, or else the swim that came
+// closest. Swims without a standard for their age group are ignored. The
+// convert function maps a swim to the standard's course.
+func evaluateSwims(
+	swims []db.ListOfficialSwimsRow,
+	birthDate time.Time,
+	scheme domain.AgeGroupScheme,
+	stdTimesMap map[string]map[string]int32,
+	convert func(event string, timeMS int) (string, int, *conversion.Conversion),
+) map[string]*swimEvaluation {
+	evaluations := make(map[string]*swimEvaluation)
+	for _, swim := range swims {
+		event, timeMS, conv := convert(swim.Event, int(swim.TimeMs))
+
+		age := scheme.Age(birthDate, swim.MeetDate.Time)
+		stdTimeMS, ageGroup, ok := getStandardTime(stdTimesMap, event, string(scheme.GroupForAge(age)))
+		if !ok {
+			continue
+		}
+
+		eval := &swimEvaluation{
+			timeMS:         timeMS,
+			date:           swim.Date.Time,
+			meetName:       swim.MeetName,
+			age:            age,
+			ageGroup:       ageGroup,
+			standardTimeMS: int(stdTimeMS),
+			conv:           conv,
+		}
+
+		best, ok := evaluations[event]
+		switch {
+		case !ok:
+			evaluations[event] = eval
+		case eval.timeMS <= eval.standardTimeMS:
+			if best.timeMS > best.standardTimeMS || eval.date.After(best.date) {
+				evaluations[event] = eval
+			}
+		case best.timeMS > best.standardTimeMS && eval.diffPercent() < best.diffPercent():
+			evaluations[event] = eval
+		}
+	}
+	return evaluations
+}
+
+// applySwimEvaluation replaces the personal best comparison of an event with
+// the evaluation of the swim at the time it was swum.
+func applySwimEvaluation(comp *EventComparison, eval *swimEvaluation, birthDate time.Time, scheme domain.AgeGroupScheme, threshold float64) {
+	achieved := false
+	comp.AchievedAtTime = &achieved
+	if eval == nil {
+		// No swim has a standard for the age group it was swum in
+		comp.Status = StatusNoStandard
+		comp.StandardTimeMS = nil
+		comp.StandardTimeFormatted = nil
+		comp.DifferenceMS = nil
+		comp.DifferenceFormatted = nil
+		comp.DifferencePercent = nil
+		return
+	}
+
+	swimmerTime := eval.timeMS
+	swimmerTimeFormatted := domain.FormatTime(swimmerTime)
+	comp.SwimmerTimeMS = &swimmerTime
+	comp.SwimmerTimeFormatted = &swimmerTimeFormatted
+
+	meetName := eval.meetName
+	comp.MeetName = &meetName
+	date := eval.date.Format("Jan 2, 2006")
+	comp.Date = &date
+
+	comp.Converted = eval.conv != nil
+	comp.ConvertedFrom, comp.OriginalEvent, comp.OriginalTimeMS = nil, nil, nil
+	comp.OriginalTimeFormatted, comp.ConversionFactor = nil, nil
+	if conv := eval.conv; conv != nil {
+		comp.ConvertedFrom = &conv.FromCourse
+		comp.OriginalEvent = &conv.Event
+		comp.OriginalTimeMS = &conv.TimeMS
+		comp.OriginalTimeFormatted = &conv.TimeFormatted
+		comp.ConversionFactor = &conv.Factor
+	}
+
+	comp.AgeGroup = eval.ageGroup
+	age := eval.age
+	comp.SwimAge = &age
+
+	standardTime := eval.standardTimeMS
+	standardTimeFormatted := domain.FormatTime(standardTime)
+	comp.StandardTimeMS = &standardTime
+	comp.StandardTimeFormatted = &standardTimeFormatted
+
+	diff := swimmerTime - standardTime
+	diffFormatted := formatDifference(diff)
+	diffPercent := eval.diffPercent()
+	comp.DifferenceMS = &diff
+	comp.DifferenceFormatted = &diffFormatted
+	comp.DifferencePercent = &diffPercent
+
+	comp.Status = statusFor(diff, diffPercent, threshold)
+	achieved = diff <= 0
+
+	if start, end, ok := scheme.Window(birthDate, domain.AgeGroup(eval.ageGroup)); ok {
+		window := &QualifyingWindow{
+			AgeGroup:  eval.ageGroup,
+			StartDate: start.Format("2006-01-02"),
+		}
+		if end != nil {
+			endDate := end.Format("2006-01-02")
+			window.EndDate = &endDate
+		}
+		comp.QualifyingWindow = window
+	}
+}
+
+// qualifyingPeriod returns the qualifying rules of a standard as of now, or
+// nil if every swim counts toward it.
+func qualifyingPeriod(standard *db.TimeStandard, now time.Time) *QualifyingPeriod {
+	if !standard.QualifyingStart.Valid && !standard.QualifyingEnd.Valid &&
+		!standard.RequiredCourse.Valid && !standard.SanctionedOnly {
+		return nil
+	}
+
+	period := &QualifyingPeriod{SanctionedOnly: standard.SanctionedOnly}
+	if standard.QualifyingStart.Valid {
+		start := standard.QualifyingStart.Time.Format("2006-01-02")
+		period.StartDate = &start
+	}
+	if standard.QualifyingEnd.Valid {
+		end := standard.QualifyingEnd.Time.Format("2006-01-02")
+		period.EndDate = &end
+		// The window is open through the whole end date
+		period.Closed = !now.Before(standard.QualifyingEnd.Time.AddDate(0, 0, 1))
+	}
+	if standard.RequiredCourse.Valid {
+		course := standard.RequiredCourse.String
+		period.RequiredCourse = &course
+	}
+	return period
+}
+
+// qualificationFor returns the standing of an event with the given status in
+// a qualifying period.
+func qualificationFor(status ComparisonStatus, closed bool) QualificationStatus {
+	switch {
+	case status == StatusAchieved:
+		return QualificationQualified
+	case closed:
+		return QualificationWindowClosed
+	default:
+		return QualificationNotYet
+	}
 }
 
 // getStandardTime looks up a standard time, trying the specific age group first,
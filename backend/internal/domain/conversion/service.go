@@ -0,0 +1,244 @@
+// Package conversion estimates equivalent swim times between pool courses.
+package conversion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/store/db"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// ErrNoFactor is returned when no conversion factor exists for a course pair.
+var ErrNoFactor = errors.New("no conversion factor")
+
+// Service provides course conversion business logic.
+type Service struct {
+	repo *postgres.ConversionRepository
+}
+
+// NewService creates a new conversion service.
+func NewService(repo *postgres.ConversionRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// WithTx returns a service that runs its queries in the transaction.
+func (s *Service) WithTx(tx pgx.Tx) *Service {
+	return &Service{repo: s.repo.WithTx(tx)}
+}
+
+// Factor is a multiplier estimating the time of a swim in another course.
+// An empty Event applies to all events without a specific factor.
+type Factor struct {
+	ID         uuid.UUID `json:"id"`
+	FromCourse string    `json:"from_course"`
+	ToCourse   string    `json:"to_course"`
+	Event      string    `json:"event,omitempty"`
+	Factor     float64   `json:"factor"`
+}
+
+// FactorList represents a list of conversion factors.
+type FactorList struct {
+	Factors []Factor `json:"factors"`
+}
+
+// FactorInput represents input for setting a conversion factor.
+type FactorInput struct {
+	FromCourse string  `json:"from_course"`
+	ToCourse   string  `json:"to_course"`
+	Event      string  `json:"event,omitempty"`
+	Factor     float64 `json:"factor"`
+}
+
+// Validate validates the factor input.
+func (i FactorInput) Validate() error {
+	if !domain.CourseType(i.FromCourse).IsValid() || !domain.CourseType(i.ToCourse).IsValid() {
+		return errors.New("from_course and to_course must be '25m', '50m' or '25y'")
+	}
+	if i.FromCourse == i.ToCourse {
+		return errors.New("from_course and to_course must differ")
+	}
+	if i.Event != "" && !domain.EventCode(i.Event).IsValidForCourse(domain.CourseType(i.FromCourse)) {
+		return fmt.Errorf("invalid event code for %s: %s", i.FromCourse, i.Event)
+	}
+	if i.Factor <= 0 || i.Factor >= 100 {
+		return errors.New("factor must be greater than 0 and less than 100")
+	}
+	return nil
+}
+
+// Conversion is an estimated equivalent time in another course.
+type Conversion struct {
+	Event                  string  `json:"event"`
+	FromCourse             string  `json:"from_course"`
+	TimeMS                 int     `json:"time_ms"`
+	TimeFormatted          string  `json:"time_formatted"`
+	ToCourse               string  `json:"to_course"`
+	ConvertedEvent         string  `json:"converted_event"`
+	ConvertedTimeMS        int     `json:"converted_time_ms"`
+	ConvertedTimeFormatted string  `json:"converted_time_formatted"`
+	Factor                 float64 `json:"factor"`
+	Estimated              bool    `json:"estimated"`
+}
+
+// ListFactors lists all conversion factors.
+func (s *Service) ListFactors(ctx context.Context) ([]Factor, error) {
+	rows, err := s.repo.ListFactors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	factors := make([]Factor, len(rows))
+	for i, row := range rows {
+		factors[i] = toFactor(row)
+	}
+	return factors, nil
+}
+
+// SetFactor creates or replaces the factor for a course pair and event.
+func (s *Service) SetFactor(ctx context.Context, input FactorInput) (*Factor, error) {
+	input.Event = strings.ToUpper(strings.TrimSpace(input.Event))
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+
+	var factor pgtype.Numeric
+	_ = factor.Scan(fmt.Sprintf("%.4f", input.Factor))
+
+	row, err := s.repo.UpsertFactor(ctx, db.UpsertConversionFactorParams{
+		FromCourse: input.FromCourse,
+		ToCourse:   input.ToCourse,
+		Event:      input.Event,
+		Factor:     factor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toFactor(*row)
+	return &result, nil
+}
+
+// DeleteFactor deletes a conversion factor.
+func (s *Service) DeleteFactor(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteFactor(ctx, id)
+}
+
+// Convert estimates the equivalent time of a swim in another course.
+func (s *Service) Convert(ctx context.Context, event, fromCourse, toCourse string, timeMS int) (*Conversion, error) {
+	if !domain.CourseType(fromCourse).IsValid() || !domain.CourseType(toCourse).IsValid() {
+		return nil, errors.New("validation: from and to must be '25m', '50m' or '25y'")
+	}
+	if !domain.EventCode(event).IsValidForCourse(domain.CourseType(fromCourse)) {
+		return nil, fmt.Errorf("validation: invalid event code for %s: %s", fromCourse, event)
+	}
+	if timeMS <= 0 {
+		return nil, errors.New("validation: time_ms must be positive")
+	}
+
+	converter, err := s.Converter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion, ok := converter.Convert(event, fromCourse, toCourse, timeMS)
+	if !ok {
+		return nil, fmt.Errorf("%w from %s to %s for %s", ErrNoFactor, fromCourse, toCourse, event)
+	}
+	return conversion, nil
+}
+
+// Converter loads all conversion factors for converting many times at once.
+func (s *Service) Converter(ctx context.Context) (*Converter, error) {
+	factors, err := s.ListFactors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewConverter(factors), nil
+}
+
+// Converter converts times between courses using a fixed set of factors.
+type Converter struct {
+	factors map[factorKey]float64
+}
+
+type factorKey struct {
+	from, to, event string
+}
+
+// NewConverter creates a converter from conversion factors.
+func NewConverter(factors []Factor) *Converter {
+	c := &Converter{factors: make(map[factorKey]float64, len(factors))}
+	for _, f := range factors {
+		c.factors[factorKey{f.FromCourse, f.ToCourse, f.Event}] = f.Factor
+	}
+	return c
+}
+
+// Convert estimates the equivalent time of a swim in another course. The
+// event-specific factor is preferred over the course pair's general factor;
+// when neither exists the inverse of the reverse conversion is used. It
+// returns false if the courses cannot be converted.
+func (c *Converter) Convert(event, fromCourse, toCourse string, timeMS int) (*Conversion, bool) {
+	converted := domain.EventCode(event).CourseEquivalent(domain.CourseType(toCourse)).String()
+
+	factor, ok := c.factor(event, converted, fromCourse, toCourse)
+	if !ok {
+		return nil, false
+	}
+
+	convertedMS := int(math.Round(float64(timeMS)*factor/10) * 10) // times are kept to hundredths
+	return &Conversion{
+		Event:                  event,
+		FromCourse:             fromCourse,
+		TimeMS:                 timeMS,
+		TimeFormatted:          domain.FormatTime(timeMS),
+		ToCourse:               toCourse,
+		ConvertedEvent:         converted,
+		ConvertedTimeMS:        convertedMS,
+		ConvertedTimeFormatted: domain.FormatTime(convertedMS),
+		Factor:                 factor,
+		Estimated:              fromCourse != toCourse,
+	}, true
+}
+
+// factor looks up the factor converting event in fromCourse to converted in toCourse.
+func (c *Converter) factor(event, converted, fromCourse, toCourse string) (float64, bool) {
+	if fromCourse == toCourse {
+		return 1, true
+	}
+	for _, key := range []factorKey{{fromCourse, toCourse, event}, {fromCourse, toCourse, ""}} {
+		if f, ok := c.factors[key]; ok {
+			return f, true
+		}
+	}
+	for _, key := range []factorKey{{toCourse, fromCourse, converted}, {toCourse, fromCourse, ""}} {
+		if f, ok := c.factors[key]; ok {
+			return 1 / f, true
+		}
+	}
+	return 0, false
+}
+
+func toFactor(row db.CourseConversionFactor) Factor {
+	var factor float64
+	if f, err := row.Factor.Float64Value(); err == nil && f.Valid {
+		factor = f.Float64
+	}
+
+	return Factor{
+		ID:         row.ID,
+		FromCourse: row.FromCourse,
+		ToCourse:   row.ToCourse,
+		Event:      row.Event,
+		Factor:     factor,
+	}
+}
@@ -0,0 +1,167 @@
+// Package dataformat keeps the registry of the versions of the JSON data
+// format used by exports and imports, upgrading documents of older versions
+// to the current one.
+package dataformat
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Current is the version of the data format written by exports.
+// Register a new version with an upgrade from the previous one when making
+// breaking changes to the format.
+const Current = "1.1"
+
+//go:embed schemas/*.json
+var schemas embed.FS
+
+// version is a registered version of the data format. upgrade converts a
+// document of the version to the next one; the current version has none.
+type version struct {
+	name    string
+	upgrade func(doc map[string]any) error
+}
+
+// versions lists the versions of the data format, oldest first.
+var versions = []version{
+	{name: "1.0", upgrade: upgrade1_0},
+	{name: "1.1"},
+}
+
+// ErrUnknownVersion is returned for a version that is not registered.
+var ErrUnknownVersion = errors.New("unknown format version")
+
+// VersionError reports a document whose format version cannot be imported.
+type VersionError struct {
+	Version string
+	Future  bool // written by a newer release
+}
+
+func (e *VersionError) Error() string {
+	if e.Future {
+		return fmt.Sprintf("format version %s is newer than the supported version %s, upgrade SwimStats to import it", e.Version, Current)
+	}
+	return fmt.Sprintf("unknown format version %q, supported versions are %s", e.Version, strings.Join(Versions(), ", "))
+}
+
+// Versions returns the registered versions, oldest first.
+func Versions() []string {
+	names := make([]string, len(versions))
+	for i, v := range versions {
+		names[i] = v.name
+	}
+	return names
+}
+
+// Schema returns the JSON Schema of a version.
+func Schema(name string) ([]byte, error) {
+	if index(name) < 0 {
+		return nil, ErrUnknownVersion
+	}
+	return schemas.ReadFile("schemas/" + name + ".json")
+}
+
+// Upgrade checks the format version a JSON document declares and upgrades it
+// step by step to the current version. Documents without a version are
+// taken to be current, and documents that are not JSON objects are returned
+// as they are for decoding to report.
+func Upgrade(raw []byte) ([]byte, error) {
+	var header struct {
+		FormatVersion string `json:"format_version"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return raw, nil
+	}
+
+	name := strings.TrimSpace(header.FormatVersion)
+	if name == "" || name == Current {
+		return raw, nil
+	}
+	i := index(name)
+	if i < 0 {
+		return nil, &VersionError{Version: name, Future: isFuture(name)}
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return raw, nil
+	}
+	for ; versions[i].upgrade != nil; i++ {
+		if err := versions[i].upgrade(doc); err != nil {
+			return nil, fmt.Errorf("upgrade from format version %s: %w", versions[i].name, err)
+		}
+		doc["format_version"] = versions[i+1].name
+	}
+	return json.Marshal(doc)
+}
+
+// index returns the position of a version in the registry, or -1.
+func index(name string) int {
+	for i, v := range versions {
+		if v.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// isFuture reports whether a version is a major.minor number past the current one.
+func isFuture(name string) bool {
+	major, minor, ok := parse(name)
+	if !ok {
+		return false
+	}
+	currentMajor, currentMinor, _ := parse(Current)
+	return major > currentMajor || major == currentMajor && minor > currentMinor
+}
+
+func parse(name string) (int, int, bool) {
+	majorStr, minorStr, _ := strings.Cut(name, ".")
+	major, err := strconv.Atoi(majorStr)
+	if err != nil || major < 0 {
+		return 0, 0, false
+	}
+	minor := 0
+	if minorStr != "" {
+		minor, err = strconv.Atoi(minorStr)
+		if err != nil || minor < 0 {
+			return 0, 0, false
+		}
+	}
+	return major, minor, true
+}
+
+// upgrade1_0 makes explicit what 1.0 documents left implicit, as they predate
+// sanctioned meets and age-group schemes: meets are sanctioned and standard
+// times use the age groups of the Swimming Canada scheme. In 1.1, a standard
+// without a scheme uses the default scheme, whichever that is.
+func upgrade1_0(doc map[string]any) error {
+	for _, m := range objects(doc["meets"]) {
+		if _, ok := m["sanctioned"]; !ok {
+			m["sanctioned"] = true
+		}
+	}
+	for _, std := range objects(doc["standards"]) {
+		if scheme, _ := std["age_group_scheme"].(string); scheme == "" {
+			std["age_group_scheme"] = "Swimming Canada"
+		}
+	}
+	return nil
+}
+
+// objects returns the JSON objects of an array, skipping other elements.
+func objects(v any) []map[string]any {
+	items, _ := v.([]any)
+	var objs []map[string]any
+	for _, item := range items {
+		if obj, ok := item.(map[string]any); ok {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
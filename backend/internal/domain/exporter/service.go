@@ -5,19 +5,27 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
 	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/domain/comparison"
 	"github.com/bpg/swimstats/backend/internal/domain/meet"
 	"github.com/bpg/swimstats/backend/internal/domain/standard"
 	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
 	timeservice "github.com/bpg/swimstats/backend/internal/domain/time"
 )
 
-// Service handles exporting swimmer data to JSON format.
+// Service handles exporting swimmer data to JSON, CSV and XLSX formats.
 type Service struct {
-	swimmerService  *swimmer.Service
-	meetService     *meet.Service
-	timeService     *timeservice.Service
-	standardService *standard.Service
+	swimmerService    *swimmer.Service
+	meetService       *meet.Service
+	timeService       *timeservice.Service
+	standardService   *standard.Service
+	schemes           *agegroup.Service
+	pbService         *comparison.PersonalBestService
+	comparisonService *comparison.ComparisonService
 }
 
 // NewService creates a new exporter service.
@@ -26,17 +34,37 @@ func NewService(
 	meetService *meet.Service,
 	timeService *timeservice.Service,
 	standardService *standard.Service,
+	schemes *agegroup.Service,
+	pbService *comparison.PersonalBestService,
+	comparisonService *comparison.ComparisonService,
 ) *Service {
 	return &Service{
-		swimmerService:  swimmerService,
-		meetService:     meetService,
-		timeService:     timeService,
-		standardService: standardService,
+		swimmerService:    swimmerService,
+		meetService:       meetService,
+		timeService:       timeService,
+		standardService:   standardService,
+		schemes:           schemes,
+		pbService:         pbService,
+		comparisonService: comparisonService,
+	}
+}
+
+// WithTx returns a copy of the service whose JSON exports run in the
+// transaction tx. The spreadsheet comparisons keep reading from the pool.
+func (s *Service) WithTx(tx pgx.Tx) *Service {
+	return &Service{
+		swimmerService:    s.swimmerService.WithTx(tx),
+		meetService:       s.meetService.WithTx(tx),
+		timeService:       s.timeService.WithTx(tx),
+		standardService:   s.standardService.WithTx(tx),
+		schemes:           s.schemes.WithTx(tx),
+		pbService:         s.pbService,
+		comparisonService: s.comparisonService,
 	}
 }
 
-// ExportAll exports all user data including swimmer profile, meets, times, and custom standards.
-func (s *Service) ExportAll(ctx context.Context) (*ExportData, error) {
+// ExportAll exports all data of a swimmer including their profile, meets, times, and custom standards.
+func (s *Service) ExportAll(ctx context.Context, ownerID string, swimmerID uuid.UUID) (*ExportData, error) {
 	export := &ExportData{
 		FormatVersion: CurrentFormatVersion,
 		Meets:         []MeetExport{},
@@ -44,7 +72,7 @@ func (s *Service) ExportAll(ctx context.Context) (*ExportData, error) {
 	}
 
 	// 1. Export swimmer
-	swimmerData, err := s.swimmerService.Get(ctx)
+	swimmerData, err := s.swimmerService.GetForOwner(ctx, ownerID, swimmerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get swimmer: %w", err)
 	}
@@ -54,28 +82,22 @@ func (s *Service) ExportAll(ctx context.Context) (*ExportData, error) {
 		BirthDate:        swimmerData.BirthDate,
 		Gender:           swimmerData.Gender,
 		ThresholdPercent: swimmerData.ThresholdPercent,
+		SeasonStart:      swimmerData.SeasonStart,
 	}
 
-	// 2. Export meets with times
-	// Use a large limit to get all meets
-	meetList, err := s.meetService.List(ctx, meet.ListParams{
-		CourseType: nil,   // Get all course types
-		Limit:      10000, // Large limit to get all meets
-		Offset:     0,
-	})
+	splits, err := s.timeService.ListSplitsBySwimmer(ctx, swimmerData.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list meets: %w", err)
+		return nil, fmt.Errorf("failed to get splits: %w", err)
 	}
 
-	meets := meetList.Meets
-
-	// Sort meets by start date (oldest first for chronological export)
-	// Dates are already strings in YYYY-MM-DD format, so lexicographic sort works
-	sort.Slice(meets, func(i, j int) bool {
-		return meets[i].StartDate < meets[j].StartDate
-	})
+	// 2. Export meets with times
+	swims, err := s.swims(ctx, ownerID, swimmerData.ID)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, m := range meets {
+	for _, ms := range swims {
+		m := ms.meet
 		meetExport := MeetExport{
 			Name:       m.Name,
 			City:       m.City,
@@ -83,36 +105,31 @@ func (s *Service) ExportAll(ctx context.Context) (*ExportData, error) {
 			StartDate:  m.StartDate,
 			EndDate:    m.EndDate,
 			CourseType: m.CourseType,
+			Sanctioned: m.Sanctioned,
 			Times:      []TimeExport{},
 		}
 
-		// Get times for this meet
-		meetID := m.ID
-		timeList, err := s.timeService.List(ctx, timeservice.ListParams{
-			SwimmerID:  swimmerData.ID,
-			MeetID:     &meetID,
-			CourseType: nil,
-			Event:      nil,
-			Limit:      10000, // Large limit to get all times
-			Offset:     0,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to get times for meet %s: %w", m.Name, err)
-		}
-
-		times := timeList.Times
-
-		// Sort times by event date
-		sort.Slice(times, func(i, j int) bool {
-			return times[i].EventDate < times[j].EventDate
-		})
-
-		for _, t := range times {
+		for _, t := range ms.times {
 			timeExport := TimeExport{
-				Event:     t.Event,
-				Time:      domain.FormatTime(t.TimeMS),
-				EventDate: t.EventDate,
-				Notes:     t.Notes,
+				Event:       t.Event,
+				EventDate:   t.EventDate,
+				Notes:       t.Notes,
+				DQCode:      t.DQCode,
+				DQReason:    t.DQReason,
+				RelayLeg:    t.RelayLeg,
+				RelayStroke: t.RelayStroke,
+			}
+			if t.TimeMS > 0 {
+				timeExport.Time = domain.FormatTime(t.TimeMS)
+			}
+			if t.Status != domain.ResultOK.String() {
+				timeExport.Status = t.Status
+			}
+			for _, split := range splits[t.ID] {
+				timeExport.Splits = append(timeExport.Splits, SplitExport{
+					Distance: split.Distance,
+					Time:     domain.FormatTime(split.TimeMS),
+				})
 			}
 			meetExport.Times = append(meetExport.Times, timeExport)
 		}
@@ -120,8 +137,36 @@ func (s *Service) ExportAll(ctx context.Context) (*ExportData, error) {
 		export.Meets = append(export.Meets, meetExport)
 	}
 
-	// 3. Export custom standards (exclude preloaded ones)
-	standardList, err := s.standardService.List(ctx, standard.ListParams{
+	// 3. Export the owner's custom standards (exclude shared ones)
+	export.Standards, err = s.ExportStandards(ctx, ownerID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// ExportOwnedStandards exports the custom standards of every owner, by owner.
+// Shared standards are not included.
+func (s *Service) ExportOwnedStandards(ctx context.Context) (map[string][]StandardExport, error) {
+	owners, err := s.standardService.ListOwners(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list standard owners: %w", err)
+	}
+	standards := make(map[string][]StandardExport, len(owners))
+	for _, ownerID := range owners {
+		if standards[ownerID], err = s.ExportStandards(ctx, ownerID, false); err != nil {
+			return nil, err
+		}
+	}
+	return standards, nil
+}
+
+// ExportStandards exports the custom standards of the owner with their times,
+// or the preloaded ones if preloaded is set. An empty owner exports the
+// shared standards.
+func (s *Service) ExportStandards(ctx context.Context, ownerID string, preloaded bool) ([]StandardExport, error) {
+	standardList, err := s.standardService.List(ctx, ownerID, standard.ListParams{
 		CourseType: nil,
 		Gender:     nil,
 	})
@@ -129,22 +174,49 @@ func (s *Service) ExportAll(ctx context.Context) (*ExportData, error) {
 		return nil, fmt.Errorf("failed to list standards: %w", err)
 	}
 
-	// Filter to custom standards only
+	schemeList, err := s.schemes.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list age group schemes: %w", err)
+	}
+	schemeNames := make(map[uuid.UUID]string, len(schemeList.Schemes))
+	for _, scheme := range schemeList.Schemes {
+		schemeNames[scheme.ID] = scheme.Name
+	}
+
+	familyList, err := s.standardService.ListFamilies(ctx, ownerID, standard.ListParams{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list standard families: %w", err)
+	}
+	familyNames := make(map[uuid.UUID]string, len(familyList.Families))
+	for _, family := range familyList.Families {
+		familyNames[family.ID] = family.Name
+	}
+
+	standards := []StandardExport{}
 	for _, std := range standardList.Standards {
-		if std.IsPreloaded {
-			continue // Skip preloaded standards
+		if std.IsPreloaded != preloaded || std.OwnerID != ownerID {
+			continue
 		}
 
 		standardExport := StandardExport{
-			Name:        std.Name,
-			Description: std.Description,
-			CourseType:  std.CourseType,
-			Gender:      std.Gender,
-			Times:       make(map[string][]string),
+			Name:            std.Name,
+			Description:     std.Description,
+			CourseType:      std.CourseType,
+			Gender:          std.Gender,
+			AgeGroupScheme:  schemeNames[std.AgeGroupSchemeID],
+			Times:           make(map[string][]string),
+			QualifyingRules: std.QualifyingRules,
+		}
+		if std.FamilyID != nil {
+			standardExport.VersionRef = standard.VersionRef{
+				Family:        familyNames[*std.FamilyID],
+				Version:       std.Version,
+				EffectiveFrom: std.EffectiveFrom,
+			}
 		}
 
 		// Get all standard times for this standard
-		standardWithTimes, err := s.standardService.GetWithTimes(ctx, std.ID)
+		standardWithTimes, err := s.standardService.GetWithTimes(ctx, ownerID, std.ID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get times for standard %s: %w", std.Name, err)
 		}
@@ -155,8 +227,69 @@ func (s *Service) ExportAll(ctx context.Context) (*ExportData, error) {
 			standardExport.Times[st.Event] = append(standardExport.Times[st.Event], timeStr)
 		}
 
-		export.Standards = append(export.Standards, standardExport)
+		standards = append(standards, standardExport)
 	}
 
-	return export, nil
+	return standards, nil
+}
+
+// meetSwims is a meet with the times a swimmer swam in it.
+type meetSwims struct {
+	meet  meet.Meet
+	times []timeservice.TimeRecord
+}
+
+// swims lists the owner's meets oldest first with the swimmer's times in
+// each, in event date order. Meets shared with siblings in which the swimmer
+// did not swim are left out.
+func (s *Service) swims(ctx context.Context, ownerID string, swimmerID uuid.UUID) ([]meetSwims, error) {
+	// Use a large limit to get all meets
+	meetList, err := s.meetService.List(ctx, ownerID, meet.ListParams{
+		CourseType: nil,   // Get all course types
+		Limit:      10000, // Large limit to get all meets
+		Offset:     0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list meets: %w", err)
+	}
+
+	meets := meetList.Meets
+
+	// Sort meets by start date (oldest first for chronological export)
+	// Dates are already strings in YYYY-MM-DD format, so lexicographic sort works
+	sort.Slice(meets, func(i, j int) bool {
+		return meets[i].StartDate < meets[j].StartDate
+	})
+
+	swims := make([]meetSwims, 0, len(meets))
+	for _, m := range meets {
+		// Get times for this meet
+		meetID := m.ID
+		timeList, err := s.timeService.List(ctx, timeservice.ListParams{
+			SwimmerID:  swimmerID,
+			MeetID:     &meetID,
+			CourseType: nil,
+			Event:      nil,
+			Limit:      10000, // Large limit to get all times
+			Offset:     0,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get times for meet %s: %w", m.Name, err)
+		}
+
+		times := timeList.Times
+
+		// Skip meets shared with siblings in which this swimmer did not swim
+		if len(times) == 0 && m.TimeCount > 0 {
+			continue
+		}
+
+		// Sort times by event date
+		sort.Slice(times, func(i, j int) bool {
+			return times[i].EventDate < times[j].EventDate
+		})
+
+		swims = append(swims, meetSwims{meet: m, times: times})
+	}
+	return swims, nil
 }
@@ -0,0 +1,283 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
+	timeservice "github.com/bpg/swimstats/backend/internal/domain/time"
+)
+
+// Sheet is a table of exported data, written as a CSV file or as a worksheet
+// of an XLSX workbook. Cells are strings, ints, float64s, bools, or nil for
+// empty cells.
+type Sheet struct {
+	Name    string
+	Columns []string
+	Rows    [][]any
+}
+
+// courseTypes is the order in which courses are exported.
+var courseTypes = []domain.CourseType{domain.Course25m, domain.Course50m, domain.Course25y}
+
+// ExportCSV exports the times of a swimmer as CSV, one row per swim.
+func (s *Service) ExportCSV(ctx context.Context, ownerID string, swimmerID uuid.UUID) ([]byte, error) {
+	swimmerData, swims, err := s.swimmerSwims(ctx, ownerID, swimmerID)
+	if err != nil {
+		return nil, err
+	}
+
+	times, err := s.timesSheet(ctx, swimmerData, swims)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, times); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportXLSX exports the meets, times and personal bests of a swimmer and
+// their comparison with the standards as an XLSX workbook with a sheet each.
+// Personal bests and comparisons cover the courses the swimmer swam in.
+func (s *Service) ExportXLSX(ctx context.Context, ownerID string, swimmerID uuid.UUID) ([]byte, error) {
+	swimmerData, swims, err := s.swimmerSwims(ctx, ownerID, swimmerID)
+	if err != nil {
+		return nil, err
+	}
+
+	times, err := s.timesSheet(ctx, swimmerData, swims)
+	if err != nil {
+		return nil, err
+	}
+
+	swum := make(map[domain.CourseType]bool)
+	for _, ms := range swims {
+		if len(ms.times) > 0 {
+			swum[domain.CourseType(ms.meet.CourseType)] = true
+		}
+	}
+
+	pbs := Sheet{
+		Name:    "Personal Bests",
+		Columns: []string{"Course", "Event", "Time", "Seconds", "Meet", "Date", "Relay Event", "Points"},
+	}
+	comparisons := Sheet{
+		Name:    "Comparisons",
+		Columns: []string{"Course", "Event", "Personal Best", "Standard", "Age Group", "Standard Time", "Achieved", "Gap (s)"},
+	}
+	for _, course := range courseTypes {
+		if !swum[course] {
+			continue
+		}
+
+		pbList, err := s.pbService.GetPersonalBests(ctx, swimmerData.ID, string(course))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get personal bests: %w", err)
+		}
+		for _, pb := range pbList.PersonalBests {
+			var points any
+			if pb.Points != nil {
+				points = *pb.Points
+			}
+			pbs.Rows = append(pbs.Rows, []any{
+				string(course), pb.Event, pb.TimeFormatted, seconds(pb.TimeMS),
+				pb.MeetName, pb.Date, pb.RelayEvent, points,
+			})
+		}
+
+		matrix, err := s.comparisonService.Attainment(ctx, swimmerData.ID, string(course))
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare with standards: %w", err)
+		}
+		for _, event := range matrix.Events {
+			// Only events with a personal best are compared
+			if event.SwimmerTimeMS == nil {
+				continue
+			}
+			for _, level := range event.Standards {
+				comparisons.Rows = append(comparisons.Rows, []any{
+					string(course), event.Event, *event.SwimmerTimeFormatted,
+					level.StandardName, level.AgeGroup, level.TimeFormatted, level.Achieved,
+					float64(*event.SwimmerTimeMS-level.TimeMS) / 1000,
+				})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, []Sheet{meetsSheet(swims), times, pbs, comparisons}); err != nil {
+		return nil, fmt.Errorf("failed to write XLSX: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// swimmerSwims loads a swimmer with the meets they swam in.
+func (s *Service) swimmerSwims(ctx context.Context, ownerID string, swimmerID uuid.UUID) (*swimmer.Swimmer, []meetSwims, error) {
+	swimmerData, err := s.swimmerService.GetForOwner(ctx, ownerID, swimmerID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get swimmer: %w", err)
+	}
+
+	swims, err := s.swims(ctx, ownerID, swimmerData.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return swimmerData, swims, nil
+}
+
+// meetsSheet lists the meets with the number of swims in each.
+func meetsSheet(swims []meetSwims) Sheet {
+	sheet := Sheet{
+		Name:    "Meets",
+		Columns: []string{"Name", "City", "Country", "Start Date", "End Date", "Course", "Sanctioned", "Swims"},
+	}
+	for _, ms := range swims {
+		m := ms.meet
+		sheet.Rows = append(sheet.Rows, []any{
+			m.Name, m.City, m.Country, m.StartDate, m.EndDate, m.CourseType, m.Sanctioned, len(ms.times),
+		})
+	}
+	return sheet
+}
+
+// timesSheet lists the swims in meet order. A swim is flagged as a PB when it
+// is the fastest official time of its course and event, counting relay
+// lead-off legs as individual swims. The age group is the swimmer's at the
+// meet under the default age-group scheme.
+func (s *Service) timesSheet(ctx context.Context, swimmerData *swimmer.Swimmer, swims []meetSwims) (Sheet, error) {
+	sheet := Sheet{
+		Name: "Times",
+		Columns: []string{
+			"Date", "Meet", "City", "Course", "Event", "Time", "Seconds", "Status",
+			"PB", "Age", "Age Group", "Relay Leg", "Notes",
+		},
+	}
+
+	scheme, err := s.schemes.Default(ctx)
+	if err != nil {
+		return sheet, err
+	}
+	birthDate, err := time.Parse("2006-01-02", swimmerData.BirthDate)
+	if err != nil {
+		return sheet, fmt.Errorf("failed to parse birth date: %w", err)
+	}
+
+	// Fastest official time per course and event
+	bests := make(map[string]int)
+	for _, ms := range swims {
+		for _, t := range ms.times {
+			key := pbKey(ms.meet.CourseType, t)
+			if key == "" {
+				continue
+			}
+			if best, ok := bests[key]; !ok || t.TimeMS < best {
+				bests[key] = t.TimeMS
+			}
+		}
+	}
+
+	for _, ms := range swims {
+		m := ms.meet
+		var age, ageGroup any
+		if meetDate, err := time.Parse("2006-01-02", m.StartDate); err == nil {
+			a := scheme.Age(birthDate, meetDate)
+			age, ageGroup = a, string(scheme.GroupForAge(a))
+		}
+
+		for _, t := range ms.times {
+			date := t.EventDate
+			if date == "" {
+				date = m.StartDate
+			}
+			var timeFormatted string
+			if t.TimeMS > 0 {
+				timeFormatted = domain.FormatTime(t.TimeMS)
+			}
+			var relayLeg any
+			if t.RelayLeg > 0 {
+				relayLeg = t.RelayLeg
+			}
+			key := pbKey(m.CourseType, t)
+			isPB := key != "" && t.TimeMS == bests[key]
+
+			sheet.Rows = append(sheet.Rows, []any{
+				date, m.Name, m.City, m.CourseType, t.Event, timeFormatted, seconds(t.TimeMS), t.Status,
+				isPB, age, ageGroup, relayLeg, t.Notes,
+			})
+		}
+	}
+	return sheet, nil
+}
+
+// pbKey returns the course and individual event a swim counts for as a
+// personal best, or "" for unofficial results and relay legs other than the
+// lead-off.
+func pbKey(courseType string, t timeservice.TimeRecord) string {
+	event := t.Event
+	if t.RelayLeg > 0 {
+		event = t.OfficialEvent
+	}
+	if event == "" || t.TimeMS <= 0 || !domain.ResultStatus(t.Status).IsOfficial() {
+		return ""
+	}
+	return courseType + "/" + event
+}
+
+// seconds converts a time to seconds, or nil for swims without a time.
+func seconds(ms int) any {
+	if ms <= 0 {
+		return nil
+	}
+	return float64(ms) / 1000
+}
+
+// WriteCSV writes a sheet as CSV with a header row of its columns.
+func WriteCSV(w io.Writer, sheet Sheet) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(sheet.Columns); err != nil {
+		return err
+	}
+	record := make([]string, len(sheet.Columns))
+	for _, row := range sheet.Rows {
+		for i := range record {
+			record[i] = ""
+			if i < len(row) {
+				record[i] = cellText(row[i])
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// cellText formats a cell value as text.
+func cellText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -1,9 +1,14 @@
 // Package exporter provides functionality to export swimmer data to JSON files.
 package exporter
 
+import (
+	"github.com/bpg/swimstats/backend/internal/domain/dataformat"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
+)
+
 // CurrentFormatVersion is the current export format version.
-// Increment when making breaking changes to the export format.
-const CurrentFormatVersion = "1.0"
+// Versions are registered in the dataformat package.
+const CurrentFormatVersion = dataformat.Current
 
 // ExportData represents the complete export structure containing all user data.
 type ExportData struct {
@@ -19,6 +24,7 @@ type SwimmerExport struct {
 	BirthDate        string  `json:"birth_date"`        // YYYY-MM-DD format
 	Gender           string  `json:"gender"`            // "female" or "male"
 	ThresholdPercent float64 `json:"threshold_percent"` // "almost there" threshold percentage
+	SeasonStart      string  `json:"season_start"`      // First day of the season in MM-DD format
 }
 
 // MeetExport represents a meet with its associated times for export.
@@ -28,23 +34,39 @@ type MeetExport struct {
 	Country    string       `json:"country"`
 	StartDate  string       `json:"start_date"`  // YYYY-MM-DD format
 	EndDate    string       `json:"end_date"`    // YYYY-MM-DD format
-	CourseType string       `json:"course_type"` // "25m" or "50m"
+	CourseType string       `json:"course_type"` // "25m", "50m" or "25y"
+	Sanctioned bool         `json:"sanctioned"`  // Whether swims count toward sanctioned-only standards
 	Times      []TimeExport `json:"times"`
 }
 
 // TimeExport represents a swim time for export.
 type TimeExport struct {
-	Event     string `json:"event"`      // Event code (e.g., "50FR", "100BK")
-	Time      string `json:"time"`       // Time in MM:SS.HH or SS.HH format
-	EventDate string `json:"event_date"` // YYYY-MM-DD format
-	Notes     string `json:"notes"`      // Optional notes
+	Event       string        `json:"event"`                  // Event code (e.g., "50FR", "100BK")
+	Time        string        `json:"time"`                   // Time in MM:SS.HH or SS.HH format, empty if none was recorded
+	EventDate   string        `json:"event_date"`             // YYYY-MM-DD format
+	Notes       string        `json:"notes"`                  // Optional notes
+	Status      string        `json:"status,omitempty"`       // Result status (dq, dns, dnf, scr); omitted for official swims
+	DQCode      string        `json:"dq_code,omitempty"`      // Disqualification code
+	DQReason    string        `json:"dq_reason,omitempty"`    // Disqualification reason
+	RelayLeg    int           `json:"relay_leg,omitempty"`    // Leg position (1-4) for relay events
+	RelayStroke string        `json:"relay_stroke,omitempty"` // Stroke of the relay leg (FR, BK, BR, FL)
+	Splits      []SplitExport `json:"splits,omitempty"`       // Optional cumulative splits
+}
+
+// SplitExport represents the cumulative time at a distance within a swim.
+type SplitExport struct {
+	Distance int    `json:"distance"` // Distance in meters
+	Time     string `json:"time"`     // Cumulative time in MM:SS.HH or SS.HH format
 }
 
 // StandardExport represents a time standard for export (custom standards only).
 type StandardExport struct {
-	Name        string              `json:"name"`
-	Description string              `json:"description"`
-	CourseType  string              `json:"course_type"` // "25m" or "50m"
-	Gender      string              `json:"gender"`      // "female" or "male"
-	Times       map[string][]string `json:"times"`       // Event -> [age_group:time, ...]
+	Name           string              `json:"name"`
+	Description    string              `json:"description"`
+	CourseType     string              `json:"course_type"`                // "25m", "50m" or "25y"
+	Gender         string              `json:"gender"`                     // "female" or "male"
+	AgeGroupScheme string              `json:"age_group_scheme,omitempty"` // Defaults to "Swimming Canada"
+	Times          map[string][]string `json:"times"`                      // Event -> [age_group:time, ...]
+	standard.QualifyingRules
+	standard.VersionRef
 }
@@ -0,0 +1,175 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// The parts of an XLSX workbook are written directly: a workbook of
+// worksheets with inline strings, a bold header row frozen in place, and no
+// shared strings or formulas.
+const (
+	xlsxHeader       = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+	xlsxMainNS       = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xlsxRelNS        = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	xlsxPackageRelNS = "http://schemas.openxmlformats.org/package/2006/relationships"
+
+	xlsxRootRels = xlsxHeader +
+		`<Relationships xmlns="` + xlsxPackageRelNS + `">` +
+		`<Relationship Id="rId1" Type="` + xlsxRelNS + `/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	xlsxStyles = xlsxHeader +
+		`<styleSheet xmlns="` + xlsxMainNS + `">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+		`</styleSheet>`
+)
+
+// xlsxPart is a file of the XLSX zip archive.
+type xlsxPart struct {
+	name    string
+	content []byte
+}
+
+// WriteXLSX writes sheets as the worksheets of an XLSX workbook, each with a
+// header row of its columns.
+func WriteXLSX(w io.Writer, sheets []Sheet) error {
+	zw := zip.NewWriter(w)
+
+	parts := []xlsxPart{
+		{"[Content_Types].xml", xlsxContentTypes(len(sheets))},
+		{"_rels/.rels", []byte(xlsxRootRels)},
+		{"xl/workbook.xml", xlsxWorkbook(sheets)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels(len(sheets))},
+		{"xl/styles.xml", []byte(xlsxStyles)},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, xlsxPart{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), xlsxWorksheet(sheet)})
+	}
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(part.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func xlsxContentTypes(sheetCount int) []byte {
+	var b bytes.Buffer
+	b.WriteString(xlsxHeader)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.Bytes()
+}
+
+func xlsxWorkbook(sheets []Sheet) []byte {
+	var b bytes.Buffer
+	b.WriteString(xlsxHeader)
+	b.WriteString(`<workbook xmlns="` + xlsxMainNS + `" xmlns:r="` + xlsxRelNS + `"><sheets>`)
+	for i, sheet := range sheets {
+		b.WriteString(`<sheet name="`)
+		xmlEscape(&b, sheet.Name)
+		fmt.Fprintf(&b, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.Bytes()
+}
+
+func xlsxWorkbookRels(sheetCount int) []byte {
+	var b bytes.Buffer
+	b.WriteString(xlsxHeader)
+	b.WriteString(`<Relationships xmlns="` + xlsxPackageRelNS + `">`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`, i, xlsxRelNS, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s/styles" Target="styles.xml"/>`, sheetCount+1, xlsxRelNS)
+	b.WriteString(`</Relationships>`)
+	return b.Bytes()
+}
+
+func xlsxWorksheet(sheet Sheet) []byte {
+	var b bytes.Buffer
+	b.WriteString(xlsxHeader)
+	b.WriteString(`<worksheet xmlns="` + xlsxMainNS + `">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0">`)
+	b.WriteString(`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+	b.WriteString(`</sheetView></sheetViews><sheetData>`)
+
+	header := make([]any, len(sheet.Columns))
+	for i, column := range sheet.Columns {
+		header[i] = column
+	}
+	xlsxRow(&b, 1, header, true)
+	for i, row := range sheet.Rows {
+		xlsxRow(&b, i+2, row, false)
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.Bytes()
+}
+
+// xlsxRow writes a row of cells; empty cells are left out.
+func xlsxRow(b *bytes.Buffer, n int, cells []any, bold bool) {
+	fmt.Fprintf(b, `<row r="%d">`, n)
+	for i, v := range cells {
+		if v == nil {
+			continue
+		}
+		ref := columnName(i) + strconv.Itoa(n)
+		style := ""
+		if bold {
+			style = ` s="1"`
+		}
+		switch v := v.(type) {
+		case int, float64:
+			fmt.Fprintf(b, `<c r="%s"%s><v>%s</v></c>`, ref, style, cellText(v))
+		case bool:
+			value := "0"
+			if v {
+				value = "1"
+			}
+			fmt.Fprintf(b, `<c r="%s"%s t="b"><v>%s</v></c>`, ref, style, value)
+		default:
+			fmt.Fprintf(b, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">`, ref, style)
+			xmlEscape(b, cellText(v))
+			b.WriteString(`</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+}
+
+// columnName returns the spreadsheet name of a zero-based column: A, B, ...
+// Z, AA, AB, ...
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func xmlEscape(b *bytes.Buffer, s string) {
+	// Writing to a bytes.Buffer never fails
+	_ = xml.EscapeText(b, []byte(s))
+}
@@ -0,0 +1,41 @@
+package importer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Athlete identifies the swimmer whose results are extracted from a meet results file.
+type Athlete struct {
+	Name      string // Full name as stored on the swimmer profile
+	BirthDate string // YYYY-MM-DD format
+}
+
+// matchesName reports whether a name from a results file refers to the athlete.
+// Every word of the profile name must appear in the candidate, in any order, so
+// "Jane Doe" matches "Doe, Jane M" and "DOE Jane".
+func (a Athlete) matchesName(candidate string) bool {
+	want := nameWords(a.Name)
+	if len(want) == 0 {
+		return false
+	}
+
+	have := make(map[string]bool)
+	for _, w := range nameWords(candidate) {
+		have[w] = true
+	}
+
+	for _, w := range want {
+		if !have[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// nameWords lowercases a name and splits it into words, dropping punctuation.
+func nameWords(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-' && r != '\''
+	})
+}
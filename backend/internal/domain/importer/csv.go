@@ -0,0 +1,329 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/dataformat"
+)
+
+// RowError is a row of a CSV file that cannot be imported. Rows are numbered
+// by line, the header being row 1.
+type RowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// csvColumns maps the accepted CSV headers, lowercased without spaces,
+// underscores or hyphens, to the fields they hold.
+var csvColumns = map[string]string{
+	"meet":        "meet",
+	"meetname":    "meet",
+	"competition": "meet",
+	"city":        "city",
+	"location":    "city",
+	"country":     "country",
+	"startdate":   "start_date",
+	"meetstart":   "start_date",
+	"enddate":     "end_date",
+	"meetend":     "end_date",
+	"course":      "course",
+	"coursetype":  "course",
+	"pool":        "course",
+	"sanctioned":  "sanctioned",
+	"event":       "event",
+	"time":        "time",
+	"date":        "date",
+	"eventdate":   "date",
+	"swimdate":    "date",
+	"status":      "status",
+	"notes":       "notes",
+	"note":        "notes",
+	"comments":    "notes",
+	"dqcode":      "dq_code",
+	"dqreason":    "dq_reason",
+	"relayleg":    "relay_leg",
+	"relaystroke": "relay_stroke",
+}
+
+var csvHeaderReplacer = strings.NewReplacer(" ", "", "_", "", "-", "")
+
+// csvCourses maps course names used in spreadsheets to course types.
+var csvCourses = map[string]string{
+	"25M": "25m",
+	"50M": "50m",
+	"25Y": "25y",
+	"SCM": "25m",
+	"LCM": "50m",
+	"SCY": "25y",
+}
+
+// csvMeetGap is the longest break between the swims of a meet grouped
+// without a start date column.
+const csvMeetGap = 7 * 24 * time.Hour
+
+// csvRow is a data row of a CSV file with the time it holds.
+type csvRow struct {
+	row  int
+	date time.Time
+	time TimeData
+}
+
+// csvMeet is a meet of a CSV file with its rows.
+type csvMeet struct {
+	data MeetData
+	rows []csvRow
+}
+
+// parseCSV converts a CSV file with one row per swim into ImportData. The
+// header names the columns; meet, course, event, time and either date (of
+// the swim) or start_date (of the meet) are required. Rows are grouped into
+// meets by meet name, course and start date. Without a start date column,
+// rows of a meet name and course are split into separate meets when more than
+// a week passes between swims, and each meet spans the dates of its swims.
+// Rows that fail the validation of JSON imports are left out and reported
+// as row errors.
+func (s *Service) parseCSV(raw []byte) (*ImportData, []RowError, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf"))))
+	reader.Comma = csvDelimiter(raw)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		key := csvHeaderReplacer.Replace(strings.ToLower(strings.TrimSpace(name)))
+		if field, ok := csvColumns[key]; ok {
+			if _, seen := columns[field]; !seen {
+				columns[field] = i
+			}
+		}
+	}
+	for _, field := range []string{"meet", "course", "event", "time"} {
+		if _, ok := columns[field]; !ok {
+			return nil, nil, fmt.Errorf("CSV file has no %s column", field)
+		}
+	}
+	_, hasStart := columns["start_date"]
+	if _, hasDate := columns["date"]; !hasDate && !hasStart {
+		return nil, nil, fmt.Errorf("CSV file has no date or start_date column")
+	}
+
+	var rowErrors []RowError
+	meets := make(map[string]*csvMeet)
+	var keys []string
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, RowError{Row: parseErr.StartLine, Error: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, fmt.Errorf("invalid CSV file: %w", err)
+		}
+		n, _ := reader.FieldPos(0)
+
+		get := func(field string) string {
+			i, ok := columns[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue // Blank line
+		}
+
+		name := get("meet")
+		if name == "" {
+			rowErrors = append(rowErrors, RowError{Row: n, Error: "meet name is required"})
+			continue
+		}
+		course, ok := csvCourses[strings.ToUpper(get("course"))]
+		if !ok {
+			rowErrors = append(rowErrors, RowError{Row: n, Error: fmt.Sprintf("course must be '25m', '50m' or '25y', got: %s", get("course"))})
+			continue
+		}
+
+		startDate := get("start_date")
+		date := get("date")
+		if date == "" {
+			date = startDate
+		}
+		swimDate, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: n, Error: fmt.Sprintf("invalid date (expected YYYY-MM-DD): %s", date)})
+			continue
+		}
+
+		row := csvRow{
+			row:  n,
+			date: swimDate,
+			time: TimeData{
+				Event:       strings.ToUpper(strings.ReplaceAll(get("event"), " ", "")),
+				Time:        get("time"),
+				EventDate:   date,
+				Notes:       get("notes"),
+				Status:      get("status"),
+				DQCode:      get("dq_code"),
+				DQReason:    get("dq_reason"),
+				RelayStroke: get("relay_stroke"),
+			},
+		}
+		if leg := get("relay_leg"); leg != "" {
+			row.time.RelayLeg, err = strconv.Atoi(leg)
+			if err != nil {
+				rowErrors = append(rowErrors, RowError{Row: n, Error: fmt.Sprintf("invalid relay leg: %s", leg)})
+				continue
+			}
+		}
+
+		key := name + "|" + course + "|" + startDate
+		m, ok := meets[key]
+		if !ok {
+			m = &csvMeet{data: MeetData{
+				Name:       name,
+				City:       get("city"),
+				Country:    get("country"),
+				StartDate:  startDate,
+				EndDate:    get("end_date"),
+				CourseType: course,
+			}}
+			if sanctioned := get("sanctioned"); sanctioned != "" {
+				value, err := parseCSVBool(sanctioned)
+				if err != nil {
+					rowErrors = append(rowErrors, RowError{Row: n, Error: err.Error()})
+					continue
+				}
+				m.data.Sanctioned = &value
+			}
+			meets[key] = m
+			keys = append(keys, key)
+		}
+		m.rows = append(m.rows, row)
+	}
+
+	data := &ImportData{FormatVersion: dataformat.Current}
+	for _, key := range keys {
+		for _, m := range splitCSVMeet(meets[key]) {
+			meetData, errs := s.validateCSVMeet(m)
+			rowErrors = append(rowErrors, errs...)
+			if len(meetData.Times) > 0 {
+				data.Meets = append(data.Meets, meetData)
+			}
+		}
+	}
+
+	sort.SliceStable(data.Meets, func(i, j int) bool {
+		return data.Meets[i].StartDate < data.Meets[j].StartDate
+	})
+	sort.SliceStable(rowErrors, func(i, j int) bool {
+		return rowErrors[i].Row < rowErrors[j].Row
+	})
+
+	return data, rowErrors, nil
+}
+
+// splitCSVMeet sets the dates of a meet without a start date from its swims,
+// splitting it where more than csvMeetGap passes between swims. Meets with a
+// start date end on the end date, or on their last swim if there is none.
+func splitCSVMeet(m *csvMeet) []*csvMeet {
+	sort.SliceStable(m.rows, func(i, j int) bool {
+		return m.rows[i].date.Before(m.rows[j].date)
+	})
+	last := m.rows[len(m.rows)-1].date.Format("2006-01-02")
+
+	if m.data.StartDate != "" {
+		if m.data.EndDate == "" {
+			m.data.EndDate = m.data.StartDate
+			if last > m.data.StartDate {
+				m.data.EndDate = last
+			}
+		}
+		return []*csvMeet{m}
+	}
+
+	var split []*csvMeet
+	var current *csvMeet
+	for _, row := range m.rows {
+		if current == nil || row.date.Sub(current.rows[len(current.rows)-1].date) > csvMeetGap {
+			current = &csvMeet{data: m.data}
+			current.data.StartDate = row.date.Format("2006-01-02")
+			split = append(split, current)
+		}
+		current.rows = append(current.rows, row)
+		current.data.EndDate = row.date.Format("2006-01-02")
+	}
+	return split
+}
+
+// validateCSVMeet validates a meet and each of its rows as a JSON import
+// does, returning the meet with the times of the valid rows.
+func (s *Service) validateCSVMeet(m *csvMeet) (MeetData, []RowError) {
+	var rowErrors []RowError
+
+	parsed, err := s.parseMeet(&m.data)
+	if err != nil {
+		for _, row := range m.rows {
+			rowErrors = append(rowErrors, RowError{Row: row.row, Error: err.Error()})
+		}
+		return m.data, rowErrors
+	}
+
+	meetData := m.data
+	meetData.Times = make([]TimeData, 0, len(m.rows))
+	for _, row := range m.rows {
+		parsedTime, err := s.parseTime(&row.time, parsed.StartDate, parsed.EndDate)
+		if err == nil && !domain.EventCode(parsedTime.Event).IsValidForCourse(domain.CourseType(parsed.CourseType)) {
+			err = fmt.Errorf("event %s is not swum in a %s pool", parsedTime.Event, parsed.CourseType)
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row.row, Error: err.Error()})
+			continue
+		}
+		meetData.Times = append(meetData.Times, row.time)
+	}
+	return meetData, rowErrors
+}
+
+// csvDelimiter returns the delimiter of a CSV file: a semicolon when the
+// header has semicolons but no commas, as spreadsheets in many locales
+// write, otherwise a comma.
+func csvDelimiter(raw []byte) rune {
+	header, _, _ := bytes.Cut(raw, []byte("\n"))
+	if bytes.Contains(header, []byte(";")) && !bytes.Contains(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+// parseCSVBool parses a yes/no spreadsheet value.
+func parseCSVBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "yes", "y", "true", "1":
+		return true, nil
+	case "no", "n", "false", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid sanctioned value: %s", s)
+}
@@ -0,0 +1,434 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/dataformat"
+)
+
+// Lenex 3.0 document structure. Only the elements needed to extract a
+// single athlete's individual results are mapped.
+type lenexDocument struct {
+	XMLName xml.Name    `xml:"LENEX"`
+	Version string      `xml:"version,attr"`
+	Meets   []lenexMeet `xml:"MEETS>MEET"`
+}
+
+type lenexMeet struct {
+	Name     string         `xml:"name,attr"`
+	City     string         `xml:"city,attr"`
+	Nation   string         `xml:"nation,attr"`
+	Course   string         `xml:"course,attr"`
+	Sessions []lenexSession `xml:"SESSIONS>SESSION"`
+	Clubs    []lenexClub    `xml:"CLUBS>CLUB"`
+}
+
+type lenexSession struct {
+	Number string       `xml:"number,attr"`
+	Date   string       `xml:"date,attr"`
+	Course string       `xml:"course,attr"`
+	Events []lenexEvent `xml:"EVENTS>EVENT"`
+}
+
+type lenexEvent struct {
+	EventID   string         `xml:"eventid,attr"`
+	SwimStyle lenexSwimStyle `xml:"SWIMSTYLE"`
+}
+
+type lenexSwimStyle struct {
+	Distance   int    `xml:"distance,attr"`
+	RelayCount int    `xml:"relaycount,attr"`
+	Stroke     string `xml:"stroke,attr"`
+}
+
+type lenexClub struct {
+	Athletes []lenexAthlete `xml:"ATHLETES>ATHLETE"`
+}
+
+type lenexAthlete struct {
+	FirstName string        `xml:"firstname,attr"`
+	LastName  string        `xml:"lastname,attr"`
+	BirthDate string        `xml:"birthdate,attr"`
+	Gender    string        `xml:"gender,attr"`
+	Results   []lenexResult `xml:"RESULTS>RESULT"`
+}
+
+type lenexResult struct {
+	EventID  string       `xml:"eventid,attr"`
+	SwimTime string       `xml:"swimtime,attr"`
+	Status   string       `xml:"status,attr"`
+	Comment  string       `xml:"comment,attr"`
+	Splits   []lenexSplit `xml:"SPLITS>SPLIT"`
+}
+
+type lenexSplit struct {
+	Distance int    `xml:"distance,attr"`
+	SwimTime string `xml:"swimtime,attr"`
+}
+
+// lenexStrokes maps Lenex stroke codes to event code suffixes.
+var lenexStrokes = map[string]string{
+	"FREE":   "FR",
+	"BACK":   "BK",
+	"BREAST": "BR",
+	"FLY":    "FL",
+	"MEDLEY": "IM",
+}
+
+// lenexStatuses maps Lenex result statuses to result statuses. Exhibition
+// swims (EXH) are official times.
+var lenexStatuses = map[string]domain.ResultStatus{
+	"":    domain.ResultOK,
+	"EXH": domain.ResultOK,
+	"DSQ": domain.ResultDisqualified,
+	"DNS": domain.ResultDidNotStart,
+	"DNF": domain.ResultDidNotFinish,
+	"WDR": domain.ResultScratched,
+}
+
+// lenexCourses maps Lenex course codes to course types.
+var lenexCourses = map[string]string{
+	"SCM": "25m",
+	"LCM": "50m",
+	"SCY": "25y",
+}
+
+// lenexSwimTime matches the Lenex swim time format HH:MM:SS.hh.
+var lenexSwimTime = regexp.MustCompile(`^(\d{2}):(\d{2}):(\d{2})\.(\d{2})$`)
+
+// ParseLenex converts a Lenex 3.0 results file into ImportData containing the
+// individual results of the given athlete. Both plain XML (.lef) and zipped
+// (.lxf) files are accepted. Results that cannot be imported (relays,
+// unsupported events or courses) are skipped and reported as warnings.
+// Disqualified and other unofficial results are imported with their status.
+// Of several swims of an event, e.g. prelims and finals, the fastest official
+// one is kept.
+func ParseLenex(raw []byte, athlete Athlete) (*ImportData, []string, error) {
+	content, err := unzipLenex(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var doc lenexDocument
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.CharsetReader = lenexCharsetReader
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("invalid Lenex file: %w", err)
+	}
+
+	if len(doc.Meets) == 0 {
+		return nil, nil, fmt.Errorf("lenex file contains no meets")
+	}
+
+	data := &ImportData{FormatVersion: dataformat.Current}
+	var warnings []string
+	found := false
+
+	for _, m := range doc.Meets {
+		a := findLenexAthlete(m, athlete)
+		if a == nil {
+			continue
+		}
+		found = true
+
+		meetData, meetWarnings, err := convertLenexMeet(m, a)
+		warnings = append(warnings, meetWarnings...)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Meet %s skipped: %v", m.Name, err))
+			continue
+		}
+		if len(meetData.Times) == 0 {
+			warnings = append(warnings, fmt.Sprintf("Meet %s skipped: no individual results", m.Name))
+			continue
+		}
+		data.Meets = append(data.Meets, *meetData)
+	}
+
+	if !found {
+		return nil, nil, fmt.Errorf("athlete %s (born %s) not found in Lenex file", athlete.Name, athlete.BirthDate)
+	}
+
+	return data, warnings, nil
+}
+
+// unzipLenex returns the XML content of a Lenex file, extracting it from
+// the zip archive of a .lxf file when needed.
+func unzipLenex(raw []byte) ([]byte, error) {
+	if !bytes.HasPrefix(raw, []byte("PK\x03\x04")) {
+		return raw, nil
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Lenex archive: %w", err)
+	}
+
+	for _, f := range archive.File {
+		if !strings.EqualFold(path.Ext(f.Name), ".lef") {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+		}
+		defer rc.Close()
+
+		content, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		return content, nil
+	}
+
+	return nil, fmt.Errorf("lenex archive contains no .lef file")
+}
+
+// lenexCharsetReader supports the ISO-8859-1 encoding used by older meet
+// management software in addition to UTF-8. Such files are decoded as
+// Windows-1252, its superset that Windows software writes under that label.
+func lenexCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252":
+		return charmap.Windows1252.NewDecoder().Reader(input), nil
+	default:
+		return nil, fmt.Errorf("unsupported charset: %s", charset)
+	}
+}
+
+// findLenexAthlete returns the meet's athlete matching the given name and
+// birth date. When the meet lists a single athlete (a personal results
+// export), that athlete is used if the birth date does not contradict it.
+func findLenexAthlete(m lenexMeet, athlete Athlete) *lenexAthlete {
+	var all []*lenexAthlete
+	for i := range m.Clubs {
+		for j := range m.Clubs[i].Athletes {
+			all = append(all, &m.Clubs[i].Athletes[j])
+		}
+	}
+
+	for _, a := range all {
+		if a.BirthDate == athlete.BirthDate && athlete.matchesName(a.FirstName+" "+a.LastName) {
+			return a
+		}
+	}
+
+	if len(all) == 1 && (all[0].BirthDate == "" || all[0].BirthDate == athlete.BirthDate) {
+		return all[0]
+	}
+
+	return nil
+}
+
+// convertLenexMeet converts a Lenex meet and the athlete's results into MeetData.
+func convertLenexMeet(m lenexMeet, a *lenexAthlete) (*MeetData, []string, error) {
+	type lenexEventInfo struct {
+		Event  string
+		Date   string
+		Course string
+		Err    error
+	}
+
+	events := make(map[string]lenexEventInfo)
+	var dates []string
+	courses := make(map[string]bool)
+
+	for _, session := range m.Sessions {
+		if _, err := time.Parse("2006-01-02", session.Date); err != nil {
+			return nil, nil, fmt.Errorf("session %s has invalid date %q", session.Number, session.Date)
+		}
+		dates = append(dates, session.Date)
+
+		course := session.Course
+		if course == "" {
+			course = m.Course
+		}
+
+		for _, e := range session.Events {
+			event, err := lenexEventCode(e.SwimStyle)
+			events[e.EventID] = lenexEventInfo{Event: event, Date: session.Date, Course: course, Err: err}
+		}
+	}
+
+	if len(dates) == 0 {
+		return nil, nil, fmt.Errorf("no sessions")
+	}
+	sort.Strings(dates)
+
+	var warnings []string
+	var times []TimeData
+
+	// Of several swims of an event, e.g. prelims and finals, the fastest
+	// official one is kept, or the first result if none is official
+	index := make(map[string]int)  // event -> position of its result in times
+	bestMS := make(map[string]int) // event -> time of its official result
+	keep := func(data TimeData, officialMS int) {
+		i, ok := index[data.Event]
+		if !ok {
+			index[data.Event] = len(times)
+			times = append(times, data)
+			bestMS[data.Event] = officialMS
+			return
+		}
+		if officialMS > 0 && (bestMS[data.Event] == 0 || officialMS < bestMS[data.Event]) {
+			times[i] = data
+			bestMS[data.Event] = officialMS
+		}
+	}
+
+	for _, r := range a.Results {
+		info, ok := events[r.EventID]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("Meet %s: result for unknown event %s skipped", m.Name, r.EventID))
+			continue
+		}
+		if info.Err != nil {
+			warnings = append(warnings, fmt.Sprintf("Meet %s: %v", m.Name, info.Err))
+			continue
+		}
+		status, ok := lenexStatuses[r.Status]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("Meet %s: %s result with status %s skipped", m.Name, info.Event, r.Status))
+			continue
+		}
+
+		if !status.IsOfficial() {
+			data := TimeData{
+				Event:     info.Event,
+				EventDate: info.Date,
+				Status:    status.String(),
+			}
+			// A disqualified or unfinished swim keeps its time when one was recorded
+			if timeMS, err := parseLenexSwimTime(r.SwimTime); err == nil && status.Started() {
+				data.Time = domain.FormatTime(timeMS)
+			}
+			if status == domain.ResultDisqualified {
+				data.DQReason = strings.TrimSpace(r.Comment)
+			}
+			courses[info.Course] = true
+			keep(data, 0)
+			continue
+		}
+
+		timeMS, err := parseLenexSwimTime(r.SwimTime)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Meet %s: %s result skipped: %v", m.Name, info.Event, err))
+			continue
+		}
+
+		courses[info.Course] = true
+		keep(TimeData{
+			Event:     info.Event,
+			Time:      domain.FormatTime(timeMS),
+			EventDate: info.Date,
+			Splits:    convertLenexSplits(r.Splits, info.Event, timeMS),
+		}, timeMS)
+	}
+
+	if len(courses) > 1 {
+		return nil, warnings, fmt.Errorf("results were swum in more than one course")
+	}
+
+	course := m.Course
+	for c := range courses {
+		course = c
+	}
+	courseType, ok := lenexCourses[course]
+	if !ok {
+		return nil, warnings, fmt.Errorf("unsupported course %q", course)
+	}
+
+	return &MeetData{
+		Name:       strings.TrimSpace(m.Name),
+		City:       strings.TrimSpace(m.City),
+		Country:    strings.TrimSpace(m.Nation),
+		StartDate:  dates[0],
+		EndDate:    dates[len(dates)-1],
+		CourseType: courseType,
+		Times:      times,
+	}, warnings, nil
+}
+
+// convertLenexSplits converts Lenex splits to cumulative splits. Lenex omits
+// the split at the finish, so the final time is appended. Splits that cannot
+// be parsed or are out of order are dropped.
+func convertLenexSplits(splits []lenexSplit, event string, timeMS int) []SplitData {
+	if len(splits) == 0 {
+		return nil
+	}
+
+	sort.Slice(splits, func(i, j int) bool {
+		return splits[i].Distance < splits[j].Distance
+	})
+
+	distance := domain.EventCode(event).Distance()
+	result := make([]SplitData, 0, len(splits)+1)
+	prevDistance, prevMS := 0, 0
+	for _, split := range splits {
+		splitMS, err := parseLenexSwimTime(split.SwimTime)
+		if err != nil || split.Distance <= prevDistance || split.Distance >= distance ||
+			splitMS <= prevMS || splitMS >= timeMS {
+			continue
+		}
+		result = append(result, SplitData{Distance: split.Distance, Time: domain.FormatTime(splitMS)})
+		prevDistance, prevMS = split.Distance, splitMS
+	}
+
+	return append(result, SplitData{Distance: distance, Time: domain.FormatTime(timeMS)})
+}
+
+// lenexEventCode maps a Lenex swim style to an event code.
+func lenexEventCode(style lenexSwimStyle) (string, error) {
+	if style.RelayCount > 1 {
+		return "", fmt.Errorf("relay event %dx%d %s skipped", style.RelayCount, style.Distance, style.Stroke)
+	}
+
+	suffix, ok := lenexStrokes[style.Stroke]
+	if !ok {
+		return "", fmt.Errorf("unsupported stroke %s skipped", style.Stroke)
+	}
+
+	event := domain.EventCode(strconv.Itoa(style.Distance) + suffix)
+	if !event.IsValid() {
+		return "", fmt.Errorf("unsupported event %s skipped", event)
+	}
+
+	return event.String(), nil
+}
+
+// parseLenexSwimTime converts a Lenex swim time (HH:MM:SS.hh) to milliseconds.
+func parseLenexSwimTime(s string) (int, error) {
+	if s == "" || s == "NT" {
+		return 0, fmt.Errorf("no time recorded")
+	}
+
+	matches := lenexSwimTime.FindStringSubmatch(s)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid swim time %q", s)
+	}
+
+	parts := make([]int, 4)
+	for i := range parts {
+		parts[i], _ = strconv.Atoi(matches[i+1])
+	}
+
+	ms := ((parts[0]*60+parts[1])*60+parts[2])*1000 + parts[3]*10
+	if ms <= 0 {
+		return 0, fmt.Errorf("no time recorded")
+	}
+
+	return ms, nil
+}
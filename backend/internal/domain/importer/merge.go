@@ -0,0 +1,403 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/meet"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
+	timeservice "github.com/bpg/swimstats/backend/internal/domain/time"
+)
+
+// mergeSwimmer reconciles the swimmer section with the existing swimmer,
+// updating a changed profile only when requested.
+func (s *Service) mergeSwimmer(ctx context.Context, ownerID string, current *swimmer.Swimmer, parsed *ParsedSwimmer, update bool, result *ImportResult) error {
+	birthDate := parsed.BirthDate.Format("2006-01-02")
+
+	var changed []string
+	if current.Name != parsed.Name {
+		changed = append(changed, "name")
+	}
+	if current.BirthDate != birthDate {
+		changed = append(changed, "birth_date")
+	}
+	if current.Gender != parsed.Gender {
+		changed = append(changed, "gender")
+	}
+	if parsed.ThresholdPercent != nil && current.ThresholdPercent != *parsed.ThresholdPercent {
+		changed = append(changed, "threshold_percent")
+	}
+	if parsed.SeasonStart != nil && current.SeasonStart != *parsed.SeasonStart {
+		changed = append(changed, "season_start")
+	}
+
+	result.SwimmerID = current.ID.String()
+	result.SwimmerName = current.Name
+	switch {
+	case len(changed) == 0:
+	case update:
+		updated, err := s.swimmerService.Update(ctx, ownerID, current.ID, swimmer.Input{
+			Name:             parsed.Name,
+			BirthDate:        birthDate,
+			Gender:           parsed.Gender,
+			ThresholdPercent: parsed.ThresholdPercent,
+			SeasonStart:      parsed.SeasonStart,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update swimmer: %w", err)
+		}
+		result.SwimmerName = updated.Name
+		result.SwimmerReplaced = true
+	default:
+		result.Merge.Conflicts = append(result.Merge.Conflicts, conflict("Swimmer "+current.Name, changed))
+	}
+	return nil
+}
+
+// mergeMeets merges meets and their times into the swimmer's data. Times are
+// matched by meet and event, as a swimmer swims an event once per meet.
+func (s *Service) mergeMeets(ctx context.Context, ownerID string, swimmerID uuid.UUID, meets []ParsedMeet, update bool, result *ImportResult) error {
+	times, err := s.listAllTimes(ctx, swimmerID)
+	if err != nil {
+		return err
+	}
+	splits, err := s.timeService.ListSplitsBySwimmer(ctx, swimmerID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]*timeservice.TimeRecord, len(times))
+	for i := range times {
+		t := &times[i]
+		t.Splits = splits[t.ID]
+		existing[t.MeetID.String()+"/"+t.Event] = t
+	}
+
+	for i := range meets {
+		parsedMeet := &meets[i]
+
+		m, err := s.mergeMeet(ctx, ownerID, parsedMeet, update, result)
+		if err != nil {
+			return fmt.Errorf("failed to import meet %s: %w", parsedMeet.Name, err)
+		}
+
+		for j := range parsedMeet.Times {
+			parsedTime := &parsedMeet.Times[j]
+			key := m.ID.String() + "/" + parsedTime.Event
+
+			record, err := s.mergeTime(ctx, ownerID, swimmerID, m, existing[key], parsedTime, update, result)
+			if err != nil {
+				return fmt.Errorf("failed to import meet %s: %w", parsedMeet.Name, err)
+			}
+			existing[key] = record
+		}
+	}
+	return nil
+}
+
+// mergeTimesPage is the number of times listed at once when merging.
+const mergeTimesPage = 1000
+
+// listAllTimes lists every time of the swimmer, a page at a time, as any
+// time left out would be imported again.
+func (s *Service) listAllTimes(ctx context.Context, swimmerID uuid.UUID) ([]timeservice.TimeRecord, error) {
+	var times []timeservice.TimeRecord
+	for {
+		page, err := s.timeService.List(ctx, timeservice.ListParams{
+			SwimmerID: swimmerID,
+			Limit:     mergeTimesPage,
+			Offset:    len(times),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list times: %w", err)
+		}
+		times = append(times, page.Times...)
+		if len(page.Times) < mergeTimesPage || len(times) >= page.Total {
+			return times, nil
+		}
+	}
+}
+
+// mergeMeet finds or creates a meet, updating a changed meet only when
+// requested. Meets are shared between an owner's swimmers, so updates apply
+// to all of them.
+func (s *Service) mergeMeet(ctx context.Context, ownerID string, parsed *ParsedMeet, update bool, result *ImportResult) (*meet.Meet, error) {
+	input := meetInput(parsed)
+	m, created, err := s.meetService.FindOrCreate(ctx, ownerID, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create meet: %w", err)
+	}
+	if created {
+		result.MeetsCreated++
+		result.Merge.Meets.Inserted++
+		return m, nil
+	}
+
+	// A missing country or sanctioned flag keeps the existing one
+	var changed []string
+	if m.City != input.City {
+		changed = append(changed, "city")
+	}
+	if input.Country != "" && m.Country != input.Country {
+		changed = append(changed, "country")
+	}
+	if m.EndDate != input.EndDate {
+		changed = append(changed, "end_date")
+	}
+	if input.Sanctioned != nil && m.Sanctioned != *input.Sanctioned {
+		changed = append(changed, "sanctioned")
+	}
+
+	switch {
+	case len(changed) == 0:
+		result.Merge.Meets.Matched++
+	case update:
+		m, err = s.meetService.Update(ctx, ownerID, m.ID, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update meet: %w", err)
+		}
+		result.Merge.Meets.Updated++
+	default:
+		result.Merge.Meets.Conflicting++
+		result.Merge.Conflicts = append(result.Merge.Conflicts,
+			conflict(fmt.Sprintf("Meet %s (%s, %s)", m.Name, m.StartDate, m.CourseType), changed))
+	}
+	return m, nil
+}
+
+// mergeTime records a time the swimmer does not have in the meet, or
+// reconciles it with the current one, updating it only when requested.
+// A different event date counts as a change. Returns the time as stored.
+func (s *Service) mergeTime(ctx context.Context, ownerID string, swimmerID uuid.UUID, m *meet.Meet, current *timeservice.TimeRecord, parsed *ParsedTime, update bool, result *ImportResult) (*timeservice.TimeRecord, error) {
+	input := timeInput(m.ID, parsed)
+	if current == nil {
+		created, err := s.timeService.Create(ctx, ownerID, swimmerID, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create time for event %s: %w", parsed.Event, err)
+		}
+		result.TimesCreated++
+		result.Merge.Times.Inserted++
+		return created, nil
+	}
+
+	var changed []string
+	if current.TimeMS != input.TimeMS {
+		changed = append(changed, "time")
+	}
+	if current.EventDate != input.EventDate {
+		changed = append(changed, "event_date")
+	}
+	if current.Status != input.Status {
+		changed = append(changed, "status")
+	}
+	if current.Notes != input.Notes {
+		changed = append(changed, "notes")
+	}
+	if current.DQCode != input.DQCode || current.DQReason != input.DQReason {
+		changed = append(changed, "disqualification")
+	}
+	if current.RelayLeg != input.RelayLeg || current.RelayStroke != input.RelayStroke {
+		changed = append(changed, "relay leg")
+	}
+	// Imported times without splits keep the existing ones
+	if len(input.Splits) == 0 {
+		input.Splits = nil
+	} else if !sameSplits(current.Splits, input.Splits) {
+		changed = append(changed, "splits")
+	}
+
+	switch {
+	case len(changed) == 0:
+		result.Merge.Times.Matched++
+	case update:
+		updated, err := s.timeService.Update(ctx, ownerID, current.ID, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update time for event %s: %w", parsed.Event, err)
+		}
+		result.Merge.Times.Updated++
+		return updated, nil
+	default:
+		result.Merge.Times.Conflicting++
+		result.Merge.Conflicts = append(result.Merge.Conflicts,
+			conflict(fmt.Sprintf("Time %s at %s (%s)", parsed.Event, m.Name, m.StartDate), changed))
+	}
+	return current, nil
+}
+
+// mergeStandards creates the standards the owner does not have yet and
+// reconciles the times of the owner's standards of the same name, adding and
+// updating times only when requested. Times the import does not have are
+// kept, and standards of another course or gender are always conflicts.
+func (s *Service) mergeStandards(ctx context.Context, ownerID string, standards []ParsedStandard, update bool, result *ImportResult) error {
+	standardList, err := s.standardService.List(ctx, ownerID, standard.ListParams{})
+	if err != nil {
+		return fmt.Errorf("failed to list standards: %w", err)
+	}
+	byName := make(map[string]standard.Standard, len(standardList.Standards))
+	for _, std := range standardList.Standards {
+		if std.OwnerID == ownerID {
+			byName[std.Name] = std
+		}
+	}
+
+	for i := range standards {
+		parsedStandard := &standards[i]
+
+		existing, ok := byName[parsedStandard.Name]
+		if !ok {
+			if err := s.importStandard(ctx, ownerID, parsedStandard); err != nil {
+				return fmt.Errorf("failed to import standard %s: %w", parsedStandard.Name, err)
+			}
+			result.StandardsCreated++
+			result.Merge.Standards.Inserted++
+			continue
+		}
+
+		if err := s.mergeStandard(ctx, ownerID, &existing, parsedStandard, update, result); err != nil {
+			return fmt.Errorf("failed to import standard %s: %w", parsedStandard.Name, err)
+		}
+	}
+	return nil
+}
+
+// mergeStandard reconciles an existing standard with an imported one: its
+// description, qualifying rules, age group scheme and times. Times the import
+// does not have are kept unless the imported scheme lacks their age group.
+// Preloaded standards cannot be edited, so only their times are updated and
+// other changes are reported as conflicts.
+func (s *Service) mergeStandard(ctx context.Context, ownerID string, existing *standard.Standard, parsed *ParsedStandard, update bool, result *ImportResult) error {
+	var changed []string
+	if existing.CourseType != parsed.CourseType {
+		changed = append(changed, "course_type")
+	}
+	if existing.Gender != parsed.Gender {
+		changed = append(changed, "gender")
+	}
+	if len(changed) > 0 {
+		result.Merge.Standards.Conflicting++
+		result.Merge.Conflicts = append(result.Merge.Conflicts, conflict("Standard "+existing.Name, changed))
+		return nil
+	}
+
+	scheme, err := s.schemes.Resolve(ctx, parsed.AgeGroupScheme)
+	if err != nil {
+		return fmt.Errorf("failed to resolve age group scheme: %w", err)
+	}
+	current, err := s.standardService.GetWithTimes(ctx, ownerID, existing.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get standard times: %w", err)
+	}
+
+	description := strings.TrimSpace(parsed.Description)
+	if existing.Description != description {
+		changed = append(changed, "description")
+	}
+	if existing.QualifyingRules != parsed.QualifyingRules {
+		changed = append(changed, "qualifying rules")
+	}
+	schemeChanged := existing.AgeGroupSchemeID != scheme.ID
+	if schemeChanged {
+		changed = append(changed, "age_group_scheme")
+	}
+	metadataChanged := len(changed) > 0
+	if existing.IsPreloaded && metadataChanged {
+		result.Merge.Conflicts = append(result.Merge.Conflicts, conflict("Standard "+existing.Name, changed))
+		changed = nil
+		metadataChanged = false
+		schemeChanged = false
+		if scheme, err = s.schemes.Get(ctx, existing.AgeGroupSchemeID); err != nil {
+			return fmt.Errorf("failed to get age group scheme: %w", err)
+		}
+	}
+
+	// Existing times of age groups the scheme lacks are dropped
+	times := make(map[string]standard.StandardTimeInput, len(current.Times))
+	var kept []standard.StandardTimeInput
+	changes := 0
+	for _, t := range current.Times {
+		if !scheme.Has(domain.AgeGroup(t.AgeGroup)) {
+			changes++
+			continue
+		}
+		input := standard.StandardTimeInput{Event: t.Event, AgeGroup: t.AgeGroup, TimeMs: t.TimeMs}
+		times[t.Event+"/"+t.AgeGroup] = input
+		kept = append(kept, input)
+	}
+	for event, timesForEvent := range parsed.Times {
+		for _, t := range timesForEvent {
+			ageGroup, ok := scheme.Normalize(t.AgeGroup)
+			if !ok {
+				return fmt.Errorf("age group %s of %s is not part of the %s scheme", t.AgeGroup, event, scheme.Name)
+			}
+			key := event + "/" + string(ageGroup)
+			if times[key].TimeMs != int(t.TimeMS) {
+				times[key] = standard.StandardTimeInput{Event: event, AgeGroup: string(ageGroup), TimeMs: int(t.TimeMS)}
+				changes++
+			}
+		}
+	}
+	if changes > 0 {
+		changed = append(changed, fmt.Sprintf("%d time(s)", changes))
+	}
+
+	switch {
+	case len(changed) == 0:
+		result.Merge.Standards.Matched++
+	case update:
+		// The times left must be in the scheme before the scheme changes
+		if schemeChanged {
+			if _, err := s.standardService.SetTimes(ctx, ownerID, existing.ID, kept); err != nil {
+				return fmt.Errorf("failed to set standard times: %w", err)
+			}
+		}
+		if metadataChanged {
+			if _, err := s.standardService.Update(ctx, ownerID, existing.ID, standard.Input{
+				Name:             existing.Name,
+				Description:      description,
+				CourseType:       existing.CourseType,
+				Gender:           existing.Gender,
+				AgeGroupSchemeID: &scheme.ID,
+				QualifyingRules:  parsed.QualifyingRules,
+			}); err != nil {
+				return fmt.Errorf("failed to update standard: %w", err)
+			}
+		}
+		if changes > 0 {
+			merged := make([]standard.StandardTimeInput, 0, len(times))
+			for _, t := range times {
+				merged = append(merged, t)
+			}
+			if _, err := s.standardService.SetTimes(ctx, ownerID, existing.ID, merged); err != nil {
+				return fmt.Errorf("failed to set standard times: %w", err)
+			}
+		}
+		result.Merge.Standards.Updated++
+	default:
+		result.Merge.Standards.Conflicting++
+		result.Merge.Conflicts = append(result.Merge.Conflicts, conflict("Standard "+existing.Name, changed))
+	}
+	return nil
+}
+
+// sameSplits reports whether two sets of splits have the same distances and times.
+func sameSplits(a, b []timeservice.Split) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Distance != b[i].Distance || a[i].TimeMS != b[i].TimeMS {
+			return false
+		}
+	}
+	return true
+}
+
+// conflict describes a record that differs from the existing one.
+func conflict(record string, changed []string) string {
+	return fmt.Sprintf("%s: %s differ(s) from the existing record", record, strings.Join(changed, ", "))
+}
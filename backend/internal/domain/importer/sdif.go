@@ -0,0 +1,306 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+)
+
+// SDIF (Standard Data Interchange Format, v3) record positions. SDIF files
+// (.cl2/.sd3) hold fixed-width 160 character records; positions are 1-based
+// and inclusive as in the specification.
+type sdifField struct {
+	start, end int
+}
+
+// B1 (meet) record fields.
+var (
+	sdifMeetName      = sdifField{12, 41}
+	sdifMeetCity      = sdifField{86, 105}
+	sdifMeetCountry   = sdifField{118, 120}
+	sdifMeetStartDate = sdifField{122, 129}
+	sdifMeetEndDate   = sdifField{130, 137}
+	sdifMeetCourse    = sdifField{150, 150}
+)
+
+// D0 (individual event) record fields.
+var (
+	sdifSwimmerName   = sdifField{12, 39}
+	sdifBirthDate     = sdifField{56, 63}
+	sdifEventDistance = sdifField{68, 71}
+	sdifEventStroke   = sdifField{72, 72}
+	sdifSwimDate      = sdifField{81, 88}
+	sdifPrelimTime    = sdifField{98, 105}
+	sdifPrelimCourse  = sdifField{106, 106}
+	sdifSwimOffTime   = sdifField{107, 114}
+	sdifSwimOffCourse = sdifField{115, 115}
+	sdifFinalsTime    = sdifField{116, 123}
+	sdifFinalsCourse  = sdifField{124, 124}
+)
+
+const (
+	sdifRecordMeet    = "B1"
+	sdifRecordEvent   = "D0"
+	sdifDateLayout    = "01022006"
+	sdifCourseDisqual = "X" // course code marking a disqualified swim
+)
+
+// sdifStrokes maps SDIF stroke codes to event code suffixes.
+// Relay strokes (6, 7) are not imported.
+var sdifStrokes = map[string]string{
+	"1": "FR",
+	"2": "BK",
+	"3": "BR",
+	"4": "FL",
+	"5": "IM",
+}
+
+// sdifStatuses maps SDIF non-numeric time codes to result statuses.
+var sdifStatuses = map[string]domain.ResultStatus{
+	"DQ":  domain.ResultDisqualified,
+	"NS":  domain.ResultDidNotStart,
+	"DNF": domain.ResultDidNotFinish,
+	"SCR": domain.ResultScratched,
+}
+
+// sdifCourses maps SDIF course codes to course types.
+var sdifCourses = map[string]string{
+	"1": "25m",
+	"S": "25m",
+	"2": "25y",
+	"Y": "25y",
+	"3": "50m",
+	"L": "50m",
+}
+
+// get returns the trimmed value of the field, or "" if the record is too short.
+func (f sdifField) get(record string) string {
+	if len(record) < f.start {
+		return ""
+	}
+	end := f.end
+	if len(record) < end {
+		end = len(record)
+	}
+	return strings.TrimSpace(record[f.start-1 : end])
+}
+
+// ParseSDIF extracts the athlete's individual results from an SDIF results
+// file. Each B1 record starts a meet and the following D0 records hold the
+// results of that meet. When a swim has several times (prelims, swim-off,
+// finals) the fastest one is kept. Results that cannot be imported are
+// skipped and reported as warnings.
+func ParseSDIF(raw []byte, athlete Athlete) ([]ParsedMeet, []string, error) {
+	var meets []ParsedMeet
+	var warnings []string
+	var current *ParsedMeet
+	found := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		record := strings.TrimRight(scanner.Text(), "\r\n")
+		if len(record) < 2 {
+			continue
+		}
+
+		switch record[:2] {
+		case sdifRecordMeet:
+			parsed, err := parseSDIFMeet(record)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			meets = append(meets, *parsed)
+			current = &meets[len(meets)-1]
+
+		case sdifRecordEvent:
+			if !athlete.matchesName(sdifSwimmerName.get(record)) {
+				continue
+			}
+			if birthDate := sdifBirthDate.get(record); birthDate != "" && formatSDIFDate(birthDate) != athlete.BirthDate {
+				continue
+			}
+			found = true
+
+			if current == nil {
+				return nil, nil, fmt.Errorf("line %d: individual event before meet record", lineNo)
+			}
+
+			parsedTime, err := parseSDIFResult(record, current)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("Meet %s: %v", current.Name, err))
+				continue
+			}
+			current.Times = append(current.Times, *parsedTime)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read SDIF file: %w", err)
+	}
+
+	if len(meets) == 0 {
+		return nil, nil, fmt.Errorf("SDIF file contains no meet record")
+	}
+	if !found {
+		return nil, nil, fmt.Errorf("swimmer %s (born %s) not found in SDIF file", athlete.Name, athlete.BirthDate)
+	}
+
+	result := make([]ParsedMeet, 0, len(meets))
+	for _, m := range meets {
+		if len(m.Times) == 0 {
+			continue
+		}
+		if m.CourseType == "" {
+			warnings = append(warnings, fmt.Sprintf("Meet %s skipped: unsupported course", m.Name))
+			continue
+		}
+		result = append(result, m)
+	}
+
+	return result, warnings, nil
+}
+
+// parseSDIFMeet parses a B1 meet record.
+func parseSDIFMeet(record string) (*ParsedMeet, error) {
+	name := sdifMeetName.get(record)
+	if name == "" {
+		return nil, fmt.Errorf("meet name is required")
+	}
+
+	startDate, err := time.Parse(sdifDateLayout, sdifMeetStartDate.get(record))
+	if err != nil {
+		return nil, fmt.Errorf("invalid meet start date: %q", sdifMeetStartDate.get(record))
+	}
+
+	endDate := startDate
+	if s := sdifMeetEndDate.get(record); s != "" {
+		endDate, err = time.Parse(sdifDateLayout, s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid meet end date: %q", s)
+		}
+	}
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("end date cannot be before start date")
+	}
+
+	return &ParsedMeet{
+		Name:       name,
+		City:       sdifMeetCity.get(record),
+		Country:    sdifMeetCountry.get(record),
+		StartDate:  startDate,
+		EndDate:    endDate,
+		CourseType: sdifCourses[sdifMeetCourse.get(record)],
+	}, nil
+}
+
+// parseSDIFResult parses a D0 individual event record into the fastest valid
+// time of the swim. A swim without any valid time is recorded with the status
+// of its last round (DQ, DNS, DNF or scratch) when there is one.
+func parseSDIFResult(record string, m *ParsedMeet) (*ParsedTime, error) {
+	suffix, ok := sdifStrokes[sdifEventStroke.get(record)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported stroke code %q skipped", sdifEventStroke.get(record))
+	}
+
+	distance, err := strconv.Atoi(sdifEventDistance.get(record))
+	if err != nil {
+		return nil, fmt.Errorf("invalid event distance %q skipped", sdifEventDistance.get(record))
+	}
+
+	event := domain.EventCode(strconv.Itoa(distance) + suffix)
+	if !event.IsValid() {
+		return nil, fmt.Errorf("unsupported event %s skipped", event)
+	}
+
+	eventDate := m.StartDate
+	if s := sdifSwimDate.get(record); s != "" {
+		eventDate, err = time.Parse(sdifDateLayout, s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid swim date %q skipped", event, s)
+		}
+	}
+	if eventDate.Before(m.StartDate) || eventDate.After(m.EndDate) {
+		return nil, fmt.Errorf("%s: swim date %s outside meet dates skipped", event, eventDate.Format("2006-01-02"))
+	}
+
+	swims := []struct {
+		round  string
+		time   sdifField
+		course sdifField
+	}{
+		{"Prelims", sdifPrelimTime, sdifPrelimCourse},
+		{"Swim-off", sdifSwimOffTime, sdifSwimOffCourse},
+		{"Finals", sdifFinalsTime, sdifFinalsCourse},
+	}
+
+	var best *ParsedTime
+	var status domain.ResultStatus
+	var statusRound string
+	for _, swim := range swims {
+		course := swim.course.get(record)
+		if course == sdifCourseDisqual {
+			status, statusRound = domain.ResultDisqualified, swim.round
+			continue
+		}
+		if s, ok := sdifStatuses[strings.ToUpper(swim.time.get(record))]; ok {
+			status, statusRound = s, swim.round
+			continue
+		}
+		if course != "" {
+			courseType, ok := sdifCourses[course]
+			if !ok {
+				continue
+			}
+			if m.CourseType == "" {
+				m.CourseType = courseType
+			}
+			if courseType != m.CourseType {
+				continue
+			}
+		}
+
+		// Other non-numeric times (NT) fail to parse and are ignored
+		timeMS, err := domain.ParseTime(swim.time.get(record))
+		if err != nil || timeMS <= 0 {
+			continue
+		}
+
+		if best == nil || int32(timeMS) < best.TimeMS {
+			best = &ParsedTime{
+				Event:     event.String(),
+				TimeMS:    int32(timeMS),
+				EventDate: eventDate,
+				Notes:     swim.round,
+			}
+		}
+	}
+
+	if best == nil && status != "" {
+		return &ParsedTime{
+			Event:     event.String(),
+			EventDate: eventDate,
+			Notes:     statusRound,
+			Status:    status.String(),
+		}, nil
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%s: no valid time skipped", event)
+	}
+
+	return best, nil
+}
+
+// formatSDIFDate converts an SDIF MMDDYYYY date to YYYY-MM-DD, or returns "" if it is invalid.
+func formatSDIFDate(s string) string {
+	d, err := time.Parse(sdifDateLayout, s)
+	if err != nil {
+		return ""
+	}
+	return d.Format("2006-01-02")
+}
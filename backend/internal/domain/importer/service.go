@@ -2,17 +2,22 @@ package importer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
 	"github.com/bpg/swimstats/backend/internal/domain/meet"
 	"github.com/bpg/swimstats/backend/internal/domain/standard"
 	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
 	timeservice "github.com/bpg/swimstats/backend/internal/domain/time"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
 )
 
 // Service handles importing swimmer data from JSON files.
@@ -21,6 +26,8 @@ type Service struct {
 	meetService     *meet.Service
 	timeService     *timeservice.Service
 	standardService *standard.Service
+	schemes         *agegroup.Service
+	txs             postgres.TxBeginner
 }
 
 // NewService creates a new importer service.
@@ -29,116 +36,324 @@ func NewService(
 	meetService *meet.Service,
 	timeService *timeservice.Service,
 	standardService *standard.Service,
+	schemes *agegroup.Service,
+	txs postgres.TxBeginner,
 ) *Service {
 	return &Service{
 		swimmerService:  swimmerService,
 		meetService:     meetService,
 		timeService:     timeService,
 		standardService: standardService,
+		schemes:         schemes,
+		txs:             txs,
+	}
+}
+
+// WithTx returns a service that imports in the transaction.
+func (s *Service) WithTx(tx pgx.Tx) *Service {
+	return &Service{
+		swimmerService:  s.swimmerService.WithTx(tx),
+		meetService:     s.meetService.WithTx(tx),
+		timeService:     s.timeService.WithTx(tx),
+		standardService: s.standardService.WithTx(tx),
+		schemes:         s.schemes.WithTx(tx),
+		txs:             tx,
 	}
 }
 
 // ImportSwimmerData imports a complete swimmer dataset from parsed JSON.
-// Sections are optional and will REPLACE existing data if present.
-func (s *Service) ImportSwimmerData(ctx context.Context, data *ImportData) (*ImportResult, error) {
+// Sections are optional and, in replace mode, will REPLACE existing data if present.
+// In append mode nothing is deleted and duplicate events are skipped. In merge
+// mode nothing is deleted either, and existing records that differ from the
+// imported ones are updated if opts.Update is set or reported as conflicts.
+// The data is imported into the given swimmer of the owner; a nil swimmerID
+// targets the owner's default swimmer, creating it from the swimmer section if needed.
+// All sections are validated before anything is changed and the import runs
+// in a single transaction, so a failure leaves the existing data untouched.
+// A dry run performs the whole import and rolls it back, returning the result
+// the import would have.
+func (s *Service) ImportSwimmerData(ctx context.Context, ownerID string, swimmerID *uuid.UUID, data *ImportData, opts ImportOptions) (*ImportResult, error) {
 	result := &ImportResult{
 		Success: false,
+		DryRun:  opts.DryRun,
 		Errors:  []string{},
 	}
 
-	var swimmerID string
+	mode := opts.Mode
+	if mode == "" {
+		mode = ModeReplace
+	}
+	if !mode.IsValid() {
+		result.Errors = append(result.Errors, fmt.Sprintf("Invalid import mode: %s", mode))
+		return result, fmt.Errorf("invalid import mode: %s", mode)
+	}
+
+	// 1. Validate all sections before changing anything
+	parsed, errs := s.parseImportData(data)
+	if len(errs) > 0 {
+		result.Errors = errs
+		return result, fmt.Errorf("validation: %d invalid section(s) in import data", len(errs))
+	}
+
+	// 2. Import everything or nothing
+	var imported *ImportResult
+	err := postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		imported = &ImportResult{
+			DryRun: opts.DryRun,
+			Errors: []string{},
+		}
+		if mode == ModeMerge {
+			imported.Merge = &MergeResult{}
+		}
+		if err := s.WithTx(tx).importParsed(ctx, ownerID, swimmerID, parsed, opts.Update, mode, imported); err != nil {
+			return err
+		}
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		result.Errors = append(result.Errors, err.Error())
+		return result, err
+	}
+
+	imported.Success = true
+	return imported, nil
+}
+
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+// parsedImportData is validated import data ready for database insertion.
+type parsedImportData struct {
+	swimmer   *ParsedSwimmer
+	meets     []ParsedMeet
+	standards []ParsedStandard
+}
+
+// parseImportData validates every section of the import data, returning the
+// errors of all invalid ones.
+func (s *Service) parseImportData(data *ImportData) (*parsedImportData, []string) {
+	parsed := &parsedImportData{}
+	var errs []string
 
-	// 1. Replace swimmer if present in import data
 	if data.Swimmer != nil {
 		parsedSwimmer, err := s.parseSwimmer(data.Swimmer)
 		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Swimmer validation failed: %v", err))
-			return result, err
+			errs = append(errs, fmt.Sprintf("Swimmer validation failed: %v", err))
 		}
+		parsed.swimmer = parsedSwimmer
+	}
 
-		swimmerID, err = s.createOrUpdateSwimmer(ctx, parsedSwimmer)
+	for i, meetData := range data.Meets {
+		parsedMeet, err := s.parseMeet(&meetData)
 		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Failed to create/update swimmer: %v", err))
-			return result, err
+			errs = append(errs, fmt.Sprintf("Meet %d (%s) validation failed: %v", i+1, meetData.Name, err))
+			continue
 		}
+		parsed.meets = append(parsed.meets, *parsedMeet)
+	}
+
+	for i, standardData := range data.Standards {
+		parsedStandard, err := s.parseStandard(&standardData)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Standard %d (%s) validation failed: %v", i+1, standardData.Name, err))
+			continue
+		}
+		parsed.standards = append(parsed.standards, *parsedStandard)
+	}
 
-		result.SwimmerID = swimmerID
-		result.SwimmerName = parsedSwimmer.Name
+	return parsed, errs
+}
+
+// importParsed imports validated data, stopping at the first failure.
+func (s *Service) importParsed(ctx context.Context, ownerID string, swimmerID *uuid.UUID, parsed *parsedImportData, update bool, mode Mode, result *ImportResult) error {
+	if mode == ModeMerge {
+		return s.mergeParsed(ctx, ownerID, swimmerID, parsed, update, result)
+	}
+
+	var targetID uuid.UUID
+
+	// 1. Replace swimmer if present in import data
+	if parsed.swimmer != nil {
+		var err error
+		targetID, err = s.createOrUpdateSwimmer(ctx, ownerID, swimmerID, parsed.swimmer)
+		if err != nil {
+			return err
+		}
+
+		result.SwimmerID = targetID.String()
+		result.SwimmerName = parsed.swimmer.Name
 		result.SwimmerReplaced = true
-	} else {
+	} else if len(parsed.meets) > 0 {
 		// Get existing swimmer ID for meets/times import
-		swimmerData, err := s.swimmerService.Get(ctx)
+		swimmerData, err := s.targetSwimmer(ctx, ownerID, swimmerID)
 		if err != nil {
-			result.Errors = append(result.Errors, "No swimmer profile exists. Import must include swimmer section.")
-			return result, fmt.Errorf("no swimmer profile found")
+			return fmt.Errorf("no swimmer profile exists, import must include swimmer section")
 		}
-		swimmerID = swimmerData.ID.String()
+		targetID = swimmerData.ID
 	}
 
 	// 2. Replace meets if present in import data
-	if len(data.Meets) > 0 {
-		// Delete ALL existing meets (cascades to delete times)
-		meetsDeleted, err := s.deleteAllMeets(ctx)
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Failed to delete existing meets: %v", err))
-			return result, err
+	if len(parsed.meets) > 0 {
+		if mode == ModeReplace {
+			// Delete the swimmer's times and any meets left without times
+			meetsDeleted, err := s.deleteAllMeets(ctx, ownerID, targetID)
+			if err != nil {
+				return err
+			}
+			result.MeetsDeleted = meetsDeleted
 		}
-		result.MeetsDeleted = meetsDeleted
 
 		// Import new meets with their times
-		for i, meetData := range data.Meets {
-			parsedMeet, err := s.parseMeet(&meetData)
-			if err != nil {
-				result.Errors = append(result.Errors, fmt.Sprintf("Meet %d (%s) validation failed: %v", i+1, meetData.Name, err))
-				continue
-			}
+		for i := range parsed.meets {
+			parsedMeet := &parsed.meets[i]
 
-			meetID, timesCreated, skipped, err := s.importMeet(ctx, swimmerID, parsedMeet)
+			meetID, created, timesCreated, skipped, err := s.importMeet(ctx, ownerID, targetID, parsedMeet)
 			if err != nil {
-				result.Errors = append(result.Errors, fmt.Sprintf("Failed to import meet %s: %v", meetData.Name, err))
-				continue
+				return fmt.Errorf("failed to import meet %s: %w", parsedMeet.Name, err)
 			}
 
-			result.MeetsCreated++
+			if created {
+				result.MeetsCreated++
+			}
 			result.TimesCreated += timesCreated
 			result.SkippedTimes += skipped
 
 			if skipped > 0 {
 				result.SkippedReason = append(result.SkippedReason,
-					fmt.Sprintf("Meet %s (ID: %s): %d duplicate event(s) skipped", meetData.Name, meetID, skipped))
+					fmt.Sprintf("Meet %s (ID: %s): %d duplicate event(s) skipped", parsedMeet.Name, meetID, skipped))
 			}
 		}
 	}
 
-	// 3. Replace custom standards if present in import data
-	if len(data.Standards) > 0 {
-		// Delete all custom standards (exclude preloaded)
-		standardsDeleted, err := s.deleteAllCustomStandards(ctx)
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Failed to delete existing standards: %v", err))
-			return result, err
+	// 3. Replace the owner's custom standards if present in import data
+	if len(parsed.standards) > 0 {
+		if mode == ModeReplace {
+			// Delete the owner's custom standards (exclude preloaded and shared)
+			standardsDeleted, err := s.deleteAllCustomStandards(ctx, ownerID)
+			if err != nil {
+				return err
+			}
+			result.StandardsDeleted = standardsDeleted
 		}
-		result.StandardsDeleted = standardsDeleted
 
 		// Import new standards
-		for i, standardData := range data.Standards {
-			parsedStandard, err := s.parseStandard(&standardData)
-			if err != nil {
-				result.Errors = append(result.Errors, fmt.Sprintf("Standard %d (%s) validation failed: %v", i+1, standardData.Name, err))
-				continue
+		for i := range parsed.standards {
+			parsedStandard := &parsed.standards[i]
+			if err := s.importStandard(ctx, ownerID, parsedStandard); err != nil {
+				return fmt.Errorf("failed to import standard %s: %w", parsedStandard.Name, err)
 			}
+			result.StandardsCreated++
+		}
+	}
 
-			err = s.importStandard(ctx, parsedStandard)
+	return nil
+}
+
+// mergeParsed merges validated data into the existing data, stopping at the
+// first failure.
+func (s *Service) mergeParsed(ctx context.Context, ownerID string, swimmerID *uuid.UUID, parsed *parsedImportData, update bool, result *ImportResult) error {
+	current, err := s.targetSwimmer(ctx, ownerID, swimmerID)
+	switch {
+	case err == nil && parsed.swimmer != nil:
+		if err := s.mergeSwimmer(ctx, ownerID, current, parsed.swimmer, update, result); err != nil {
+			return err
+		}
+	case err == nil:
+		result.SwimmerID = current.ID.String()
+		result.SwimmerName = current.Name
+	case parsed.swimmer != nil && swimmerID == nil:
+		// Create the owner's default swimmer
+		createdID, err := s.createOrUpdateSwimmer(ctx, ownerID, nil, parsed.swimmer)
+		if err != nil {
+			return err
+		}
+		current = &swimmer.Swimmer{ID: createdID}
+		result.SwimmerID = createdID.String()
+		result.SwimmerName = parsed.swimmer.Name
+		result.SwimmerReplaced = true
+	case len(parsed.meets) == 0:
+		// Standards only
+	default:
+		return fmt.Errorf("no swimmer profile exists, import must include swimmer section")
+	}
+
+	if len(parsed.meets) > 0 {
+		if err := s.mergeMeets(ctx, ownerID, current.ID, parsed.meets, update, result); err != nil {
+			return err
+		}
+	}
+
+	if len(parsed.standards) > 0 {
+		if err := s.mergeStandards(ctx, ownerID, parsed.standards, update, result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportSDIF imports the swimmer's individual results from an SDIF (.cl2/.sd3)
+// results file. The swimmer is matched by name and birth date. Nothing is
+// deleted: meets are found or created and events the swimmer already has
+// in a meet are skipped. The import runs in a single transaction and any
+// failing meet fails it as a whole, leaving the existing data untouched.
+func (s *Service) ImportSDIF(ctx context.Context, ownerID string, swimmerID *uuid.UUID, raw []byte) (*ImportResult, error) {
+	swimmerData, err := s.targetSwimmer(ctx, ownerID, swimmerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get swimmer: %w", err)
+	}
+
+	meets, warnings, err := ParseSDIF(raw, Athlete{
+		Name:      swimmerData.Name,
+		BirthDate: swimmerData.BirthDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+
+	var result *ImportResult
+	err = postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		result = &ImportResult{
+			SwimmerID:   swimmerData.ID.String(),
+			SwimmerName: swimmerData.Name,
+			Errors:      []string{},
+			Warnings:    warnings,
+		}
+		txService := s.WithTx(tx)
+
+		for i := range meets {
+			parsedMeet := &meets[i]
+
+			meetID, created, timesCreated, skipped, err := txService.importMeet(ctx, ownerID, swimmerData.ID, parsedMeet)
+			if isValidationError(err) {
+				// Results the file should not have, e.g. an event the course does not swim
+				return fmt.Errorf("validation: failed to import meet %s: %w", parsedMeet.Name, err)
+			}
 			if err != nil {
-				result.Errors = append(result.Errors, fmt.Sprintf("Failed to import standard %s: %v", standardData.Name, err))
-				continue
+				return fmt.Errorf("failed to import meet %s: %w", parsedMeet.Name, err)
 			}
 
-			result.StandardsCreated++
+			if created {
+				result.MeetsCreated++
+			}
+			result.TimesCreated += timesCreated
+			result.SkippedTimes += skipped
+
+			if skipped > 0 {
+				result.SkippedReason = append(result.SkippedReason,
+					fmt.Sprintf("Meet %s (ID: %s): %d duplicate event(s) skipped", parsedMeet.Name, meetID, skipped))
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	result.Success = len(result.Errors) == 0 || result.MeetsCreated > 0 || result.StandardsCreated > 0 || result.SwimmerReplaced
+	result.Success = true
 	return result, nil
 }
 
@@ -169,11 +384,18 @@ func (s *Service) parseSwimmer(data *SwimmerData) (*ParsedSwimmer, error) {
 		}
 	}
 
+	if data.SeasonStart != nil {
+		if _, err := domain.ParseSeasonStart(*data.SeasonStart); err != nil {
+			return nil, err
+		}
+	}
+
 	return &ParsedSwimmer{
 		Name:             name,
 		BirthDate:        birthDate,
 		Gender:           gender,
 		ThresholdPercent: data.ThresholdPercent,
+		SeasonStart:      data.SeasonStart,
 	}, nil
 }
 
@@ -191,8 +413,8 @@ func (s *Service) parseMeet(data *MeetData) (*ParsedMeet, error) {
 		return nil, fmt.Errorf("meet name is required")
 	}
 
-	if courseType != "25m" && courseType != "50m" {
-		return nil, fmt.Errorf("course_type must be '25m' or '50m', got: %s", courseType)
+	if !domain.CourseType(courseType).IsValid() {
+		return nil, fmt.Errorf("course_type must be '25m', '50m' or '25y', got: %s", courseType)
 	}
 
 	startDate, err := time.Parse("2006-01-02", startDateStr)
@@ -216,6 +438,9 @@ func (s *Service) parseMeet(data *MeetData) (*ParsedMeet, error) {
 		if err != nil {
 			return nil, fmt.Errorf("time %d validation failed: %v", i+1, err)
 		}
+		if !domain.EventCode(parsedTime.Event).IsValidForCourse(domain.CourseType(courseType)) {
+			return nil, fmt.Errorf("time %d validation failed: event %s is not swum in a %s pool", i+1, parsedTime.Event, courseType)
+		}
 		parsedTimes = append(parsedTimes, *parsedTime)
 	}
 
@@ -226,6 +451,7 @@ func (s *Service) parseMeet(data *MeetData) (*ParsedMeet, error) {
 		StartDate:  startDate,
 		EndDate:    endDate,
 		CourseType: courseType,
+		Sanctioned: data.Sanctioned,
 		Times:      parsedTimes,
 	}, nil
 }
@@ -237,28 +463,34 @@ func (s *Service) parseTime(data *TimeData, meetStart, meetEnd time.Time) (*Pars
 	timeStr := strings.TrimSpace(data.Time)
 	eventDateStr := strings.TrimSpace(data.EventDate)
 	notes := strings.TrimSpace(data.Notes)
+	status := strings.ToLower(strings.TrimSpace(data.Status))
+	dqCode := strings.TrimSpace(data.DQCode)
+	dqReason := strings.TrimSpace(data.DQReason)
+	relayStroke := strings.ToUpper(strings.TrimSpace(data.RelayStroke))
+	if status == "" {
+		status = domain.ResultOK.String()
+	}
 
 	if event == "" {
 		return nil, fmt.Errorf("event is required")
 	}
 
-	// Validate event code (basic validation - list of valid events)
-	validEvents := map[string]bool{
-		"50FR": true, "100FR": true, "200FR": true, "400FR": true, "800FR": true, "1500FR": true,
-		"50BK": true, "100BK": true, "200BK": true,
-		"50BR": true, "100BR": true, "200BR": true,
-		"50FL": true, "100FL": true, "200FL": true,
-		"200IM": true, "400IM": true,
-	}
-
-	if !validEvents[event] {
+	// Validate event code, individual or relay
+	if code := domain.EventCode(event); !code.IsValid() && !code.IsRelay() {
 		return nil, fmt.Errorf("invalid event code: %s", event)
 	}
 
-	// Parse time string to milliseconds
-	timeMS, err := parseTimeToMS(timeStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid time format: %v", err)
+	// Parse time string to milliseconds; results other than official swims may have no time
+	var timeMS int
+	if timeStr != "" || domain.ResultStatus(status).IsOfficial() {
+		var err error
+		timeMS, err = parseTimeToMS(timeStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time format: %v", err)
+		}
+	}
+	if err := timeservice.ValidateStatus(status, timeMS, dqCode, dqReason); err != nil {
+		return nil, err
 	}
 
 	// Parse and validate event date
@@ -275,11 +507,33 @@ func (s *Service) parseTime(data *TimeData, meetStart, meetEnd time.Time) (*Pars
 			eventDateStr, meetStart.Format("2006-01-02"), meetEnd.Format("2006-01-02"))
 	}
 
+	if err := timeservice.ValidateRelay(event, data.RelayLeg, relayStroke); err != nil {
+		return nil, err
+	}
+
+	splits := make([]timeservice.Split, 0, len(data.Splits))
+	for _, split := range data.Splits {
+		splitMS, err := parseTimeToMS(strings.TrimSpace(split.Time))
+		if err != nil {
+			return nil, fmt.Errorf("invalid split time at %dm: %v", split.Distance, err)
+		}
+		splits = append(splits, timeservice.Split{Distance: split.Distance, TimeMS: splitMS})
+	}
+	if err := timeservice.ValidateSplits(event, timeMS, splits); err != nil {
+		return nil, err
+	}
+
 	return &ParsedTime{
-		Event:     event,
-		TimeMS:    int32(timeMS),
-		EventDate: eventDate,
-		Notes:     notes,
+		Event:       event,
+		TimeMS:      int32(timeMS),
+		EventDate:   eventDate,
+		Notes:       notes,
+		Status:      status,
+		DQCode:      dqCode,
+		DQReason:    dqReason,
+		RelayLeg:    data.RelayLeg,
+		RelayStroke: relayStroke,
+		Splits:      splits,
 	}, nil
 }
 
@@ -324,78 +578,172 @@ func parseTimeToMS(timeStr string) (int, error) {
 	return milliseconds, nil
 }
 
-// createOrUpdateSwimmer creates a new swimmer or updates existing one.
-func (s *Service) createOrUpdateSwimmer(ctx context.Context, parsed *ParsedSwimmer) (string, error) {
+// targetSwimmer returns the swimmer an import applies to.
+func (s *Service) targetSwimmer(ctx context.Context, ownerID string, swimmerID *uuid.UUID) (*swimmer.Swimmer, error) {
+	if swimmerID != nil {
+		return s.swimmerService.GetForOwner(ctx, ownerID, *swimmerID)
+	}
+	return s.swimmerService.Get(ctx, ownerID)
+}
+
+// createOrUpdateSwimmer updates the target swimmer, or creates or updates the owner's default swimmer.
+func (s *Service) createOrUpdateSwimmer(ctx context.Context, ownerID string, swimmerID *uuid.UUID, parsed *ParsedSwimmer) (uuid.UUID, error) {
 	input := swimmer.Input{
 		Name:             parsed.Name,
 		BirthDate:        parsed.BirthDate.Format("2006-01-02"),
 		Gender:           parsed.Gender,
 		ThresholdPercent: parsed.ThresholdPercent,
+		SeasonStart:      parsed.SeasonStart,
 	}
 
-	created, _, err := s.swimmerService.CreateOrUpdate(ctx, input)
+	if swimmerID != nil {
+		updated, err := s.swimmerService.Update(ctx, ownerID, *swimmerID, input)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to update swimmer: %w", err)
+		}
+		return updated.ID, nil
+	}
+
+	created, _, err := s.swimmerService.CreateOrUpdate(ctx, ownerID, input)
 	if err != nil {
-		return "", fmt.Errorf("failed to create/update swimmer: %w", err)
+		return uuid.Nil, fmt.Errorf("failed to create/update swimmer: %w", err)
 	}
 
-	return created.ID.String(), nil
+	return created.ID, nil
 }
 
-// importMeet creates a meet and its associated times.
-// Returns: meetID, timesCreated, timesSkipped, error
-func (s *Service) importMeet(ctx context.Context, swimmerID string, parsed *ParsedMeet) (string, int, int, error) {
-	// Create meet
-	meetInput := meet.Input{
+// importMeet finds or creates a meet and records the swimmer's times in it.
+// Meets are shared between an owner's swimmers, so an existing meet with the
+// same name, start date and course type is reused.
+// Returns: meetID, meetCreated, timesCreated, timesSkipped, error
+func (s *Service) importMeet(ctx context.Context, ownerID string, swimmerID uuid.UUID, parsed *ParsedMeet) (string, bool, int, int, error) {
+	// Find or create meet
+	importedMeet, created, err := s.meetService.FindOrCreate(ctx, ownerID, meetInput(parsed))
+	if err != nil {
+		return "", false, 0, 0, fmt.Errorf("failed to create meet: %w", err)
+	}
+
+	// Import times
+	timesCreated := 0
+	timesSkipped := 0
+
+	for i := range parsed.Times {
+		timeData := &parsed.Times[i]
+		_, err := s.timeService.Create(ctx, ownerID, swimmerID, timeInput(importedMeet.ID, timeData))
+		if err != nil {
+			// Check if it's a duplicate event error
+			if errors.Is(err, postgres.ErrDuplicateEvent) {
+				timesSkipped++
+				continue
+			}
+			return importedMeet.ID.String(), created, timesCreated, timesSkipped, fmt.Errorf("failed to create time for event %s: %w", timeData.Event, err)
+		}
+
+		timesCreated++
+	}
+
+	return importedMeet.ID.String(), created, timesCreated, timesSkipped, nil
+}
+
+// isValidationError reports whether err or an error it wraps is a validation
+// error of the domain services, whose messages start with "validation".
+func isValidationError(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if strings.HasPrefix(err.Error(), "validation") {
+			return true
+		}
+	}
+	return false
+}
+
+// meetInput converts a parsed meet into meet service input.
+func meetInput(parsed *ParsedMeet) meet.Input {
+	return meet.Input{
 		Name:       parsed.Name,
 		City:       parsed.City,
 		Country:    parsed.Country,
 		StartDate:  parsed.StartDate.Format("2006-01-02"),
 		EndDate:    parsed.EndDate.Format("2006-01-02"),
 		CourseType: parsed.CourseType,
+		Sanctioned: parsed.Sanctioned,
+	}
+}
+
+// timeInput converts a parsed time into time service input for a meet.
+func timeInput(meetID uuid.UUID, parsed *ParsedTime) timeservice.Input {
+	return timeservice.Input{
+		MeetID:      meetID,
+		Event:       parsed.Event,
+		TimeMS:      int(parsed.TimeMS),
+		EventDate:   parsed.EventDate.Format("2006-01-02"),
+		Notes:       parsed.Notes,
+		Status:      parsed.Status,
+		DQCode:      parsed.DQCode,
+		DQReason:    parsed.DQReason,
+		RelayLeg:    parsed.RelayLeg,
+		RelayStroke: parsed.RelayStroke,
+		Splits:      parsed.Splits,
 	}
+}
 
-	createdMeet, err := s.meetService.Create(ctx, meetInput)
+// PreviewLenex converts a Lenex results file into import data for the target
+// swimmer and previews appending it. The converted data is returned with the
+// preview so it can be confirmed through the regular import.
+func (s *Service) PreviewLenex(ctx context.Context, ownerID string, swimmerID *uuid.UUID, raw []byte) (*PreviewResult, error) {
+	swimmerData, err := s.targetSwimmer(ctx, ownerID, swimmerID)
 	if err != nil {
-		return "", 0, 0, fmt.Errorf("failed to create meet: %w", err)
+		return nil, fmt.Errorf("failed to get swimmer: %w", err)
 	}
 
-	// Import times
-	timesCreated := 0
-	timesSkipped := 0
+	data, warnings, err := ParseLenex(raw, Athlete{
+		Name:      swimmerData.Name,
+		BirthDate: swimmerData.BirthDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
 
-	swimmerUUID, err := uuid.Parse(swimmerID)
+	preview, err := s.Preview(ctx, ownerID, swimmerID, data, ModeAppend)
 	if err != nil {
-		return createdMeet.ID.String(), 0, 0, fmt.Errorf("invalid swimmer ID: %w", err)
+		return nil, err
 	}
+	preview.Data = data
+	preview.Warnings = warnings
 
-	for _, timeData := range parsed.Times {
-		timeInput := timeservice.Input{
-			MeetID:    createdMeet.ID,
-			Event:     timeData.Event,
-			TimeMS:    int(timeData.TimeMS),
-			EventDate: timeData.EventDate.Format("2006-01-02"),
-			Notes:     timeData.Notes,
-		}
+	return preview, nil
+}
 
-		_, err := s.timeService.Create(ctx, swimmerUUID, timeInput)
-		if err != nil {
-			// Check if it's a duplicate event error
-			if strings.Contains(err.Error(), "DUPLICATE_EVENT") {
-				timesSkipped++
-				continue
-			}
-			return createdMeet.ID.String(), timesCreated, timesSkipped, fmt.Errorf("failed to create time for event %s: %w", timeData.Event, err)
-		}
+// PreviewCSV converts a CSV file of meets and times into import data and
+// previews appending it. Rows that cannot be imported are left out of the
+// data and reported as row errors; the converted data is returned with the
+// preview so it can be confirmed through the regular import.
+func (s *Service) PreviewCSV(ctx context.Context, ownerID string, swimmerID *uuid.UUID, raw []byte) (*PreviewResult, error) {
+	data, rowErrors, err := s.parseCSV(raw)
+	if err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
 
-		timesCreated++
+	preview, err := s.Preview(ctx, ownerID, swimmerID, data, ModeAppend)
+	if err != nil {
+		return nil, err
 	}
+	preview.Data = data
+	preview.RowErrors = rowErrors
 
-	return createdMeet.ID.String(), timesCreated, timesSkipped, nil
+	return preview, nil
 }
 
 // Preview analyzes the import data and returns what will be deleted/replaced.
-func (s *Service) Preview(ctx context.Context, data *ImportData) (*PreviewResult, error) {
-	preview := &PreviewResult{}
+// In append and merge modes nothing is deleted, so only the new counts are reported.
+func (s *Service) Preview(ctx context.Context, ownerID string, swimmerID *uuid.UUID, data *ImportData, mode Mode) (*PreviewResult, error) {
+	if mode == "" {
+		mode = ModeReplace
+	}
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("validation: invalid import mode: %s", mode)
+	}
+
+	preview := &PreviewResult{Mode: mode}
 
 	// Check if swimmer will be replaced
 	preview.WillReplaceSwimmer = data.Swimmer != nil
@@ -403,36 +751,44 @@ func (s *Service) Preview(ctx context.Context, data *ImportData) (*PreviewResult
 	// Count existing meets and times if meets section is present
 	if len(data.Meets) > 0 {
 		// Get swimmer to count their meets/times
-		swimmerData, err := s.swimmerService.Get(ctx)
-		if err != nil {
-			// If no swimmer exists yet, counts are 0
+		swimmerData, err := s.targetSwimmer(ctx, ownerID, swimmerID)
+		if err != nil || mode != ModeReplace {
+			// If no swimmer exists yet or nothing is deleted, counts are 0
 			preview.CurrentMeetsCount = 0
 			preview.CurrentTimesCount = 0
 		} else {
-			// Count existing meets
-			meetList, err := s.meetService.List(ctx, meet.ListParams{
+			// Count existing times of the swimmer
+			timeList, err := s.timeService.List(ctx, timeservice.ListParams{
+				SwimmerID:  swimmerData.ID,
+				MeetID:     nil,
 				CourseType: nil,
+				Event:      nil,
 				Limit:      10000,
 				Offset:     0,
 			})
 			if err != nil {
-				return nil, fmt.Errorf("failed to list meets: %w", err)
+				return nil, fmt.Errorf("failed to list times: %w", err)
 			}
-			preview.CurrentMeetsCount = len(meetList.Meets)
+			preview.CurrentTimesCount = len(timeList.Times)
 
-			// Count existing times across all meets
-			timeList, err := s.timeService.List(ctx, timeservice.ListParams{
-				SwimmerID:  swimmerData.ID,
-				MeetID:     nil,
+			// Count meets that will be left without times once the swimmer's times are removed
+			swimmerTimesPerMeet := make(map[uuid.UUID]int)
+			for _, t := range timeList.Times {
+				swimmerTimesPerMeet[t.MeetID]++
+			}
+			meetList, err := s.meetService.List(ctx, ownerID, meet.ListParams{
 				CourseType: nil,
-				Event:      nil,
 				Limit:      10000,
 				Offset:     0,
 			})
 			if err != nil {
-				return nil, fmt.Errorf("failed to list times: %w", err)
+				return nil, fmt.Errorf("failed to list meets: %w", err)
+			}
+			for _, m := range meetList.Meets {
+				if m.TimeCount == swimmerTimesPerMeet[m.ID] {
+					preview.CurrentMeetsCount++
+				}
 			}
-			preview.CurrentTimesCount = len(timeList.Times)
 		}
 
 		// Count new meets and times from import data
@@ -444,9 +800,9 @@ func (s *Service) Preview(ctx context.Context, data *ImportData) (*PreviewResult
 		preview.NewTimesCount = totalTimes
 	}
 
-	// Count existing custom standards if standards section is present
-	if len(data.Standards) > 0 {
-		standardList, err := s.standardService.List(ctx, standard.ListParams{
+	// Count the owner's custom standards if standards section is present
+	if len(data.Standards) > 0 && mode == ModeReplace {
+		standardList, err := s.standardService.List(ctx, ownerID, standard.ListParams{
 			CourseType: nil,
 			Gender:     nil,
 		})
@@ -454,48 +810,41 @@ func (s *Service) Preview(ctx context.Context, data *ImportData) (*PreviewResult
 			return nil, fmt.Errorf("failed to list standards: %w", err)
 		}
 
-		// Count only custom standards (exclude preloaded)
+		// Count only the owner's custom standards (exclude preloaded and shared)
 		customCount := 0
 		for _, std := range standardList.Standards {
-			if !std.IsPreloaded {
+			if !std.IsPreloaded && std.OwnerID == ownerID {
 				customCount++
 			}
 		}
 		preview.CurrentStandardsCount = customCount
-		preview.NewStandardsCount = len(data.Standards)
 	}
+	preview.NewStandardsCount = len(data.Standards)
 
 	return preview, nil
 }
 
-// deleteAllMeets deletes all meets for the current swimmer.
+// deleteAllMeets deletes all times of the swimmer and the owner's meets left without times.
+// Meets that still hold times of other swimmers are kept.
 // Returns the count of deleted meets.
-func (s *Service) deleteAllMeets(ctx context.Context) (int, error) {
-	meetList, err := s.meetService.List(ctx, meet.ListParams{
-		CourseType: nil,
-		Limit:      10000,
-		Offset:     0,
-	})
-	if err != nil {
-		return 0, fmt.Errorf("failed to list meets: %w", err)
+func (s *Service) deleteAllMeets(ctx context.Context, ownerID string, swimmerID uuid.UUID) (int, error) {
+	if err := s.timeService.DeleteBySwimmer(ctx, swimmerID); err != nil {
+		return 0, fmt.Errorf("failed to delete times: %w", err)
 	}
 
-	deletedCount := 0
-	for _, m := range meetList.Meets {
-		err := s.meetService.Delete(ctx, m.ID)
-		if err != nil {
-			return deletedCount, fmt.Errorf("failed to delete meet %s: %w", m.Name, err)
-		}
-		deletedCount++
+	deletedCount, err := s.meetService.DeleteEmpty(ctx, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete meets: %w", err)
 	}
 
 	return deletedCount, nil
 }
 
-// deleteAllCustomStandards deletes all custom (non-preloaded) standards.
-// Returns the count of deleted standards.
-func (s *Service) deleteAllCustomStandards(ctx context.Context) (int, error) {
-	standardList, err := s.standardService.List(ctx, standard.ListParams{
+// deleteAllCustomStandards deletes the custom (non-preloaded) standards of
+// the owner, or the shared ones for an empty owner. Standards of other owners
+// are never deleted. Returns the count of deleted standards.
+func (s *Service) deleteAllCustomStandards(ctx context.Context, ownerID string) (int, error) {
+	standardList, err := s.standardService.List(ctx, ownerID, standard.ListParams{
 		CourseType: nil,
 		Gender:     nil,
 	})
@@ -505,11 +854,11 @@ func (s *Service) deleteAllCustomStandards(ctx context.Context) (int, error) {
 
 	deletedCount := 0
 	for _, std := range standardList.Standards {
-		if std.IsPreloaded {
-			continue // Skip preloaded standards
+		if std.IsPreloaded || std.OwnerID != ownerID {
+			continue // Skip preloaded and other owners' standards
 		}
 
-		err := s.standardService.Delete(ctx, std.ID)
+		err := s.standardService.Delete(ctx, ownerID, std.ID)
 		if err != nil {
 			return deletedCount, fmt.Errorf("failed to delete standard %s: %w", std.Name, err)
 		}
@@ -525,14 +874,20 @@ func (s *Service) parseStandard(data *StandardData) (*ParsedStandard, error) {
 		return nil, fmt.Errorf("standard name is required")
 	}
 
-	if data.CourseType != "25m" && data.CourseType != "50m" {
-		return nil, fmt.Errorf("course_type must be '25m' or '50m', got: %s", data.CourseType)
+	if !domain.CourseType(data.CourseType).IsValid() {
+		return nil, fmt.Errorf("course_type must be '25m', '50m' or '25y', got: %s", data.CourseType)
 	}
 
 	if data.Gender != "female" && data.Gender != "male" {
 		return nil, fmt.Errorf("gender must be 'female' or 'male', got: %s", data.Gender)
 	}
 
+	rules := data.QualifyingRules
+	rules.Sanitize()
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
 	// Parse times for each event
 	parsedTimes := make(map[string][]ParsedStandardTime)
 	for event, timeStrings := range data.Times {
@@ -559,42 +914,63 @@ func (s *Service) parseStandard(data *StandardData) (*ParsedStandard, error) {
 	}
 
 	return &ParsedStandard{
-		Name:        data.Name,
-		Description: data.Description,
-		CourseType:  data.CourseType,
-		Gender:      data.Gender,
-		Times:       parsedTimes,
+		Name:            data.Name,
+		Description:     data.Description,
+		CourseType:      data.CourseType,
+		Gender:          data.Gender,
+		AgeGroupScheme:  data.AgeGroupScheme,
+		Times:           parsedTimes,
+		QualifyingRules: rules,
+		VersionRef:      data.VersionRef,
 	}, nil
 }
 
-// importStandard creates a standard and its associated times.
-func (s *Service) importStandard(ctx context.Context, parsed *ParsedStandard) error {
-	// Create standard
-	standardInput := standard.Input{
-		Name:        parsed.Name,
-		Description: parsed.Description,
-		CourseType:  parsed.CourseType,
-		Gender:      parsed.Gender,
-	}
-
-	createdStandard, err := s.standardService.Create(ctx, standardInput)
+// importStandard creates a standard of the owner and its associated times.
+func (s *Service) importStandard(ctx context.Context, ownerID string, parsed *ParsedStandard) error {
+	scheme, err := s.schemes.Resolve(ctx, parsed.AgeGroupScheme)
 	if err != nil {
-		return fmt.Errorf("failed to create standard: %w", err)
+		return fmt.Errorf("failed to resolve age group scheme: %w", err)
 	}
 
-	// Create standard times
+	// Map age groups to the scheme's codes before creating anything
 	var times []standard.StandardTimeInput
 	for event, timesForEvent := range parsed.Times {
 		for _, t := range timesForEvent {
+			ageGroup, ok := scheme.Normalize(t.AgeGroup)
+			if !ok {
+				return fmt.Errorf("age group %s of %s is not part of the %s scheme", t.AgeGroup, event, scheme.Name)
+			}
 			times = append(times, standard.StandardTimeInput{
 				Event:    event,
-				AgeGroup: t.AgeGroup,
+				AgeGroup: string(ageGroup),
 				TimeMs:   int(t.TimeMS),
 			})
 		}
 	}
 
-	_, err = s.standardService.SetTimes(ctx, createdStandard.ID, times)
+	versioning, err := s.standardService.ResolveVersion(ctx, ownerID, parsed.VersionRef, parsed.CourseType, parsed.Gender)
+	if err != nil {
+		return fmt.Errorf("failed to resolve standard family: %w", err)
+	}
+
+	// Create standard
+	standardInput := standard.Input{
+		Name:             parsed.Name,
+		Description:      parsed.Description,
+		CourseType:       parsed.CourseType,
+		Gender:           parsed.Gender,
+		AgeGroupSchemeID: &scheme.ID,
+		QualifyingRules:  parsed.QualifyingRules,
+		Versioning:       versioning,
+	}
+
+	createdStandard, err := s.standardService.Create(ctx, ownerID, standardInput)
+	if err != nil {
+		return fmt.Errorf("failed to create standard: %w", err)
+	}
+
+	// Create standard times
+	_, err = s.standardService.SetTimes(ctx, ownerID, createdStandard.ID, times)
 	if err != nil {
 		return fmt.Errorf("failed to set standard times: %w", err)
 	}
@@ -1,8 +1,13 @@
-// Package importer provides functionality to import swimmer data from JSON files.
+// Package importer provides functionality to import swimmer data from JSON, CSV, Lenex and SDIF files.
 package importer
 
 import (
+	"encoding/json"
 	"time"
+
+	"github.com/bpg/swimstats/backend/internal/domain/dataformat"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
+	timeservice "github.com/bpg/swimstats/backend/internal/domain/time"
 )
 
 // ImportData represents the root structure for importing swimmer data.
@@ -14,6 +19,18 @@ type ImportData struct {
 	Standards     []StandardData `json:"standards,omitempty"`
 }
 
+// UnmarshalJSON decodes import data of any supported format version,
+// upgrading older documents to the current version. Unknown and future
+// versions fail with a *dataformat.VersionError.
+func (d *ImportData) UnmarshalJSON(raw []byte) error {
+	upgraded, err := dataformat.Upgrade(raw)
+	if err != nil {
+		return err
+	}
+	type plain ImportData
+	return json.Unmarshal(upgraded, (*plain)(d))
+}
+
 // SwimmerImport is deprecated, use ImportData instead.
 // Kept for backward compatibility.
 type SwimmerImport = ImportData
@@ -24,6 +41,7 @@ type SwimmerData struct {
 	BirthDate        string   `json:"birth_date"`                  // YYYY-MM-DD format
 	Gender           string   `json:"gender"`                      // "female" or "male"
 	ThresholdPercent *float64 `json:"threshold_percent,omitempty"` // "almost there" threshold percentage
+	SeasonStart      *string  `json:"season_start,omitempty"`      // First day of the season in MM-DD format
 }
 
 // MeetData represents a meet with its associated times for import.
@@ -31,37 +49,88 @@ type MeetData struct {
 	Name       string     `json:"name"`
 	City       string     `json:"city"`
 	Country    string     `json:"country"`
-	StartDate  string     `json:"start_date"`  // YYYY-MM-DD format
-	EndDate    string     `json:"end_date"`    // YYYY-MM-DD format
-	CourseType string     `json:"course_type"` // "25m" or "50m"
+	StartDate  string     `json:"start_date"`           // YYYY-MM-DD format
+	EndDate    string     `json:"end_date"`             // YYYY-MM-DD format
+	CourseType string     `json:"course_type"`          // "25m", "50m" or "25y"
+	Sanctioned *bool      `json:"sanctioned,omitempty"` // Defaults to true
 	Times      []TimeData `json:"times"`
 }
 
 // TimeData represents a swim time for import.
 type TimeData struct {
-	Event     string `json:"event"`      // Event code (e.g., "50FR", "100BK")
-	Time      string `json:"time"`       // Time in MM:SS.HH or SS.HH format
-	EventDate string `json:"event_date"` // YYYY-MM-DD format
-	Notes     string `json:"notes"`      // Optional notes
+	Event       string      `json:"event"`                  // Event code (e.g., "50FR", "100BK")
+	Time        string      `json:"time"`                   // Time in MM:SS.HH or SS.HH format, may be empty for DQ, DNS, DNF and scratches
+	EventDate   string      `json:"event_date"`             // YYYY-MM-DD format
+	Notes       string      `json:"notes"`                  // Optional notes
+	Status      string      `json:"status,omitempty"`       // Result status (ok, dq, dns, dnf, scr), defaults to ok
+	DQCode      string      `json:"dq_code,omitempty"`      // Disqualification code
+	DQReason    string      `json:"dq_reason,omitempty"`    // Disqualification reason
+	RelayLeg    int         `json:"relay_leg,omitempty"`    // Leg position (1-4) for relay events
+	RelayStroke string      `json:"relay_stroke,omitempty"` // Stroke of the relay leg (FR, BK, BR, FL)
+	Splits      []SplitData `json:"splits,omitempty"`       // Optional cumulative splits
+}
+
+// SplitData represents the cumulative time at a distance within a swim.
+type SplitData struct {
+	Distance int    `json:"distance"` // Distance in meters
+	Time     string `json:"time"`     // Cumulative time in MM:SS.HH or SS.HH format
 }
 
 // StandardData represents a time standard for import.
 type StandardData struct {
-	Name        string              `json:"name"`
-	Description string              `json:"description"`
-	CourseType  string              `json:"course_type"` // "25m" or "50m"
-	Gender      string              `json:"gender"`      // "female" or "male"
-	Times       map[string][]string `json:"times"`       // Event -> [age_group:time, ...]
+	Name           string              `json:"name"`
+	Description    string              `json:"description"`
+	CourseType     string              `json:"course_type"`                // "25m", "50m" or "25y"
+	Gender         string              `json:"gender"`                     // "female" or "male"
+	AgeGroupScheme string              `json:"age_group_scheme,omitempty"` // Defaults to "Swimming Canada"
+	Times          map[string][]string `json:"times"`                      // Event -> [age_group:time, ...]
+	standard.QualifyingRules
+	standard.VersionRef
+}
+
+// Mode controls how imported sections are combined with existing data.
+type Mode string
+
+const (
+	// ModeReplace deletes existing data of each present section before importing (default).
+	ModeReplace Mode = "replace"
+	// ModeAppend adds meets, times and standards without deleting anything.
+	// Meets are matched by name, start date and course type, and events the
+	// swimmer already has in a meet are skipped.
+	ModeAppend Mode = "append"
+	// ModeMerge adds what is new without deleting anything and reconciles the
+	// rest with the existing data. Meets are matched by name, start date and
+	// course type, times by meet and event, and standards by name. Matched
+	// records that differ are updated if requested, otherwise left unchanged
+	// and reported as conflicts.
+	ModeMerge Mode = "merge"
+)
+
+// IsValid checks if the import mode is valid.
+func (m Mode) IsValid() bool {
+	return m == ModeReplace || m == ModeAppend || m == ModeMerge
+}
+
+// ImportOptions contains options for importing data.
+type ImportOptions struct {
+	Mode   Mode
+	DryRun bool // import and roll back, reporting the result the import would have
+	Update bool // in merge mode, update existing records that differ from the imported ones
 }
 
 // ImportRequest wraps ImportData with a confirmation flag.
+// Dry runs change nothing and need no confirmation.
 type ImportRequest struct {
 	Data      ImportData `json:"data"`
 	Confirmed bool       `json:"confirmed"`
+	Mode      Mode       `json:"mode,omitempty"`
+	DryRun    bool       `json:"dry_run,omitempty"`
+	Update    bool       `json:"update,omitempty"`
 }
 
 // PreviewResult contains information about what will be deleted during import.
 type PreviewResult struct {
+	Mode                  Mode `json:"mode"`
 	WillReplaceSwimmer    bool `json:"will_replace_swimmer"`
 	CurrentMeetsCount     int  `json:"current_meets_count"`
 	CurrentTimesCount     int  `json:"current_times_count"`
@@ -69,11 +138,18 @@ type PreviewResult struct {
 	NewMeetsCount         int  `json:"new_meets_count"`
 	NewTimesCount         int  `json:"new_times_count"`
 	NewStandardsCount     int  `json:"new_standards_count"`
+
+	// Data holds the converted import data for non-JSON sources (e.g. Lenex),
+	// to be sent back to the import endpoint once confirmed.
+	Data      *ImportData `json:"data,omitempty"`
+	Warnings  []string    `json:"warnings,omitempty"`
+	RowErrors []RowError  `json:"row_errors,omitempty"` // CSV rows left out of Data
 }
 
 // ImportResult contains the results of an import operation.
 type ImportResult struct {
 	Success          bool     `json:"success"`
+	DryRun           bool     `json:"dry_run,omitempty"`
 	SwimmerReplaced  bool     `json:"swimmer_replaced,omitempty"`
 	SwimmerID        string   `json:"swimmer_id,omitempty"`
 	SwimmerName      string   `json:"swimmer_name,omitempty"`
@@ -85,6 +161,28 @@ type ImportResult struct {
 	Errors           []string `json:"errors,omitempty"`
 	SkippedTimes     int      `json:"skipped_times,omitempty"`
 	SkippedReason    []string `json:"skipped_reason,omitempty"`
+	Warnings         []string `json:"warnings,omitempty"`
+
+	// Merge reports how the records of a merge import matched existing ones.
+	Merge *MergeResult `json:"merge,omitempty"`
+}
+
+// MergeCounts counts the imported records of a kind by how they were merged:
+// matched records equal existing ones, and conflicting records differ from
+// existing ones that were left unchanged.
+type MergeCounts struct {
+	Matched     int `json:"matched"`
+	Inserted    int `json:"inserted"`
+	Updated     int `json:"updated"`
+	Conflicting int `json:"conflicting"`
+}
+
+// MergeResult reports a merge import per kind of record, describing each conflict.
+type MergeResult struct {
+	Meets     MergeCounts `json:"meets"`
+	Times     MergeCounts `json:"times"`
+	Standards MergeCounts `json:"standards"`
+	Conflicts []string    `json:"conflicts,omitempty"`
 }
 
 // ParsedSwimmer is the validated swimmer data ready for database insertion.
@@ -93,6 +191,7 @@ type ParsedSwimmer struct {
 	BirthDate        time.Time
 	Gender           string
 	ThresholdPercent *float64
+	SeasonStart      *string
 }
 
 // ParsedMeet is the validated meet data ready for database insertion.
@@ -103,24 +202,34 @@ type ParsedMeet struct {
 	StartDate  time.Time
 	EndDate    time.Time
 	CourseType string
+	Sanctioned *bool
 	Times      []ParsedTime
 }
 
 // ParsedTime is the validated time data ready for database insertion.
 type ParsedTime struct {
-	Event     string
-	TimeMS    int32
-	EventDate time.Time
-	Notes     string
+	Event       string
+	TimeMS      int32
+	EventDate   time.Time
+	Notes       string
+	Status      string
+	DQCode      string
+	DQReason    string
+	RelayLeg    int
+	RelayStroke string
+	Splits      []timeservice.Split
 }
 
 // ParsedStandard is the validated standard data ready for database insertion.
 type ParsedStandard struct {
-	Name        string
-	Description string
-	CourseType  string
-	Gender      string
-	Times       map[string][]ParsedStandardTime
+	Name           string
+	Description    string
+	CourseType     string
+	Gender         string
+	AgeGroupScheme string
+	Times          map[string][]ParsedStandardTime
+	standard.QualifyingRules
+	standard.VersionRef
 }
 
 // ParsedStandardTime represents a single time entry in a standard.
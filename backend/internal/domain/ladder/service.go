@@ -0,0 +1,651 @@
+// Package ladder provides standard ladders: standards ordered into tiers.
+package ladder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/store/db"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// Service provides ladder business logic.
+type Service struct {
+	repo         *postgres.LadderRepository
+	standardRepo *postgres.StandardRepository
+	timeRepo     *postgres.TimeRepository
+	schemes      *agegroup.Service
+	txs          postgres.TxBeginner
+}
+
+// NewService creates a new ladder service.
+func NewService(
+	repo *postgres.LadderRepository,
+	standardRepo *postgres.StandardRepository,
+	timeRepo *postgres.TimeRepository,
+	schemes *agegroup.Service,
+	txs postgres.TxBeginner,
+) *Service {
+	return &Service{
+		repo:         repo,
+		standardRepo: standardRepo,
+		timeRepo:     timeRepo,
+		schemes:      schemes,
+		txs:          txs,
+	}
+}
+
+// WithTx returns a service that runs its queries in the transaction.
+// Transactions begun by the returned service are nested in tx.
+func (s *Service) WithTx(tx pgx.Tx) *Service {
+	return &Service{
+		repo:         s.repo.WithTx(tx),
+		standardRepo: s.standardRepo.WithTx(tx),
+		timeRepo:     s.timeRepo.WithTx(tx),
+		schemes:      s.schemes.WithTx(tx),
+		txs:          tx,
+	}
+}
+
+// ErrShared is returned when a user changes a ladder shared by all users.
+var ErrShared = errors.New("shared ladders cannot be changed")
+
+// Rung is a tier of a ladder. Position 1 is the lowest tier.
+type Rung struct {
+	Position     int       `json:"position"`
+	StandardID   uuid.UUID `json:"standard_id"`
+	StandardName string    `json:"standard_name"`
+	Label        string    `json:"label,omitempty"`
+}
+
+// Name returns the label of the rung, or the name of its standard.
+func (r Rung) Name() string {
+	if r.Label != "" {
+		return r.Label
+	}
+	return r.StandardName
+}
+
+// Ladder is an ordered set of standards of one course and gender. A grading
+// ladder grades every official swim of its course and gender of its owner's
+// swimmers. Ladders belong to the user that created them; shared ladders,
+// without an owner, are visible to all users and grade the swims of users
+// without a grading ladder of their own.
+type Ladder struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CourseType  string    `json:"course_type"`
+	Gender      string    `json:"gender"`
+	Grading     bool      `json:"grading"`
+	Shared      bool      `json:"shared"`
+	OwnerID     string    `json:"-"`
+	Rungs       []Rung    `json:"rungs"`
+}
+
+// LadderList represents a list of ladders.
+type LadderList struct {
+	Ladders []Ladder `json:"ladders"`
+}
+
+// ListParams contains parameters for listing ladders.
+type ListParams struct {
+	CourseType string
+	Gender     string
+}
+
+// RungInput represents input for a rung.
+type RungInput struct {
+	StandardID uuid.UUID `json:"standard_id"`
+	Label      string    `json:"label,omitempty"`
+}
+
+// Input represents input for creating/updating a ladder. Rungs are listed
+// from the lowest tier up.
+type Input struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	CourseType  string      `json:"course_type"`
+	Gender      string      `json:"gender"`
+	Grading     bool        `json:"grading"`
+	Rungs       []RungInput `json:"rungs"`
+}
+
+// Sanitize trims whitespace from string fields.
+func (i *Input) Sanitize() {
+	i.Name = strings.TrimSpace(i.Name)
+	i.Description = strings.TrimSpace(i.Description)
+	i.CourseType = strings.TrimSpace(i.CourseType)
+	i.Gender = strings.ToLower(strings.TrimSpace(i.Gender))
+	for j := range i.Rungs {
+		i.Rungs[j].Label = strings.TrimSpace(i.Rungs[j].Label)
+	}
+}
+
+// Validate validates the ladder input. Call Sanitize() first.
+func (i Input) Validate() error {
+	if i.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(i.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	if !domain.CourseType(i.CourseType).IsValid() {
+		return errors.New("course_type must be '25m', '50m' or '25y'")
+	}
+	if !domain.Gender(i.Gender).IsValid() {
+		return errors.New("gender must be 'female' or 'male'")
+	}
+	if len(i.Rungs) == 0 {
+		return errors.New("at least one rung is required")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(i.Rungs))
+	for n, r := range i.Rungs {
+		if r.StandardID == uuid.Nil {
+			return fmt.Errorf("rung %d: standard_id is required", n+1)
+		}
+		if seen[r.StandardID] {
+			return fmt.Errorf("rung %d: standard is already on the ladder", n+1)
+		}
+		seen[r.StandardID] = true
+		if len(r.Label) > 20 {
+			return fmt.Errorf("rung %d: label must be at most 20 characters", n+1)
+		}
+	}
+	return nil
+}
+
+// RegradeResult reports the swims whose grade changed.
+type RegradeResult struct {
+	Updated int `json:"updated"`
+}
+
+// Get retrieves a ladder with its rungs, returning postgres.ErrNotFound if
+// the owner does not see it.
+func (s *Service) Get(ctx context.Context, ownerID string, id uuid.UUID) (*Ladder, error) {
+	dbLadder, err := s.get(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListRungs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ladder := toLadder(dbLadder)
+	for _, row := range rows {
+		ladder.Rungs = append(ladder.Rungs, toRung(row.Position, row.StandardID, row.StandardName, row.Label))
+	}
+	return ladder, nil
+}
+
+// List retrieves the shared ladders and those of the owner matching the
+// filter with their rungs.
+func (s *Service) List(ctx context.Context, ownerID string, params ListParams) (*LadderList, error) {
+	dbLadders, err := s.repo.List(ctx, postgres.ListLaddersParams{
+		CourseType: params.CourseType,
+		Gender:     params.Gender,
+		OwnerID:    ownerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListAllRungs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rungsByLadder := make(map[uuid.UUID][]Rung)
+	for _, row := range rows {
+		rungsByLadder[row.LadderID] = append(rungsByLadder[row.LadderID], toRung(row.Position, row.StandardID, row.StandardName, row.Label))
+	}
+
+	ladders := make([]Ladder, len(dbLadders))
+	for i, dbLadder := range dbLadders {
+		ladders[i] = *toLadder(&dbLadder)
+		if rungs, ok := rungsByLadder[dbLadder.ID]; ok {
+			ladders[i].Rungs = rungs
+		}
+	}
+	return &LadderList{Ladders: ladders}, nil
+}
+
+// Create creates a new ladder owned by the given user, or a shared one
+// without an owner. The owner's swims are regraded when it is a grading
+// ladder.
+func (s *Service) Create(ctx context.Context, ownerID string, input Input) (*Ladder, error) {
+	input.Sanitize()
+
+	var ladder *Ladder
+	err := postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		txService := s.WithTx(tx)
+		if err := txService.validate(ctx, ownerID, input, uuid.Nil); err != nil {
+			return err
+		}
+
+		dbLadder, err := txService.repo.Create(ctx, db.CreateLadderParams{
+			Name:        input.Name,
+			Description: textColumn(input.Description),
+			CourseType:  input.CourseType,
+			Gender:      input.Gender,
+			Grading:     input.Grading,
+			OwnerID:     ownerID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := txService.saveRungs(ctx, dbLadder.ID, input.Rungs); err != nil {
+			return err
+		}
+
+		if dbLadder.Grading {
+			if _, err := txService.regrade(ctx, ownerID, dbLadder.CourseType, dbLadder.Gender); err != nil {
+				return err
+			}
+		}
+		ladder, err = txService.Get(ctx, ownerID, dbLadder.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ladder, nil
+}
+
+// Update replaces a ladder of the owner and its rungs. Swims are regraded
+// when it is or was a grading ladder.
+func (s *Service) Update(ctx context.Context, ownerID string, id uuid.UUID, input Input) (*Ladder, error) {
+	input.Sanitize()
+
+	var ladder *Ladder
+	err := postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		txService := s.WithTx(tx)
+		existing, err := txService.getOwned(ctx, ownerID, id)
+		if err != nil {
+			return err
+		}
+		if err := txService.validate(ctx, existing.OwnerID, input, id); err != nil {
+			return err
+		}
+
+		dbLadder, err := txService.repo.Update(ctx, db.UpdateLadderParams{
+			ID:          id,
+			Name:        input.Name,
+			Description: textColumn(input.Description),
+			CourseType:  input.CourseType,
+			Gender:      input.Gender,
+			Grading:     input.Grading,
+		})
+		if err != nil {
+			return err
+		}
+		if err := txService.repo.DeleteRungs(ctx, id); err != nil {
+			return err
+		}
+		if err := txService.saveRungs(ctx, id, input.Rungs); err != nil {
+			return err
+		}
+
+		if existing.Grading && (existing.CourseType != dbLadder.CourseType || existing.Gender != dbLadder.Gender || !dbLadder.Grading) {
+			if _, err := txService.regrade(ctx, existing.OwnerID, existing.CourseType, existing.Gender); err != nil {
+				return err
+			}
+		}
+		if dbLadder.Grading {
+			if _, err := txService.regrade(ctx, existing.OwnerID, dbLadder.CourseType, dbLadder.Gender); err != nil {
+				return err
+			}
+		}
+		ladder, err = txService.Get(ctx, ownerID, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ladder, nil
+}
+
+// Delete deletes a ladder of the owner. The grades of a grading ladder are
+// cleared.
+func (s *Service) Delete(ctx context.Context, ownerID string, id uuid.UUID) error {
+	return postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		txService := s.WithTx(tx)
+		existing, err := txService.getOwned(ctx, ownerID, id)
+		if err != nil {
+			return err
+		}
+		if err := txService.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		if existing.Grading {
+			if _, err := txService.regrade(ctx, existing.OwnerID, existing.CourseType, existing.Gender); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Regrade recomputes the grades of the owner's swims of a grading ladder's
+// course and gender, e.g. after the times of its standards changed.
+func (s *Service) Regrade(ctx context.Context, ownerID string, id uuid.UUID) (*RegradeResult, error) {
+	var result *RegradeResult
+	err := postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		txService := s.WithTx(tx)
+		dbLadder, err := txService.get(ctx, ownerID, id)
+		if err != nil {
+			return err
+		}
+		if !dbLadder.Grading {
+			return errors.New("validation: ladder is not a grading ladder")
+		}
+
+		updated, err := txService.regrade(ctx, ownerID, dbLadder.CourseType, dbLadder.Gender)
+		if err != nil {
+			return err
+		}
+		result = &RegradeResult{Updated: updated}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListGrading lists the grading ladders with the standard as a rung, without
+// their rungs.
+func (s *Service) ListGrading(ctx context.Context, standardID uuid.UUID) ([]Ladder, error) {
+	dbLadders, err := s.repo.ListGradingByStandard(ctx, standardID)
+	if err != nil {
+		return nil, err
+	}
+	ladders := make([]Ladder, len(dbLadders))
+	for i, dbLadder := range dbLadders {
+		ladders[i] = *toLadder(&dbLadder)
+	}
+	return ladders, nil
+}
+
+// RegradeAll recomputes the grades of the swims the grading ladders grade,
+// e.g. after a standard they share changed. Call it in a transaction.
+func (s *Service) RegradeAll(ctx context.Context, ladders []Ladder) error {
+	for _, l := range ladders {
+		if _, err := s.regrade(ctx, l.OwnerID, l.CourseType, l.Gender); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Grader loads the grading ladder of a course and gender that grades the
+// owner's swims: their own or else the shared one. It returns a nil Grader,
+// which grades nothing, if there is none.
+func (s *Service) Grader(ctx context.Context, ownerID, courseType, gender string) (*Grader, error) {
+	dbLadder, err := s.repo.GetGrading(ctx, ownerID, courseType, gender)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rows, err := s.repo.ListRungs(ctx, dbLadder.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	standards, err := s.standardRepo.List(ctx, postgres.ListStandardsParams{
+		CourseType: &courseType,
+		Gender:     &gender,
+		OwnerID:    dbLadder.OwnerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	schemeIDs := make(map[uuid.UUID]uuid.UUID, len(standards))
+	for _, std := range standards {
+		schemeIDs[std.ID] = std.AgeGroupSchemeID
+	}
+
+	schemeList, err := s.schemes.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list age group schemes: %w", err)
+	}
+	schemes := make(map[uuid.UUID]domain.AgeGroupScheme, len(schemeList.Schemes))
+	for _, scheme := range schemeList.Schemes {
+		schemes[scheme.ID] = scheme.AgeGroupScheme
+	}
+
+	standardTimes, err := s.standardRepo.ListTimesForCourse(ctx, courseType, gender)
+	if err != nil {
+		return nil, err
+	}
+	times := make(map[uuid.UUID]map[string]map[string]int)
+	for _, st := range standardTimes {
+		if times[st.StandardID] == nil {
+			times[st.StandardID] = make(map[string]map[string]int)
+		}
+		if times[st.StandardID][st.Event] == nil {
+			times[st.StandardID][st.Event] = make(map[string]int)
+		}
+		times[st.StandardID][st.Event][st.AgeGroup] = int(st.TimeMs)
+	}
+
+	// Rungs of standards no longer in the ladder's course and gender are skipped
+	g := &Grader{}
+	for i := len(rows) - 1; i >= 0; i-- {
+		row := rows[i]
+		schemeID, ok := schemeIDs[row.StandardID]
+		if !ok {
+			continue
+		}
+		g.rungs = append(g.rungs, gradedRung{
+			grade:  toRung(row.Position, row.StandardID, row.StandardName, row.Label).Name(),
+			scheme: schemes[schemeID],
+			times:  times[row.StandardID],
+		})
+	}
+	return g, nil
+}
+
+// Grader grades swims on a grading ladder. A nil Grader grades nothing.
+type Grader struct {
+	rungs []gradedRung // highest tier first
+}
+
+type gradedRung struct {
+	grade  string
+	scheme domain.AgeGroupScheme
+	times  map[string]map[string]int // event -> age_group -> time_ms
+}
+
+// Grade returns the label of the highest rung a swim achieved in the age
+// group the swimmer was in at the meet, falling back to OPEN times, or an
+// empty string if it achieved none.
+func (g *Grader) Grade(event string, birthDate, meetDate time.Time, timeMS int) string {
+	if g == nil || timeMS <= 0 {
+		return ""
+	}
+	for _, r := range g.rungs {
+		eventTimes := r.times[event]
+		if len(eventTimes) == 0 {
+			continue
+		}
+		ageGroup := r.scheme.GroupForAge(r.scheme.Age(birthDate, meetDate))
+		stdTimeMS, ok := eventTimes[string(ageGroup)]
+		if !ok {
+			stdTimeMS, ok = eventTimes[string(domain.AgeGroupOpen)]
+		}
+		if ok && timeMS <= stdTimeMS {
+			return r.grade
+		}
+	}
+	return ""
+}
+
+// GradeColumn returns the grade column of a swim, which only official swims
+// of an individual event or a relay lead-off leg have.
+func (g *Grader) GradeColumn(officialEvent, status string, birthDate, meetDate time.Time, timeMS int) pgtype.Text {
+	if officialEvent == "" || !domain.ResultStatus(status).IsOfficial() {
+		return pgtype.Text{}
+	}
+	return textColumn(g.Grade(officialEvent, birthDate, meetDate, timeMS))
+}
+
+// regrade recomputes the grades of the owner's swims of a course and gender,
+// or those of all swims for an empty owner, and returns the number of swims
+// whose grade changed. Each swim is graded on the grading ladder of its
+// swimmer's owner.
+func (s *Service) regrade(ctx context.Context, ownerID, courseType, gender string) (int, error) {
+	rows, err := s.timeRepo.ListForGrading(ctx, ownerID, courseType, gender)
+	if err != nil {
+		return 0, err
+	}
+
+	graders := make(map[string]*Grader)
+	updated := 0
+	for _, row := range rows {
+		grader, ok := graders[row.OwnerID]
+		if !ok {
+			grader, err = s.Grader(ctx, row.OwnerID, courseType, gender)
+			if err != nil {
+				return 0, err
+			}
+			graders[row.OwnerID] = grader
+		}
+		grade := grader.GradeColumn(row.OfficialEvent, row.Status, row.BirthDate.Time, row.MeetStartDate.Time, int(row.TimeMs))
+		if grade == row.Grade {
+			continue
+		}
+		if err := s.timeRepo.UpdateGrade(ctx, row.ID, grade); err != nil {
+			return 0, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// validate validates the input of a ladder of the owner and checks that the
+// name is free, that the owner has only one grading ladder per course and
+// gender, and that every rung is a standard the owner sees of the ladder's
+// course and gender. Shared ladders only have shared standards as rungs.
+func (s *Service) validate(ctx context.Context, ownerID string, i Input, excludeID uuid.UUID) error {
+	if err := i.Validate(); err != nil {
+		return fmt.Errorf("validation: %w", err)
+	}
+
+	exists, err := s.repo.NameExists(ctx, ownerID, i.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("validation: a ladder with this name already exists")
+	}
+
+	if i.Grading {
+		exists, err := s.repo.GradingExists(ctx, ownerID, i.CourseType, i.Gender, excludeID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("validation: a grading ladder already exists for %s %s", i.Gender, i.CourseType)
+		}
+	}
+
+	for n, r := range i.Rungs {
+		std, err := s.standardRepo.GetForOwner(ctx, ownerID, r.StandardID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return fmt.Errorf("validation: rung %d: standard not found", n+1)
+			}
+			return err
+		}
+		if ownerID == "" && std.OwnerID != "" {
+			return fmt.Errorf("validation: rung %d: %s is not a shared standard", n+1, std.Name)
+		}
+		if std.CourseType != i.CourseType || std.Gender != i.Gender {
+			return fmt.Errorf("validation: rung %d: %s is not a %s %s standard", n+1, std.Name, i.Gender, i.CourseType)
+		}
+	}
+	return nil
+}
+
+// get retrieves a ladder the owner sees.
+func (s *Service) get(ctx context.Context, ownerID string, id uuid.UUID) (*db.StandardLadder, error) {
+	dbLadder, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != "" && dbLadder.OwnerID != "" && dbLadder.OwnerID != ownerID {
+		return nil, postgres.ErrNotFound
+	}
+	return dbLadder, nil
+}
+
+// getOwned retrieves a ladder the owner may change, returning ErrShared for
+// shared ladders.
+func (s *Service) getOwned(ctx context.Context, ownerID string, id uuid.UUID) (*db.StandardLadder, error) {
+	dbLadder, err := s.get(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != "" && dbLadder.OwnerID != ownerID {
+		return nil, ErrShared
+	}
+	return dbLadder, nil
+}
+
+// saveRungs adds the rungs of a ladder in order, the first one lowest.
+func (s *Service) saveRungs(ctx context.Context, ladderID uuid.UUID, rungs []RungInput) error {
+	for n, r := range rungs {
+		err := s.repo.CreateRung(ctx, db.CreateLadderRungParams{
+			LadderID:   ladderID,
+			Position:   int16(n + 1),
+			StandardID: r.StandardID,
+			Label:      textColumn(r.Label),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func textColumn(s string) pgtype.Text {
+	if s == "" {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: s, Valid: true}
+}
+
+func toLadder(dbLadder *db.StandardLadder) *Ladder {
+	return &Ladder{
+		ID:          dbLadder.ID,
+		Name:        dbLadder.Name,
+		Description: dbLadder.Description.String,
+		CourseType:  dbLadder.CourseType,
+		Gender:      dbLadder.Gender,
+		Grading:     dbLadder.Grading,
+		Shared:      dbLadder.OwnerID == "",
+		OwnerID:     dbLadder.OwnerID,
+		Rungs:       []Rung{},
+	}
+}
+
+func toRung(position int16, standardID uuid.UUID, standardName string, label pgtype.Text) Rung {
+	return Rung{
+		Position:     int(position),
+		StandardID:   standardID,
+		StandardName: standardName,
+		Label:        label.String,
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/bpg/swimstats/backend/internal/domain"
 	"github.com/bpg/swimstats/backend/internal/store/db"
 	"github.com/bpg/swimstats/backend/internal/store/postgres"
 )
@@ -25,6 +27,11 @@ func NewService(repo *postgres.MeetRepository) *Service {
 	return &Service{repo: repo}
 }
 
+// WithTx returns a service that runs its queries in the transaction.
+func (s *Service) WithTx(tx pgx.Tx) *Service {
+	return &Service{repo: s.repo.WithTx(tx)}
+}
+
 // Meet represents a meet with computed fields.
 type Meet struct {
 	ID         uuid.UUID `json:"id"`
@@ -34,9 +41,16 @@ type Meet struct {
 	StartDate  string    `json:"start_date"`
 	EndDate    string    `json:"end_date"`
 	CourseType string    `json:"course_type"`
+	Sanctioned bool      `json:"sanctioned"`
 	TimeCount  int       `json:"time_count,omitempty"`
 }
 
+// OwnedMeet is a meet with the user it belongs to.
+type OwnedMeet struct {
+	OwnerID string
+	Meet
+}
+
 // MeetList represents a paginated list of meets.
 type MeetList struct {
 	Meets []Meet `json:"meets"`
@@ -44,6 +58,7 @@ type MeetList struct {
 }
 
 // Input represents input for creating/updating a meet.
+// Sanctioned defaults to true on create and to the current value on update.
 type Input struct {
 	Name       string `json:"name"`
 	City       string `json:"city"`
@@ -51,6 +66,7 @@ type Input struct {
 	StartDate  string `json:"start_date"`
 	EndDate    string `json:"end_date,omitempty"`
 	CourseType string `json:"course_type"`
+	Sanctioned *bool  `json:"sanctioned,omitempty"`
 }
 
 // Sanitize trims whitespace from string fields.
@@ -99,8 +115,8 @@ func (i Input) Validate() error {
 		return errors.New("end_date cannot be before start_date")
 	}
 
-	if i.CourseType != "25m" && i.CourseType != "50m" {
-		return errors.New("course_type must be '25m' or '50m'")
+	if !domain.CourseType(i.CourseType).IsValid() {
+		return errors.New("course_type must be '25m', '50m' or '25y'")
 	}
 	return nil
 }
@@ -112,23 +128,24 @@ type ListParams struct {
 	Offset     int
 }
 
-// Get retrieves a meet by ID.
-func (s *Service) Get(ctx context.Context, id uuid.UUID) (*Meet, error) {
-	row, err := s.repo.GetWithTimeCount(ctx, id)
+// Get retrieves an owner's meet by ID.
+func (s *Service) Get(ctx context.Context, ownerID string, id uuid.UUID) (*Meet, error) {
+	row, err := s.repo.GetWithTimeCount(ctx, id, ownerID)
 	if err != nil {
 		return nil, err
 	}
 	return toMeetFromRow(row), nil
 }
 
-// List retrieves a paginated list of meets.
-func (s *Service) List(ctx context.Context, params ListParams) (*MeetList, error) {
+// List retrieves a paginated list of an owner's meets.
+func (s *Service) List(ctx context.Context, ownerID string, params ListParams) (*MeetList, error) {
 	limit := int32(params.Limit)
 	if limit <= 0 {
 		limit = 50
 	}
 
 	rows, err := s.repo.List(ctx, postgres.ListMeetsParams{
+		OwnerID:    ownerID,
 		CourseType: params.CourseType,
 		Limit:      limit,
 		Offset:     int32(params.Offset),
@@ -137,7 +154,7 @@ func (s *Service) List(ctx context.Context, params ListParams) (*MeetList, error
 		return nil, fmt.Errorf("list meets: %w", err)
 	}
 
-	count, err := s.repo.Count(ctx, params.CourseType)
+	count, err := s.repo.Count(ctx, ownerID, params.CourseType)
 	if err != nil {
 		return nil, fmt.Errorf("count meets: %w", err)
 	}
@@ -152,6 +169,7 @@ func (s *Service) List(ctx context.Context, params ListParams) (*MeetList, error
 			StartDate:  row.StartDate.Time.Format("2006-01-02"),
 			EndDate:    row.EndDate.Time.Format("2006-01-02"),
 			CourseType: row.CourseType,
+			Sanctioned: row.Sanctioned,
 			TimeCount:  int(row.TimeCount),
 		}
 	}
@@ -162,8 +180,8 @@ func (s *Service) List(ctx context.Context, params ListParams) (*MeetList, error
 	}, nil
 }
 
-// Create creates a new meet.
-func (s *Service) Create(ctx context.Context, input Input) (*Meet, error) {
+// Create creates a new meet owned by the given user.
+func (s *Service) Create(ctx context.Context, ownerID string, input Input) (*Meet, error) {
 	input.Sanitize()
 	if err := input.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
@@ -183,6 +201,11 @@ func (s *Service) Create(ctx context.Context, input Input) (*Meet, error) {
 	}
 	endDate, _ := time.Parse("2006-01-02", endDateStr)
 
+	sanctioned := true
+	if input.Sanctioned != nil {
+		sanctioned = *input.Sanctioned
+	}
+
 	params := db.CreateMeetParams{
 		Name:       input.Name,
 		City:       input.City,
@@ -190,6 +213,8 @@ func (s *Service) Create(ctx context.Context, input Input) (*Meet, error) {
 		StartDate:  pgtype.Date{Time: startDate, Valid: true},
 		EndDate:    pgtype.Date{Time: endDate, Valid: true},
 		CourseType: input.CourseType,
+		OwnerID:    ownerID,
+		Sanctioned: sanctioned,
 	}
 
 	dbMeet, err := s.repo.Create(ctx, params)
@@ -199,13 +224,19 @@ func (s *Service) Create(ctx context.Context, input Input) (*Meet, error) {
 	return toMeetFromDB(dbMeet), nil
 }
 
-// Update updates an existing meet.
-func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Meet, error) {
+// Update updates an existing meet of the given owner.
+func (s *Service) Update(ctx context.Context, ownerID string, id uuid.UUID, input Input) (*Meet, error) {
 	input.Sanitize()
 	if err := input.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
 	}
 
+	// Verify ownership
+	existing, err := s.repo.Get(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
 	country := input.Country
 	if country == "" {
 		country = "Canada"
@@ -220,6 +251,11 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Meet,
 	}
 	endDate, _ := time.Parse("2006-01-02", endDateStr)
 
+	sanctioned := existing.Sanctioned
+	if input.Sanctioned != nil {
+		sanctioned = *input.Sanctioned
+	}
+
 	params := db.UpdateMeetParams{
 		ID:         id,
 		Name:       input.Name,
@@ -228,6 +264,7 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Meet,
 		StartDate:  pgtype.Date{Time: startDate, Valid: true},
 		EndDate:    pgtype.Date{Time: endDate, Valid: true},
 		CourseType: input.CourseType,
+		Sanctioned: sanctioned,
 	}
 
 	dbMeet, err := s.repo.Update(ctx, params)
@@ -237,10 +274,10 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Meet,
 	return toMeetFromDB(dbMeet), nil
 }
 
-// Delete deletes a meet.
-func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete deletes a meet of the given owner.
+func (s *Service) Delete(ctx context.Context, ownerID string, id uuid.UUID) error {
 	// First check if meet exists
-	_, err := s.repo.Get(ctx, id)
+	_, err := s.repo.Get(ctx, id, ownerID)
 	if err != nil {
 		return err
 	}
@@ -251,9 +288,62 @@ func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// GetRecent retrieves the most recent meets.
-func (s *Service) GetRecent(ctx context.Context, courseType *string, limit int) ([]Meet, error) {
-	rows, err := s.repo.GetRecent(ctx, courseType, int32(limit))
+// FindOrCreate returns the owner's meet with the same name, start date and
+// course type as the input, creating it if none exists.
+func (s *Service) FindOrCreate(ctx context.Context, ownerID string, input Input) (*Meet, bool, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, false, fmt.Errorf("validation: %w", err)
+	}
+
+	startDate, _ := time.Parse("2006-01-02", input.StartDate)
+	dbMeet, err := s.repo.Find(ctx, db.FindMeetParams{
+		OwnerID:    ownerID,
+		Name:       input.Name,
+		StartDate:  pgtype.Date{Time: startDate, Valid: true},
+		CourseType: input.CourseType,
+	})
+	if err == nil {
+		return toMeetFromDB(dbMeet), false, nil
+	}
+	if !errors.Is(err, postgres.ErrNotFound) {
+		return nil, false, fmt.Errorf("find meet: %w", err)
+	}
+
+	created, err := s.Create(ctx, ownerID, input)
+	if err != nil {
+		return nil, false, err
+	}
+	return created, true, nil
+}
+
+// DeleteEmpty deletes an owner's meets that have no recorded times.
+func (s *Service) DeleteEmpty(ctx context.Context, ownerID string) (int, error) {
+	count, err := s.repo.DeleteEmpty(ctx, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// ListEmpty retrieves the meets of all owners that have no recorded times,
+// ordered by owner.
+func (s *Service) ListEmpty(ctx context.Context) ([]OwnedMeet, error) {
+	dbMeets, err := s.repo.ListEmpty(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	meets := make([]OwnedMeet, len(dbMeets))
+	for i := range dbMeets {
+		meets[i] = OwnedMeet{OwnerID: dbMeets[i].OwnerID, Meet: *toMeetFromDB(&dbMeets[i])}
+	}
+	return meets, nil
+}
+
+// GetRecent retrieves an owner's most recent meets.
+func (s *Service) GetRecent(ctx context.Context, ownerID string, courseType *string, limit int) ([]Meet, error) {
+	rows, err := s.repo.GetRecent(ctx, ownerID, courseType, int32(limit))
 	if err != nil {
 		return nil, fmt.Errorf("get recent meets: %w", err)
 	}
@@ -268,6 +358,7 @@ func (s *Service) GetRecent(ctx context.Context, courseType *string, limit int)
 			StartDate:  row.StartDate.Time.Format("2006-01-02"),
 			EndDate:    row.EndDate.Time.Format("2006-01-02"),
 			CourseType: row.CourseType,
+			Sanctioned: row.Sanctioned,
 			TimeCount:  int(row.TimeCount),
 		}
 	}
@@ -283,6 +374,7 @@ func toMeetFromDB(dbMeet *db.Meet) *Meet {
 		StartDate:  dbMeet.StartDate.Time.Format("2006-01-02"),
 		EndDate:    dbMeet.EndDate.Time.Format("2006-01-02"),
 		CourseType: dbMeet.CourseType,
+		Sanctioned: dbMeet.Sanctioned,
 	}
 }
 
@@ -295,6 +387,7 @@ func toMeetFromRow(row *db.GetMeetWithTimeCountRow) *Meet {
 		StartDate:  row.StartDate.Time.Format("2006-01-02"),
 		EndDate:    row.EndDate.Time.Format("2006-01-02"),
 		CourseType: row.CourseType,
+		Sanctioned: row.Sanctioned,
 		TimeCount:  int(row.TimeCount),
 	}
 }
@@ -0,0 +1,271 @@
+// Package points computes World Aquatics points from base time tables.
+package points
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/store/db"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// Service provides points business logic.
+type Service struct {
+	repo *postgres.PointsRepository
+	txs  postgres.TxBeginner
+}
+
+// NewService creates a new points service.
+func NewService(repo *postgres.PointsRepository, txs postgres.TxBeginner) *Service {
+	return &Service{repo: repo, txs: txs}
+}
+
+// WithTx returns a service that runs its queries in the transaction.
+// Transactions begun by the returned service are nested in tx.
+func (s *Service) WithTx(tx pgx.Tx) *Service {
+	return &Service{repo: s.repo.WithTx(tx), txs: tx}
+}
+
+// BaseTime is the time worth 1000 points in an event.
+type BaseTime struct {
+	Event         string `json:"event"`
+	TimeMS        int    `json:"time_ms"`
+	TimeFormatted string `json:"time_formatted,omitempty"`
+}
+
+// Table is the set of base times of a year, course and gender.
+type Table struct {
+	Year       int        `json:"year"`
+	CourseType string     `json:"course_type"`
+	Gender     string     `json:"gender"`
+	Times      []BaseTime `json:"times"`
+}
+
+// TableList represents a list of base time tables.
+type TableList struct {
+	Tables []Table `json:"tables"`
+}
+
+// Sanitize normalizes the table's fields.
+func (t *Table) Sanitize() {
+	t.CourseType = strings.TrimSpace(t.CourseType)
+	t.Gender = strings.ToLower(strings.TrimSpace(t.Gender))
+	for i := range t.Times {
+		t.Times[i].Event = strings.ToUpper(strings.TrimSpace(t.Times[i].Event))
+	}
+}
+
+// Validate validates a table. Call Sanitize() first. Only individual events
+// have base times.
+func (t Table) Validate() error {
+	if t.Year < 1900 || t.Year > 9999 {
+		return errors.New("year must be between 1900 and 9999")
+	}
+	if !domain.CourseType(t.CourseType).IsValid() {
+		return errors.New("course_type must be '25m', '50m' or '25y'")
+	}
+	if !domain.Gender(t.Gender).IsValid() {
+		return errors.New("gender must be 'female' or 'male'")
+	}
+	if len(t.Times) == 0 {
+		return errors.New("at least one base time is required")
+	}
+
+	seen := make(map[string]bool, len(t.Times))
+	for _, bt := range t.Times {
+		code := domain.EventCode(bt.Event)
+		if !code.IsValidForCourse(domain.CourseType(t.CourseType)) || code.IsRelay() {
+			return fmt.Errorf("invalid event code for %s: %s", t.CourseType, bt.Event)
+		}
+		if seen[bt.Event] {
+			return fmt.Errorf("duplicate base time for %s", bt.Event)
+		}
+		seen[bt.Event] = true
+		if bt.TimeMS <= 0 {
+			return fmt.Errorf("%s: time_ms must be positive", bt.Event)
+		}
+	}
+	return nil
+}
+
+// Compute returns the World Aquatics points of a swim: 1000 * (base / time)^3,
+// truncated to a whole number.
+func Compute(baseMS, timeMS int) int {
+	if baseMS <= 0 || timeMS <= 0 {
+		return 0
+	}
+	ratio := float64(baseMS) / float64(timeMS)
+	return int(math.Floor(1000 * ratio * ratio * ratio))
+}
+
+// ListTables lists all base time tables, most recent year first.
+func (s *Service) ListTables(ctx context.Context) (*TableList, error) {
+	rows, err := s.repo.ListBaseTimes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &TableList{Tables: toTables(rows)}, nil
+}
+
+// SetTable creates or replaces the base time table of a year, course and
+// gender in a single transaction, so a failing base time keeps the old table.
+func (s *Service) SetTable(ctx context.Context, input Table) (*Table, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+
+	rows := make([]db.PointsBaseTime, 0, len(input.Times))
+	err := postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		repo := s.repo.WithTx(tx)
+
+		// Replace the whole table so that events left out are removed
+		err := repo.DeleteTable(ctx, db.DeletePointsBaseTimesParams{
+			Year:       int16(input.Year),
+			CourseType: input.CourseType,
+			Gender:     input.Gender,
+		})
+		if err != nil && !errors.Is(err, postgres.ErrNotFound) {
+			return err
+		}
+
+		for _, bt := range input.Times {
+			row, err := repo.UpsertBaseTime(ctx, db.UpsertPointsBaseTimeParams{
+				Year:       int16(input.Year),
+				CourseType: input.CourseType,
+				Gender:     input.Gender,
+				Event:      bt.Event,
+				TimeMs:     int32(bt.TimeMS),
+			})
+			if err != nil {
+				return err
+			}
+			rows = append(rows, *row)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	table := toTables(rows)[0]
+	return &table, nil
+}
+
+// DeleteTable deletes the base time table of a year, course and gender.
+func (s *Service) DeleteTable(ctx context.Context, year int, courseType, gender string) error {
+	return s.repo.DeleteTable(ctx, db.DeletePointsBaseTimesParams{
+		Year:       int16(year),
+		CourseType: courseType,
+		Gender:     gender,
+	})
+}
+
+// Calculator loads the base times of a gender for computing the points of
+// many swims at once.
+func (s *Service) Calculator(ctx context.Context, gender string) (*Calculator, error) {
+	rows, err := s.repo.ListBaseTimesByGender(ctx, gender)
+	if err != nil {
+		return nil, err
+	}
+	return NewCalculator(rows), nil
+}
+
+// Calculator computes points from a fixed set of base times of one gender.
+// A nil Calculator computes no points.
+type Calculator struct {
+	baseTimes map[calculatorKey][]yearTime
+}
+
+type calculatorKey struct {
+	courseType, event string
+}
+
+type yearTime struct {
+	year   int
+	timeMS int
+}
+
+// NewCalculator creates a calculator from base times of one gender.
+func NewCalculator(rows []db.PointsBaseTime) *Calculator {
+	c := &Calculator{baseTimes: make(map[calculatorKey][]yearTime)}
+	for _, row := range rows {
+		key := calculatorKey{row.CourseType, row.Event}
+		c.baseTimes[key] = append(c.baseTimes[key], yearTime{int(row.Year), int(row.TimeMs)})
+	}
+	for _, times := range c.baseTimes {
+		sort.Slice(times, func(i, j int) bool { return times[i].year > times[j].year })
+	}
+	return c
+}
+
+// Points returns the points of a swim on the given date. The base times of
+// the latest year not after the swim are used, or the earliest year loaded
+// for older swims. It returns false if there is no base time for the event.
+func (c *Calculator) Points(courseType, event string, date time.Time, timeMS int) (int, bool) {
+	if c == nil || timeMS <= 0 {
+		return 0, false
+	}
+	times := c.baseTimes[calculatorKey{courseType, event}]
+	if len(times) == 0 {
+		return 0, false
+	}
+
+	base := times[len(times)-1]
+	for _, yt := range times {
+		if yt.year <= date.Year() {
+			base = yt
+			break
+		}
+	}
+	return Compute(base.timeMS, timeMS), true
+}
+
+// PointsPtr returns the points of a swim as a pointer, or nil if there is no
+// base time for the event.
+func (c *Calculator) PointsPtr(courseType, event string, date time.Time, timeMS int) *int {
+	points, ok := c.Points(courseType, event, date, timeMS)
+	if !ok {
+		return nil
+	}
+	return &points
+}
+
+// toTables groups base time rows into tables, keeping the order of the rows.
+func toTables(rows []db.PointsBaseTime) []Table {
+	type tableKey struct {
+		year               int
+		courseType, gender string
+	}
+
+	tables := []Table{}
+	index := make(map[tableKey]int)
+	for _, row := range rows {
+		key := tableKey{int(row.Year), row.CourseType, row.Gender}
+		i, ok := index[key]
+		if !ok {
+			i = len(tables)
+			index[key] = i
+			tables = append(tables, Table{
+				Year:       key.year,
+				CourseType: key.courseType,
+				Gender:     key.gender,
+				Times:      []BaseTime{},
+			})
+		}
+		tables[i].Times = append(tables[i].Times, BaseTime{
+			Event:         row.Event,
+			TimeMS:        int(row.TimeMs),
+			TimeFormatted: domain.FormatTime(int(row.TimeMs)),
+		})
+	}
+	return tables
+}
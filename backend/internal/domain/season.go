@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SeasonDefinition is the day of the year on which a swim season starts.
+// Seasons last one year; a season starting on January 1 is a calendar year.
+type SeasonDefinition struct {
+	StartMonth time.Month
+	StartDay   int
+}
+
+// DefaultSeasonDefinition starts seasons on September 1, as in Canada.
+var DefaultSeasonDefinition = SeasonDefinition{StartMonth: time.September, StartDay: 1}
+
+// ParseSeasonStart parses a season start in MM-DD format, e.g. "09-01".
+// February 29 is rejected as it does not occur every year.
+func ParseSeasonStart(s string) (SeasonDefinition, error) {
+	t, err := time.Parse("01-02", strings.TrimSpace(s))
+	if err != nil || (t.Month() == time.February && t.Day() == 29) {
+		return SeasonDefinition{}, errors.New("season_start must be a valid day in MM-DD format")
+	}
+	return SeasonDefinition{StartMonth: t.Month(), StartDay: t.Day()}, nil
+}
+
+// String returns the season start in MM-DD format.
+func (d SeasonDefinition) String() string {
+	return fmt.Sprintf("%02d-%02d", int(d.StartMonth), d.StartDay)
+}
+
+// Season is a swim season. Dates are inclusive.
+type Season struct {
+	Label     string    `json:"season"`
+	StartDate time.Time `json:"-"`
+	EndDate   time.Time `json:"-"`
+}
+
+// spansYears reports whether seasons cross a calendar year boundary.
+func (d SeasonDefinition) spansYears() bool {
+	return d.StartMonth != time.January || d.StartDay != 1
+}
+
+// start returns the first day of the season starting in the given year.
+func (d SeasonDefinition) start(year int) time.Time {
+	return time.Date(year, d.StartMonth, d.StartDay, 0, 0, 0, 0, time.UTC)
+}
+
+// seasonStarting returns the season starting in the given year.
+func (d SeasonDefinition) seasonStarting(year int) Season {
+	label := strconv.Itoa(year)
+	if d.spansYears() {
+		label = fmt.Sprintf("%d-%d", year, year+1)
+	}
+	return Season{
+		Label:     label,
+		StartDate: d.start(year),
+		EndDate:   d.start(year+1).AddDate(0, 0, -1),
+	}
+}
+
+// SeasonOf returns the season containing the date.
+func (d SeasonDefinition) SeasonOf(date time.Time) Season {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	year := day.Year()
+	if day.Before(d.start(year)) {
+		year--
+	}
+	return d.seasonStarting(year)
+}
+
+// Season parses a season label: "2025-2026" for seasons crossing a calendar
+// year boundary, or "2025" for calendar-year seasons.
+func (d SeasonDefinition) Season(label string) (Season, error) {
+	label = strings.TrimSpace(label)
+	first, second, crosses := strings.Cut(label, "-")
+	year, err := strconv.Atoi(first)
+	if err != nil || year < 1900 || year > 9999 || crosses != d.spansYears() {
+		return Season{}, fmt.Errorf("invalid season: %s", label)
+	}
+	if crosses {
+		if next, err := strconv.Atoi(second); err != nil || next != year+1 {
+			return Season{}, fmt.Errorf("invalid season: %s", label)
+		}
+	}
+	return d.seasonStarting(year), nil
+}
+
+// Previous returns the season before this one.
+func (s Season) Previous(d SeasonDefinition) Season {
+	return d.SeasonOf(s.StartDate.AddDate(0, 0, -1))
+}
@@ -0,0 +1,447 @@
+// Package season provides swim season reporting: season bests, improvement
+// over the previous season and season summaries.
+package season
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
+	"github.com/bpg/swimstats/backend/internal/store/db"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// Service provides season business logic.
+type Service struct {
+	timeRepo     *postgres.TimeRepository
+	swimmerRepo  *postgres.SwimmerRepository
+	standardRepo *postgres.StandardRepository
+	schemes      *agegroup.Service
+}
+
+// NewService creates a new season service.
+func NewService(
+	timeRepo *postgres.TimeRepository,
+	swimmerRepo *postgres.SwimmerRepository,
+	standardRepo *postgres.StandardRepository,
+	schemes *agegroup.Service,
+) *Service {
+	return &Service{
+		timeRepo:     timeRepo,
+		swimmerRepo:  swimmerRepo,
+		standardRepo: standardRepo,
+		schemes:      schemes,
+	}
+}
+
+// Season is a swim season with the swimmer's activity in all courses.
+type Season struct {
+	Season    string `json:"season"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Current   bool   `json:"current"`
+	Meets     int    `json:"meets"`
+	Swims     int    `json:"swims"`
+}
+
+// SeasonList represents the seasons of a swimmer, most recent first.
+type SeasonList struct {
+	SeasonStart string   `json:"season_start"`
+	Seasons     []Season `json:"seasons"`
+}
+
+// SeasonBest is the fastest time of an event in a season, compared with the
+// previous season's best. Improvement is positive when the swimmer got faster.
+type SeasonBest struct {
+	Event                       string   `json:"event"`
+	TimeMS                      int      `json:"time_ms"`
+	TimeFormatted               string   `json:"time_formatted"`
+	TimeID                      string   `json:"time_id"`
+	MeetName                    string   `json:"meet"`
+	Date                        string   `json:"date"`
+	IsPersonalBest              bool     `json:"is_personal_best"`
+	PreviousSeasonTimeMS        *int     `json:"previous_season_time_ms,omitempty"`
+	PreviousSeasonTimeFormatted *string  `json:"previous_season_time_formatted,omitempty"`
+	ImprovementMS               *int     `json:"improvement_ms,omitempty"`
+	ImprovementFormatted        *string  `json:"improvement_formatted,omitempty"`
+	ImprovementPercent          *float64 `json:"improvement_percent,omitempty"`
+}
+
+// SeasonBestList represents the season bests of a swimmer in a course type.
+type SeasonBestList struct {
+	Season         string       `json:"season"`
+	StartDate      string       `json:"start_date"`
+	EndDate        string       `json:"end_date"`
+	PreviousSeason string       `json:"previous_season"`
+	CourseType     string       `json:"course_type"`
+	SeasonBests    []SeasonBest `json:"season_bests"`
+}
+
+// StandardAchievement lists the events of a standard achieved by season bests.
+type StandardAchievement struct {
+	StandardID   uuid.UUID `json:"standard_id"`
+	StandardName string    `json:"standard_name"`
+	AgeGroup     string    `json:"age_group"`
+	Events       []string  `json:"events"`
+}
+
+// Summary is the end-of-season review of a swimmer in a course type.
+// PersonalBests counts the swims that were faster than every earlier swim in
+// their event; a first swim in an event counts too.
+type Summary struct {
+	Season            string                `json:"season"`
+	StartDate         string                `json:"start_date"`
+	EndDate           string                `json:"end_date"`
+	CourseType        string                `json:"course_type"`
+	Meets             int                   `json:"meets"`
+	Swims             int                   `json:"swims"`
+	Events            int                   `json:"events"`
+	PersonalBests     int                   `json:"personal_bests"`
+	EventsImproved    int                   `json:"events_improved"`
+	StandardsAchieved []StandardAchievement `json:"standards_achieved"`
+}
+
+// List retrieves the seasons in which the swimmer has results, together with
+// the current season.
+func (s *Service) List(ctx context.Context, swimmerID uuid.UUID) (*SeasonList, error) {
+	dbSwimmer, err := s.swimmerRepo.Get(ctx, swimmerID)
+	if err != nil {
+		return nil, fmt.Errorf("get swimmer: %w", err)
+	}
+	def := swimmer.SeasonDefinition(dbSwimmer)
+
+	rows, err := s.timeRepo.ListResultDates(ctx, swimmerID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := def.SeasonOf(time.Now())
+	seasons := map[string]*Season{current.Label: toSeason(current, current)}
+	meets := make(map[string]map[uuid.UUID]bool)
+	for _, row := range rows {
+		ds := def.SeasonOf(row.Date.Time)
+		season, ok := seasons[ds.Label]
+		if !ok {
+			season = toSeason(ds, current)
+			seasons[ds.Label] = season
+		}
+		if meets[ds.Label] == nil {
+			meets[ds.Label] = make(map[uuid.UUID]bool)
+		}
+		meets[ds.Label][row.MeetID] = true
+		if domain.ResultStatus(row.Status) == domain.ResultOK {
+			season.Swims++
+		}
+	}
+
+	list := &SeasonList{
+		SeasonStart: def.String(),
+		Seasons:     make([]Season, 0, len(seasons)),
+	}
+	for label, season := range seasons {
+		season.Meets = len(meets[label])
+		list.Seasons = append(list.Seasons, *season)
+	}
+	sort.Slice(list.Seasons, func(i, j int) bool {
+		return list.Seasons[i].StartDate > list.Seasons[j].StartDate
+	})
+	return list, nil
+}
+
+// Bests retrieves the season bests of a swimmer in a course type. An empty
+// label selects the current season.
+func (s *Service) Bests(ctx context.Context, swimmerID uuid.UUID, courseType, label string) (*SeasonBestList, error) {
+	dbSwimmer, season, err := s.resolve(ctx, swimmerID, courseType, label)
+	if err != nil {
+		return nil, err
+	}
+	previous := season.Previous(swimmer.SeasonDefinition(dbSwimmer))
+
+	bests, err := s.seasonBests(ctx, swimmerID, courseType, season)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SeasonBestList{
+		Season:         season.Label,
+		StartDate:      season.StartDate.Format("2006-01-02"),
+		EndDate:        season.EndDate.Format("2006-01-02"),
+		PreviousSeason: previous.Label,
+		CourseType:     courseType,
+		SeasonBests:    bests,
+	}, nil
+}
+
+// Summary summarizes a season of a swimmer in a course type: meets swum,
+// swims, personal bests, events improved over the previous season and the
+// standards achieved by season bests in the swimmer's age group. An empty
+// label selects the current season.
+func (s *Service) Summary(ctx context.Context, swimmerID uuid.UUID, courseType, label string) (*Summary, error) {
+	dbSwimmer, season, err := s.resolve(ctx, swimmerID, courseType, label)
+	if err != nil {
+		return nil, err
+	}
+
+	bests, err := s.seasonBests(ctx, swimmerID, courseType, season)
+	if err != nil {
+		return nil, err
+	}
+
+	// All swims up to the end of the season, to find the personal bests
+	swims, err := s.timeRepo.ListOfficialSwims(ctx, swimmerID, courseType, postgres.SwimWindow{
+		End: pgtype.Date{Time: season.EndDate, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{
+		Season:            season.Label,
+		StartDate:         season.StartDate.Format("2006-01-02"),
+		EndDate:           season.EndDate.Format("2006-01-02"),
+		CourseType:        courseType,
+		Events:            len(bests),
+		StandardsAchieved: []StandardAchievement{},
+	}
+
+	meets := make(map[uuid.UUID]bool)
+	for _, swim := range swims {
+		if !swim.Date.Time.Before(season.StartDate) {
+			meets[swim.MeetID] = true
+			summary.Swims++
+		}
+	}
+	summary.Meets = len(meets)
+	summary.PersonalBests = countPersonalBests(swims, season.StartDate)
+
+	for _, best := range bests {
+		if best.ImprovementMS != nil && *best.ImprovementMS > 0 {
+			summary.EventsImproved++
+		}
+	}
+
+	summary.StandardsAchieved, err = s.standardsAchieved(ctx, dbSwimmer, courseType, season, bests)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// resolve validates the course type and returns the swimmer and the season
+// with the given label, or the current season if the label is empty.
+func (s *Service) resolve(ctx context.Context, swimmerID uuid.UUID, courseType, label string) (*db.Swimmer, domain.Season, error) {
+	if !domain.CourseType(courseType).IsValid() {
+		return nil, domain.Season{}, errors.New("validation: course_type must be '25m', '50m' or '25y'")
+	}
+
+	dbSwimmer, err := s.swimmerRepo.Get(ctx, swimmerID)
+	if err != nil {
+		return nil, domain.Season{}, fmt.Errorf("get swimmer: %w", err)
+	}
+	def := swimmer.SeasonDefinition(dbSwimmer)
+
+	if label == "" {
+		return dbSwimmer, def.SeasonOf(time.Now()), nil
+	}
+	season, err := def.Season(label)
+	if err != nil {
+		return nil, domain.Season{}, fmt.Errorf("validation: %w", err)
+	}
+	return dbSwimmer, season, nil
+}
+
+// seasonBests returns the bests of a season compared with the previous season
+// and with the personal bests from before the season.
+func (s *Service) seasonBests(ctx context.Context, swimmerID uuid.UUID, courseType string, season domain.Season) ([]SeasonBest, error) {
+	def := domain.SeasonDefinition{StartMonth: season.StartDate.Month(), StartDay: season.StartDate.Day()}
+	previous := season.Previous(def)
+
+	current, err := s.timeRepo.GetPersonalBestsInWindow(ctx, swimmerID, courseType, window(season.StartDate, season.EndDate))
+	if err != nil {
+		return nil, err
+	}
+	last, err := s.timeRepo.GetPersonalBestsInWindow(ctx, swimmerID, courseType, window(previous.StartDate, previous.EndDate))
+	if err != nil {
+		return nil, err
+	}
+	before, err := s.timeRepo.GetPersonalBestsInWindow(ctx, swimmerID, courseType, postgres.SwimWindow{
+		End: pgtype.Date{Time: previous.EndDate, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lastByEvent := make(map[string]int, len(last))
+	for _, pb := range last {
+		lastByEvent[pb.Event] = int(pb.TimeMs)
+	}
+	beforeByEvent := make(map[string]int, len(before))
+	for _, pb := range before {
+		beforeByEvent[pb.Event] = int(pb.TimeMs)
+	}
+
+	bests := make([]SeasonBest, len(current))
+	for i, pb := range current {
+		timeMS := int(pb.TimeMs)
+		date := ""
+		if pb.MeetDate.Valid {
+			date = pb.MeetDate.Time.Format("2006-01-02")
+		}
+		best := SeasonBest{
+			Event:         pb.Event,
+			TimeMS:        timeMS,
+			TimeFormatted: domain.FormatTime(timeMS),
+			TimeID:        pb.ID.String(),
+			MeetName:      pb.MeetName,
+			Date:          date,
+		}
+
+		prior, hasPrior := beforeByEvent[pb.Event]
+		best.IsPersonalBest = !hasPrior || timeMS < prior
+
+		if lastTime, ok := lastByEvent[pb.Event]; ok {
+			lastFormatted := domain.FormatTime(lastTime)
+			improvement := lastTime - timeMS
+			improvementFormatted := domain.FormatTime(abs(improvement))
+			if improvement < 0 {
+				improvementFormatted = "-" + improvementFormatted
+			}
+			improvementPercent := float64(improvement) / float64(lastTime) * 100
+			best.PreviousSeasonTimeMS = &lastTime
+			best.PreviousSeasonTimeFormatted = &lastFormatted
+			best.ImprovementMS = &improvement
+			best.ImprovementFormatted = &improvementFormatted
+			best.ImprovementPercent = &improvementPercent
+		}
+		bests[i] = best
+	}
+	return bests, nil
+}
+
+// standardsAchieved returns the standards of the swimmer's gender in the
+// course with events achieved by season bests. Each standard is judged in the
+// age group of its scheme the swimmer was in at the end of the season, or
+// today for the current season, falling back to OPEN times. Of a standard
+// family, only the version in effect on that date is judged.
+func (s *Service) standardsAchieved(ctx context.Context, dbSwimmer *db.Swimmer, courseType string, season domain.Season, bests []SeasonBest) ([]StandardAchievement, error) {
+	standards, err := s.standardRepo.List(ctx, postgres.ListStandardsParams{
+		CourseType: &courseType,
+		Gender:     &dbSwimmer.Gender,
+		OwnerID:    dbSwimmer.OwnerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list standards: %w", err)
+	}
+
+	asOf := season.EndDate
+	if now := time.Now(); now.Before(asOf) {
+		asOf = now
+	}
+	standards = standard.EffectiveVersions(standards, asOf)
+
+	achievements := []StandardAchievement{}
+	schemes := make(map[uuid.UUID]*agegroup.Scheme)
+	for _, std := range standards {
+		scheme, ok := schemes[std.AgeGroupSchemeID]
+		if !ok {
+			scheme, err = s.schemes.Get(ctx, std.AgeGroupSchemeID)
+			if err != nil {
+				return nil, fmt.Errorf("get age group scheme: %w", err)
+			}
+			schemes[std.AgeGroupSchemeID] = scheme
+		}
+		ageGroup := string(scheme.GroupForAge(scheme.Age(dbSwimmer.BirthDate.Time, asOf)))
+
+		stdTimes, err := s.standardRepo.ListTimes(ctx, std.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get standard times: %w", err)
+		}
+		byEvent := make(map[string]map[string]int)
+		for _, st := range stdTimes {
+			if byEvent[st.Event] == nil {
+				byEvent[st.Event] = make(map[string]int)
+			}
+			byEvent[st.Event][st.AgeGroup] = int(st.TimeMs)
+		}
+
+		achievement := StandardAchievement{
+			StandardID:   std.ID,
+			StandardName: std.Name,
+			AgeGroup:     ageGroup,
+			Events:       []string{},
+		}
+		for _, best := range bests {
+			stdTime, ok := byEvent[best.Event][ageGroup]
+			if !ok {
+				stdTime, ok = byEvent[best.Event][string(domain.AgeGroupOpen)]
+			}
+			if ok && best.TimeMS <= stdTime {
+				achievement.Events = append(achievement.Events, best.Event)
+			}
+		}
+		if len(achievement.Events) > 0 {
+			achievements = append(achievements, achievement)
+		}
+	}
+	return achievements, nil
+}
+
+// countPersonalBests counts the swims from the given date on that were faster
+// than every earlier swim in their event. Swims are grouped by event with the
+// most recent first.
+func countPersonalBests(swims []db.ListOfficialSwimsRow, from time.Time) int {
+	count := 0
+	for end := len(swims); end > 0; {
+		// Find the start of the event's group
+		start := end - 1
+		for start > 0 && swims[start-1].Event == swims[end-1].Event {
+			start--
+		}
+
+		best := 0
+		for i := end - 1; i >= start; i-- {
+			swim := swims[i]
+			if best == 0 || int(swim.TimeMs) < best {
+				best = int(swim.TimeMs)
+				if !swim.Date.Time.Before(from) {
+					count++
+				}
+			}
+		}
+		end = start
+	}
+	return count
+}
+
+// window returns the swim window between two dates.
+func window(start, end time.Time) postgres.SwimWindow {
+	return postgres.SwimWindow{
+		Start: pgtype.Date{Time: start, Valid: true},
+		End:   pgtype.Date{Time: end, Valid: true},
+	}
+}
+
+func toSeason(season, current domain.Season) *Season {
+	return &Season{
+		Season:    season.Label,
+		StartDate: season.StartDate.Format("2006-01-02"),
+		EndDate:   season.EndDate.Format("2006-01-02"),
+		Current:   season.Label == current.Label,
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,453 @@
+package standard
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// CSVTimeFormat is the format of the times in a CSV file.
+type CSVTimeFormat string
+
+const (
+	// CSVTimeClock is a time such as "1:05.32" or "28.45" (default).
+	CSVTimeClock CSVTimeFormat = "clock"
+	// CSVTimeSeconds is a number of seconds such as "65.32".
+	CSVTimeSeconds CSVTimeFormat = "seconds"
+	// CSVTimeHundredths is a whole number of hundredths such as "6532".
+	CSVTimeHundredths CSVTimeFormat = "hundredths"
+)
+
+// IsValid checks if the time format is valid.
+func (f CSVTimeFormat) IsValid() bool {
+	return f == CSVTimeClock || f == CSVTimeSeconds || f == CSVTimeHundredths
+}
+
+// CSVMapping describes the columns of a CSV file of standard times, one row
+// per event. Columns are named by the header row, case-insensitively.
+// AgeGroupColumns maps columns to age groups of the scheme (e.g. "13-14" to
+// "13-14" or "Open" to "OPEN"); when empty, every column other than the event,
+// gender and course columns is an age group named by its header. Without a
+// gender or course column, every row has the gender or course of the import.
+type CSVMapping struct {
+	EventColumn     string            `json:"event_column,omitempty"` // defaults to "Event"
+	AgeGroupColumns map[string]string `json:"age_group_columns,omitempty"`
+	GenderColumn    string            `json:"gender_column,omitempty"`
+	CourseColumn    string            `json:"course_column,omitempty"`
+	TimeFormat      CSVTimeFormat     `json:"time_format,omitempty"` // defaults to "clock"
+	Delimiter       string            `json:"delimiter,omitempty"`   // defaults to ","
+}
+
+// CSVImportInput represents a CSV file of standard times to import. A file
+// with gender or course columns creates a standard per gender and course
+// found, named after the standard with the gender and course appended (e.g.
+// "OSC 2026 (female, 25m)").
+type CSVImportInput struct {
+	Name           string     `json:"name"`
+	Description    string     `json:"description,omitempty"`
+	CourseType     string     `json:"course_type,omitempty"`
+	Gender         string     `json:"gender,omitempty"`
+	AgeGroupScheme string     `json:"age_group_scheme,omitempty"`
+	Mapping        CSVMapping `json:"mapping"`
+	CSV            string     `json:"csv"`
+	QualifyingRules
+}
+
+// CSVImportResult contains the results of a CSV file import. Cells that
+// cannot be imported are reported in the errors without blocking the import.
+type CSVImportResult struct {
+	Standards []StandardWithTimes `json:"standards"`
+	Imported  int                 `json:"imported"`
+	Errors    []string            `json:"errors,omitempty"`
+}
+
+// csvGroup collects the times of a gender and course of a CSV file.
+type csvGroup struct {
+	courseType string
+	gender     string
+	times      []StandardTimeInput
+}
+
+// ImportFromCSV imports the standards of a CSV file as standards of the
+// owner. The standards are created in a single transaction.
+func (s *Service) ImportFromCSV(ctx context.Context, ownerID string, input CSVImportInput) (*CSVImportResult, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return nil, errors.New("validation: name is required")
+	}
+	mapping := input.Mapping
+	if mapping.EventColumn == "" {
+		mapping.EventColumn = "Event"
+	}
+	if mapping.TimeFormat == "" {
+		mapping.TimeFormat = CSVTimeClock
+	}
+	if !mapping.TimeFormat.IsValid() {
+		return nil, errors.New("validation: time_format must be 'clock', 'seconds' or 'hundredths'")
+	}
+	delimiter := ','
+	if mapping.Delimiter != "" {
+		runes := []rune(mapping.Delimiter)
+		if len(runes) != 1 {
+			return nil, errors.New("validation: delimiter must be a single character")
+		}
+		delimiter = runes[0]
+	}
+	if mapping.GenderColumn == "" && input.Gender != "female" && input.Gender != "male" {
+		return nil, errors.New("validation: gender must be 'female' or 'male' without a gender column")
+	}
+	if mapping.CourseColumn == "" && !domain.CourseType(input.CourseType).IsValid() {
+		return nil, errors.New("validation: course_type must be '25m', '50m' or '25y' without a course column")
+	}
+	input.QualifyingRules.Sanitize()
+	if err := input.QualifyingRules.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+
+	scheme, err := s.schemes.Resolve(ctx, input.AgeGroupScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(input.CSV, "\ufeff")))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("validation: invalid CSV: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, errors.New("validation: the CSV file needs a header row and at least one row of times")
+	}
+
+	// Locate the columns
+	header := records[0]
+	column := func(name string) int {
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(name)) {
+				return i
+			}
+		}
+		return -1
+	}
+	eventCol := column(mapping.EventColumn)
+	if eventCol < 0 {
+		return nil, fmt.Errorf("validation: event column '%s' not found", mapping.EventColumn)
+	}
+	genderCol, courseCol := -1, -1
+	if mapping.GenderColumn != "" {
+		if genderCol = column(mapping.GenderColumn); genderCol < 0 {
+			return nil, fmt.Errorf("validation: gender column '%s' not found", mapping.GenderColumn)
+		}
+	}
+	if mapping.CourseColumn != "" {
+		if courseCol = column(mapping.CourseColumn); courseCol < 0 {
+			return nil, fmt.Errorf("validation: course column '%s' not found", mapping.CourseColumn)
+		}
+	}
+
+	result := &CSVImportResult{Standards: make([]StandardWithTimes, 0)}
+	ageGroups := make(map[int]domain.AgeGroup)
+	if len(mapping.AgeGroupColumns) > 0 {
+		for name, label := range mapping.AgeGroupColumns {
+			col := column(name)
+			if col < 0 {
+				return nil, fmt.Errorf("validation: age group column '%s' not found", name)
+			}
+			ageGroup, ok := scheme.Normalize(label)
+			if !ok {
+				return nil, fmt.Errorf("validation: unknown age group '%s' for %s", label, scheme.Name)
+			}
+			ageGroups[col] = ageGroup
+		}
+	} else {
+		for col, name := range header {
+			if col == eventCol || col == genderCol || col == courseCol || strings.TrimSpace(name) == "" {
+				continue
+			}
+			ageGroup, ok := scheme.Normalize(name)
+			if !ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("column '%s': unknown age group for %s", name, scheme.Name))
+				continue
+			}
+			ageGroups[col] = ageGroup
+		}
+	}
+	if len(ageGroups) == 0 {
+		return nil, errors.New("validation: no age group columns found")
+	}
+	cols := make([]int, 0, len(ageGroups))
+	for col := range ageGroups {
+		cols = append(cols, col)
+	}
+	sort.Ints(cols)
+
+	// Collect the times of each gender and course
+	var groups []*csvGroup
+	groupIndex := make(map[string]*csvGroup)
+	cell := func(record []string, col int) string {
+		if col < 0 || col >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[col])
+	}
+	for idx, record := range records[1:] {
+		line := idx + 2
+		rawEvent := cell(record, eventCol)
+		if rawEvent == "" {
+			continue // blank or separator row
+		}
+
+		gender := input.Gender
+		if genderCol >= 0 {
+			var ok bool
+			if gender, ok = parseCSVGender(cell(record, genderCol)); !ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("line %d: unknown gender '%s'", line, cell(record, genderCol)))
+				continue
+			}
+		}
+		courseType := input.CourseType
+		if courseCol >= 0 {
+			var ok bool
+			if courseType, ok = parseCSVCourse(cell(record, courseCol)); !ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("line %d: unknown course '%s'", line, cell(record, courseCol)))
+				continue
+			}
+		}
+		event, ok := parseCSVEvent(rawEvent)
+		if !ok || !domain.EventCode(event).IsValidForCourse(domain.CourseType(courseType)) {
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d: unknown event '%s' for %s", line, rawEvent, courseType))
+			continue
+		}
+
+		key := gender + "/" + courseType
+		group, ok := groupIndex[key]
+		if !ok {
+			group = &csvGroup{courseType: courseType, gender: gender}
+			groupIndex[key] = group
+			groups = append(groups, group)
+		}
+		for _, col := range cols {
+			value := cell(record, col)
+			if value == "" || value == "-" {
+				continue // No time for this age group
+			}
+			timeMs, err := parseCSVTime(value, mapping.TimeFormat)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("line %d: %s %s: invalid time '%s'", line, event, ageGroups[col], value))
+				continue
+			}
+			group.times = append(group.times, StandardTimeInput{
+				Event:    event,
+				AgeGroup: string(ageGroups[col]),
+				TimeMs:   timeMs,
+			})
+		}
+	}
+
+	var inputs []ImportInput
+	for _, group := range groups {
+		if len(group.times) == 0 {
+			continue
+		}
+		name := input.Name
+		if genderCol >= 0 || courseCol >= 0 {
+			name = fmt.Sprintf("%s (%s, %s)", input.Name, group.gender, group.courseType)
+		}
+		inputs = append(inputs, ImportInput{
+			Name:             name,
+			Description:      input.Description,
+			CourseType:       group.courseType,
+			Gender:           group.gender,
+			AgeGroupSchemeID: &scheme.ID,
+			QualifyingRules:  input.QualifyingRules,
+			Times:            group.times,
+		})
+	}
+	if len(inputs) == 0 {
+		return nil, errors.New("validation: no valid times found")
+	}
+
+	err = postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		txService := s.WithTx(tx)
+		for _, importInput := range inputs {
+			std, err := txService.Import(ctx, ownerID, importInput)
+			if err != nil {
+				return err
+			}
+			result.Standards = append(result.Standards, *std)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Imported = len(result.Standards)
+	return result, nil
+}
+
+// ExportCSV returns the times of a standard as a CSV file with a row per event
+// and a column per age group of its scheme, which imports back with the
+// default mapping.
+func (s *Service) ExportCSV(ctx context.Context, ownerID string, id uuid.UUID) (*Standard, []byte, error) {
+	std, err := s.GetWithTimes(ctx, ownerID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	scheme, err := s.scheme(ctx, &std.AgeGroupSchemeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, std, scheme.AgeGroupScheme); err != nil {
+		return nil, nil, fmt.Errorf("write standard CSV: %w", err)
+	}
+	return &std.Standard, buf.Bytes(), nil
+}
+
+// WriteCSV writes the times of a standard as CSV, ordering the age group
+// columns as the scheme does.
+func WriteCSV(w io.Writer, std *StandardWithTimes, scheme domain.AgeGroupScheme) error {
+	order := make(map[string]int, len(scheme.Groups)+1)
+	for i, g := range scheme.Groups {
+		order[string(g.Code)] = i
+	}
+	if _, ok := order[string(domain.AgeGroupOpen)]; !ok {
+		order[string(domain.AgeGroupOpen)] = len(scheme.Groups)
+	}
+
+	var ageGroups, events []string
+	seen := make(map[string]bool)
+	times := make(map[string]map[string]int)
+	for _, t := range std.Times {
+		if _, ok := times[t.Event]; !ok {
+			times[t.Event] = make(map[string]int)
+			events = append(events, t.Event)
+		}
+		if !seen[t.AgeGroup] {
+			seen[t.AgeGroup] = true
+			ageGroups = append(ageGroups, t.AgeGroup)
+		}
+		times[t.Event][t.AgeGroup] = t.TimeMs
+	}
+	sort.SliceStable(ageGroups, func(i, j int) bool {
+		oi, iKnown := order[ageGroups[i]]
+		oj, jKnown := order[ageGroups[j]]
+		if iKnown != jKnown {
+			return iKnown
+		}
+		return oi < oj
+	})
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append([]string{"Event"}, ageGroups...)); err != nil {
+		return err
+	}
+	for _, event := range events {
+		row := []string{event}
+		for _, ageGroup := range ageGroups {
+			if ms, ok := times[event][ageGroup]; ok {
+				row = append(row, domain.FormatTime(ms))
+			} else {
+				row = append(row, "")
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvStrokes maps stroke names to event code suffixes.
+var csvStrokes = map[string]string{
+	"FR": "FR", "FREE": "FR", "FREESTYLE": "FR",
+	"BK": "BK", "BACK": "BK", "BACKSTROKE": "BK",
+	"BR": "BR", "BREAST": "BR", "BREASTSTROKE": "BR",
+	"FL": "FL", "FLY": "FL", "BUTTERFLY": "FL",
+	"IM": "IM", "MEDLEY": "IM", "INDIVIDUAL MEDLEY": "IM",
+}
+
+// csvDistanceUnits lists the units that may follow the distance of an event.
+var csvDistanceUnits = []string{"METRES", "METERS", "METRE", "METER", "YARDS", "YARD", "M", "Y"}
+
+// parseCSVEvent maps an event name of a spreadsheet, such as "50FR",
+// "100 Free", "200m Backstroke" or "400 IM", to its event code.
+func parseCSVEvent(s string) (string, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if i <= 0 {
+		return "", false
+	}
+	distance, name := s[:i], strings.Join(strings.Fields(s[i:]), " ")
+	if stroke, ok := csvStrokes[name]; ok {
+		return distance + stroke, true
+	}
+	for _, unit := range csvDistanceUnits {
+		if rest, ok := strings.CutPrefix(name, unit); ok {
+			if stroke, ok := csvStrokes[strings.TrimSpace(rest)]; ok {
+				return distance + stroke, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseCSVGender maps a gender label of a spreadsheet to a gender.
+func parseCSVGender(s string) (string, bool) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "F", "FEMALE", "W", "WOMEN", "G", "GIRLS":
+		return "female", true
+	case "M", "MALE", "MEN", "B", "BOYS":
+		return "male", true
+	}
+	return "", false
+}
+
+// parseCSVCourse maps a course label of a spreadsheet to a course type.
+func parseCSVCourse(s string) (string, bool) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "25M", "SC", "SCM", "SHORT COURSE":
+		return "25m", true
+	case "50M", "LC", "LCM", "LONG COURSE":
+		return "50m", true
+	case "25Y", "SCY", "YARDS":
+		return "25y", true
+	}
+	return "", false
+}
+
+// parseCSVTime parses a time of a CSV file to milliseconds.
+func parseCSVTime(s string, format CSVTimeFormat) (int, error) {
+	switch format {
+	case CSVTimeSeconds:
+		seconds, err := strconv.ParseFloat(s, 64)
+		if err != nil || seconds <= 0 {
+			return 0, fmt.Errorf("cannot parse seconds: %s", s)
+		}
+		return int(math.Round(seconds * 1000)), nil
+	case CSVTimeHundredths:
+		hundredths, err := strconv.Atoi(s)
+		if err != nil || hundredths <= 0 {
+			return 0, fmt.Errorf("cannot parse hundredths: %s", s)
+		}
+		return hundredths * 10, nil
+	default:
+		return domain.ParseTime(s)
+	}
+}
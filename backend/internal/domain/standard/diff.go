@@ -0,0 +1,197 @@
+package standard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/store/db"
+)
+
+// ChangeStatus describes how a standard time changed between two versions.
+type ChangeStatus string
+
+const (
+	ChangeFaster    ChangeStatus = "faster"
+	ChangeSlower    ChangeStatus = "slower"
+	ChangeUnchanged ChangeStatus = "unchanged"
+	ChangeAdded     ChangeStatus = "added"
+	ChangeRemoved   ChangeStatus = "removed"
+)
+
+// TimeChange is the change of the time of an event and age group. The change
+// is only set when both versions have a time; a negative change is faster.
+type TimeChange struct {
+	Event             string       `json:"event"`
+	AgeGroup          string       `json:"age_group"`
+	Status            ChangeStatus `json:"status"`
+	FromTimeMS        *int         `json:"from_time_ms"`
+	FromTimeFormatted *string      `json:"from_time_formatted"`
+	ToTimeMS          *int         `json:"to_time_ms"`
+	ToTimeFormatted   *string      `json:"to_time_formatted"`
+	ChangeMS          *int         `json:"change_ms"`
+	ChangeFormatted   *string      `json:"change_formatted"`
+	ChangePercent     *float64     `json:"change_percent"`
+}
+
+// DiffSummary counts the times of a diff by change.
+type DiffSummary struct {
+	Faster    int `json:"faster"`
+	Slower    int `json:"slower"`
+	Unchanged int `json:"unchanged"`
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+}
+
+// Diff compares the times of two versions of a standard side by side.
+type Diff struct {
+	From    Standard     `json:"from"`
+	To      Standard     `json:"to"`
+	Changes []TimeChange `json:"changes"`
+	Summary DiffSummary  `json:"summary"`
+}
+
+// Diff compares the times of a standard with those of another one of the same
+// course, by default the previous version of its family. Both must be
+// standards the owner sees.
+func (s *Service) Diff(ctx context.Context, ownerID string, id uuid.UUID, fromID *uuid.UUID) (*Diff, error) {
+	to, err := s.get(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var from *db.TimeStandard
+	if fromID != nil {
+		from, err = s.get(ctx, ownerID, *fromID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		from, err = s.previousVersion(ctx, to)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if from.CourseType != to.CourseType {
+		return nil, errors.New("validation: standards of different courses cannot be compared")
+	}
+
+	fromTimes, err := s.repo.ListTimes(ctx, from.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get standard times: %w", err)
+	}
+	toTimes, err := s.repo.ListTimes(ctx, to.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get standard times: %w", err)
+	}
+
+	changes, summary := diffTimes(to.CourseType, fromTimes, toTimes)
+	return &Diff{
+		From:    *toStandard(from),
+		To:      *toStandard(to),
+		Changes: changes,
+		Summary: summary,
+	}, nil
+}
+
+// diffTimes compares two sets of standard times of a course by event and age
+// group.
+func diffTimes(courseType string, fromTimes, toTimes []db.StandardTime) ([]TimeChange, DiffSummary) {
+	type key struct{ event, ageGroup string }
+	var keys []key
+	fromMap := make(map[key]int, len(fromTimes))
+	toMap := make(map[key]int, len(toTimes))
+	for _, t := range fromTimes {
+		k := key{t.Event, t.AgeGroup}
+		fromMap[k] = int(t.TimeMs)
+		keys = append(keys, k)
+	}
+	for _, t := range toTimes {
+		k := key{t.Event, t.AgeGroup}
+		toMap[k] = int(t.TimeMs)
+		if _, ok := fromMap[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	// Times are listed in event order, added age groups after the others
+	eventOrder := make(map[string]int)
+	for i, event := range domain.EventsForCourse(domain.CourseType(courseType)) {
+		eventOrder[string(event)] = i
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return eventOrder[keys[i].event] < eventOrder[keys[j].event]
+	})
+
+	var summary DiffSummary
+	changes := make([]TimeChange, 0, len(keys))
+	for _, k := range keys {
+		change := TimeChange{Event: k.event, AgeGroup: k.ageGroup}
+		fromMS, hasFrom := fromMap[k]
+		toMS, hasTo := toMap[k]
+		if hasFrom {
+			formatted := domain.FormatTime(fromMS)
+			change.FromTimeMS = &fromMS
+			change.FromTimeFormatted = &formatted
+		}
+		if hasTo {
+			formatted := domain.FormatTime(toMS)
+			change.ToTimeMS = &toMS
+			change.ToTimeFormatted = &formatted
+		}
+
+		switch {
+		case !hasTo:
+			change.Status = ChangeRemoved
+			summary.Removed++
+		case !hasFrom:
+			change.Status = ChangeAdded
+			summary.Added++
+		default:
+			changeMS := toMS - fromMS
+			changeFormatted := domain.TimeDifference(toMS, fromMS)
+			changePercent := domain.TimeDifferencePercent(toMS, fromMS)
+			change.ChangeMS = &changeMS
+			change.ChangeFormatted = &changeFormatted
+			change.ChangePercent = &changePercent
+			switch {
+			case changeMS < 0:
+				change.Status = ChangeFaster
+				summary.Faster++
+			case changeMS > 0:
+				change.Status = ChangeSlower
+				summary.Slower++
+			default:
+				change.Status = ChangeUnchanged
+				summary.Unchanged++
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, summary
+}
+
+// previousVersion returns the version of a standard's family effective before
+// it.
+func (s *Service) previousVersion(ctx context.Context, std *db.TimeStandard) (*db.TimeStandard, error) {
+	if !std.FamilyID.Valid {
+		return nil, fmt.Errorf("validation: %s is not a version of a standard family; choose a standard to compare with", std.Name)
+	}
+	versions, err := s.repo.ListVersions(ctx, uuid.UUID(std.FamilyID.Bytes))
+	if err != nil {
+		return nil, err
+	}
+	for i := range versions {
+		if versions[i].ID == std.ID {
+			if i == 0 {
+				return nil, fmt.Errorf("validation: %s is the first version of its family", std.Name)
+			}
+			return &versions[i-1], nil
+		}
+	}
+	return nil, fmt.Errorf("validation: %s is the first version of its family", std.Name)
+}
@@ -0,0 +1,308 @@
+package standard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/bpg/swimstats/backend/internal/store/db"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// Family is a logical standard (e.g. OSC) with its versions, earliest first.
+// Families belong to the owner of their versions, or are shared.
+type Family struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	CourseType  string     `json:"course_type"`
+	Gender      string     `json:"gender"`
+	Shared      bool       `json:"shared"`
+	Versions    []Standard `json:"versions"`
+}
+
+// FamilyList represents a list of standard families.
+type FamilyList struct {
+	Families []Family `json:"families"`
+}
+
+// FamilyInput represents input for creating/updating a standard family.
+// The course type and gender of a family cannot be changed.
+type FamilyInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	CourseType  string `json:"course_type"`
+	Gender      string `json:"gender"`
+}
+
+// Sanitize trims whitespace from string fields.
+func (i *FamilyInput) Sanitize() {
+	i.Name = strings.TrimSpace(i.Name)
+	i.Description = strings.TrimSpace(i.Description)
+	i.CourseType = strings.TrimSpace(i.CourseType)
+	i.Gender = strings.TrimSpace(i.Gender)
+}
+
+// Validate validates the family input. Call Sanitize() first.
+func (i FamilyInput) Validate() error {
+	input := Input{Name: i.Name, CourseType: i.CourseType, Gender: i.Gender}
+	return input.Validate()
+}
+
+// VersionRef places a standard in a family of its course and gender by name
+// in files, where family IDs are not portable. The family is created when it
+// does not exist.
+type VersionRef struct {
+	Family        string `json:"family,omitempty"`
+	Version       string `json:"version,omitempty"`
+	EffectiveFrom string `json:"effective_from,omitempty"` // YYYY-MM-DD, required with a family
+}
+
+// GetFamily retrieves a standard family with its versions, returning
+// postgres.ErrNotFound if the owner does not see it.
+func (s *Service) GetFamily(ctx context.Context, ownerID string, id uuid.UUID) (*Family, error) {
+	dbFamily, err := s.getFamily(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.repo.ListVersions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toFamily(dbFamily, versions), nil
+}
+
+// ListFamilies retrieves the shared standard families and those of the owner
+// matching the filter.
+func (s *Service) ListFamilies(ctx context.Context, ownerID string, params ListParams) (*FamilyList, error) {
+	filter := postgres.ListStandardsParams{CourseType: params.CourseType, Gender: params.Gender, OwnerID: ownerID}
+	dbFamilies, err := s.repo.ListFamilies(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	dbStandards, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list standards: %w", err)
+	}
+
+	versions := make(map[uuid.UUID][]db.TimeStandard)
+	for _, std := range dbStandards {
+		if std.FamilyID.Valid {
+			familyID := uuid.UUID(std.FamilyID.Bytes)
+			versions[familyID] = append(versions[familyID], std)
+		}
+	}
+
+	families := make([]Family, len(dbFamilies))
+	for i, f := range dbFamilies {
+		familyVersions := versions[f.ID]
+		sort.Slice(familyVersions, func(a, b int) bool {
+			return familyVersions[a].EffectiveFrom.Time.Before(familyVersions[b].EffectiveFrom.Time)
+		})
+		families[i] = *toFamily(&f, familyVersions)
+	}
+	return &FamilyList{Families: families}, nil
+}
+
+// CreateFamily creates a new standard family of the owner without versions.
+func (s *Service) CreateFamily(ctx context.Context, ownerID string, input FamilyInput) (*Family, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+	if err := s.checkFamilyName(ctx, ownerID, input, uuid.UUID{}); err != nil {
+		return nil, err
+	}
+
+	var description pgtype.Text
+	if input.Description != "" {
+		description = pgtype.Text{String: input.Description, Valid: true}
+	}
+	dbFamily, err := s.repo.CreateFamily(ctx, db.CreateStandardFamilyParams{
+		Name:        input.Name,
+		Description: description,
+		CourseType:  input.CourseType,
+		Gender:      input.Gender,
+		OwnerID:     ownerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toFamily(dbFamily, nil), nil
+}
+
+// UpdateFamily renames a standard family of the owner or changes its
+// description.
+func (s *Service) UpdateFamily(ctx context.Context, ownerID string, id uuid.UUID, input FamilyInput) (*Family, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+
+	existing, err := s.getOwnedFamily(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if input.CourseType != existing.CourseType || input.Gender != existing.Gender {
+		return nil, errors.New("validation: the course type and gender of a family cannot be changed")
+	}
+	if err := s.checkFamilyName(ctx, existing.OwnerID, input, id); err != nil {
+		return nil, err
+	}
+
+	var description pgtype.Text
+	if input.Description != "" {
+		description = pgtype.Text{String: input.Description, Valid: true}
+	}
+	dbFamily, err := s.repo.UpdateFamily(ctx, db.UpdateStandardFamilyParams{
+		ID:          id,
+		Name:        input.Name,
+		Description: description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.repo.ListVersions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toFamily(dbFamily, versions), nil
+}
+
+// DeleteFamily deletes a standard family of the owner. Its versions are kept
+// as standalone standards.
+func (s *Service) DeleteFamily(ctx context.Context, ownerID string, id uuid.UUID) error {
+	if _, err := s.getOwnedFamily(ctx, ownerID, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteFamily(ctx, id)
+}
+
+// ResolveVersion returns the versioning a file's version reference stands
+// for, creating its family when the owner has no family of that name for the
+// course type and gender.
+func (s *Service) ResolveVersion(ctx context.Context, ownerID string, ref VersionRef, courseType, gender string) (Versioning, error) {
+	ref.Family = strings.TrimSpace(ref.Family)
+	if ref.Family == "" {
+		return Versioning{Version: ref.Version, EffectiveFrom: ref.EffectiveFrom}, nil
+	}
+
+	dbFamily, err := s.repo.GetFamilyByName(ctx, ownerID, ref.Family, courseType, gender)
+	if errors.Is(err, postgres.ErrNotFound) {
+		family, err := s.CreateFamily(ctx, ownerID, FamilyInput{Name: ref.Family, CourseType: courseType, Gender: gender})
+		if err != nil {
+			return Versioning{}, err
+		}
+		return Versioning{FamilyID: &family.ID, Version: ref.Version, EffectiveFrom: ref.EffectiveFrom}, nil
+	}
+	if err != nil {
+		return Versioning{}, err
+	}
+	return Versioning{FamilyID: &dbFamily.ID, Version: ref.Version, EffectiveFrom: ref.EffectiveFrom}, nil
+}
+
+// checkFamilyName checks that no other family of the owner, course and
+// gender has the name.
+func (s *Service) checkFamilyName(ctx context.Context, ownerID string, input FamilyInput, excludeID uuid.UUID) error {
+	exists, err := s.repo.FamilyNameExists(ctx, ownerID, input.Name, input.CourseType, input.Gender, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("validation: a standard family with this name already exists for the course and gender")
+	}
+	return nil
+}
+
+// getFamily retrieves a standard family the owner sees.
+func (s *Service) getFamily(ctx context.Context, ownerID string, id uuid.UUID) (*db.StandardFamily, error) {
+	dbFamily, err := s.repo.GetFamily(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !Visible(dbFamily.OwnerID, ownerID) {
+		return nil, postgres.ErrNotFound
+	}
+	return dbFamily, nil
+}
+
+// getOwnedFamily retrieves a standard family the owner may change, returning
+// ErrShared for shared families.
+func (s *Service) getOwnedFamily(ctx context.Context, ownerID string, id uuid.UUID) (*db.StandardFamily, error) {
+	dbFamily, err := s.getFamily(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != "" && dbFamily.OwnerID != ownerID {
+		return nil, ErrShared
+	}
+	return dbFamily, nil
+}
+
+// EffectiveVersions returns the standards in effect on the given date: every
+// standard outside a family, and for each family the latest version effective
+// on or before the date. Before its first version takes effect, a family is
+// represented by that version.
+func EffectiveVersions(standards []db.TimeStandard, date time.Time) []db.TimeStandard {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	effective := make(map[uuid.UUID]int) // family -> index of its effective version
+	for i, std := range standards {
+		if !std.FamilyID.Valid {
+			continue
+		}
+		familyID := uuid.UUID(std.FamilyID.Bytes)
+		j, ok := effective[familyID]
+		if !ok || supersedes(std, standards[j], day) {
+			effective[familyID] = i
+		}
+	}
+
+	result := make([]db.TimeStandard, 0, len(standards))
+	for i, std := range standards {
+		if !std.FamilyID.Valid || effective[uuid.UUID(std.FamilyID.Bytes)] == i {
+			result = append(result, std)
+		}
+	}
+	return result
+}
+
+// supersedes reports whether version a rather than b of a family is effective
+// on the day.
+func supersedes(a, b db.TimeStandard, day time.Time) bool {
+	aStarted := !a.EffectiveFrom.Time.After(day)
+	bStarted := !b.EffectiveFrom.Time.After(day)
+	if aStarted != bStarted {
+		return aStarted
+	}
+	if aStarted {
+		return a.EffectiveFrom.Time.After(b.EffectiveFrom.Time)
+	}
+	return a.EffectiveFrom.Time.Before(b.EffectiveFrom.Time)
+}
+
+func toFamily(dbFamily *db.StandardFamily, versions []db.TimeStandard) *Family {
+	description := ""
+	if dbFamily.Description.Valid {
+		description = dbFamily.Description.String
+	}
+	family := &Family{
+		ID:          dbFamily.ID,
+		Name:        dbFamily.Name,
+		Description: description,
+		CourseType:  dbFamily.CourseType,
+		Gender:      dbFamily.Gender,
+		Shared:      dbFamily.OwnerID == "",
+		Versions:    make([]Standard, len(versions)),
+	}
+	for i := range versions {
+		family.Versions[i] = *toStandard(&versions[i])
+	}
+	return family
+}
@@ -0,0 +1,313 @@
+package standard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/store/db"
+	"github.com/bpg/swimstats/backend/internal/store/postgres"
+)
+
+// ImportMode controls how a JSON file import treats standards that already
+// exist. Existing standards keep their ID, name, flags and settings; only
+// their times change.
+type ImportMode string
+
+const (
+	// ImportModeSkip leaves existing standards untouched (default).
+	ImportModeSkip ImportMode = "skip"
+	// ImportModeReplace makes the times of existing standards those of the
+	// file, removing times the file does not have.
+	ImportModeReplace ImportMode = "replace"
+	// ImportModeMerge adds and updates the times of existing standards,
+	// keeping times the file does not have.
+	ImportModeMerge ImportMode = "merge"
+)
+
+// IsValid checks if the import mode is valid.
+func (m ImportMode) IsValid() bool {
+	return m == ImportModeSkip || m == ImportModeReplace || m == ImportModeMerge
+}
+
+// JSONImportOptions contains options for importing a JSON file.
+type JSONImportOptions struct {
+	Mode   ImportMode
+	DryRun bool // report the changes without making them
+}
+
+// ImportAction is what an import does with a standard of the file.
+type ImportAction string
+
+const (
+	ImportActionCreate    ImportAction = "create"
+	ImportActionUpdate    ImportAction = "update"
+	ImportActionUnchanged ImportAction = "unchanged"
+)
+
+// StandardImportChange lists the times an import inserts, updates and removes
+// for a standard of the file. StandardID is set for existing standards and,
+// after the import, for created ones.
+type StandardImportChange struct {
+	Code       string       `json:"code"`
+	Name       string       `json:"name"`
+	StandardID *uuid.UUID   `json:"standard_id,omitempty"`
+	Action     ImportAction `json:"action"`
+	Inserts    []TimeChange `json:"inserts"`
+	Updates    []TimeChange `json:"updates"`
+	Removals   []TimeChange `json:"removals"`
+
+	meta     JSONStandardMeta
+	times    []StandardTimeInput
+	existing *db.TimeStandard
+}
+
+// planJSONImport works out the changes a JSON file makes without making them.
+// Standards that cannot be imported are skipped and reported in the errors.
+func (s *Service) planJSONImport(ctx context.Context, ownerID string, input JSONFileInput, scheme *agegroup.Scheme, opts JSONImportOptions) (*JSONImportResult, error) {
+	result := &JSONImportResult{
+		Mode:      opts.Mode,
+		DryRun:    opts.DryRun,
+		Standards: make([]StandardWithTimes, 0),
+		Changes:   make([]StandardImportChange, 0, len(input.Standards)),
+	}
+
+	codes := make([]string, 0, len(input.Standards))
+	for code := range input.Standards {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	names := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		meta := input.Standards[code]
+
+		// Build the standard name
+		name := meta.Name
+		if name == "" {
+			name = fmt.Sprintf("%s %s %s", input.Source, code, input.Season)
+		}
+		if names[name] {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: standard '%s' appears more than once in the file", code, name))
+			result.Skipped++
+			continue
+		}
+		names[name] = true
+
+		times := input.timesFor(code, scheme, result)
+		if len(times) == 0 {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: no valid times found", code))
+			result.Skipped++
+			continue
+		}
+
+		change := StandardImportChange{Code: code, Name: name, meta: meta, times: times}
+		existing, err := s.repo.GetByName(ctx, ownerID, name)
+		switch {
+		case errors.Is(err, postgres.ErrNotFound):
+			change.Action = ImportActionCreate
+			change.Inserts, _ = diffTimes(input.CourseType, nil, toDBTimes(times))
+			change.Updates = []TimeChange{}
+			change.Removals = []TimeChange{}
+			result.Imported++
+		case err != nil:
+			return nil, err
+		case opts.Mode == ImportModeSkip:
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: standard '%s' already exists", code, name))
+			result.Skipped++
+			continue
+		case existing.CourseType != input.CourseType || existing.Gender != input.Gender:
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: standard '%s' exists for another course or gender", code, name))
+			result.Skipped++
+			continue
+		default:
+			// The times must use the age groups of the existing standard
+			if existing.AgeGroupSchemeID != scheme.ID {
+				existingScheme, err := s.scheme(ctx, &existing.AgeGroupSchemeID)
+				if err != nil {
+					return nil, err
+				}
+				if err := validateAgeGroups(times, existingScheme); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", code, err))
+					result.Skipped++
+					continue
+				}
+			}
+			dbTimes, err := s.repo.ListTimes(ctx, existing.ID)
+			if err != nil {
+				return nil, fmt.Errorf("get standard times: %w", err)
+			}
+
+			change.StandardID = &existing.ID
+			change.existing = existing
+			change.Inserts = []TimeChange{}
+			change.Updates = []TimeChange{}
+			change.Removals = []TimeChange{}
+			timeChanges, _ := diffTimes(input.CourseType, dbTimes, toDBTimes(times))
+			for _, tc := range timeChanges {
+				switch tc.Status {
+				case ChangeAdded:
+					change.Inserts = append(change.Inserts, tc)
+				case ChangeFaster, ChangeSlower:
+					change.Updates = append(change.Updates, tc)
+				case ChangeRemoved:
+					if opts.Mode == ImportModeReplace {
+						change.Removals = append(change.Removals, tc)
+					}
+				}
+			}
+
+			if len(change.Inserts)+len(change.Updates)+len(change.Removals) == 0 {
+				change.Action = ImportActionUnchanged
+				result.Unchanged++
+			} else {
+				change.Action = ImportActionUpdate
+				result.Updated++
+			}
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	return result, nil
+}
+
+// applyJSONImport makes the planned changes of a JSON file. Any failure
+// aborts the import.
+func (s *Service) applyJSONImport(ctx context.Context, ownerID string, input JSONFileInput, scheme *agegroup.Scheme, effectiveFrom string, result *JSONImportResult) error {
+	for i := range result.Changes {
+		change := &result.Changes[i]
+		switch change.Action {
+		case ImportActionCreate:
+			importInput := ImportInput{
+				Name:             change.Name,
+				Description:      change.meta.Description,
+				CourseType:       input.CourseType,
+				Gender:           input.Gender,
+				AgeGroupSchemeID: &scheme.ID,
+				QualifyingRules:  input.QualifyingRules,
+				Times:            change.times,
+			}
+
+			// Add the standard as a new version of its family
+			if effectiveFrom != "" {
+				family := change.meta.Family
+				if family == "" {
+					family = strings.TrimSpace(fmt.Sprintf("%s %s", input.Source, change.Code))
+				}
+				versioning, err := s.ResolveVersion(ctx, ownerID, VersionRef{
+					Family:        family,
+					Version:       input.Season,
+					EffectiveFrom: effectiveFrom,
+				}, input.CourseType, input.Gender)
+				if err != nil {
+					return importError(change.Code, err)
+				}
+				importInput.Versioning = versioning
+			}
+
+			std, err := s.Import(ctx, ownerID, importInput)
+			if err != nil {
+				return importError(change.Code, err)
+			}
+			change.StandardID = &std.ID
+			result.Standards = append(result.Standards, *std)
+
+		case ImportActionUpdate:
+			id := change.existing.ID
+			upserts := append(append([]TimeChange{}, change.Inserts...), change.Updates...)
+			err := s.regradeLadders(ctx, id, func() error {
+				for _, tc := range upserts {
+					_, err := s.repo.UpsertTime(ctx, db.UpsertStandardTimeParams{
+						StandardID: id,
+						Event:      tc.Event,
+						AgeGroup:   tc.AgeGroup,
+						TimeMs:     int32(*tc.ToTimeMS),
+					})
+					if err != nil {
+						return err
+					}
+				}
+				for _, tc := range change.Removals {
+					if err := s.repo.DeleteTime(ctx, id, tc.Event, tc.AgeGroup); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return importError(change.Code, err)
+			}
+
+			dbTimes, err := s.repo.ListTimes(ctx, id)
+			if err != nil {
+				return importError(change.Code, err)
+			}
+			result.Standards = append(result.Standards, *toStandardWithTimes(change.existing, dbTimes))
+		}
+	}
+	return nil
+}
+
+// importError names the standard code an import failed on, keeping
+// validation errors recognizable as such.
+func importError(code string, err error) error {
+	if msg, ok := strings.CutPrefix(err.Error(), "validation: "); ok {
+		return fmt.Errorf("validation: %s: %s", code, msg)
+	}
+	return fmt.Errorf("import %s: %w", code, err)
+}
+
+// timesFor collects the times of a standard code from the file, reporting
+// times that cannot be imported in the result's errors.
+func (i JSONFileInput) timesFor(code string, scheme *agegroup.Scheme, result *JSONImportResult) []StandardTimeInput {
+	var times []StandardTimeInput
+	for event, ageGroups := range i.Times {
+		for ageGroupRaw, stdTimes := range ageGroups {
+			timeStr, ok := stdTimes[code]
+			if !ok || timeStr == "" {
+				continue // No time for this standard
+			}
+
+			// Parse time string to milliseconds
+			timeMs, err := parseTimeString(timeStr)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s/%s/%s: invalid time '%s': %v", code, event, ageGroupRaw, timeStr, err))
+				continue
+			}
+
+			// Map age group to the scheme's code
+			ageGroup, ok := scheme.Normalize(ageGroupRaw)
+			if !ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s/%s: unknown age group '%s' for %s", code, event, ageGroupRaw, scheme.Name))
+				continue
+			}
+
+			// Validate event
+			if !domain.EventCode(event).IsValidForCourse(domain.CourseType(i.CourseType)) {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: unknown event '%s' for %s", code, event, i.CourseType))
+				continue
+			}
+
+			times = append(times, StandardTimeInput{
+				Event:    event,
+				AgeGroup: string(ageGroup),
+				TimeMs:   timeMs,
+			})
+		}
+	}
+	return times
+}
+
+func toDBTimes(times []StandardTimeInput) []db.StandardTime {
+	dbTimes := make([]db.StandardTime, len(times))
+	for i, t := range times {
+		dbTimes[i] = db.StandardTime{Event: t.Event, AgeGroup: t.AgeGroup, TimeMs: int32(t.TimeMs)}
+	}
+	return dbTimes
+}
@@ -5,34 +5,166 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/agegroup"
+	"github.com/bpg/swimstats/backend/internal/domain/ladder"
 	"github.com/bpg/swimstats/backend/internal/store/db"
 	"github.com/bpg/swimstats/backend/internal/store/postgres"
 )
 
 // Service provides standard business logic.
 type Service struct {
-	repo *postgres.StandardRepository
+	repo    *postgres.StandardRepository
+	schemes *agegroup.Service
+	ladders *ladder.Service
+	txs     postgres.TxBeginner
 }
 
 // NewService creates a new standard service.
-func NewService(repo *postgres.StandardRepository) *Service {
-	return &Service{repo: repo}
+func NewService(repo *postgres.StandardRepository, schemes *agegroup.Service, ladders *ladder.Service, txs postgres.TxBeginner) *Service {
+	return &Service{repo: repo, schemes: schemes, ladders: ladders, txs: txs}
 }
 
-// Standard represents a time standard with computed fields.
+// WithTx returns a service that runs its queries in the transaction.
+// Transactions begun by the returned service are nested in tx.
+func (s *Service) WithTx(tx pgx.Tx) *Service {
+	return &Service{repo: s.repo.WithTx(tx), schemes: s.schemes.WithTx(tx), ladders: s.ladders.WithTx(tx), txs: tx}
+}
+
+// ErrShared is returned when a user changes a standard or family shared by
+// all users, which only the administrative commands can change.
+var ErrShared = errors.New("shared standards cannot be changed")
+
+// Standard represents a time standard with computed fields. Standards belong
+// to the user that created them; shared standards, without an owner, are
+// visible to all users.
 type Standard struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description,omitempty"`
-	CourseType  string    `json:"course_type"`
-	Gender      string    `json:"gender"`
-	IsPreloaded bool      `json:"is_preloaded"`
+	ID               uuid.UUID `json:"id"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description,omitempty"`
+	CourseType       string    `json:"course_type"`
+	Gender           string    `json:"gender"`
+	AgeGroupSchemeID uuid.UUID `json:"age_group_scheme_id"`
+	IsPreloaded      bool      `json:"is_preloaded"`
+	Shared           bool      `json:"shared"`
+	OwnerID          string    `json:"-"`
+	QualifyingRules
+	Versioning
+}
+
+// QualifyingRules restrict the swims that count toward a standard. Swims must
+// be done between QualifyingStart and QualifyingEnd (YYYY-MM-DD, inclusive;
+// an empty date leaves that end open), in RequiredCourse if set (converted
+// times do not count) and at a sanctioned meet if SanctionedOnly is set.
+type QualifyingRules struct {
+	QualifyingStart string `json:"qualifying_start,omitempty"`
+	QualifyingEnd   string `json:"qualifying_end,omitempty"`
+	RequiredCourse  string `json:"required_course,omitempty"`
+	SanctionedOnly  bool   `json:"sanctioned_only"`
+}
+
+// Sanitize trims whitespace from string fields.
+func (r *QualifyingRules) Sanitize() {
+	r.QualifyingStart = strings.TrimSpace(r.QualifyingStart)
+	r.QualifyingEnd = strings.TrimSpace(r.QualifyingEnd)
+	r.RequiredCourse = strings.TrimSpace(r.RequiredCourse)
+}
+
+// Validate validates the qualifying rules. Call Sanitize() first.
+func (r QualifyingRules) Validate() error {
+	start, err := parseDate(r.QualifyingStart)
+	if err != nil {
+		return errors.New("qualifying_start must be a valid date in YYYY-MM-DD format")
+	}
+	end, err := parseDate(r.QualifyingEnd)
+	if err != nil {
+		return errors.New("qualifying_end must be a valid date in YYYY-MM-DD format")
+	}
+	if start.Valid && end.Valid && end.Time.Before(start.Time) {
+		return errors.New("qualifying_end cannot be before qualifying_start")
+	}
+	if r.RequiredCourse != "" && !domain.CourseType(r.RequiredCourse).IsValid() {
+		return errors.New("required_course must be '25m', '50m' or '25y'")
+	}
+	return nil
+}
+
+// parseDate parses an optional YYYY-MM-DD date; an empty string is NULL.
+func parseDate(s string) (pgtype.Date, error) {
+	if s == "" {
+		return pgtype.Date{}, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return pgtype.Date{}, err
+	}
+	return pgtype.Date{Time: t, Valid: true}, nil
+}
+
+// columns returns the database values of validated rules.
+func (r QualifyingRules) columns() (start, end pgtype.Date, course pgtype.Text) {
+	start, _ = parseDate(r.QualifyingStart)
+	end, _ = parseDate(r.QualifyingEnd)
+	if r.RequiredCourse != "" {
+		course = pgtype.Text{String: r.RequiredCourse, Valid: true}
+	}
+	return start, end, course
+}
+
+// Versioning places a standard in a family as one of its versions, e.g. the
+// 2026-2028 edition of a standard. A version is effective from EffectiveFrom
+// (YYYY-MM-DD) until the next version of the family takes effect.
+type Versioning struct {
+	FamilyID      *uuid.UUID `json:"family_id,omitempty"`
+	Version       string     `json:"version,omitempty"`
+	EffectiveFrom string     `json:"effective_from,omitempty"`
+}
+
+// Sanitize trims whitespace from string fields.
+func (v *Versioning) Sanitize() {
+	v.Version = strings.TrimSpace(v.Version)
+	v.EffectiveFrom = strings.TrimSpace(v.EffectiveFrom)
+}
+
+// Validate validates the versioning. Call Sanitize() first.
+func (v Versioning) Validate() error {
+	if v.FamilyID == nil {
+		if v.Version != "" || v.EffectiveFrom != "" {
+			return errors.New("version and effective_from require a family_id")
+		}
+		return nil
+	}
+	if len(v.Version) > 50 {
+		return errors.New("version must be at most 50 characters")
+	}
+	if v.EffectiveFrom == "" {
+		return errors.New("effective_from is required for a version of a family")
+	}
+	if _, err := parseDate(v.EffectiveFrom); err != nil {
+		return errors.New("effective_from must be a valid date in YYYY-MM-DD format")
+	}
+	return nil
+}
+
+// columns returns the database values of a validated versioning.
+func (v Versioning) columns() (familyID pgtype.UUID, version pgtype.Text, effectiveFrom pgtype.Date) {
+	if v.FamilyID == nil {
+		return familyID, version, effectiveFrom
+	}
+	familyID = pgtype.UUID{Bytes: *v.FamilyID, Valid: true}
+	if v.Version != "" {
+		version = pgtype.Text{String: v.Version, Valid: true}
+	}
+	effectiveFrom, _ = parseDate(v.EffectiveFrom)
+	return familyID, version, effectiveFrom
 }
 
 // StandardTime represents a qualifying time within a standard.
@@ -55,11 +187,17 @@ type StandardList struct {
 }
 
 // Input represents input for creating/updating a standard.
+// AgeGroupSchemeID defaults to the Swimming Canada scheme on create and to
+// the current scheme on update. Without a family, an update keeps the
+// standard's current versioning.
 type Input struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
-	CourseType  string `json:"course_type"`
-	Gender      string `json:"gender"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description,omitempty"`
+	CourseType       string     `json:"course_type"`
+	Gender           string     `json:"gender"`
+	AgeGroupSchemeID *uuid.UUID `json:"age_group_scheme_id,omitempty"`
+	QualifyingRules
+	Versioning
 }
 
 // Sanitize trims whitespace from string fields.
@@ -68,6 +206,8 @@ func (i *Input) Sanitize() {
 	i.Description = strings.TrimSpace(i.Description)
 	i.CourseType = strings.TrimSpace(i.CourseType)
 	i.Gender = strings.TrimSpace(i.Gender)
+	i.QualifyingRules.Sanitize()
+	i.Versioning.Sanitize()
 }
 
 // Validate validates the standard input. Call Sanitize() first.
@@ -78,13 +218,16 @@ func (i Input) Validate() error {
 	if len(i.Name) > 255 {
 		return errors.New("name must be at most 255 characters")
 	}
-	if i.CourseType != "25m" && i.CourseType != "50m" {
-		return errors.New("course_type must be '25m' or '50m'")
+	if !domain.CourseType(i.CourseType).IsValid() {
+		return errors.New("course_type must be '25m', '50m' or '25y'")
 	}
 	if i.Gender != "female" && i.Gender != "male" {
 		return errors.New("gender must be 'female' or 'male'")
 	}
-	return nil
+	if err := i.QualifyingRules.Validate(); err != nil {
+		return err
+	}
+	return i.Versioning.Validate()
 }
 
 // StandardTimeInput represents input for a qualifying time.
@@ -99,8 +242,11 @@ func (i StandardTimeInput) Validate() error {
 	if !domain.EventCode(i.Event).IsValid() {
 		return fmt.Errorf("invalid event code: %s", i.Event)
 	}
-	if !domain.AgeGroup(i.AgeGroup).IsValid() {
-		return fmt.Errorf("invalid age group: %s", i.AgeGroup)
+	if i.AgeGroup == "" {
+		return errors.New("age_group is required")
+	}
+	if len(i.AgeGroup) > 20 {
+		return errors.New("age_group must be at most 20 characters")
 	}
 	if i.TimeMs <= 0 {
 		return errors.New("time_ms must be greater than 0")
@@ -110,11 +256,14 @@ func (i StandardTimeInput) Validate() error {
 
 // ImportInput represents input for importing a complete standard with times.
 type ImportInput struct {
-	Name        string              `json:"name"`
-	Description string              `json:"description,omitempty"`
-	CourseType  string              `json:"course_type"`
-	Gender      string              `json:"gender"`
-	Times       []StandardTimeInput `json:"times"`
+	Name             string              `json:"name"`
+	Description      string              `json:"description,omitempty"`
+	CourseType       string              `json:"course_type"`
+	Gender           string              `json:"gender"`
+	AgeGroupSchemeID *uuid.UUID          `json:"age_group_scheme_id,omitempty"`
+	Times            []StandardTimeInput `json:"times"`
+	QualifyingRules
+	Versioning
 }
 
 // Sanitize trims whitespace from string fields.
@@ -123,6 +272,8 @@ func (i *ImportInput) Sanitize() {
 	i.Description = strings.TrimSpace(i.Description)
 	i.CourseType = strings.TrimSpace(i.CourseType)
 	i.Gender = strings.TrimSpace(i.Gender)
+	i.QualifyingRules.Sanitize()
+	i.Versioning.Sanitize()
 	for idx := range i.Times {
 		i.Times[idx].Event = strings.TrimSpace(i.Times[idx].Event)
 		i.Times[idx].AgeGroup = strings.TrimSpace(i.Times[idx].AgeGroup)
@@ -132,10 +283,12 @@ func (i *ImportInput) Sanitize() {
 // Validate validates the import input. Call Sanitize() first.
 func (i ImportInput) Validate() error {
 	input := Input{
-		Name:        i.Name,
-		Description: i.Description,
-		CourseType:  i.CourseType,
-		Gender:      i.Gender,
+		Name:            i.Name,
+		Description:     i.Description,
+		CourseType:      i.CourseType,
+		Gender:          i.Gender,
+		QualifyingRules: i.QualifyingRules,
+		Versioning:      i.Versioning,
 	}
 	if err := input.Validate(); err != nil {
 		return err
@@ -144,6 +297,27 @@ func (i ImportInput) Validate() error {
 		if err := t.Validate(); err != nil {
 			return fmt.Errorf("times[%d]: %w", idx, err)
 		}
+		if err := validateCourse(t.Event, i.CourseType); err != nil {
+			return fmt.Errorf("times[%d]: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+// validateAgeGroups validates that the times use the age groups of the scheme.
+func validateAgeGroups(times []StandardTimeInput, scheme *agegroup.Scheme) error {
+	for idx, t := range times {
+		if !scheme.Has(domain.AgeGroup(t.AgeGroup)) {
+			return fmt.Errorf("times[%d]: age group %s is not part of the %s scheme", idx, t.AgeGroup, scheme.Name)
+		}
+	}
+	return nil
+}
+
+// validateCourse validates that the event is swum in the standard's course.
+func validateCourse(event, courseType string) error {
+	if !domain.EventCode(event).IsValidForCourse(domain.CourseType(courseType)) {
+		return fmt.Errorf("event %s is not swum in a %s pool", event, courseType)
 	}
 	return nil
 }
@@ -155,36 +329,79 @@ type ListParams struct {
 }
 
 // JSONFileInput represents the JSON file format for bulk importing standards.
+// AgeGroupScheme names the scheme of the age groups and defaults to Swimming Canada.
+// The qualifying rules apply to every standard in the file. Standards of a
+// file with an effective date become versions of their families, effective
+// from EffectiveFrom or else the start of the season the Season label begins
+// with (e.g. September 1, 2026 for "2026-2028").
 type JSONFileInput struct {
-	Season     string                         `json:"season"`
-	Source     string                         `json:"source"`
-	CourseType string                         `json:"course_type"`
-	Gender     string                         `json:"gender"`
-	Standards  map[string]JSONStandardMeta    `json:"standards"`
-	AgeGroups  []string                       `json:"age_groups"`
-	Times      map[string]map[string]JSONTime `json:"times"` // event -> age_group -> times
+	Season         string                         `json:"season"`
+	EffectiveFrom  string                         `json:"effective_from,omitempty"`
+	Source         string                         `json:"source"`
+	CourseType     string                         `json:"course_type"`
+	Gender         string                         `json:"gender"`
+	AgeGroupScheme string                         `json:"age_group_scheme,omitempty"`
+	Standards      map[string]JSONStandardMeta    `json:"standards"`
+	AgeGroups      []string                       `json:"age_groups"`
+	Times          map[string]map[string]JSONTime `json:"times"` // event -> age_group -> times
+	QualifyingRules
 }
 
 // JSONStandardMeta contains metadata for a standard in the JSON file.
+// Family names the family of a versioned standard and defaults to the source
+// and standard code, e.g. "Swimming Canada OSC".
 type JSONStandardMeta struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
+	Family      string `json:"family,omitempty"`
+}
+
+// effectiveFrom returns the date from which the standards of the file take
+// effect, or an empty string if they are not versioned.
+func (i JSONFileInput) effectiveFrom() (string, error) {
+	if i.EffectiveFrom != "" {
+		if _, err := parseDate(i.EffectiveFrom); err != nil {
+			return "", errors.New("effective_from must be a valid date in YYYY-MM-DD format")
+		}
+		return i.EffectiveFrom, nil
+	}
+	first, _, _ := strings.Cut(i.Season, "-")
+	year, err := strconv.Atoi(first)
+	if err != nil || year < 1900 || year > 9999 {
+		return "", nil
+	}
+	d := domain.DefaultSeasonDefinition
+	return time.Date(year, d.StartMonth, d.StartDay, 0, 0, 0, 0, time.UTC).Format("2006-01-02"), nil
 }
 
 // JSONTime contains the time values for different standards.
 type JSONTime map[string]string // standard_code -> time_string (e.g., "OSC" -> "1:05.32")
 
-// JSONImportResult contains the results of a JSON file import.
+// JSONImportResult contains the results of a JSON file import. Standards
+// lists the created and updated standards; a dry run only reports Changes.
 type JSONImportResult struct {
-	Standards []StandardWithTimes `json:"standards"`
-	Imported  int                 `json:"imported"`
-	Skipped   int                 `json:"skipped"`
-	Errors    []string            `json:"errors,omitempty"`
+	Mode      ImportMode             `json:"mode"`
+	DryRun    bool                   `json:"dry_run"`
+	Standards []StandardWithTimes    `json:"standards"`
+	Changes   []StandardImportChange `json:"changes"`
+	Imported  int                    `json:"imported"`
+	Updated   int                    `json:"updated"`
+	Unchanged int                    `json:"unchanged"`
+	Skipped   int                    `json:"skipped"`
+	Errors    []string               `json:"errors,omitempty"`
 }
 
-// Get retrieves a standard by ID (without times).
-func (s *Service) Get(ctx context.Context, id uuid.UUID) (*Standard, error) {
-	dbStandard, err := s.repo.Get(ctx, id)
+// Visible reports whether a user sees a standard or family of the given
+// owner: shared ones and their own. The administrative commands act without
+// a user and see everything.
+func Visible(ownerID, userID string) bool {
+	return userID == "" || ownerID == "" || ownerID == userID
+}
+
+// Get retrieves a standard by ID (without times), returning
+// postgres.ErrNotFound if the owner does not see it.
+func (s *Service) Get(ctx context.Context, ownerID string, id uuid.UUID) (*Standard, error) {
+	dbStandard, err := s.get(ctx, ownerID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -192,8 +409,8 @@ func (s *Service) Get(ctx context.Context, id uuid.UUID) (*Standard, error) {
 }
 
 // GetWithTimes retrieves a standard with all its qualifying times.
-func (s *Service) GetWithTimes(ctx context.Context, id uuid.UUID) (*StandardWithTimes, error) {
-	dbStandard, err := s.repo.Get(ctx, id)
+func (s *Service) GetWithTimes(ctx context.Context, ownerID string, id uuid.UUID) (*StandardWithTimes, error) {
+	dbStandard, err := s.get(ctx, ownerID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -206,11 +423,13 @@ func (s *Service) GetWithTimes(ctx context.Context, id uuid.UUID) (*StandardWith
 	return toStandardWithTimes(dbStandard, dbTimes), nil
 }
 
-// List retrieves all standards matching the filter.
-func (s *Service) List(ctx context.Context, params ListParams) (*StandardList, error) {
+// List retrieves the shared standards and those of the owner matching the
+// filter.
+func (s *Service) List(ctx context.Context, ownerID string, params ListParams) (*StandardList, error) {
 	dbStandards, err := s.repo.List(ctx, postgres.ListStandardsParams{
 		CourseType: params.CourseType,
 		Gender:     params.Gender,
+		OwnerID:    ownerID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("list standards: %w", err)
@@ -224,8 +443,14 @@ func (s *Service) List(ctx context.Context, params ListParams) (*StandardList, e
 	return &StandardList{Standards: standards}, nil
 }
 
-// Create creates a new standard.
-func (s *Service) Create(ctx context.Context, input Input) (*Standard, error) {
+// ListOwners lists the users that own standards.
+func (s *Service) ListOwners(ctx context.Context) ([]string, error) {
+	return s.repo.ListOwners(ctx)
+}
+
+// Create creates a new standard owned by the given user, or a shared one
+// without an owner.
+func (s *Service) Create(ctx context.Context, ownerID string, input Input) (*Standard, error) {
 	input.Sanitize()
 	if err := input.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
@@ -233,7 +458,7 @@ func (s *Service) Create(ctx context.Context, input Input) (*Standard, error) {
 
 	// Check for duplicate name
 	emptyID := uuid.UUID{}
-	exists, err := s.repo.NameExists(ctx, input.Name, emptyID)
+	exists, err := s.repo.NameExists(ctx, ownerID, input.Name, emptyID)
 	if err != nil {
 		return nil, fmt.Errorf("check name exists: %w", err)
 	}
@@ -241,17 +466,36 @@ func (s *Service) Create(ctx context.Context, input Input) (*Standard, error) {
 		return nil, fmt.Errorf("validation: a standard with this name already exists")
 	}
 
+	scheme, err := s.scheme(ctx, input.AgeGroupSchemeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkVersion(ctx, ownerID, input.Versioning, input.CourseType, input.Gender, emptyID); err != nil {
+		return nil, err
+	}
+
 	var description pgtype.Text
 	if input.Description != "" {
 		description = pgtype.Text{String: input.Description, Valid: true}
 	}
+	qualifyingStart, qualifyingEnd, requiredCourse := input.QualifyingRules.columns()
+	familyID, version, effectiveFrom := input.Versioning.columns()
 
 	dbStandard, err := s.repo.Create(ctx, db.CreateStandardParams{
-		Name:        input.Name,
-		Description: description,
-		CourseType:  input.CourseType,
-		Gender:      input.Gender,
-		IsPreloaded: false,
+		Name:             input.Name,
+		Description:      description,
+		CourseType:       input.CourseType,
+		Gender:           input.Gender,
+		IsPreloaded:      false,
+		AgeGroupSchemeID: scheme.ID,
+		QualifyingStart:  qualifyingStart,
+		QualifyingEnd:    qualifyingEnd,
+		RequiredCourse:   requiredCourse,
+		SanctionedOnly:   input.SanctionedOnly,
+		FamilyID:         familyID,
+		Version:          version,
+		EffectiveFrom:    effectiveFrom,
+		OwnerID:          ownerID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("create standard: %w", err)
@@ -260,15 +504,15 @@ func (s *Service) Create(ctx context.Context, input Input) (*Standard, error) {
 	return toStandard(dbStandard), nil
 }
 
-// Update updates an existing standard.
-func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Standard, error) {
+// Update updates an existing standard of the owner.
+func (s *Service) Update(ctx context.Context, ownerID string, id uuid.UUID, input Input) (*Standard, error) {
 	input.Sanitize()
 	if err := input.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
 	}
 
 	// Check standard exists
-	existing, err := s.repo.Get(ctx, id)
+	existing, err := s.getOwned(ctx, ownerID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -279,7 +523,7 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Stand
 	}
 
 	// Check for duplicate name (excluding current standard)
-	exists, err := s.repo.NameExists(ctx, input.Name, id)
+	exists, err := s.repo.NameExists(ctx, existing.OwnerID, input.Name, id)
 	if err != nil {
 		return nil, fmt.Errorf("check name exists: %w", err)
 	}
@@ -287,29 +531,79 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Stand
 		return nil, fmt.Errorf("validation: a standard with this name already exists")
 	}
 
+	// Keep the scheme unless another one is given, in which case the
+	// existing times must use its age groups
+	schemeID := existing.AgeGroupSchemeID
+	if input.AgeGroupSchemeID != nil && *input.AgeGroupSchemeID != schemeID {
+		scheme, err := s.scheme(ctx, input.AgeGroupSchemeID)
+		if err != nil {
+			return nil, err
+		}
+		dbTimes, err := s.repo.ListTimes(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get standard times: %w", err)
+		}
+		for _, t := range dbTimes {
+			if !scheme.Has(domain.AgeGroup(t.AgeGroup)) {
+				return nil, fmt.Errorf("validation: age group %s of %s is not part of the %s scheme", t.AgeGroup, t.Event, scheme.Name)
+			}
+		}
+		schemeID = scheme.ID
+	}
+
+	if input.FamilyID == nil {
+		input.Versioning = toVersioning(existing)
+	}
+	if err := s.checkVersion(ctx, existing.OwnerID, input.Versioning, input.CourseType, input.Gender, id); err != nil {
+		return nil, err
+	}
+
 	var description pgtype.Text
 	if input.Description != "" {
 		description = pgtype.Text{String: input.Description, Valid: true}
 	}
-
-	dbStandard, err := s.repo.Update(ctx, db.UpdateStandardParams{
-		ID:          id,
-		Name:        input.Name,
-		Description: description,
-		CourseType:  input.CourseType,
-		Gender:      input.Gender,
+	qualifyingStart, qualifyingEnd, requiredCourse := input.QualifyingRules.columns()
+	familyID, version, effectiveFrom := input.Versioning.columns()
+
+	// The course, gender and scheme of a ladder's rung decide the grades
+	var dbStandard *db.TimeStandard
+	err = postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		txService := s.WithTx(tx)
+		return txService.regradeLadders(ctx, id, func() error {
+			var err error
+			dbStandard, err = txService.repo.Update(ctx, db.UpdateStandardParams{
+				ID:               id,
+				Name:             input.Name,
+				Description:      description,
+				CourseType:       input.CourseType,
+				Gender:           input.Gender,
+				AgeGroupSchemeID: schemeID,
+				QualifyingStart:  qualifyingStart,
+				QualifyingEnd:    qualifyingEnd,
+				RequiredCourse:   requiredCourse,
+				SanctionedOnly:   input.SanctionedOnly,
+				FamilyID:         familyID,
+				Version:          version,
+				EffectiveFrom:    effectiveFrom,
+			})
+			if err != nil {
+				return fmt.Errorf("update standard: %w", err)
+			}
+			return nil
+		})
 	})
 	if err != nil {
-		return nil, fmt.Errorf("update standard: %w", err)
+		return nil, err
 	}
 
 	return toStandard(dbStandard), nil
 }
 
-// Delete deletes a standard.
-func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete deletes a standard of the owner, regrading the swims of the grading
+// ladders it was a rung of.
+func (s *Service) Delete(ctx context.Context, ownerID string, id uuid.UUID) error {
 	// Check if standard exists
-	existing, err := s.repo.Get(ctx, id)
+	existing, err := s.getOwned(ctx, ownerID, id)
 	if err != nil {
 		return err
 	}
@@ -319,16 +613,22 @@ func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
 		return errors.New("preloaded standards cannot be deleted")
 	}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return fmt.Errorf("delete standard: %w", err)
-	}
-	return nil
+	return postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		txService := s.WithTx(tx)
+		return txService.regradeLadders(ctx, id, func() error {
+			if err := txService.repo.Delete(ctx, id); err != nil {
+				return fmt.Errorf("delete standard: %w", err)
+			}
+			return nil
+		})
+	})
 }
 
-// SetTimes replaces all times for a standard with the provided list.
-func (s *Service) SetTimes(ctx context.Context, standardID uuid.UUID, times []StandardTimeInput) (*StandardWithTimes, error) {
+// SetTimes replaces all times for a standard of the owner with the provided
+// list, regrading the swims of the grading ladders it is a rung of.
+func (s *Service) SetTimes(ctx context.Context, ownerID string, standardID uuid.UUID, times []StandardTimeInput) (*StandardWithTimes, error) {
 	// Check standard exists
-	dbStandard, err := s.repo.Get(ctx, standardID)
+	dbStandard, err := s.getOwned(ctx, ownerID, standardID)
 	if err != nil {
 		return nil, err
 	}
@@ -338,33 +638,53 @@ func (s *Service) SetTimes(ctx context.Context, standardID uuid.UUID, times []St
 		if err := t.Validate(); err != nil {
 			return nil, fmt.Errorf("times[%d]: %w", idx, err)
 		}
+		if err := validateCourse(t.Event, dbStandard.CourseType); err != nil {
+			return nil, fmt.Errorf("times[%d]: %w", idx, err)
+		}
 	}
-
-	// Delete existing times
-	if err := s.repo.DeleteTimes(ctx, standardID); err != nil {
-		return nil, fmt.Errorf("delete existing times: %w", err)
+	scheme, err := s.scheme(ctx, &dbStandard.AgeGroupSchemeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAgeGroups(times, scheme); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
 	}
 
-	// Insert new times
 	dbTimes := make([]db.StandardTime, 0, len(times))
-	for _, t := range times {
-		dbTime, err := s.repo.UpsertTime(ctx, db.UpsertStandardTimeParams{
-			StandardID: standardID,
-			Event:      t.Event,
-			AgeGroup:   t.AgeGroup,
-			TimeMs:     int32(t.TimeMs),
+	err = postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		txService := s.WithTx(tx)
+		return txService.regradeLadders(ctx, standardID, func() error {
+			// Delete existing times
+			if err := txService.repo.DeleteTimes(ctx, standardID); err != nil {
+				return fmt.Errorf("delete existing times: %w", err)
+			}
+
+			// Insert new times
+			for _, t := range times {
+				dbTime, err := txService.repo.UpsertTime(ctx, db.UpsertStandardTimeParams{
+					StandardID: standardID,
+					Event:      t.Event,
+					AgeGroup:   t.AgeGroup,
+					TimeMs:     int32(t.TimeMs),
+				})
+				if err != nil {
+					return fmt.Errorf("insert time: %w", err)
+				}
+				dbTimes = append(dbTimes, *dbTime)
+			}
+			return nil
 		})
-		if err != nil {
-			return nil, fmt.Errorf("insert time: %w", err)
-		}
-		dbTimes = append(dbTimes, *dbTime)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return toStandardWithTimes(dbStandard, dbTimes), nil
 }
 
-// Import creates a new standard with all its times in one operation.
-func (s *Service) Import(ctx context.Context, input ImportInput) (*StandardWithTimes, error) {
+// Import creates a new standard of the owner with all its times in one
+// operation.
+func (s *Service) Import(ctx context.Context, ownerID string, input ImportInput) (*StandardWithTimes, error) {
 	input.Sanitize()
 	if err := input.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
@@ -372,7 +692,7 @@ func (s *Service) Import(ctx context.Context, input ImportInput) (*StandardWithT
 
 	// Check for duplicate name
 	emptyID := uuid.UUID{}
-	exists, err := s.repo.NameExists(ctx, input.Name, emptyID)
+	exists, err := s.repo.NameExists(ctx, ownerID, input.Name, emptyID)
 	if err != nil {
 		return nil, fmt.Errorf("check name exists: %w", err)
 	}
@@ -380,18 +700,40 @@ func (s *Service) Import(ctx context.Context, input ImportInput) (*StandardWithT
 		return nil, fmt.Errorf("validation: a standard with this name already exists")
 	}
 
+	scheme, err := s.scheme(ctx, input.AgeGroupSchemeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAgeGroups(input.Times, scheme); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+	if err := s.checkVersion(ctx, ownerID, input.Versioning, input.CourseType, input.Gender, emptyID); err != nil {
+		return nil, err
+	}
+
 	var description pgtype.Text
 	if input.Description != "" {
 		description = pgtype.Text{String: input.Description, Valid: true}
 	}
+	qualifyingStart, qualifyingEnd, requiredCourse := input.QualifyingRules.columns()
+	familyID, version, effectiveFrom := input.Versioning.columns()
 
 	// Create the standard
 	dbStandard, err := s.repo.Create(ctx, db.CreateStandardParams{
-		Name:        input.Name,
-		Description: description,
-		CourseType:  input.CourseType,
-		Gender:      input.Gender,
-		IsPreloaded: false,
+		Name:             input.Name,
+		Description:      description,
+		CourseType:       input.CourseType,
+		Gender:           input.Gender,
+		IsPreloaded:      false,
+		AgeGroupSchemeID: scheme.ID,
+		QualifyingStart:  qualifyingStart,
+		QualifyingEnd:    qualifyingEnd,
+		RequiredCourse:   requiredCourse,
+		SanctionedOnly:   input.SanctionedOnly,
+		FamilyID:         familyID,
+		Version:          version,
+		EffectiveFrom:    effectiveFrom,
+		OwnerID:          ownerID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("create standard: %w", err)
@@ -416,111 +758,64 @@ func (s *Service) Import(ctx context.Context, input ImportInput) (*StandardWithT
 }
 
 // ImportFromJSON imports standards from a JSON file format.
-// Each standard code (e.g., "OSC", "OAG") in the file creates a separate standard.
-func (s *Service) ImportFromJSON(ctx context.Context, input JSONFileInput) (*JSONImportResult, error) {
+// Each standard code (e.g., "OSC", "OAG") in the file creates a separate standard,
+// or changes the times of the existing standard of that name as the mode
+// directs. Only the owner's own standards are changed. The file is imported
+// in a single transaction.
+func (s *Service) ImportFromJSON(ctx context.Context, ownerID string, input JSONFileInput, opts JSONImportOptions) (*JSONImportResult, error) {
+	if opts.Mode == "" {
+		opts.Mode = ImportModeSkip
+	}
+	if !opts.Mode.IsValid() {
+		return nil, errors.New("validation: mode must be 'skip', 'replace' or 'merge'")
+	}
+
 	// Validate basic fields
-	if input.CourseType != "25m" && input.CourseType != "50m" {
-		return nil, errors.New("validation: course_type must be '25m' or '50m'")
+	if !domain.CourseType(input.CourseType).IsValid() {
+		return nil, errors.New("validation: course_type must be '25m', '50m' or '25y'")
 	}
 	if input.Gender != "female" && input.Gender != "male" {
 		return nil, errors.New("validation: gender must be 'female' or 'male'")
 	}
+	input.QualifyingRules.Sanitize()
+	if err := input.QualifyingRules.Validate(); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
 	if len(input.Standards) == 0 {
 		return nil, errors.New("validation: no standards defined in file")
 	}
 	if len(input.Times) == 0 {
 		return nil, errors.New("validation: no times defined in file")
 	}
-
-	result := &JSONImportResult{
-		Standards: make([]StandardWithTimes, 0),
+	input.Season = strings.TrimSpace(input.Season)
+	input.EffectiveFrom = strings.TrimSpace(input.EffectiveFrom)
+	effectiveFrom, err := input.effectiveFrom()
+	if err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
 	}
 
-	// Process each standard type (e.g., OSC, OAG)
-	for code, meta := range input.Standards {
-		// Build the standard name
-		name := meta.Name
-		if name == "" {
-			name = fmt.Sprintf("%s %s %s", input.Source, code, input.Season)
-		}
-
-		// Check if standard already exists
-		emptyID := uuid.UUID{}
-		exists, err := s.repo.NameExists(ctx, name, emptyID)
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to check name: %v", code, err))
-			result.Skipped++
-			continue
-		}
-		if exists {
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: standard '%s' already exists", code, name))
-			result.Skipped++
-			continue
-		}
-
-		// Collect times for this standard
-		var times []StandardTimeInput
-		for event, ageGroups := range input.Times {
-			for ageGroupRaw, stdTimes := range ageGroups {
-				timeStr, ok := stdTimes[code]
-				if !ok || timeStr == "" {
-					continue // No time for this standard
-				}
-
-				// Parse time string to milliseconds
-				timeMs, err := parseTimeString(timeStr)
-				if err != nil {
-					result.Errors = append(result.Errors, fmt.Sprintf("%s/%s/%s: invalid time '%s': %v", code, event, ageGroupRaw, timeStr, err))
-					continue
-				}
-
-				// Map age group to our format
-				ageGroup := mapAgeGroup(ageGroupRaw)
-				if !domain.AgeGroup(ageGroup).IsValid() {
-					result.Errors = append(result.Errors, fmt.Sprintf("%s/%s: unknown age group '%s'", code, event, ageGroupRaw))
-					continue
-				}
-
-				// Validate event
-				if !domain.EventCode(event).IsValid() {
-					result.Errors = append(result.Errors, fmt.Sprintf("%s: unknown event '%s'", code, event))
-					continue
-				}
-
-				times = append(times, StandardTimeInput{
-					Event:    event,
-					AgeGroup: ageGroup,
-					TimeMs:   timeMs,
-				})
-			}
-		}
-
-		if len(times) == 0 {
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: no valid times found", code))
-			result.Skipped++
-			continue
-		}
+	scheme, err := s.schemes.Resolve(ctx, input.AgeGroupScheme)
+	if err != nil {
+		return nil, err
+	}
 
-		// Create the standard with times
-		importInput := ImportInput{
-			Name:        name,
-			Description: meta.Description,
-			CourseType:  input.CourseType,
-			Gender:      input.Gender,
-			Times:       times,
-		}
+	if opts.DryRun {
+		return s.planJSONImport(ctx, ownerID, input, scheme, opts)
+	}
 
-		std, err := s.Import(ctx, importInput)
+	var result *JSONImportResult
+	err = postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		txService := s.WithTx(tx)
+		var err error
+		result, err = txService.planJSONImport(ctx, ownerID, input, scheme, opts)
 		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to import: %v", code, err))
-			result.Skipped++
-			continue
+			return err
 		}
-
-		result.Standards = append(result.Standards, *std)
-		result.Imported++
+		return txService.applyJSONImport(ctx, ownerID, input, scheme, effectiveFrom, result)
+	})
+	if err != nil {
+		return nil, err
 	}
-
 	return result, nil
 }
 
@@ -564,28 +859,89 @@ func parseTimeString(s string) (int, error) {
 	return 0, fmt.Errorf("cannot parse time: %s", s)
 }
 
-// mapAgeGroup maps JSON age group codes to our internal format.
-func mapAgeGroup(raw string) string {
-	switch raw {
-	case "10U", "10&U", "10 & Under":
-		return "10U"
-	case "11U", "11&U", "11 & Under":
-		return "10U" // Map 11U to 10U for now
-	case "12", "12U":
-		return "11-12"
-	case "13U", "13&U", "13 & Under":
-		return "13-14"
-	case "14":
-		return "13-14"
-	case "15":
-		return "15-17"
-	case "16":
-		return "15-17"
-	case "17O", "17&O", "17 & Over", "17+", "OPEN", "Open":
-		return "OPEN"
-	default:
-		return raw
+// checkVersion checks that the family of a version exists with the
+// standard's owner, course and gender and that no other version of it takes
+// effect on the same date.
+func (s *Service) checkVersion(ctx context.Context, ownerID string, v Versioning, courseType, gender string, excludeID uuid.UUID) error {
+	if v.FamilyID == nil {
+		return nil
+	}
+	family, err := s.repo.GetFamily(ctx, *v.FamilyID)
+	if errors.Is(err, postgres.ErrNotFound) || (err == nil && family.OwnerID != ownerID) {
+		return fmt.Errorf("validation: unknown standard family: %s", v.FamilyID)
+	}
+	if err != nil {
+		return err
+	}
+	if family.CourseType != courseType || family.Gender != gender {
+		return fmt.Errorf("validation: versions of %s must be %s %s standards", family.Name, family.CourseType, family.Gender)
+	}
+
+	_, _, effectiveFrom := v.columns()
+	exists, err := s.repo.VersionExists(ctx, *v.FamilyID, effectiveFrom, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("validation: a version of %s is already effective from %s", family.Name, v.EffectiveFrom)
+	}
+	return nil
+}
+
+// get retrieves a standard the owner sees.
+func (s *Service) get(ctx context.Context, ownerID string, id uuid.UUID) (*db.TimeStandard, error) {
+	dbStandard, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !Visible(dbStandard.OwnerID, ownerID) {
+		return nil, postgres.ErrNotFound
+	}
+	return dbStandard, nil
+}
+
+// getOwned retrieves a standard the owner may change, returning ErrShared
+// for shared standards.
+func (s *Service) getOwned(ctx context.Context, ownerID string, id uuid.UUID) (*db.TimeStandard, error) {
+	dbStandard, err := s.get(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != "" && dbStandard.OwnerID != ownerID {
+		return nil, ErrShared
+	}
+	return dbStandard, nil
+}
+
+// regradeLadders makes a change to a standard and then regrades the swims of
+// the grading ladders it is a rung of. The ladders are looked up before the
+// change, so that deleting the standard regrades them as well. Call it in a
+// transaction.
+func (s *Service) regradeLadders(ctx context.Context, id uuid.UUID, change func() error) error {
+	ladders, err := s.ladders.ListGrading(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		return err
 	}
+	return s.ladders.RegradeAll(ctx, ladders)
+}
+
+// scheme returns the age-group scheme with the given ID, or the default
+// scheme if id is nil.
+func (s *Service) scheme(ctx context.Context, id *uuid.UUID) (*agegroup.Scheme, error) {
+	if id == nil {
+		return s.schemes.Default(ctx)
+	}
+	scheme, err := s.schemes.Get(ctx, *id)
+	if errors.Is(err, postgres.ErrNotFound) {
+		return nil, fmt.Errorf("validation: unknown age group scheme: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get age group scheme: %w", err)
+	}
+	return scheme, nil
 }
 
 // Conversion helpers
@@ -596,13 +952,50 @@ func toStandard(dbStd *db.TimeStandard) *Standard {
 		description = dbStd.Description.String
 	}
 	return &Standard{
-		ID:          dbStd.ID,
-		Name:        dbStd.Name,
-		Description: description,
-		CourseType:  dbStd.CourseType,
-		Gender:      dbStd.Gender,
-		IsPreloaded: dbStd.IsPreloaded,
+		ID:               dbStd.ID,
+		Name:             dbStd.Name,
+		Description:      description,
+		CourseType:       dbStd.CourseType,
+		Gender:           dbStd.Gender,
+		AgeGroupSchemeID: dbStd.AgeGroupSchemeID,
+		IsPreloaded:      dbStd.IsPreloaded,
+		Shared:           dbStd.OwnerID == "",
+		OwnerID:          dbStd.OwnerID,
+		QualifyingRules:  toQualifyingRules(dbStd),
+		Versioning:       toVersioning(dbStd),
+	}
+}
+
+// toQualifyingRules returns the qualifying rules of a stored standard.
+func toQualifyingRules(dbStd *db.TimeStandard) QualifyingRules {
+	rules := QualifyingRules{SanctionedOnly: dbStd.SanctionedOnly}
+	if dbStd.QualifyingStart.Valid {
+		rules.QualifyingStart = dbStd.QualifyingStart.Time.Format("2006-01-02")
+	}
+	if dbStd.QualifyingEnd.Valid {
+		rules.QualifyingEnd = dbStd.QualifyingEnd.Time.Format("2006-01-02")
+	}
+	if dbStd.RequiredCourse.Valid {
+		rules.RequiredCourse = dbStd.RequiredCourse.String
+	}
+	return rules
+}
+
+// toVersioning returns the versioning of a stored standard.
+func toVersioning(dbStd *db.TimeStandard) Versioning {
+	var v Versioning
+	if !dbStd.FamilyID.Valid {
+		return v
+	}
+	familyID := uuid.UUID(dbStd.FamilyID.Bytes)
+	v.FamilyID = &familyID
+	if dbStd.Version.Valid {
+		v.Version = dbStd.Version.String
+	}
+	if dbStd.EffectiveFrom.Valid {
+		v.EffectiveFrom = dbStd.EffectiveFrom.Time.Format("2006-01-02")
 	}
+	return v
 }
 
 func toStandardWithTimes(dbStd *db.TimeStandard, dbTimes []db.StandardTime) *StandardWithTimes {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/bpg/swimstats/backend/internal/domain"
@@ -18,11 +19,18 @@ import (
 // Service provides swimmer business logic.
 type Service struct {
 	repo *postgres.SwimmerRepository
+	txs  postgres.TxBeginner
 }
 
 // NewService creates a new swimmer service.
-func NewService(repo *postgres.SwimmerRepository) *Service {
-	return &Service{repo: repo}
+func NewService(repo *postgres.SwimmerRepository, txs postgres.TxBeginner) *Service {
+	return &Service{repo: repo, txs: txs}
+}
+
+// WithTx returns a service that runs its queries in the transaction.
+// Transactions begun by the returned service are nested in tx.
+func (s *Service) WithTx(tx pgx.Tx) *Service {
+	return &Service{repo: s.repo.WithTx(tx), txs: tx}
 }
 
 // Swimmer represents a swimmer with computed fields.
@@ -32,19 +40,42 @@ type Swimmer struct {
 	BirthDate        string    `json:"birth_date"`
 	Gender           string    `json:"gender"`
 	ThresholdPercent float64   `json:"threshold_percent"`
+	SeasonStart      string    `json:"season_start"`
 	CurrentAge       int       `json:"current_age"`
 	CurrentAgeGroup  string    `json:"current_age_group"`
 }
 
+// SwimmerList represents the list of an owner's swimmers.
+type SwimmerList struct {
+	Swimmers []Swimmer `json:"swimmers"`
+	Total    int       `json:"total"`
+}
+
+// OwnedSwimmer is a swimmer with the user that owns it.
+type OwnedSwimmer struct {
+	OwnerID string
+	Swimmer
+}
+
+// ClaimResult reports the data assigned to an owner by ClaimUnowned.
+type ClaimResult struct {
+	OwnerID  string `json:"owner_id"`
+	Swimmers int64  `json:"swimmers"`
+	Meets    int64  `json:"meets"`
+}
+
 // DefaultThresholdPercent is the default "almost there" threshold.
 const DefaultThresholdPercent = 3.0
 
 // Input represents input for creating/updating a swimmer.
+// SeasonStart (MM-DD) defaults to September 1 on create and to the current
+// value on update.
 type Input struct {
 	Name             string   `json:"name"`
 	BirthDate        string   `json:"birth_date"`
 	Gender           string   `json:"gender"`
 	ThresholdPercent *float64 `json:"threshold_percent,omitempty"`
+	SeasonStart      *string  `json:"season_start,omitempty"`
 }
 
 // Sanitize trims whitespace from string fields.
@@ -76,13 +107,17 @@ func (i Input) Validate() error {
 			return errors.New("threshold_percent must be between 0 and 100")
 		}
 	}
+	if i.SeasonStart != nil {
+		if _, err := domain.ParseSeasonStart(*i.SeasonStart); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// Get retrieves the swimmer profile.
-// In single-user mode, returns the first swimmer.
-func (s *Service) Get(ctx context.Context) (*Swimmer, error) {
-	dbSwimmer, err := s.repo.GetFirst(ctx)
+// Get retrieves the owner's default swimmer (the first one created).
+func (s *Service) Get(ctx context.Context, ownerID string) (*Swimmer, error) {
+	dbSwimmer, err := s.repo.GetFirst(ctx, ownerID)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			return nil, postgres.ErrNotFound
@@ -101,8 +136,73 @@ func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*Swimmer, error) {
 	return toSwimmer(dbSwimmer), nil
 }
 
-// Create creates a new swimmer.
-func (s *Service) Create(ctx context.Context, input Input) (*Swimmer, error) {
+// GetForOwner retrieves a swimmer by ID, returning postgres.ErrNotFound
+// if the swimmer does not belong to the owner.
+func (s *Service) GetForOwner(ctx context.Context, ownerID string, id uuid.UUID) (*Swimmer, error) {
+	dbSwimmer, err := s.repo.GetForOwner(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return toSwimmer(dbSwimmer), nil
+}
+
+// List retrieves all swimmers of an owner, ordered by name.
+func (s *Service) List(ctx context.Context, ownerID string) (*SwimmerList, error) {
+	rows, err := s.repo.List(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("list swimmers: %w", err)
+	}
+
+	swimmers := make([]Swimmer, len(rows))
+	for i := range rows {
+		swimmers[i] = *toSwimmer(&rows[i])
+	}
+
+	return &SwimmerList{
+		Swimmers: swimmers,
+		Total:    len(swimmers),
+	}, nil
+}
+
+// ListAll retrieves the swimmers of all owners, ordered by owner with each
+// owner's default swimmer first.
+func (s *Service) ListAll(ctx context.Context) ([]OwnedSwimmer, error) {
+	rows, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	swimmers := make([]OwnedSwimmer, len(rows))
+	for i := range rows {
+		swimmers[i] = OwnedSwimmer{OwnerID: rows[i].OwnerID, Swimmer: *toSwimmer(&rows[i])}
+	}
+	return swimmers, nil
+}
+
+// ClaimUnowned assigns the swimmers and meets recorded before multi-swimmer
+// support, which have no owner, to the given owner. Reads never claim data,
+// so this is run on purpose by an administrator. Concurrent claims are
+// serialized, so all legacy data goes to a single owner.
+func (s *Service) ClaimUnowned(ctx context.Context, ownerID string) (*ClaimResult, error) {
+	ownerID = domain.SanitizeString(ownerID)
+	if ownerID == "" {
+		return nil, errors.New("validation: owner is required")
+	}
+
+	result := &ClaimResult{OwnerID: ownerID}
+	err := postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		var err error
+		result.Swimmers, result.Meets, err = s.repo.WithTx(tx).ClaimUnowned(ctx, ownerID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Create creates a new swimmer owned by the given user.
+func (s *Service) Create(ctx context.Context, ownerID string, input Input) (*Swimmer, error) {
 	input.Sanitize()
 	if err := input.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
@@ -116,11 +216,19 @@ func (s *Service) Create(ctx context.Context, input Input) (*Swimmer, error) {
 		threshold = *input.ThresholdPercent
 	}
 
+	season := domain.DefaultSeasonDefinition
+	if input.SeasonStart != nil {
+		season, _ = domain.ParseSeasonStart(*input.SeasonStart)
+	}
+
 	params := db.CreateSwimmerParams{
 		Name:             input.Name,
 		BirthDate:        pgtype.Date{Time: birthDate, Valid: true},
 		Gender:           input.Gender,
 		ThresholdPercent: floatToNumeric(threshold),
+		OwnerID:          ownerID,
+		SeasonStartMonth: int16(season.StartMonth),
+		SeasonStartDay:   int16(season.StartDay),
 	}
 
 	dbSwimmer, err := s.repo.Create(ctx, params)
@@ -130,13 +238,19 @@ func (s *Service) Create(ctx context.Context, input Input) (*Swimmer, error) {
 	return toSwimmer(dbSwimmer), nil
 }
 
-// Update updates an existing swimmer.
-func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Swimmer, error) {
+// Update updates an existing swimmer of the given owner.
+func (s *Service) Update(ctx context.Context, ownerID string, id uuid.UUID, input Input) (*Swimmer, error) {
 	input.Sanitize()
 	if err := input.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
 	}
 
+	// Verify ownership
+	existing, err := s.repo.GetForOwner(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
 	birthDate, _ := time.Parse("2006-01-02", input.BirthDate)
 
 	// Use default threshold if not provided
@@ -145,12 +259,19 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Swimm
 		threshold = *input.ThresholdPercent
 	}
 
+	season := SeasonDefinition(existing)
+	if input.SeasonStart != nil {
+		season, _ = domain.ParseSeasonStart(*input.SeasonStart)
+	}
+
 	params := db.UpdateSwimmerParams{
 		ID:               id,
 		Name:             input.Name,
 		BirthDate:        pgtype.Date{Time: birthDate, Valid: true},
 		Gender:           input.Gender,
 		ThresholdPercent: floatToNumeric(threshold),
+		SeasonStartMonth: int16(season.StartMonth),
+		SeasonStartDay:   int16(season.StartDay),
 	}
 
 	dbSwimmer, err := s.repo.Update(ctx, params)
@@ -160,26 +281,39 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Swimm
 	return toSwimmer(dbSwimmer), nil
 }
 
-// CreateOrUpdate creates a swimmer if none exists, otherwise updates the first one.
-func (s *Service) CreateOrUpdate(ctx context.Context, input Input) (*Swimmer, bool, error) {
+// Delete deletes a swimmer of the given owner together with their times.
+func (s *Service) Delete(ctx context.Context, ownerID string, id uuid.UUID) error {
+	// Verify ownership
+	if _, err := s.repo.GetForOwner(ctx, id, ownerID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete swimmer: %w", err)
+	}
+	return nil
+}
+
+// CreateOrUpdate creates a swimmer if the owner has none, otherwise updates their default swimmer.
+func (s *Service) CreateOrUpdate(ctx context.Context, ownerID string, input Input) (*Swimmer, bool, error) {
 	// Check if swimmer exists
-	existing, err := s.repo.GetFirst(ctx)
+	existing, err := s.Get(ctx, ownerID)
 	if err != nil && !errors.Is(err, postgres.ErrNotFound) {
 		return nil, false, fmt.Errorf("check existing: %w", err)
 	}
 
 	if existing != nil {
 		// Update existing
-		swimmer, err := s.Update(ctx, existing.ID, input)
+		swimmer, err := s.Update(ctx, ownerID, existing.ID, input)
 		return swimmer, false, err
 	}
 
 	// Create new
-	swimmer, err := s.Create(ctx, input)
+	swimmer, err := s.Create(ctx, ownerID, input)
 	return swimmer, true, err
 }
 
-// Exists checks if a swimmer exists.
+// Exists checks if any swimmer exists.
 func (s *Service) Exists(ctx context.Context) (bool, error) {
 	count, err := s.repo.Count(ctx)
 	if err != nil {
@@ -200,11 +334,20 @@ func toSwimmer(dbSwimmer *db.Swimmer) *Swimmer {
 		BirthDate:        birthDate,
 		Gender:           dbSwimmer.Gender,
 		ThresholdPercent: numericToFloat(dbSwimmer.ThresholdPercent),
+		SeasonStart:      SeasonDefinition(dbSwimmer).String(),
 		CurrentAge:       currentAge,
 		CurrentAgeGroup:  string(ageGroup),
 	}
 }
 
+// SeasonDefinition returns the season definition of a stored swimmer.
+func SeasonDefinition(dbSwimmer *db.Swimmer) domain.SeasonDefinition {
+	return domain.SeasonDefinition{
+		StartMonth: time.Month(dbSwimmer.SeasonStartMonth),
+		StartDay:   int(dbSwimmer.SeasonStartDay),
+	}
+}
+
 // floatToNumeric converts a float64 to pgtype.Numeric.
 func floatToNumeric(f float64) pgtype.Numeric {
 	var n pgtype.Numeric
@@ -5,43 +5,110 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	gotime "time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/bpg/swimstats/backend/internal/domain"
+	"github.com/bpg/swimstats/backend/internal/domain/ladder"
+	"github.com/bpg/swimstats/backend/internal/domain/points"
 	"github.com/bpg/swimstats/backend/internal/store/db"
 	"github.com/bpg/swimstats/backend/internal/store/postgres"
 )
 
 // Service provides time business logic.
 type Service struct {
-	timeRepo *postgres.TimeRepository
-	meetRepo *postgres.MeetRepository
+	timeRepo    *postgres.TimeRepository
+	meetRepo    *postgres.MeetRepository
+	swimmerRepo *postgres.SwimmerRepository
+	points      *points.Service
+	ladders     *ladder.Service
+	txs         postgres.TxBeginner
+
+	// scorings caches what scoring each swimmer's swims needs for the
+	// lifetime of a transaction, as imports create many times in one
+	scorings map[uuid.UUID]*scoring
+}
+
+// scoring holds a swimmer with the points calculator of the swimmer's gender
+// and the grading ladders loaded so far, by course type.
+type scoring struct {
+	swimmer    *db.Swimmer
+	calculator *points.Calculator
+	graders    map[string]*ladder.Grader
 }
 
 // NewService creates a new time service.
-func NewService(timeRepo *postgres.TimeRepository, meetRepo *postgres.MeetRepository) *Service {
+func NewService(
+	timeRepo *postgres.TimeRepository,
+	meetRepo *postgres.MeetRepository,
+	swimmerRepo *postgres.SwimmerRepository,
+	pointsService *points.Service,
+	ladderService *ladder.Service,
+	txs postgres.TxBeginner,
+) *Service {
+	return &Service{
+		timeRepo:    timeRepo,
+		meetRepo:    meetRepo,
+		swimmerRepo: swimmerRepo,
+		points:      pointsService,
+		ladders:     ladderService,
+		txs:         txs,
+	}
+}
+
+// WithTx returns a service that runs its queries in the transaction.
+// Transactions begun by the returned service are nested in tx, and share
+// its scorings.
+func (s *Service) WithTx(tx pgx.Tx) *Service {
+	scorings := s.scorings
+	if scorings == nil {
+		scorings = make(map[uuid.UUID]*scoring)
+	}
 	return &Service{
-		timeRepo: timeRepo,
-		meetRepo: meetRepo,
+		timeRepo:    s.timeRepo.WithTx(tx),
+		meetRepo:    s.meetRepo.WithTx(tx),
+		swimmerRepo: s.swimmerRepo.WithTx(tx),
+		points:      s.points.WithTx(tx),
+		ladders:     s.ladders.WithTx(tx),
+		txs:         tx,
+		scorings:    scorings,
 	}
 }
 
 // TimeRecord represents a recorded time with computed fields.
 type TimeRecord struct {
 	ID            uuid.UUID `json:"id"`
+	SwimmerID     uuid.UUID `json:"swimmer_id"`
 	MeetID        uuid.UUID `json:"meet_id"`
 	Event         string    `json:"event"`
 	TimeMS        int       `json:"time_ms"`
 	TimeFormatted string    `json:"time_formatted"`
 	EventDate     string    `json:"event_date,omitempty"`
 	Notes         string    `json:"notes,omitempty"`
+	Status        string    `json:"status"`
+	DQCode        string    `json:"dq_code,omitempty"`
+	DQReason      string    `json:"dq_reason,omitempty"`
+	RelayLeg      int       `json:"relay_leg,omitempty"`
+	RelayStroke   string    `json:"relay_stroke,omitempty"`
+	OfficialEvent string    `json:"official_event,omitempty"`
 	IsPB          bool      `json:"is_pb,omitempty"`
+	Points        *int      `json:"points,omitempty"`
+	Grade         string    `json:"grade,omitempty"`
+	Splits        []Split   `json:"splits,omitempty"`
 	Meet          *Meet     `json:"meet,omitempty"`
 }
 
+// Split is the cumulative time at a distance within a swim.
+type Split struct {
+	Distance      int    `json:"distance"`
+	TimeMS        int    `json:"time_ms"`
+	TimeFormatted string `json:"time_formatted,omitempty"`
+}
+
 // Meet represents basic meet info embedded in a time record.
 type Meet struct {
 	ID         uuid.UUID `json:"id"`
@@ -65,12 +132,22 @@ type BatchResult struct {
 }
 
 // Input represents input for creating/updating a time.
+// For relay events TimeMS is the swimmer's own leg; RelayStroke defaults to
+// the stroke of the leg. On update, nil Splits keep the existing splits.
+// Status defaults to "ok"; other results have no time (0) unless one was
+// recorded for a DQ or DNF.
 type Input struct {
-	MeetID    uuid.UUID `json:"meet_id"`
-	Event     string    `json:"event"`
-	TimeMS    int       `json:"time_ms"`
-	EventDate string    `json:"event_date"`
-	Notes     string    `json:"notes,omitempty"`
+	MeetID      uuid.UUID `json:"meet_id"`
+	Event       string    `json:"event"`
+	TimeMS      int       `json:"time_ms"`
+	EventDate   string    `json:"event_date"`
+	Notes       string    `json:"notes,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	DQCode      string    `json:"dq_code,omitempty"`
+	DQReason    string    `json:"dq_reason,omitempty"`
+	RelayLeg    int       `json:"relay_leg,omitempty"`
+	RelayStroke string    `json:"relay_stroke,omitempty"`
+	Splits      []Split   `json:"splits,omitempty"`
 }
 
 // Sanitize trims whitespace from string fields.
@@ -78,14 +155,24 @@ func (i *Input) Sanitize() {
 	i.Event = domain.SanitizeString(i.Event)
 	i.EventDate = domain.SanitizeString(i.EventDate)
 	i.Notes = domain.SanitizeString(i.Notes)
+	i.Status = sanitizeStatus(i.Status)
+	i.DQCode = domain.SanitizeString(i.DQCode)
+	i.DQReason = domain.SanitizeString(i.DQReason)
+	i.RelayStroke = strings.ToUpper(domain.SanitizeString(i.RelayStroke))
 }
 
 // BatchTimeInput represents a single time in a batch.
 type BatchTimeInput struct {
-	Event     string `json:"event"`
-	TimeMS    int    `json:"time_ms"`
-	EventDate string `json:"event_date"`
-	Notes     string `json:"notes,omitempty"`
+	Event       string  `json:"event"`
+	TimeMS      int     `json:"time_ms"`
+	EventDate   string  `json:"event_date"`
+	Notes       string  `json:"notes,omitempty"`
+	Status      string  `json:"status,omitempty"`
+	DQCode      string  `json:"dq_code,omitempty"`
+	DQReason    string  `json:"dq_reason,omitempty"`
+	RelayLeg    int     `json:"relay_leg,omitempty"`
+	RelayStroke string  `json:"relay_stroke,omitempty"`
+	Splits      []Split `json:"splits,omitempty"`
 }
 
 // Sanitize trims whitespace from string fields.
@@ -93,6 +180,10 @@ func (i *BatchTimeInput) Sanitize() {
 	i.Event = domain.SanitizeString(i.Event)
 	i.EventDate = domain.SanitizeString(i.EventDate)
 	i.Notes = domain.SanitizeString(i.Notes)
+	i.Status = sanitizeStatus(i.Status)
+	i.DQCode = domain.SanitizeString(i.DQCode)
+	i.DQReason = domain.SanitizeString(i.DQReason)
+	i.RelayStroke = strings.ToUpper(domain.SanitizeString(i.RelayStroke))
 }
 
 // BatchInput represents input for batch time creation.
@@ -106,11 +197,14 @@ func (i Input) Validate() error {
 	if i.MeetID == uuid.Nil {
 		return errors.New("meet_id is required")
 	}
-	if !domain.IsValidEvent(i.Event) {
+	if !domain.IsValidEvent(i.Event) && !domain.IsValidRelayEvent(i.Event) {
 		return errors.New("invalid event code")
 	}
-	if i.TimeMS <= 0 {
-		return errors.New("time_ms must be positive")
+	if err := ValidateStatus(i.Status, i.TimeMS, i.DQCode, i.DQReason); err != nil {
+		return err
+	}
+	if err := ValidateRelay(i.Event, i.RelayLeg, i.RelayStroke); err != nil {
+		return err
 	}
 	if len(i.Notes) > 1000 {
 		return errors.New("notes must be at most 1000 characters")
@@ -121,6 +215,103 @@ func (i Input) Validate() error {
 	if _, err := gotime.Parse("2006-01-02", i.EventDate); err != nil {
 		return errors.New("event_date must be a valid date in YYYY-MM-DD format")
 	}
+	return ValidateSplits(i.Event, i.TimeMS, i.Splits)
+}
+
+// ValidateStatus validates the result status of a swim. Official swims need
+// a time; DNS and scratches have none, while DQ and DNF results may keep the
+// time recorded. A DQ code and reason are only allowed for disqualifications.
+func ValidateStatus(status string, timeMS int, dqCode, dqReason string) error {
+	result := domain.ResultStatus(status)
+	if !result.IsValid() {
+		return errors.New("status must be one of 'ok', 'dq', 'dns', 'dnf' or 'scr'")
+	}
+
+	switch {
+	case result.IsOfficial() && timeMS <= 0:
+		return errors.New("time_ms must be positive")
+	case !result.Started() && timeMS != 0:
+		return fmt.Errorf("time_ms must be 0 for %s results", result.Label())
+	case timeMS < 0:
+		return errors.New("time_ms must not be negative")
+	}
+
+	if result != domain.ResultDisqualified && (dqCode != "" || dqReason != "") {
+		return errors.New("dq_code and dq_reason are only allowed for disqualifications")
+	}
+	if len(dqCode) > 20 {
+		return errors.New("dq_code must be at most 20 characters")
+	}
+	if len(dqReason) > 1000 {
+		return errors.New("dq_reason must be at most 1000 characters")
+	}
+	return nil
+}
+
+// ValidateRelay validates the relay leg and stroke of a swim. Relay events
+// require a leg (1-4); the stroke is optional but must match the leg.
+// Individual events must not have relay details.
+func ValidateRelay(event string, leg int, stroke string) error {
+	code := domain.EventCode(event)
+	if !code.IsRelay() {
+		if leg != 0 || stroke != "" {
+			return errors.New("relay_leg and relay_stroke are only allowed for relay events")
+		}
+		return nil
+	}
+
+	if leg < 1 || leg > domain.RelayLegs {
+		return fmt.Errorf("relay_leg must be between 1 and %d for relay events", domain.RelayLegs)
+	}
+	if stroke != "" && stroke != code.LegStroke(leg) {
+		return fmt.Errorf("relay_stroke for leg %d of %s must be %s", leg, event, code.LegStroke(leg))
+	}
+	return nil
+}
+
+// ValidateSplits validates the cumulative splits of a swim. Distances and
+// times must increase, and the last split must be at the distance swum
+// (the leg distance for relays) and equal the final time.
+func ValidateSplits(event string, timeMS int, splits []Split) error {
+	if len(splits) == 0 {
+		return nil
+	}
+	if timeMS <= 0 {
+		return errors.New("splits require a final time")
+	}
+
+	distance := domain.EventCode(event).LegDistance()
+	prev := Split{}
+	for _, split := range splits {
+		if split.Distance <= prev.Distance {
+			return errors.New("split distances must be positive and increasing")
+		}
+		if split.TimeMS <= prev.TimeMS {
+			return errors.New("split times must be positive and increasing")
+		}
+		if split.Distance > distance {
+			return fmt.Errorf("split distance %d exceeds swim distance %d", split.Distance, distance)
+		}
+		prev = split
+	}
+
+	if prev.Distance != distance {
+		return fmt.Errorf("last split must be at the swim distance %d", distance)
+	}
+	if prev.TimeMS != timeMS {
+		return fmt.Errorf("last split %s must equal the final time %s",
+			domain.FormatTime(prev.TimeMS), domain.FormatTime(timeMS))
+	}
+
+	return nil
+}
+
+// ValidateCourse validates that the event is swum in the meet's course, so
+// yard distances are only recorded at yards meets and vice versa.
+func ValidateCourse(event, courseType string) error {
+	if !domain.EventCode(event).IsValidForCourse(domain.CourseType(courseType)) {
+		return fmt.Errorf("event %s is not swum in a %s pool", event, courseType)
+	}
 	return nil
 }
 
@@ -158,13 +349,41 @@ type ListParams struct {
 	Offset     int
 }
 
+// ResultStats counts a swimmer's results by status. Swims are the official
+// results; the other counts are the unofficial ones.
+type ResultStats struct {
+	Total        int `json:"total"`
+	Swims        int `json:"swims"`
+	Disqualified int `json:"disqualified"`
+	DidNotStart  int `json:"did_not_start"`
+	DidNotFinish int `json:"did_not_finish"`
+	Scratched    int `json:"scratched"`
+}
+
+// StatsParams contains parameters for counting results.
+type StatsParams struct {
+	SwimmerID  uuid.UUID
+	CourseType string
+	StartDate  *gotime.Time
+	EndDate    *gotime.Time
+}
+
 // Get retrieves a time by ID with meet details.
 func (s *Service) Get(ctx context.Context, id uuid.UUID) (*TimeRecord, error) {
 	row, err := s.timeRepo.GetWithMeet(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return toTimeRecordFromRow(row), nil
+
+	record := toTimeRecordFromRow(row)
+	record.Splits, err = s.listSplits(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.setPoints(ctx, record.SwimmerID, row.MeetCourseType, []*TimeRecord{record}); err != nil {
+		return nil, err
+	}
+	return record, nil
 }
 
 // List retrieves a paginated list of times.
@@ -197,6 +416,7 @@ func (s *Service) List(ctx context.Context, params ListParams) (*TimeList, error
 	}
 
 	times := make([]TimeRecord, len(rows))
+	records := make([]*TimeRecord, len(rows))
 	for i, row := range rows {
 		var eventDate string
 		if row.EventDate.Valid {
@@ -205,6 +425,7 @@ func (s *Service) List(ctx context.Context, params ListParams) (*TimeList, error
 
 		times[i] = TimeRecord{
 			ID:            row.ID,
+			SwimmerID:     row.SwimmerID,
 			MeetID:        row.MeetID,
 			Event:         row.Event,
 			TimeMS:        int(row.TimeMs),
@@ -220,6 +441,13 @@ func (s *Service) List(ctx context.Context, params ListParams) (*TimeList, error
 				CourseType: row.MeetCourseType,
 			},
 		}
+		times[i].setRelay(row.RelayLeg, row.RelayStroke, row.OfficialEvent)
+		times[i].setStatus(row.Status, row.DqCode, row.DqReason)
+		times[i].Grade = row.Grade.String
+		records[i] = &times[i]
+	}
+	if err := s.setPoints(ctx, params.SwimmerID, "", records); err != nil {
+		return nil, err
 	}
 
 	return &TimeList{
@@ -228,15 +456,63 @@ func (s *Service) List(ctx context.Context, params ListParams) (*TimeList, error
 	}, nil
 }
 
-// Create creates a new time.
-func (s *Service) Create(ctx context.Context, swimmerID uuid.UUID, input Input) (*TimeRecord, error) {
+// Stats counts a swimmer's results by status, optionally within a course and date range.
+func (s *Service) Stats(ctx context.Context, params StatsParams) (*ResultStats, error) {
+	if params.CourseType != "" && !domain.CourseType(params.CourseType).IsValid() {
+		return nil, errors.New("validation: course_type must be '25m', '50m' or '25y'")
+	}
+	if params.StartDate != nil && params.EndDate != nil && params.EndDate.Before(*params.StartDate) {
+		return nil, errors.New("validation: end_date cannot be before start_date")
+	}
+
+	rows, err := s.timeRepo.CountResultsByStatus(ctx, params.SwimmerID, params.CourseType, params.StartDate, params.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ResultStats{}
+	for _, row := range rows {
+		count := int(row.Count)
+		stats.Total += count
+		switch domain.ResultStatus(row.Status) {
+		case domain.ResultOK:
+			stats.Swims = count
+		case domain.ResultDisqualified:
+			stats.Disqualified = count
+		case domain.ResultDidNotStart:
+			stats.DidNotStart = count
+		case domain.ResultDidNotFinish:
+			stats.DidNotFinish = count
+		case domain.ResultScratched:
+			stats.Scratched = count
+		}
+	}
+	return stats, nil
+}
+
+// Create creates a new time for a swimmer at one of the owner's meets. The
+// time and its splits are saved in a single transaction.
+func (s *Service) Create(ctx context.Context, ownerID string, swimmerID uuid.UUID, input Input) (*TimeRecord, error) {
+	var record *TimeRecord
+	err := postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		var err error
+		record, err = s.WithTx(tx).create(ctx, ownerID, swimmerID, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (s *Service) create(ctx context.Context, ownerID string, swimmerID uuid.UUID, input Input) (*TimeRecord, error) {
 	input.Sanitize()
 	if err := input.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
 	}
 
 	// Verify meet exists and get course type
-	meet, err := s.meetRepo.Get(ctx, input.MeetID)
+	meet, err := s.meetRepo.Get(ctx, input.MeetID, ownerID)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			return nil, fmt.Errorf("meet not found")
@@ -248,6 +524,9 @@ func (s *Service) Create(ctx context.Context, swimmerID uuid.UUID, input Input)
 	if err := ValidateEventDate(input.EventDate, meet.StartDate.Time, meet.EndDate.Time); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
 	}
+	if err := ValidateCourse(input.Event, meet.CourseType); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
 
 	// Check for duplicate event in the same meet
 	exists, err := s.timeRepo.EventExistsForMeet(ctx, swimmerID, input.MeetID, input.Event)
@@ -267,13 +546,32 @@ func (s *Service) Create(ctx context.Context, swimmerID uuid.UUID, input Input)
 	ed, _ := gotime.Parse("2006-01-02", input.EventDate)
 	eventDate := pgtype.Date{Time: ed, Valid: true}
 
+	relayLeg, relayStroke, officialEvent := relayColumns(input.Event, input.RelayLeg)
+	dqCode, dqReason := dqColumns(input.DQCode, input.DQReason)
+
+	sc, err := s.scoringFor(ctx, swimmerID)
+	if err != nil {
+		return nil, err
+	}
+	grade, err := s.grade(ctx, sc, meet, officialEvent, input.Status, input.TimeMS)
+	if err != nil {
+		return nil, err
+	}
+
 	params := db.CreateTimeParams{
-		SwimmerID: swimmerID,
-		MeetID:    input.MeetID,
-		Event:     input.Event,
-		TimeMs:    int32(input.TimeMS),
-		EventDate: eventDate,
-		Notes:     notes,
+		SwimmerID:     swimmerID,
+		MeetID:        input.MeetID,
+		Event:         input.Event,
+		TimeMs:        int32(input.TimeMS),
+		EventDate:     eventDate,
+		Notes:         notes,
+		RelayLeg:      relayLeg,
+		RelayStroke:   relayStroke,
+		OfficialEvent: officialEvent,
+		Status:        input.Status,
+		DqCode:        dqCode,
+		DqReason:      dqReason,
+		Grade:         grade,
 	}
 
 	dbTime, err := s.timeRepo.Create(ctx, params)
@@ -281,14 +579,24 @@ func (s *Service) Create(ctx context.Context, swimmerID uuid.UUID, input Input)
 		return nil, fmt.Errorf("create time: %w", err)
 	}
 
-	// Check if this is a PB
-	isPB, _ := s.timeRepo.IsPersonalBest(ctx, swimmerID, meet.CourseType, input.Event, int32(input.TimeMS), &dbTime.ID)
+	splits, err := s.saveSplits(ctx, dbTime.ID, input.Splits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if this is a PB (relay legs other than the lead-off and
+	// unofficial results never are)
+	isPB := false
+	if officialEvent != "" && domain.ResultStatus(input.Status).IsOfficial() {
+		isPB, _ = s.timeRepo.IsPersonalBest(ctx, swimmerID, meet.CourseType, officialEvent, int32(input.TimeMS), &dbTime.ID)
+	}
 
 	// EventDate is always valid since it's required
 	eventDateStr := dbTime.EventDate.Time.Format("2006-01-02")
 
-	return &TimeRecord{
+	record := &TimeRecord{
 		ID:            dbTime.ID,
+		SwimmerID:     dbTime.SwimmerID,
 		MeetID:        dbTime.MeetID,
 		Event:         dbTime.Event,
 		TimeMS:        int(dbTime.TimeMs),
@@ -296,6 +604,7 @@ func (s *Service) Create(ctx context.Context, swimmerID uuid.UUID, input Input)
 		EventDate:     eventDateStr,
 		Notes:         dbTime.Notes.String,
 		IsPB:          isPB,
+		Splits:        splits,
 		Meet: &Meet{
 			ID:         meet.ID,
 			Name:       meet.Name,
@@ -304,11 +613,30 @@ func (s *Service) Create(ctx context.Context, swimmerID uuid.UUID, input Input)
 			EndDate:    meet.EndDate.Time.Format("2006-01-02"),
 			CourseType: meet.CourseType,
 		},
-	}, nil
+	}
+	record.setRelay(dbTime.RelayLeg, dbTime.RelayStroke, dbTime.OfficialEvent)
+	record.setStatus(dbTime.Status, dbTime.DqCode, dbTime.DqReason)
+	record.Grade = dbTime.Grade.String
+	sc.setPoints(meet.CourseType, []*TimeRecord{record})
+	return record, nil
 }
 
-// CreateBatch creates multiple times in a batch.
-func (s *Service) CreateBatch(ctx context.Context, swimmerID uuid.UUID, input BatchInput) (*BatchResult, error) {
+// CreateBatch creates multiple times for a swimmer at one of the owner's
+// meets in a single transaction: either all of them are saved or none.
+func (s *Service) CreateBatch(ctx context.Context, ownerID string, swimmerID uuid.UUID, input BatchInput) (*BatchResult, error) {
+	var result *BatchResult
+	err := postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		var err error
+		result, err = s.WithTx(tx).createBatch(ctx, ownerID, swimmerID, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) createBatch(ctx context.Context, ownerID string, swimmerID uuid.UUID, input BatchInput) (*BatchResult, error) {
 	if input.MeetID == uuid.Nil {
 		return nil, errors.New("meet_id is required")
 	}
@@ -334,7 +662,7 @@ func (s *Service) CreateBatch(ctx context.Context, swimmerID uuid.UUID, input Ba
 	}
 
 	// Verify meet exists and get course type
-	meet, err := s.meetRepo.Get(ctx, input.MeetID)
+	meet, err := s.meetRepo.Get(ctx, input.MeetID, ownerID)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			return nil, fmt.Errorf("meet not found")
@@ -342,11 +670,23 @@ func (s *Service) CreateBatch(ctx context.Context, swimmerID uuid.UUID, input Ba
 		return nil, fmt.Errorf("get meet: %w", err)
 	}
 
-	// Validate event dates are within meet range
+	// Validate event dates are within meet range and splits match the final times
 	for _, t := range input.Times {
 		if err := ValidateEventDate(t.EventDate, meet.StartDate.Time, meet.EndDate.Time); err != nil {
 			return nil, fmt.Errorf("validation for %s: %w", t.Event, err)
 		}
+		if err := ValidateCourse(t.Event, meet.CourseType); err != nil {
+			return nil, fmt.Errorf("validation for %s: %w", t.Event, err)
+		}
+		if err := ValidateStatus(t.Status, t.TimeMS, t.DQCode, t.DQReason); err != nil {
+			return nil, fmt.Errorf("validation for %s: %w", t.Event, err)
+		}
+		if err := ValidateRelay(t.Event, t.RelayLeg, t.RelayStroke); err != nil {
+			return nil, fmt.Errorf("validation for %s: %w", t.Event, err)
+		}
+		if err := ValidateSplits(t.Event, t.TimeMS, t.Splits); err != nil {
+			return nil, fmt.Errorf("validation for %s: %w", t.Event, err)
+		}
 	}
 
 	// Check for duplicate events already in the meet
@@ -367,16 +707,22 @@ func (s *Service) CreateBatch(ctx context.Context, swimmerID uuid.UUID, input Ba
 		existingPBs[pb.Event] = pb.TimeMs
 	}
 
+	sc, err := s.scoringFor(ctx, swimmerID)
+	if err != nil {
+		return nil, err
+	}
+	grader, err := s.grader(ctx, sc, meet.CourseType)
+	if err != nil {
+		return nil, err
+	}
+
 	times := make([]TimeRecord, 0, len(input.Times))
 	newPBs := make(map[string]bool)
 
 	for _, t := range input.Times {
-		if !domain.IsValidEvent(t.Event) {
+		if !domain.IsValidEvent(t.Event) && !domain.IsValidRelayEvent(t.Event) {
 			return nil, fmt.Errorf("invalid event code: %s", t.Event)
 		}
-		if t.TimeMS <= 0 {
-			return nil, fmt.Errorf("time_ms must be positive for event %s", t.Event)
-		}
 
 		var notes pgtype.Text
 		if t.Notes != "" {
@@ -387,13 +733,24 @@ func (s *Service) CreateBatch(ctx context.Context, swimmerID uuid.UUID, input Ba
 		ed, _ := gotime.Parse("2006-01-02", t.EventDate)
 		eventDate := pgtype.Date{Time: ed, Valid: true}
 
+		relayLeg, relayStroke, officialEvent := relayColumns(t.Event, t.RelayLeg)
+		dqCode, dqReason := dqColumns(t.DQCode, t.DQReason)
+		grade := grader.GradeColumn(officialEvent, t.Status, sc.swimmer.BirthDate.Time, meet.StartDate.Time, t.TimeMS)
+
 		params := db.CreateTimeParams{
-			SwimmerID: swimmerID,
-			MeetID:    input.MeetID,
-			Event:     t.Event,
-			TimeMs:    int32(t.TimeMS),
-			EventDate: eventDate,
-			Notes:     notes,
+			SwimmerID:     swimmerID,
+			MeetID:        input.MeetID,
+			Event:         t.Event,
+			TimeMs:        int32(t.TimeMS),
+			EventDate:     eventDate,
+			Notes:         notes,
+			RelayLeg:      relayLeg,
+			RelayStroke:   relayStroke,
+			OfficialEvent: officialEvent,
+			Status:        t.Status,
+			DqCode:        dqCode,
+			DqReason:      dqReason,
+			Grade:         grade,
 		}
 
 		dbTime, err := s.timeRepo.Create(ctx, params)
@@ -401,14 +758,21 @@ func (s *Service) CreateBatch(ctx context.Context, swimmerID uuid.UUID, input Ba
 			return nil, fmt.Errorf("create time for %s: %w", t.Event, err)
 		}
 
-		// Check if this is a new PB
+		splits, err := s.saveSplits(ctx, dbTime.ID, t.Splits)
+		if err != nil {
+			return nil, err
+		}
+
+		// Check if this is a new PB (relay legs other than the lead-off and
+		// unofficial results never are)
 		isPB := false
-		if existingPB, exists := existingPBs[t.Event]; !exists || int32(t.TimeMS) < existingPB {
+		official := officialEvent != "" && domain.ResultStatus(t.Status).IsOfficial()
+		if existingPB, exists := existingPBs[officialEvent]; official && (!exists || int32(t.TimeMS) < existingPB) {
 			isPB = true
 			// Only add to newPBs if it's the fastest we've seen for this event in this batch
-			if !newPBs[t.Event] || int32(t.TimeMS) < existingPBs[t.Event] {
-				newPBs[t.Event] = true
-				existingPBs[t.Event] = int32(t.TimeMS) // Update for subsequent comparisons in batch
+			if !newPBs[officialEvent] || int32(t.TimeMS) < existingPBs[officialEvent] {
+				newPBs[officialEvent] = true
+				existingPBs[officialEvent] = int32(t.TimeMS) // Update for subsequent comparisons in batch
 			}
 		}
 
@@ -417,8 +781,9 @@ func (s *Service) CreateBatch(ctx context.Context, swimmerID uuid.UUID, input Ba
 			eventDateStr = dbTime.EventDate.Time.Format("2006-01-02")
 		}
 
-		times = append(times, TimeRecord{
+		record := TimeRecord{
 			ID:            dbTime.ID,
+			SwimmerID:     dbTime.SwimmerID,
 			MeetID:        dbTime.MeetID,
 			Event:         dbTime.Event,
 			TimeMS:        int(dbTime.TimeMs),
@@ -426,8 +791,19 @@ func (s *Service) CreateBatch(ctx context.Context, swimmerID uuid.UUID, input Ba
 			EventDate:     eventDateStr,
 			Notes:         dbTime.Notes.String,
 			IsPB:          isPB,
-		})
+			Splits:        splits,
+		}
+		record.setRelay(dbTime.RelayLeg, dbTime.RelayStroke, dbTime.OfficialEvent)
+		record.setStatus(dbTime.Status, dbTime.DqCode, dbTime.DqReason)
+		record.Grade = dbTime.Grade.String
+		times = append(times, record)
+	}
+
+	records := make([]*TimeRecord, len(times))
+	for i := range times {
+		records[i] = &times[i]
 	}
+	sc.setPoints(meet.CourseType, records)
 
 	// Convert newPBs map to slice
 	pbSlice := make([]string, 0, len(newPBs))
@@ -441,15 +817,29 @@ func (s *Service) CreateBatch(ctx context.Context, swimmerID uuid.UUID, input Ba
 	}, nil
 }
 
-// Update updates an existing time.
-func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*TimeRecord, error) {
+// Update updates an existing time, moving it to another of the owner's meets
+// if needed. The time and its splits are saved in a single transaction.
+func (s *Service) Update(ctx context.Context, ownerID string, id uuid.UUID, input Input) (*TimeRecord, error) {
+	var record *TimeRecord
+	err := postgres.InTx(ctx, s.txs, func(tx pgx.Tx) error {
+		var err error
+		record, err = s.WithTx(tx).update(ctx, ownerID, id, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (s *Service) update(ctx context.Context, ownerID string, id uuid.UUID, input Input) (*TimeRecord, error) {
 	input.Sanitize()
 	if err := input.Validate(); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
 	}
 
 	// Verify meet exists
-	meet, err := s.meetRepo.Get(ctx, input.MeetID)
+	meet, err := s.meetRepo.Get(ctx, input.MeetID, ownerID)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNotFound) {
 			return nil, fmt.Errorf("meet not found")
@@ -461,6 +851,20 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*TimeR
 	if err := ValidateEventDate(input.EventDate, meet.StartDate.Time, meet.EndDate.Time); err != nil {
 		return nil, fmt.Errorf("validation: %w", err)
 	}
+	if err := ValidateCourse(input.Event, meet.CourseType); err != nil {
+		return nil, fmt.Errorf("validation: %w", err)
+	}
+
+	// Existing splits are kept when none are given, so they must still match the time
+	if input.Splits == nil {
+		existing, err := s.listSplits(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := ValidateSplits(input.Event, input.TimeMS, existing); err != nil {
+			return nil, fmt.Errorf("validation: existing splits no longer match, provide updated splits: %w", err)
+		}
+	}
 
 	var notes pgtype.Text
 	if input.Notes != "" {
@@ -471,13 +875,36 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*TimeR
 	ed, _ := gotime.Parse("2006-01-02", input.EventDate)
 	eventDate := pgtype.Date{Time: ed, Valid: true}
 
+	relayLeg, relayStroke, officialEvent := relayColumns(input.Event, input.RelayLeg)
+	dqCode, dqReason := dqColumns(input.DQCode, input.DQReason)
+
+	existingTime, err := s.timeRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := s.scoringFor(ctx, existingTime.SwimmerID)
+	if err != nil {
+		return nil, err
+	}
+	grade, err := s.grade(ctx, sc, meet, officialEvent, input.Status, input.TimeMS)
+	if err != nil {
+		return nil, err
+	}
+
 	params := db.UpdateTimeParams{
-		ID:        id,
-		MeetID:    input.MeetID,
-		Event:     input.Event,
-		TimeMs:    int32(input.TimeMS),
-		EventDate: eventDate,
-		Notes:     notes,
+		ID:            id,
+		MeetID:        input.MeetID,
+		Event:         input.Event,
+		TimeMs:        int32(input.TimeMS),
+		EventDate:     eventDate,
+		Notes:         notes,
+		RelayLeg:      relayLeg,
+		RelayStroke:   relayStroke,
+		OfficialEvent: officialEvent,
+		Status:        input.Status,
+		DqCode:        dqCode,
+		DqReason:      dqReason,
+		Grade:         grade,
 	}
 
 	dbTime, err := s.timeRepo.Update(ctx, params)
@@ -485,17 +912,32 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*TimeR
 		return nil, fmt.Errorf("update time: %w", err)
 	}
 
+	var splits []Split
+	if input.Splits != nil {
+		if err := s.timeRepo.DeleteSplits(ctx, id); err != nil {
+			return nil, fmt.Errorf("delete splits: %w", err)
+		}
+		splits, err = s.saveSplits(ctx, id, input.Splits)
+	} else {
+		splits, err = s.listSplits(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	// EventDate is always valid since it's required
 	eventDateStr := dbTime.EventDate.Time.Format("2006-01-02")
 
-	return &TimeRecord{
+	record := &TimeRecord{
 		ID:            dbTime.ID,
+		SwimmerID:     dbTime.SwimmerID,
 		MeetID:        dbTime.MeetID,
 		Event:         dbTime.Event,
 		TimeMS:        int(dbTime.TimeMs),
 		TimeFormatted: domain.FormatTime(int(dbTime.TimeMs)),
 		EventDate:     eventDateStr,
 		Notes:         dbTime.Notes.String,
+		Splits:        splits,
 		Meet: &Meet{
 			ID:         meet.ID,
 			Name:       meet.Name,
@@ -504,7 +946,12 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*TimeR
 			EndDate:    meet.EndDate.Time.Format("2006-01-02"),
 			CourseType: meet.CourseType,
 		},
-	}, nil
+	}
+	record.setRelay(dbTime.RelayLeg, dbTime.RelayStroke, dbTime.OfficialEvent)
+	record.setStatus(dbTime.Status, dbTime.DqCode, dbTime.DqReason)
+	record.Grade = dbTime.Grade.String
+	sc.setPoints(meet.CourseType, []*TimeRecord{record})
+	return record, nil
 }
 
 // Delete deletes a time.
@@ -521,14 +968,216 @@ func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// DeleteBySwimmer deletes all times recorded for a swimmer.
+func (s *Service) DeleteBySwimmer(ctx context.Context, swimmerID uuid.UUID) error {
+	if err := s.timeRepo.DeleteBySwimmer(ctx, swimmerID); err != nil {
+		return fmt.Errorf("delete times: %w", err)
+	}
+	return nil
+}
+
+// ListSplitsBySwimmer returns the splits of all times of a swimmer keyed by time ID.
+func (s *Service) ListSplitsBySwimmer(ctx context.Context, swimmerID uuid.UUID) (map[uuid.UUID][]Split, error) {
+	rows, err := s.timeRepo.ListSplitsBySwimmer(ctx, swimmerID)
+	if err != nil {
+		return nil, fmt.Errorf("list splits: %w", err)
+	}
+
+	splits := make(map[uuid.UUID][]Split)
+	for _, row := range rows {
+		splits[row.TimeID] = append(splits[row.TimeID], toSplit(row))
+	}
+	return splits, nil
+}
+
+// listSplits returns the splits of a time.
+func (s *Service) listSplits(ctx context.Context, timeID uuid.UUID) ([]Split, error) {
+	rows, err := s.timeRepo.ListSplits(ctx, timeID)
+	if err != nil {
+		return nil, fmt.Errorf("list splits: %w", err)
+	}
+
+	splits := make([]Split, len(rows))
+	for i, row := range rows {
+		splits[i] = toSplit(row)
+	}
+	return splits, nil
+}
+
+// saveSplits stores validated splits of a time.
+func (s *Service) saveSplits(ctx context.Context, timeID uuid.UUID, input []Split) ([]Split, error) {
+	splits := make([]Split, 0, len(input))
+	for _, split := range input {
+		row, err := s.timeRepo.CreateSplit(ctx, db.CreateSplitParams{
+			TimeID:   timeID,
+			Distance: int32(split.Distance),
+			TimeMs:   int32(split.TimeMS),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create split: %w", err)
+		}
+		splits = append(splits, toSplit(*row))
+	}
+	return splits, nil
+}
+
+func toSplit(row db.Split) Split {
+	return Split{
+		Distance:      int(row.Distance),
+		TimeMS:        int(row.TimeMs),
+		TimeFormatted: domain.FormatTime(int(row.TimeMs)),
+	}
+}
+
+// setPoints sets the World Aquatics points of a swimmer's official swims,
+// counting relay lead-off legs as their individual event. Records without
+// meet details were swum in courseType.
+func (s *Service) setPoints(ctx context.Context, swimmerID uuid.UUID, courseType string, records []*TimeRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	sc, err := s.scoringFor(ctx, swimmerID)
+	if err != nil {
+		return err
+	}
+	sc.setPoints(courseType, records)
+	return nil
+}
+
+// scoringFor returns what scoring the swimmer's swims needs. Services bound
+// to a transaction load it once per swimmer.
+func (s *Service) scoringFor(ctx context.Context, swimmerID uuid.UUID) (*scoring, error) {
+	if sc, ok := s.scorings[swimmerID]; ok {
+		return sc, nil
+	}
+
+	dbSwimmer, err := s.swimmerRepo.Get(ctx, swimmerID)
+	if err != nil {
+		return nil, fmt.Errorf("get swimmer: %w", err)
+	}
+	calculator, err := s.points.Calculator(ctx, dbSwimmer.Gender)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := &scoring{
+		swimmer:    dbSwimmer,
+		calculator: calculator,
+		graders:    make(map[string]*ladder.Grader),
+	}
+	if s.scorings != nil {
+		s.scorings[swimmerID] = sc
+	}
+	return sc, nil
+}
+
+// setPoints sets the points of the records, see Service.setPoints.
+func (sc *scoring) setPoints(courseType string, records []*TimeRecord) {
+	for _, r := range records {
+		event := r.Event
+		if r.RelayLeg != 0 {
+			event = r.OfficialEvent
+		}
+		if event == "" || !domain.ResultStatus(r.Status).IsOfficial() {
+			continue
+		}
+
+		course := courseType
+		if r.Meet != nil {
+			course = r.Meet.CourseType
+		}
+		date, _ := gotime.Parse("2006-01-02", r.EventDate)
+		r.Points = sc.calculator.PointsPtr(course, event, date, r.TimeMS)
+	}
+}
+
+// grader returns the grading ladder of a course and the swimmer's gender that
+// grades the swims of the swimmer's owner, loading it once per scoring.
+func (s *Service) grader(ctx context.Context, sc *scoring, courseType string) (*ladder.Grader, error) {
+	if grader, ok := sc.graders[courseType]; ok {
+		return grader, nil
+	}
+	grader, err := s.ladders.Grader(ctx, sc.swimmer.OwnerID, courseType, sc.swimmer.Gender)
+	if err != nil {
+		return nil, err
+	}
+	sc.graders[courseType] = grader
+	return grader, nil
+}
+
+// grade returns the grade column of a swim at a meet on the grading ladder of
+// the meet's course and the swimmer's gender.
+func (s *Service) grade(ctx context.Context, sc *scoring, meet *db.Meet, officialEvent, status string, timeMS int) (pgtype.Text, error) {
+	grader, err := s.grader(ctx, sc, meet.CourseType)
+	if err != nil {
+		return pgtype.Text{}, err
+	}
+	return grader.GradeColumn(officialEvent, status, sc.swimmer.BirthDate.Time, meet.StartDate.Time, timeMS), nil
+}
+
+// relayColumns returns the relay leg, stroke and official event columns of a swim.
+func relayColumns(event string, leg int) (pgtype.Int2, pgtype.Text, string) {
+	code := domain.EventCode(event)
+	if !code.IsRelay() {
+		return pgtype.Int2{}, pgtype.Text{}, event
+	}
+	return pgtype.Int2{Int16: int16(leg), Valid: true},
+		pgtype.Text{String: code.LegStroke(leg), Valid: true},
+		string(code.OfficialEvent(leg))
+}
+
+// setRelay fills in the relay details of a relay swim.
+func (r *TimeRecord) setRelay(leg pgtype.Int2, stroke pgtype.Text, officialEvent string) {
+	if !leg.Valid {
+		return
+	}
+	r.RelayLeg = int(leg.Int16)
+	r.RelayStroke = stroke.String
+	r.OfficialEvent = officialEvent
+}
+
+// sanitizeStatus normalizes a result status, defaulting to an official swim.
+func sanitizeStatus(status string) string {
+	status = strings.ToLower(domain.SanitizeString(status))
+	if status == "" {
+		return domain.ResultOK.String()
+	}
+	return status
+}
+
+// dqColumns returns the DQ code and reason columns of a swim.
+func dqColumns(code, reason string) (pgtype.Text, pgtype.Text) {
+	var dqCode, dqReason pgtype.Text
+	if code != "" {
+		dqCode = pgtype.Text{String: code, Valid: true}
+	}
+	if reason != "" {
+		dqReason = pgtype.Text{String: reason, Valid: true}
+	}
+	return dqCode, dqReason
+}
+
+// setStatus fills in the result status of a swim. Results without a time
+// are shown by their status label, e.g. "DNS".
+func (r *TimeRecord) setStatus(status string, dqCode, dqReason pgtype.Text) {
+	r.Status = status
+	r.DQCode = dqCode.String
+	r.DQReason = dqReason.String
+	if r.TimeMS == 0 {
+		r.TimeFormatted = domain.ResultStatus(status).Label()
+	}
+}
+
 func toTimeRecordFromRow(row *db.GetTimeWithMeetRow) *TimeRecord {
 	var eventDate string
 	if row.EventDate.Valid {
 		eventDate = row.EventDate.Time.Format("2006-01-02")
 	}
 
-	return &TimeRecord{
+	record := &TimeRecord{
 		ID:            row.ID,
+		SwimmerID:     row.SwimmerID,
 		MeetID:        row.MeetID,
 		Event:         row.Event,
 		TimeMS:        int(row.TimeMs),
@@ -544,4 +1193,8 @@ func toTimeRecordFromRow(row *db.GetTimeWithMeetRow) *TimeRecord {
 			CourseType: row.MeetCourseType,
 		},
 	}
+	record.setRelay(row.RelayLeg, row.RelayStroke, row.OfficialEvent)
+	record.setStatus(row.Status, row.DqCode, row.DqReason)
+	record.Grade = row.Grade.String
+	return record
 }
@@ -1,7 +1,12 @@
 // Package domain contains core domain types and utilities for SwimStats.
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
 
 // CourseType represents the pool length.
 type CourseType string
@@ -9,11 +14,17 @@ type CourseType string
 const (
 	Course25m CourseType = "25m"
 	Course50m CourseType = "50m"
+	Course25y CourseType = "25y" // Short course yards, used in US meets
 )
 
 // IsValid checks if the course type is valid.
 func (c CourseType) IsValid() bool {
-	return c == Course25m || c == Course50m
+	return c == Course25m || c == Course50m || c == Course25y
+}
+
+// IsYards checks if the course is measured in yards.
+func (c CourseType) IsYards() bool {
+	return c == Course25y
 }
 
 // String returns the string representation.
@@ -105,7 +116,23 @@ const (
 	Event400IM EventCode = "400IM"
 )
 
-// ValidEventCodes contains all valid event codes.
+// Distance freestyle events swum only in yards pools.
+const (
+	Event500FR  EventCode = "500FR"
+	Event1000FR EventCode = "1000FR"
+	Event1650FR EventCode = "1650FR"
+)
+
+// Relay events. A swimmer's time in a relay is their own leg.
+const (
+	Event4x50FR  EventCode = "4X50FR"
+	Event4x100FR EventCode = "4X100FR"
+	Event4x200FR EventCode = "4X200FR"
+	Event4x50MR  EventCode = "4X50MR"
+	Event4x100MR EventCode = "4X100MR"
+)
+
+// ValidEventCodes contains all valid individual event codes.
 var ValidEventCodes = []EventCode{
 	Event50FR, Event100FR, Event200FR, Event400FR, Event800FR, Event1500FR,
 	Event50BK, Event100BK, Event200BK,
@@ -114,13 +141,99 @@ var ValidEventCodes = []EventCode{
 	Event200IM, Event400IM,
 }
 
-// IsValid checks if the event code is valid.
+// YardEventCodes contains the individual events swum only in yards pools.
+var YardEventCodes = []EventCode{Event500FR, Event1000FR, Event1650FR}
+
+// yardEquivalents maps metric distance freestyle events to the events
+// swum instead in yards pools.
+var yardEquivalents = map[EventCode]EventCode{
+	Event400FR:  Event500FR,
+	Event800FR:  Event1000FR,
+	Event1500FR: Event1650FR,
+}
+
+// RelayEventCodes contains all valid relay event codes.
+var RelayEventCodes = []EventCode{
+	Event4x50FR, Event4x100FR, Event4x200FR,
+	Event4x50MR, Event4x100MR,
+}
+
+// RelayLegs is the number of legs in a relay.
+const RelayLegs = 4
+
+// medleyRelayStrokes lists the stroke of each medley relay leg.
+var medleyRelayStrokes = [RelayLegs]string{"BK", "BR", "FL", "FR"}
+
+// IsValid checks if the event code is a valid individual event.
 func (e EventCode) IsValid() bool {
 	for _, valid := range ValidEventCodes {
 		if e == valid {
 			return true
 		}
 	}
+	return e.isYardEvent()
+}
+
+// isYardEvent checks if the event is swum only in yards pools.
+func (e EventCode) isYardEvent() bool {
+	for _, valid := range YardEventCodes {
+		if e == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidForCourse checks if the individual or relay event is swum in the
+// course. Yards-only distance events are not swum in metric pools, and the
+// metric distance events they replace are not swum in yards pools.
+func (e EventCode) IsValidForCourse(course CourseType) bool {
+	if e.isYardEvent() {
+		return course.IsYards()
+	}
+	if _, ok := yardEquivalents[e]; ok && course.IsYards() {
+		return false
+	}
+	return e.IsValid() || e.IsRelay()
+}
+
+// CourseEquivalent returns the event raced over the equivalent distance in
+// the given course, e.g. 400FR in a 25y pool is 500FR. Other events are
+// unchanged.
+func (e EventCode) CourseEquivalent(course CourseType) EventCode {
+	if course.IsYards() {
+		if yards, ok := yardEquivalents[e]; ok {
+			return yards
+		}
+		return e
+	}
+	for metric, yards := range yardEquivalents {
+		if e == yards {
+			return metric
+		}
+	}
+	return e
+}
+
+// EventsForCourse returns the individual events swum in the course.
+func EventsForCourse(course CourseType) []EventCode {
+	if !course.IsYards() {
+		return ValidEventCodes
+	}
+	events := make([]EventCode, 0, len(ValidEventCodes))
+	for _, event := range ValidEventCodes {
+		events = append(events, event.CourseEquivalent(course))
+	}
+	return events
+}
+
+// IsRelay checks if the event code is a valid relay event.
+func (e EventCode) IsRelay() bool {
+	for _, valid := range RelayEventCodes {
+		if e == valid {
+			return true
+		}
+	}
 	return false
 }
 
@@ -129,26 +242,88 @@ func (e EventCode) String() string {
 	return string(e)
 }
 
+// Distance returns the race distance in meters (or yards) of the event.
+// For relays it is the total distance of all legs.
+func (e EventCode) Distance() int {
+	if e.IsRelay() {
+		return RelayLegs * e.LegDistance()
+	}
+	digits := strings.TrimRightFunc(string(e), unicode.IsLetter)
+	distance, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0
+	}
+	return distance
+}
+
+// LegDistance returns the distance a single swimmer covers in the event:
+// the leg distance for relays and the race distance otherwise.
+func (e EventCode) LegDistance() int {
+	if !e.IsRelay() {
+		return e.Distance()
+	}
+	digits := strings.TrimRightFunc(strings.TrimPrefix(string(e), "4X"), unicode.IsLetter)
+	distance, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0
+	}
+	return distance
+}
+
+// LegStroke returns the stroke code (FR, BK, BR, FL) swum on the given
+// relay leg (1-4), or "" if the event is not a relay or the leg is invalid.
+func (e EventCode) LegStroke(leg int) string {
+	if !e.IsRelay() || leg < 1 || leg > RelayLegs {
+		return ""
+	}
+	if strings.HasSuffix(string(e), "MR") {
+		return medleyRelayStrokes[leg-1]
+	}
+	return "FR"
+}
+
+// OfficialEvent returns the individual event a swim counts toward. Individual
+// swims count as themselves. A relay lead-off leg is an official individual
+// time (e.g. the first leg of a 4X100MR counts as 100BK); other relay legs
+// return "".
+func (e EventCode) OfficialEvent(leg int) EventCode {
+	if !e.IsRelay() {
+		return e
+	}
+	if leg != 1 {
+		return ""
+	}
+	return EventCode(strconv.Itoa(e.LegDistance()) + e.LegStroke(leg))
+}
+
 // Description returns human-readable event name.
 func (e EventCode) Description() string {
 	descriptions := map[EventCode]string{
-		Event50FR:   "50m Freestyle",
-		Event100FR:  "100m Freestyle",
-		Event200FR:  "200m Freestyle",
-		Event400FR:  "400m Freestyle",
-		Event800FR:  "800m Freestyle",
-		Event1500FR: "1500m Freestyle",
-		Event50BK:   "50m Backstroke",
-		Event100BK:  "100m Backstroke",
-		Event200BK:  "200m Backstroke",
-		Event50BR:   "50m Breaststroke",
-		Event100BR:  "100m Breaststroke",
-		Event200BR:  "200m Breaststroke",
-		Event50FL:   "50m Butterfly",
-		Event100FL:  "100m Butterfly",
-		Event200FL:  "200m Butterfly",
-		Event200IM:  "200m Individual Medley",
-		Event400IM:  "400m Individual Medley",
+		Event50FR:    "50m Freestyle",
+		Event100FR:   "100m Freestyle",
+		Event200FR:   "200m Freestyle",
+		Event400FR:   "400m Freestyle",
+		Event800FR:   "800m Freestyle",
+		Event1500FR:  "1500m Freestyle",
+		Event500FR:   "500y Freestyle",
+		Event1000FR:  "1000y Freestyle",
+		Event1650FR:  "1650y Freestyle",
+		Event50BK:    "50m Backstroke",
+		Event100BK:   "100m Backstroke",
+		Event200BK:   "200m Backstroke",
+		Event50BR:    "50m Breaststroke",
+		Event100BR:   "100m Breaststroke",
+		Event200BR:   "200m Breaststroke",
+		Event50FL:    "50m Butterfly",
+		Event100FL:   "100m Butterfly",
+		Event200FL:   "200m Butterfly",
+		Event200IM:   "200m Individual Medley",
+		Event400IM:   "400m Individual Medley",
+		Event4x50FR:  "4x50m Freestyle Relay",
+		Event4x100FR: "4x100m Freestyle Relay",
+		Event4x200FR: "4x200m Freestyle Relay",
+		Event4x50MR:  "4x50m Medley Relay",
+		Event4x100MR: "4x100m Medley Relay",
 	}
 	if desc, ok := descriptions[e]; ok {
 		return desc
@@ -159,7 +334,8 @@ func (e EventCode) Description() string {
 // Stroke returns the stroke type for the event.
 func (e EventCode) Stroke() string {
 	switch e {
-	case Event50FR, Event100FR, Event200FR, Event400FR, Event800FR, Event1500FR:
+	case Event50FR, Event100FR, Event200FR, Event400FR, Event800FR, Event1500FR,
+		Event500FR, Event1000FR, Event1650FR:
 		return "Freestyle"
 	case Event50BK, Event100BK, Event200BK:
 		return "Backstroke"
@@ -169,6 +345,10 @@ func (e EventCode) Stroke() string {
 		return "Butterfly"
 	case Event200IM, Event400IM:
 		return "Individual Medley"
+	case Event4x50FR, Event4x100FR, Event4x200FR:
+		return "Freestyle Relay"
+	case Event4x50MR, Event4x100MR:
+		return "Medley Relay"
 	default:
 		return "Unknown"
 	}
@@ -177,7 +357,7 @@ func (e EventCode) Stroke() string {
 // EventsByStroke returns events grouped by stroke type.
 func EventsByStroke() map[string][]EventCode {
 	return map[string][]EventCode{
-		"Freestyle":         {Event50FR, Event100FR, Event200FR, Event400FR, Event800FR, Event1500FR},
+		"Freestyle":         {Event50FR, Event100FR, Event200FR, Event400FR, Event800FR, Event1500FR, Event500FR, Event1000FR, Event1650FR},
 		"Backstroke":        {Event50BK, Event100BK, Event200BK},
 		"Breaststroke":      {Event50BR, Event100BR, Event200BR},
 		"Butterfly":         {Event50FL, Event100FL, Event200FL},
@@ -185,6 +365,47 @@ func EventsByStroke() map[string][]EventCode {
 	}
 }
 
+// ResultStatus represents the outcome of a swim.
+type ResultStatus string
+
+const (
+	ResultOK           ResultStatus = "ok"
+	ResultDisqualified ResultStatus = "dq"
+	ResultDidNotStart  ResultStatus = "dns"
+	ResultDidNotFinish ResultStatus = "dnf"
+	ResultScratched    ResultStatus = "scr"
+)
+
+// IsValid checks if the result status is valid.
+func (r ResultStatus) IsValid() bool {
+	switch r {
+	case ResultOK, ResultDisqualified, ResultDidNotStart, ResultDidNotFinish, ResultScratched:
+		return true
+	}
+	return false
+}
+
+// IsOfficial checks if the swim counts as an official time. Only official
+// times count toward personal bests, progress and comparisons.
+func (r ResultStatus) IsOfficial() bool {
+	return r == ResultOK
+}
+
+// Started checks if the swimmer started the race, so a time may have been recorded.
+func (r ResultStatus) Started() bool {
+	return r == ResultOK || r == ResultDisqualified || r == ResultDidNotFinish
+}
+
+// Label returns the short label shown in results, e.g. "DQ".
+func (r ResultStatus) Label() string {
+	return strings.ToUpper(string(r))
+}
+
+// String returns the string representation.
+func (r ResultStatus) String() string {
+	return string(r)
+}
+
 // ValidationError represents a domain validation error.
 type ValidationError struct {
 	Field   string
@@ -200,11 +421,16 @@ func NewValidationError(field, message string) ValidationError {
 	return ValidationError{Field: field, Message: message}
 }
 
-// IsValidEvent checks if a string is a valid event code.
+// IsValidEvent checks if a string is a valid individual event code.
 func IsValidEvent(event string) bool {
 	return EventCode(event).IsValid()
 }
 
+// IsValidRelayEvent checks if a string is a valid relay event code.
+func IsValidRelayEvent(event string) bool {
+	return EventCode(event).IsRelay()
+}
+
 // AccessLevel represents the user's permission level.
 type AccessLevel string
 
@@ -4,10 +4,12 @@ package migrate
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5" // pgx driver
+	"github.com/golang-migrate/migrate/v4/source"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 
 	"github.com/bpg/swimstats/backend/migrations"
@@ -16,29 +18,11 @@ import (
 // Run executes all pending database migrations.
 // Returns the number of migrations applied and any error.
 func Run(databaseURL string, logger *slog.Logger) (int, error) {
-	// Create source driver from embedded filesystem
-	source, err := iofs.New(migrations.FS, ".")
-	if err != nil {
-		return 0, fmt.Errorf("create migration source: %w", err)
-	}
-
-	// Convert postgres:// to pgx5:// for the driver
-	pgxURL := "pgx5" + databaseURL[8:] // Replace "postgres" with "pgx5"
-
-	// Create migrate instance
-	m, err := migrate.NewWithSourceInstance("iofs", source, pgxURL)
+	m, _, err := open(databaseURL)
 	if err != nil {
-		return 0, fmt.Errorf("create migrate instance: %w", err)
+		return 0, err
 	}
-	defer func() {
-		srcErr, dbErr := m.Close()
-		if srcErr != nil {
-			logger.Warn("failed to close migration source", "error", srcErr)
-		}
-		if dbErr != nil {
-			logger.Warn("failed to close migration database", "error", dbErr)
-		}
-	}()
+	defer closeMigrate(m, logger)
 
 	// Get current version before migration
 	versionBefore, dirty, err := m.Version()
@@ -79,3 +63,64 @@ func Run(databaseURL string, logger *slog.Logger) (int, error) {
 
 	return applied, nil
 }
+
+// Status returns the migration version of the database, the version of the
+// latest embedded migration and whether a migration failed halfway.
+func Status(databaseURL string, logger *slog.Logger) (version, latest uint, dirty bool, err error) {
+	m, src, err := open(databaseURL)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	defer closeMigrate(m, logger)
+
+	version, dirty, err = m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return 0, 0, false, fmt.Errorf("get current version: %w", err)
+	}
+
+	latest, err = src.First()
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("read migrations: %w", err)
+	}
+	for {
+		next, err := src.Next(latest)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return 0, 0, false, fmt.Errorf("read migrations: %w", err)
+		}
+		latest = next
+	}
+
+	return version, latest, dirty, nil
+}
+
+// open creates a migrate instance for the embedded migrations.
+func open(databaseURL string) (*migrate.Migrate, source.Driver, error) {
+	// Create source driver from embedded filesystem
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, nil, fmt.Errorf("create migration source: %w", err)
+	}
+
+	// Convert postgres:// to pgx5:// for the driver
+	pgxURL := "pgx5" + databaseURL[8:] // Replace "postgres" with "pgx5"
+
+	// Create migrate instance
+	m, err := migrate.NewWithSourceInstance("iofs", src, pgxURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create migrate instance: %w", err)
+	}
+	return m, src, nil
+}
+
+func closeMigrate(m *migrate.Migrate, logger *slog.Logger) {
+	srcErr, dbErr := m.Close()
+	if srcErr != nil {
+		logger.Warn("failed to close migration source", "error", srcErr)
+	}
+	if dbErr != nil {
+		logger.Warn("failed to close migration database", "error", dbErr)
+	}
+}
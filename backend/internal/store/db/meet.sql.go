@@ -13,22 +13,43 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimUnownedMeets = `-- name: ClaimUnownedMeets :execrows
+UPDATE meets
+SET owner_id = $1
+WHERE owner_id = ''
+`
+
+// Assigns meets created before multi-swimmer support to an owner
+func (q *Queries) ClaimUnownedMeets(ctx context.Context, ownerID string) (int64, error) {
+	result, err := q.db.Exec(ctx, claimUnownedMeets, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const countMeets = `-- name: CountMeets :one
 SELECT COUNT(*) FROM meets
-WHERE ($1::varchar = '' OR course_type = $1)
+WHERE owner_id = $1
+  AND ($2::varchar = '' OR course_type = $2)
 `
 
-func (q *Queries) CountMeets(ctx context.Context, dollar_1 string) (int64, error) {
-	row := q.db.QueryRow(ctx, countMeets, dollar_1)
+type CountMeetsParams struct {
+	OwnerID string `json:"owner_id"`
+	Column2 string `json:"column_2"`
+}
+
+func (q *Queries) CountMeets(ctx context.Context, arg CountMeetsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countMeets, arg.OwnerID, arg.Column2)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
 const createMeet = `-- name: CreateMeet :one
-INSERT INTO meets (name, city, country, start_date, end_date, course_type)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, name, city, country, start_date, end_date, course_type, created_at, updated_at
+INSERT INTO meets (name, city, country, start_date, end_date, course_type, owner_id, sanctioned)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, name, city, country, start_date, end_date, course_type, created_at, updated_at, owner_id, sanctioned
 `
 
 type CreateMeetParams struct {
@@ -38,6 +59,8 @@ type CreateMeetParams struct {
 	StartDate  pgtype.Date `json:"start_date"`
 	EndDate    pgtype.Date `json:"end_date"`
 	CourseType string      `json:"course_type"`
+	OwnerID    string      `json:"owner_id"`
+	Sanctioned bool        `json:"sanctioned"`
 }
 
 func (q *Queries) CreateMeet(ctx context.Context, arg CreateMeetParams) (Meet, error) {
@@ -48,6 +71,8 @@ func (q *Queries) CreateMeet(ctx context.Context, arg CreateMeetParams) (Meet, e
 		arg.StartDate,
 		arg.EndDate,
 		arg.CourseType,
+		arg.OwnerID,
+		arg.Sanctioned,
 	)
 	var i Meet
 	err := row.Scan(
@@ -60,10 +85,27 @@ func (q *Queries) CreateMeet(ctx context.Context, arg CreateMeetParams) (Meet, e
 		&i.CourseType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OwnerID,
+		&i.Sanctioned,
 	)
 	return i, err
 }
 
+const deleteEmptyMeets = `-- name: DeleteEmptyMeets :execrows
+DELETE FROM meets m
+WHERE m.owner_id = $1
+  AND NOT EXISTS (SELECT 1 FROM times t WHERE t.meet_id = m.id)
+`
+
+// Removes an owner's meets that no longer have any recorded times
+func (q *Queries) DeleteEmptyMeets(ctx context.Context, ownerID string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteEmptyMeets, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteMeet = `-- name: DeleteMeet :exec
 DELETE FROM meets
 WHERE id = $1
@@ -74,14 +116,61 @@ func (q *Queries) DeleteMeet(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const findMeet = `-- name: FindMeet :one
+SELECT id, name, city, country, start_date, end_date, course_type, created_at, updated_at, owner_id, sanctioned
+FROM meets
+WHERE owner_id = $1
+  AND name = $2
+  AND start_date = $3
+  AND course_type = $4
+LIMIT 1
+`
+
+type FindMeetParams struct {
+	OwnerID    string      `json:"owner_id"`
+	Name       string      `json:"name"`
+	StartDate  pgtype.Date `json:"start_date"`
+	CourseType string      `json:"course_type"`
+}
+
+// Finds an owner's meet by its natural key (name, start date and course)
+func (q *Queries) FindMeet(ctx context.Context, arg FindMeetParams) (Meet, error) {
+	row := q.db.QueryRow(ctx, findMeet,
+		arg.OwnerID,
+		arg.Name,
+		arg.StartDate,
+		arg.CourseType,
+	)
+	var i Meet
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.City,
+		&i.Country,
+		&i.StartDate,
+		&i.EndDate,
+		&i.CourseType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OwnerID,
+		&i.Sanctioned,
+	)
+	return i, err
+}
+
 const getMeet = `-- name: GetMeet :one
-SELECT id, name, city, country, start_date, end_date, course_type, created_at, updated_at
+SELECT id, name, city, country, start_date, end_date, course_type, created_at, updated_at, owner_id, sanctioned
 FROM meets
-WHERE id = $1
+WHERE id = $1 AND owner_id = $2
 `
 
-func (q *Queries) GetMeet(ctx context.Context, id uuid.UUID) (Meet, error) {
-	row := q.db.QueryRow(ctx, getMeet, id)
+type GetMeetParams struct {
+	ID      uuid.UUID `json:"id"`
+	OwnerID string    `json:"owner_id"`
+}
+
+func (q *Queries) GetMeet(ctx context.Context, arg GetMeetParams) (Meet, error) {
+	row := q.db.QueryRow(ctx, getMeet, arg.ID, arg.OwnerID)
 	var i Meet
 	err := row.Scan(
 		&i.ID,
@@ -93,6 +182,8 @@ func (q *Queries) GetMeet(ctx context.Context, id uuid.UUID) (Meet, error) {
 		&i.CourseType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OwnerID,
+		&i.Sanctioned,
 	)
 	return i, err
 }
@@ -108,13 +199,19 @@ SELECT
     m.course_type, 
     m.created_at, 
     m.updated_at,
+    m.sanctioned,
     COUNT(t.id)::int AS time_count
 FROM meets m
 LEFT JOIN times t ON t.meet_id = m.id
-WHERE m.id = $1
+WHERE m.id = $1 AND m.owner_id = $2
 GROUP BY m.id
 `
 
+type GetMeetWithTimeCountParams struct {
+	ID      uuid.UUID `json:"id"`
+	OwnerID string    `json:"owner_id"`
+}
+
 type GetMeetWithTimeCountRow struct {
 	ID         uuid.UUID   `json:"id"`
 	Name       string      `json:"name"`
@@ -125,11 +222,12 @@ type GetMeetWithTimeCountRow struct {
 	CourseType string      `json:"course_type"`
 	CreatedAt  time.Time   `json:"created_at"`
 	UpdatedAt  time.Time   `json:"updated_at"`
+	Sanctioned bool        `json:"sanctioned"`
 	TimeCount  int32       `json:"time_count"`
 }
 
-func (q *Queries) GetMeetWithTimeCount(ctx context.Context, id uuid.UUID) (GetMeetWithTimeCountRow, error) {
-	row := q.db.QueryRow(ctx, getMeetWithTimeCount, id)
+func (q *Queries) GetMeetWithTimeCount(ctx context.Context, arg GetMeetWithTimeCountParams) (GetMeetWithTimeCountRow, error) {
+	row := q.db.QueryRow(ctx, getMeetWithTimeCount, arg.ID, arg.OwnerID)
 	var i GetMeetWithTimeCountRow
 	err := row.Scan(
 		&i.ID,
@@ -141,6 +239,7 @@ func (q *Queries) GetMeetWithTimeCount(ctx context.Context, id uuid.UUID) (GetMe
 		&i.CourseType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Sanctioned,
 		&i.TimeCount,
 	)
 	return i, err
@@ -157,17 +256,20 @@ SELECT
     m.course_type, 
     m.created_at, 
     m.updated_at,
+    m.sanctioned,
     COUNT(t.id)::int AS time_count
 FROM meets m
 LEFT JOIN times t ON t.meet_id = m.id
-WHERE ($1::varchar = '' OR m.course_type = $1)
+WHERE m.owner_id = $1
+  AND ($2::varchar = '' OR m.course_type = $2)
 GROUP BY m.id
 ORDER BY m.start_date DESC
-LIMIT $2
+LIMIT $3
 `
 
 type GetRecentMeetsParams struct {
-	Column1 string `json:"column_1"`
+	OwnerID string `json:"owner_id"`
+	Column2 string `json:"column_2"`
 	Limit   int32  `json:"limit"`
 }
 
@@ -181,11 +283,12 @@ type GetRecentMeetsRow struct {
 	CourseType string      `json:"course_type"`
 	CreatedAt  time.Time   `json:"created_at"`
 	UpdatedAt  time.Time   `json:"updated_at"`
+	Sanctioned bool        `json:"sanctioned"`
 	TimeCount  int32       `json:"time_count"`
 }
 
 func (q *Queries) GetRecentMeets(ctx context.Context, arg GetRecentMeetsParams) ([]GetRecentMeetsRow, error) {
-	rows, err := q.db.Query(ctx, getRecentMeets, arg.Column1, arg.Limit)
+	rows, err := q.db.Query(ctx, getRecentMeets, arg.OwnerID, arg.Column2, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -203,6 +306,7 @@ func (q *Queries) GetRecentMeets(ctx context.Context, arg GetRecentMeetsParams)
 			&i.CourseType,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Sanctioned,
 			&i.TimeCount,
 		); err != nil {
 			return nil, err
@@ -215,6 +319,46 @@ func (q *Queries) GetRecentMeets(ctx context.Context, arg GetRecentMeetsParams)
 	return items, nil
 }
 
+const listEmptyMeets = `-- name: ListEmptyMeets :many
+SELECT m.id, m.name, m.city, m.country, m.start_date, m.end_date, m.course_type, m.created_at, m.updated_at, m.owner_id, m.sanctioned
+FROM meets m
+WHERE NOT EXISTS (SELECT 1 FROM times t WHERE t.meet_id = m.id)
+ORDER BY m.owner_id, m.start_date, m.name
+`
+
+// Lists the meets of all owners that have no recorded times
+func (q *Queries) ListEmptyMeets(ctx context.Context) ([]Meet, error) {
+	rows, err := q.db.Query(ctx, listEmptyMeets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Meet{}
+	for rows.Next() {
+		var i Meet
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.City,
+			&i.Country,
+			&i.StartDate,
+			&i.EndDate,
+			&i.CourseType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.OwnerID,
+			&i.Sanctioned,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listMeets = `-- name: ListMeets :many
 SELECT 
     m.id, 
@@ -226,17 +370,20 @@ SELECT
     m.course_type, 
     m.created_at, 
     m.updated_at,
+    m.sanctioned,
     COUNT(t.id)::int AS time_count
 FROM meets m
 LEFT JOIN times t ON t.meet_id = m.id
-WHERE ($1::varchar = '' OR m.course_type = $1)
+WHERE m.owner_id = $1
+  AND ($2::varchar = '' OR m.course_type = $2)
 GROUP BY m.id
 ORDER BY m.start_date DESC
-LIMIT $2 OFFSET $3
+LIMIT $3 OFFSET $4
 `
 
 type ListMeetsParams struct {
-	Column1 string `json:"column_1"`
+	OwnerID string `json:"owner_id"`
+	Column2 string `json:"column_2"`
 	Limit   int32  `json:"limit"`
 	Offset  int32  `json:"offset"`
 }
@@ -251,11 +398,17 @@ type ListMeetsRow struct {
 	CourseType string      `json:"course_type"`
 	CreatedAt  time.Time   `json:"created_at"`
 	UpdatedAt  time.Time   `json:"updated_at"`
+	Sanctioned bool        `json:"sanctioned"`
 	TimeCount  int32       `json:"time_count"`
 }
 
 func (q *Queries) ListMeets(ctx context.Context, arg ListMeetsParams) ([]ListMeetsRow, error) {
-	rows, err := q.db.Query(ctx, listMeets, arg.Column1, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listMeets,
+		arg.OwnerID,
+		arg.Column2,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -273,6 +426,7 @@ func (q *Queries) ListMeets(ctx context.Context, arg ListMeetsParams) ([]ListMee
 			&i.CourseType,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Sanctioned,
 			&i.TimeCount,
 		); err != nil {
 			return nil, err
@@ -287,9 +441,9 @@ func (q *Queries) ListMeets(ctx context.Context, arg ListMeetsParams) ([]ListMee
 
 const updateMeet = `-- name: UpdateMeet :one
 UPDATE meets
-SET name = $2, city = $3, country = $4, start_date = $5, end_date = $6, course_type = $7
+SET name = $2, city = $3, country = $4, start_date = $5, end_date = $6, course_type = $7, sanctioned = $8
 WHERE id = $1
-RETURNING id, name, city, country, start_date, end_date, course_type, created_at, updated_at
+RETURNING id, name, city, country, start_date, end_date, course_type, created_at, updated_at, owner_id, sanctioned
 `
 
 type UpdateMeetParams struct {
@@ -300,6 +454,7 @@ type UpdateMeetParams struct {
 	StartDate  pgtype.Date `json:"start_date"`
 	EndDate    pgtype.Date `json:"end_date"`
 	CourseType string      `json:"course_type"`
+	Sanctioned bool        `json:"sanctioned"`
 }
 
 func (q *Queries) UpdateMeet(ctx context.Context, arg UpdateMeetParams) (Meet, error) {
@@ -311,6 +466,7 @@ func (q *Queries) UpdateMeet(ctx context.Context, arg UpdateMeetParams) (Meet, e
 		arg.StartDate,
 		arg.EndDate,
 		arg.CourseType,
+		arg.Sanctioned,
 	)
 	var i Meet
 	err := row.Scan(
@@ -323,6 +479,8 @@ func (q *Queries) UpdateMeet(ctx context.Context, arg UpdateMeetParams) (Meet, e
 		&i.CourseType,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OwnerID,
+		&i.Sanctioned,
 	)
 	return i, err
 }
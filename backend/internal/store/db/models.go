@@ -11,6 +11,34 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AgeGroupRange struct {
+	ID       uuid.UUID   `json:"id"`
+	SchemeID uuid.UUID   `json:"scheme_id"`
+	Code     string      `json:"code"`
+	MinAge   int16       `json:"min_age"`
+	MaxAge   pgtype.Int2 `json:"max_age"`
+}
+
+type AgeGroupScheme struct {
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Description pgtype.Text `json:"description"`
+	AgeRule     string      `json:"age_rule"`
+	IsPreloaded bool        `json:"is_preloaded"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+type CourseConversionFactor struct {
+	ID         uuid.UUID      `json:"id"`
+	FromCourse string         `json:"from_course"`
+	ToCourse   string         `json:"to_course"`
+	Event      string         `json:"event"`
+	Factor     pgtype.Numeric `json:"factor"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
 type Meet struct {
 	ID         uuid.UUID   `json:"id"`
 	Name       string      `json:"name"`
@@ -21,6 +49,57 @@ type Meet struct {
 	CourseType string      `json:"course_type"`
 	CreatedAt  time.Time   `json:"created_at"`
 	UpdatedAt  time.Time   `json:"updated_at"`
+	OwnerID    string      `json:"owner_id"`
+	Sanctioned bool        `json:"sanctioned"`
+}
+
+type PointsBaseTime struct {
+	ID         uuid.UUID `json:"id"`
+	Year       int16     `json:"year"`
+	CourseType string    `json:"course_type"`
+	Gender     string    `json:"gender"`
+	Event      string    `json:"event"`
+	TimeMs     int32     `json:"time_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type Split struct {
+	ID        uuid.UUID `json:"id"`
+	TimeID    uuid.UUID `json:"time_id"`
+	Distance  int32     `json:"distance"`
+	TimeMs    int32     `json:"time_ms"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type StandardFamily struct {
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Description pgtype.Text `json:"description"`
+	CourseType  string      `json:"course_type"`
+	Gender      string      `json:"gender"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	OwnerID     string      `json:"owner_id"`
+}
+
+type StandardLadder struct {
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Description pgtype.Text `json:"description"`
+	CourseType  string      `json:"course_type"`
+	Gender      string      `json:"gender"`
+	Grading     bool        `json:"grading"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	OwnerID     string      `json:"owner_id"`
+}
+
+type StandardLadderRung struct {
+	LadderID   uuid.UUID   `json:"ladder_id"`
+	Position   int16       `json:"position"`
+	StandardID uuid.UUID   `json:"standard_id"`
+	Label      pgtype.Text `json:"label"`
 }
 
 type StandardTime struct {
@@ -41,27 +120,46 @@ type Swimmer struct {
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
 	ThresholdPercent pgtype.Numeric `json:"threshold_percent"`
+	OwnerID          string         `json:"owner_id"`
+	SeasonStartMonth int16          `json:"season_start_month"`
+	SeasonStartDay   int16          `json:"season_start_day"`
 }
 
 type Time struct {
-	ID        uuid.UUID   `json:"id"`
-	SwimmerID uuid.UUID   `json:"swimmer_id"`
-	MeetID    uuid.UUID   `json:"meet_id"`
-	Event     string      `json:"event"`
-	TimeMs    int32       `json:"time_ms"`
-	EventDate pgtype.Date `json:"event_date"`
-	Notes     pgtype.Text `json:"notes"`
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt time.Time   `json:"updated_at"`
+	ID            uuid.UUID   `json:"id"`
+	SwimmerID     uuid.UUID   `json:"swimmer_id"`
+	MeetID        uuid.UUID   `json:"meet_id"`
+	Event         string      `json:"event"`
+	TimeMs        int32       `json:"time_ms"`
+	EventDate     pgtype.Date `json:"event_date"`
+	Notes         pgtype.Text `json:"notes"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+	RelayLeg      pgtype.Int2 `json:"relay_leg"`
+	RelayStroke   pgtype.Text `json:"relay_stroke"`
+	OfficialEvent string      `json:"official_event"`
+	Status        string      `json:"status"`
+	DqCode        pgtype.Text `json:"dq_code"`
+	DqReason      pgtype.Text `json:"dq_reason"`
+	Grade         pgtype.Text `json:"grade"`
 }
 
 type TimeStandard struct {
-	ID          uuid.UUID   `json:"id"`
-	Name        string      `json:"name"`
-	Description pgtype.Text `json:"description"`
-	CourseType  string      `json:"course_type"`
-	Gender      string      `json:"gender"`
-	IsPreloaded bool        `json:"is_preloaded"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
+	ID               uuid.UUID   `json:"id"`
+	Name             string      `json:"name"`
+	Description      pgtype.Text `json:"description"`
+	CourseType       string      `json:"course_type"`
+	Gender           string      `json:"gender"`
+	IsPreloaded      bool        `json:"is_preloaded"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+	AgeGroupSchemeID uuid.UUID   `json:"age_group_scheme_id"`
+	QualifyingStart  pgtype.Date `json:"qualifying_start"`
+	QualifyingEnd    pgtype.Date `json:"qualifying_end"`
+	RequiredCourse   pgtype.Text `json:"required_course"`
+	SanctionedOnly   bool        `json:"sanctioned_only"`
+	FamilyID         pgtype.UUID `json:"family_id"`
+	Version          pgtype.Text `json:"version"`
+	EffectiveFrom    pgtype.Date `json:"effective_from"`
+	OwnerID          string      `json:"owner_id"`
 }
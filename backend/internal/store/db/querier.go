@@ -8,64 +8,154 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
-	CountMeets(ctx context.Context, dollar_1 string) (int64, error)
+	// Reports whether any time standard uses the scheme
+	AgeGroupSchemeInUse(ctx context.Context, ageGroupSchemeID uuid.UUID) (bool, error)
+	AgeGroupSchemeNameExists(ctx context.Context, name string) (bool, error)
+	// Assigns meets created before multi-swimmer support to an owner
+	ClaimUnownedMeets(ctx context.Context, ownerID string) (int64, error)
+	// Assigns swimmers created before multi-swimmer support to an owner
+	ClaimUnownedSwimmers(ctx context.Context, ownerID string) (int64, error)
+	CountMeets(ctx context.Context, arg CountMeetsParams) (int64, error)
+	// Returns count of results per status for a swimmer, optionally within a course and date range
+	CountResultsByStatus(ctx context.Context, arg CountResultsByStatusParams) ([]CountResultsByStatusRow, error)
 	CountSwimmers(ctx context.Context) (int64, error)
 	CountTimes(ctx context.Context, arg CountTimesParams) (int64, error)
 	// Returns count of times per event for a swimmer
 	CountTimesByEvent(ctx context.Context, arg CountTimesByEventParams) ([]CountTimesByEventRow, error)
+	CreateAgeGroupRange(ctx context.Context, arg CreateAgeGroupRangeParams) (AgeGroupRange, error)
+	CreateAgeGroupScheme(ctx context.Context, arg CreateAgeGroupSchemeParams) (AgeGroupScheme, error)
+	CreateLadder(ctx context.Context, arg CreateLadderParams) (StandardLadder, error)
+	CreateLadderRung(ctx context.Context, arg CreateLadderRungParams) error
 	CreateMeet(ctx context.Context, arg CreateMeetParams) (Meet, error)
+	CreateSplit(ctx context.Context, arg CreateSplitParams) (Split, error)
 	CreateStandard(ctx context.Context, arg CreateStandardParams) (TimeStandard, error)
+	CreateStandardFamily(ctx context.Context, arg CreateStandardFamilyParams) (StandardFamily, error)
 	CreateStandardTime(ctx context.Context, arg CreateStandardTimeParams) (StandardTime, error)
 	CreateSwimmer(ctx context.Context, arg CreateSwimmerParams) (CreateSwimmerRow, error)
 	CreateTime(ctx context.Context, arg CreateTimeParams) (Time, error)
+	DeleteAgeGroupScheme(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteConversionFactor(ctx context.Context, id uuid.UUID) (int64, error)
+	// Removes an owner's meets that no longer have any recorded times
+	DeleteEmptyMeets(ctx context.Context, ownerID string) (int64, error)
+	DeleteLadder(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteLadderRungs(ctx context.Context, ladderID uuid.UUID) error
 	DeleteMeet(ctx context.Context, id uuid.UUID) error
+	DeletePointsBaseTimes(ctx context.Context, arg DeletePointsBaseTimesParams) (int64, error)
+	DeleteSplits(ctx context.Context, timeID uuid.UUID) error
 	DeleteStandard(ctx context.Context, id uuid.UUID) error
+	DeleteStandardFamily(ctx context.Context, id uuid.UUID) (int64, error)
 	DeleteStandardTime(ctx context.Context, id uuid.UUID) error
+	DeleteStandardTimeForEventAndAge(ctx context.Context, arg DeleteStandardTimeForEventAndAgeParams) error
 	DeleteStandardTimesByStandardID(ctx context.Context, standardID uuid.UUID) error
 	DeleteSwimmer(ctx context.Context, id uuid.UUID) error
 	DeleteTime(ctx context.Context, id uuid.UUID) error
 	DeleteTimesByMeet(ctx context.Context, meetID uuid.UUID) error
+	DeleteTimesBySwimmer(ctx context.Context, swimmerID uuid.UUID) error
 	// Check if an event already exists for a specific meet and swimmer
 	EventExistsForMeet(ctx context.Context, arg EventExistsForMeetParams) (bool, error)
-	GetMeet(ctx context.Context, id uuid.UUID) (Meet, error)
-	GetMeetWithTimeCount(ctx context.Context, id uuid.UUID) (GetMeetWithTimeCountRow, error)
-	// Returns the fastest time for a specific event
+	// Finds an owner's meet by its natural key (name, start date and course)
+	FindMeet(ctx context.Context, arg FindMeetParams) (Meet, error)
+	GetAgeGroupScheme(ctx context.Context, id uuid.UUID) (AgeGroupScheme, error)
+	GetAgeGroupSchemeByName(ctx context.Context, name string) (AgeGroupScheme, error)
+	// Returns the owner's grading ladder of a course and gender, or the shared one
+	GetGradingLadder(ctx context.Context, arg GetGradingLadderParams) (StandardLadder, error)
+	GetLadder(ctx context.Context, id uuid.UUID) (StandardLadder, error)
+	GetMeet(ctx context.Context, arg GetMeetParams) (Meet, error)
+	GetMeetWithTimeCount(ctx context.Context, arg GetMeetWithTimeCountParams) (GetMeetWithTimeCountRow, error)
+	// Returns the fastest time for a specific event, including relay lead-off legs
 	GetPersonalBestForEvent(ctx context.Context, arg GetPersonalBestForEventParams) (GetPersonalBestForEventRow, error)
 	// Returns the fastest time for each event for a swimmer in a specific course type
+	// Relay lead-off legs count toward the equivalent individual event; DQ, DNS, DNF and scratches never do
+	// Swims may be restricted to a date window ($3, $4; NULL is open) and to sanctioned meets ($5)
 	GetPersonalBests(ctx context.Context, arg GetPersonalBestsParams) ([]GetPersonalBestsRow, error)
-	// Returns time progression for a specific event over time
+	// Returns time progression for a specific event over time, including relay lead-off legs
 	// Used for progress charts visualization
 	GetProgressData(ctx context.Context, arg GetProgressDataParams) ([]GetProgressDataRow, error)
 	GetRecentMeets(ctx context.Context, arg GetRecentMeetsParams) ([]GetRecentMeetsRow, error)
 	GetStandard(ctx context.Context, id uuid.UUID) (TimeStandard, error)
+	GetStandardByName(ctx context.Context, arg GetStandardByNameParams) (TimeStandard, error)
+	GetStandardFamily(ctx context.Context, id uuid.UUID) (StandardFamily, error)
+	GetStandardFamilyByName(ctx context.Context, arg GetStandardFamilyByNameParams) (StandardFamily, error)
 	GetStandardTime(ctx context.Context, id uuid.UUID) (StandardTime, error)
 	GetStandardTimeForEventAndAge(ctx context.Context, arg GetStandardTimeForEventAndAgeParams) (StandardTime, error)
 	GetSwimmer(ctx context.Context, id uuid.UUID) (GetSwimmerRow, error)
-	// In a multi-user scenario, this would filter by user_id
-	// For single-user MVP, just return the first swimmer
-	GetSwimmerByUserID(ctx context.Context) (GetSwimmerByUserIDRow, error)
+	// Returns the owner's default swimmer (the first one created)
+	GetSwimmerByUserID(ctx context.Context, ownerID string) (GetSwimmerByUserIDRow, error)
+	GetSwimmerForOwner(ctx context.Context, arg GetSwimmerForOwnerParams) (GetSwimmerForOwnerRow, error)
 	GetTime(ctx context.Context, id uuid.UUID) (Time, error)
 	GetTimeWithMeet(ctx context.Context, id uuid.UUID) (GetTimeWithMeetRow, error)
 	GetTotalMeetCount(ctx context.Context, swimmerID uuid.UUID) (int32, error)
 	GetTotalTimeCount(ctx context.Context, swimmerID uuid.UUID) (int32, error)
+	GradingLadderExists(ctx context.Context, arg GradingLadderExistsParams) (bool, error)
 	// Check if a given time is faster than all existing times for this event/course
 	IsPersonalBest(ctx context.Context, arg IsPersonalBestParams) (bool, error)
+	LadderNameExists(ctx context.Context, arg LadderNameExistsParams) (bool, error)
+	ListAgeGroupRanges(ctx context.Context, schemeID uuid.UUID) ([]AgeGroupRange, error)
+	ListAgeGroupSchemes(ctx context.Context) ([]AgeGroupScheme, error)
+	ListAllAgeGroupRanges(ctx context.Context) ([]AgeGroupRange, error)
+	ListAllLadderRungs(ctx context.Context) ([]ListAllLadderRungsRow, error)
+	// Lists the swimmers of all owners, each owner's default swimmer first
+	ListAllSwimmers(ctx context.Context) ([]ListAllSwimmersRow, error)
+	ListConversionFactors(ctx context.Context) ([]CourseConversionFactor, error)
+	// Lists the meets of all owners that have no recorded times
+	ListEmptyMeets(ctx context.Context) ([]Meet, error)
+	// Returns the grading ladders with a standard as a rung
+	ListGradingLaddersByStandard(ctx context.Context, standardID uuid.UUID) ([]StandardLadder, error)
+	// Returns the rungs of a ladder from the lowest tier up
+	ListLadderRungs(ctx context.Context, ladderID uuid.UUID) ([]ListLadderRungsRow, error)
+	// Lists the shared ladders and those of the owner, or all ladders for an empty owner
+	ListLadders(ctx context.Context, arg ListLaddersParams) ([]StandardLadder, error)
 	ListMeets(ctx context.Context, arg ListMeetsParams) ([]ListMeetsRow, error)
+	// Returns all official swims of a swimmer in a course type, including relay lead-off legs
+	// Used to evaluate each swim against the standard of the swimmer's age group at the time
+	// Swims may be restricted to a date window ($3, $4; NULL is open) and to sanctioned meets ($5)
+	ListOfficialSwims(ctx context.Context, arg ListOfficialSwimsParams) ([]ListOfficialSwimsRow, error)
+	ListPointsBaseTimes(ctx context.Context) ([]PointsBaseTime, error)
+	// Returns the base times of all years and courses for a gender
+	// Used to compute the points of many swims at once
+	ListPointsBaseTimesByGender(ctx context.Context, gender string) ([]PointsBaseTime, error)
+	// Returns the date, meet and status of every result of a swimmer in all courses
+	// Used to assign meets and times to seasons
+	ListResultDates(ctx context.Context, swimmerID uuid.UUID) ([]ListResultDatesRow, error)
+	ListSplits(ctx context.Context, timeID uuid.UUID) ([]Split, error)
+	// Returns the splits of all times of a swimmer, ordered by time and distance
+	ListSplitsBySwimmer(ctx context.Context, swimmerID uuid.UUID) ([]Split, error)
+	// Lists the shared families and those of the owner, or all families for an empty owner
+	ListStandardFamilies(ctx context.Context, arg ListStandardFamiliesParams) ([]StandardFamily, error)
+	// Lists the users that own standards
+	ListStandardOwners(ctx context.Context) ([]string, error)
 	ListStandardTimes(ctx context.Context, standardID uuid.UUID) ([]StandardTime, error)
+	ListStandardTimesForCourse(ctx context.Context, arg ListStandardTimesForCourseParams) ([]StandardTime, error)
+	ListStandardVersions(ctx context.Context, familyID pgtype.UUID) ([]TimeStandard, error)
+	// Lists the shared standards and those of the owner, or all standards for an empty owner
 	ListStandards(ctx context.Context, arg ListStandardsParams) ([]TimeStandard, error)
-	ListSwimmers(ctx context.Context) ([]ListSwimmersRow, error)
+	ListSwimmers(ctx context.Context, ownerID string) ([]ListSwimmersRow, error)
 	ListTimes(ctx context.Context, arg ListTimesParams) ([]ListTimesRow, error)
 	ListTimesByMeet(ctx context.Context, meetID uuid.UUID) ([]Time, error)
+	// Returns the times of the owner's swimmers of a gender in a course with what their grade depends on,
+	// or those of all swimmers for an empty owner
+	// Used to regrade swims when a grading ladder changes
+	ListTimesForGrading(ctx context.Context, arg ListTimesForGradingParams) ([]ListTimesForGradingRow, error)
+	// Serializes claims of data without an owner until the transaction ends
+	LockUnownedClaims(ctx context.Context) error
 	StandardExists(ctx context.Context, id uuid.UUID) (bool, error)
+	StandardFamilyNameExists(ctx context.Context, arg StandardFamilyNameExistsParams) (bool, error)
 	StandardNameExists(ctx context.Context, arg StandardNameExistsParams) (bool, error)
+	StandardVersionExists(ctx context.Context, arg StandardVersionExistsParams) (bool, error)
+	UpdateLadder(ctx context.Context, arg UpdateLadderParams) (StandardLadder, error)
 	UpdateMeet(ctx context.Context, arg UpdateMeetParams) (Meet, error)
 	UpdateStandard(ctx context.Context, arg UpdateStandardParams) (TimeStandard, error)
+	UpdateStandardFamily(ctx context.Context, arg UpdateStandardFamilyParams) (StandardFamily, error)
 	UpdateStandardTime(ctx context.Context, arg UpdateStandardTimeParams) (StandardTime, error)
 	UpdateSwimmer(ctx context.Context, arg UpdateSwimmerParams) (UpdateSwimmerRow, error)
 	UpdateTime(ctx context.Context, arg UpdateTimeParams) (Time, error)
+	UpdateTimeGrade(ctx context.Context, arg UpdateTimeGradeParams) error
+	UpsertConversionFactor(ctx context.Context, arg UpsertConversionFactorParams) (CourseConversionFactor, error)
+	UpsertPointsBaseTime(ctx context.Context, arg UpsertPointsBaseTimeParams) (PointsBaseTime, error)
 	UpsertStandardTime(ctx context.Context, arg UpsertStandardTimeParams) (StandardTime, error)
 }
 
@@ -13,17 +13,29 @@ import (
 )
 
 const createStandard = `-- name: CreateStandard :one
-INSERT INTO time_standards (name, description, course_type, gender, is_preloaded)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, name, description, course_type, gender, is_preloaded, created_at, updated_at
+INSERT INTO time_standards (name, description, course_type, gender, is_preloaded, age_group_scheme_id,
+                            qualifying_start, qualifying_end, required_course, sanctioned_only,
+                            family_id, version, effective_from, owner_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+          qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id
 `
 
 type CreateStandardParams struct {
-	Name        string      `json:"name"`
-	Description pgtype.Text `json:"description"`
-	CourseType  string      `json:"course_type"`
-	Gender      string      `json:"gender"`
-	IsPreloaded bool        `json:"is_preloaded"`
+	Name             string      `json:"name"`
+	Description      pgtype.Text `json:"description"`
+	CourseType       string      `json:"course_type"`
+	Gender           string      `json:"gender"`
+	IsPreloaded      bool        `json:"is_preloaded"`
+	AgeGroupSchemeID uuid.UUID   `json:"age_group_scheme_id"`
+	QualifyingStart  pgtype.Date `json:"qualifying_start"`
+	QualifyingEnd    pgtype.Date `json:"qualifying_end"`
+	RequiredCourse   pgtype.Text `json:"required_course"`
+	SanctionedOnly   bool        `json:"sanctioned_only"`
+	FamilyID         pgtype.UUID `json:"family_id"`
+	Version          pgtype.Text `json:"version"`
+	EffectiveFrom    pgtype.Date `json:"effective_from"`
+	OwnerID          string      `json:"owner_id"`
 }
 
 func (q *Queries) CreateStandard(ctx context.Context, arg CreateStandardParams) (TimeStandard, error) {
@@ -33,6 +45,15 @@ func (q *Queries) CreateStandard(ctx context.Context, arg CreateStandardParams)
 		arg.CourseType,
 		arg.Gender,
 		arg.IsPreloaded,
+		arg.AgeGroupSchemeID,
+		arg.QualifyingStart,
+		arg.QualifyingEnd,
+		arg.RequiredCourse,
+		arg.SanctionedOnly,
+		arg.FamilyID,
+		arg.Version,
+		arg.EffectiveFrom,
+		arg.OwnerID,
 	)
 	var i TimeStandard
 	err := row.Scan(
@@ -44,6 +65,15 @@ func (q *Queries) CreateStandard(ctx context.Context, arg CreateStandardParams)
 		&i.IsPreloaded,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AgeGroupSchemeID,
+		&i.QualifyingStart,
+		&i.QualifyingEnd,
+		&i.RequiredCourse,
+		&i.SanctionedOnly,
+		&i.FamilyID,
+		&i.Version,
+		&i.EffectiveFrom,
+		&i.OwnerID,
 	)
 	return i, err
 }
@@ -59,7 +89,8 @@ func (q *Queries) DeleteStandard(ctx context.Context, id uuid.UUID) error {
 }
 
 const getStandard = `-- name: GetStandard :one
-SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at
+SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+       qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id
 FROM time_standards
 WHERE id = $1
 `
@@ -76,25 +107,149 @@ func (q *Queries) GetStandard(ctx context.Context, id uuid.UUID) (TimeStandard,
 		&i.IsPreloaded,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AgeGroupSchemeID,
+		&i.QualifyingStart,
+		&i.QualifyingEnd,
+		&i.RequiredCourse,
+		&i.SanctionedOnly,
+		&i.FamilyID,
+		&i.Version,
+		&i.EffectiveFrom,
+		&i.OwnerID,
 	)
 	return i, err
 }
 
+const getStandardByName = `-- name: GetStandardByName :one
+SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+       qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id
+FROM time_standards
+WHERE owner_id = $1 AND name = $2
+`
+
+type GetStandardByNameParams struct {
+	OwnerID string `json:"owner_id"`
+	Name    string `json:"name"`
+}
+
+func (q *Queries) GetStandardByName(ctx context.Context, arg GetStandardByNameParams) (TimeStandard, error) {
+	row := q.db.QueryRow(ctx, getStandardByName, arg.OwnerID, arg.Name)
+	var i TimeStandard
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CourseType,
+		&i.Gender,
+		&i.IsPreloaded,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AgeGroupSchemeID,
+		&i.QualifyingStart,
+		&i.QualifyingEnd,
+		&i.RequiredCourse,
+		&i.SanctionedOnly,
+		&i.FamilyID,
+		&i.Version,
+		&i.EffectiveFrom,
+		&i.OwnerID,
+	)
+	return i, err
+}
+
+const listStandardOwners = `-- name: ListStandardOwners :many
+SELECT DISTINCT owner_id
+FROM time_standards
+WHERE owner_id != ''
+ORDER BY owner_id
+`
+
+// Lists the users that own standards
+func (q *Queries) ListStandardOwners(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, listStandardOwners)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var owner_id string
+		if err := rows.Scan(&owner_id); err != nil {
+			return nil, err
+		}
+		items = append(items, owner_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStandardVersions = `-- name: ListStandardVersions :many
+SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+       qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id
+FROM time_standards
+WHERE family_id = $1
+ORDER BY effective_from ASC
+`
+
+func (q *Queries) ListStandardVersions(ctx context.Context, familyID pgtype.UUID) ([]TimeStandard, error) {
+	rows, err := q.db.Query(ctx, listStandardVersions, familyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TimeStandard{}
+	for rows.Next() {
+		var i TimeStandard
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CourseType,
+			&i.Gender,
+			&i.IsPreloaded,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.AgeGroupSchemeID,
+			&i.QualifyingStart,
+			&i.QualifyingEnd,
+			&i.RequiredCourse,
+			&i.SanctionedOnly,
+			&i.FamilyID,
+			&i.Version,
+			&i.EffectiveFrom,
+			&i.OwnerID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listStandards = `-- name: ListStandards :many
-SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at
+SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+       qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id
 FROM time_standards
 WHERE ($1::varchar = '' OR course_type = $1)
   AND ($2::varchar = '' OR gender = $2)
+  AND ($3::varchar = '' OR owner_id IN ('', $3))
 ORDER BY is_preloaded DESC, name ASC
 `
 
 type ListStandardsParams struct {
 	Column1 string `json:"column_1"`
 	Column2 string `json:"column_2"`
+	Column3 string `json:"column_3"`
 }
 
+// Lists the shared standards and those of the owner, or all standards for an empty owner
 func (q *Queries) ListStandards(ctx context.Context, arg ListStandardsParams) ([]TimeStandard, error) {
-	rows, err := q.db.Query(ctx, listStandards, arg.Column1, arg.Column2)
+	rows, err := q.db.Query(ctx, listStandards, arg.Column1, arg.Column2, arg.Column3)
 	if err != nil {
 		return nil, err
 	}
@@ -111,6 +266,15 @@ func (q *Queries) ListStandards(ctx context.Context, arg ListStandardsParams) ([
 			&i.IsPreloaded,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AgeGroupSchemeID,
+			&i.QualifyingStart,
+			&i.QualifyingEnd,
+			&i.RequiredCourse,
+			&i.SanctionedOnly,
+			&i.FamilyID,
+			&i.Version,
+			&i.EffectiveFrom,
+			&i.OwnerID,
 		); err != nil {
 			return nil, err
 		}
@@ -134,16 +298,34 @@ func (q *Queries) StandardExists(ctx context.Context, id uuid.UUID) (bool, error
 }
 
 const standardNameExists = `-- name: StandardNameExists :one
-SELECT EXISTS(SELECT 1 FROM time_standards WHERE name = $1 AND id != $2)
+SELECT EXISTS(SELECT 1 FROM time_standards WHERE owner_id = $1 AND name = $2 AND id != $3)
 `
 
 type StandardNameExistsParams struct {
-	Name string    `json:"name"`
-	ID   uuid.UUID `json:"id"`
+	OwnerID string    `json:"owner_id"`
+	Name    string    `json:"name"`
+	ID      uuid.UUID `json:"id"`
 }
 
 func (q *Queries) StandardNameExists(ctx context.Context, arg StandardNameExistsParams) (bool, error) {
-	row := q.db.QueryRow(ctx, standardNameExists, arg.Name, arg.ID)
+	row := q.db.QueryRow(ctx, standardNameExists, arg.OwnerID, arg.Name, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const standardVersionExists = `-- name: StandardVersionExists :one
+SELECT EXISTS(SELECT 1 FROM time_standards WHERE family_id = $1 AND effective_from = $2 AND id != $3)
+`
+
+type StandardVersionExistsParams struct {
+	FamilyID      pgtype.UUID `json:"family_id"`
+	EffectiveFrom pgtype.Date `json:"effective_from"`
+	ID            uuid.UUID   `json:"id"`
+}
+
+func (q *Queries) StandardVersionExists(ctx context.Context, arg StandardVersionExistsParams) (bool, error) {
+	row := q.db.QueryRow(ctx, standardVersionExists, arg.FamilyID, arg.EffectiveFrom, arg.ID)
 	var exists bool
 	err := row.Scan(&exists)
 	return exists, err
@@ -151,17 +333,28 @@ func (q *Queries) StandardNameExists(ctx context.Context, arg StandardNameExists
 
 const updateStandard = `-- name: UpdateStandard :one
 UPDATE time_standards
-SET name = $2, description = $3, course_type = $4, gender = $5
+SET name = $2, description = $3, course_type = $4, gender = $5, age_group_scheme_id = $6,
+    qualifying_start = $7, qualifying_end = $8, required_course = $9, sanctioned_only = $10,
+    family_id = $11, version = $12, effective_from = $13
 WHERE id = $1
-RETURNING id, name, description, course_type, gender, is_preloaded, created_at, updated_at
+RETURNING id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+          qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id
 `
 
 type UpdateStandardParams struct {
-	ID          uuid.UUID   `json:"id"`
-	Name        string      `json:"name"`
-	Description pgtype.Text `json:"description"`
-	CourseType  string      `json:"course_type"`
-	Gender      string      `json:"gender"`
+	ID               uuid.UUID   `json:"id"`
+	Name             string      `json:"name"`
+	Description      pgtype.Text `json:"description"`
+	CourseType       string      `json:"course_type"`
+	Gender           string      `json:"gender"`
+	AgeGroupSchemeID uuid.UUID   `json:"age_group_scheme_id"`
+	QualifyingStart  pgtype.Date `json:"qualifying_start"`
+	QualifyingEnd    pgtype.Date `json:"qualifying_end"`
+	RequiredCourse   pgtype.Text `json:"required_course"`
+	SanctionedOnly   bool        `json:"sanctioned_only"`
+	FamilyID         pgtype.UUID `json:"family_id"`
+	Version          pgtype.Text `json:"version"`
+	EffectiveFrom    pgtype.Date `json:"effective_from"`
 }
 
 func (q *Queries) UpdateStandard(ctx context.Context, arg UpdateStandardParams) (TimeStandard, error) {
@@ -171,6 +364,14 @@ func (q *Queries) UpdateStandard(ctx context.Context, arg UpdateStandardParams)
 		arg.Description,
 		arg.CourseType,
 		arg.Gender,
+		arg.AgeGroupSchemeID,
+		arg.QualifyingStart,
+		arg.QualifyingEnd,
+		arg.RequiredCourse,
+		arg.SanctionedOnly,
+		arg.FamilyID,
+		arg.Version,
+		arg.EffectiveFrom,
 	)
 	var i TimeStandard
 	err := row.Scan(
@@ -182,6 +383,15 @@ func (q *Queries) UpdateStandard(ctx context.Context, arg UpdateStandardParams)
 		&i.IsPreloaded,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AgeGroupSchemeID,
+		&i.QualifyingStart,
+		&i.QualifyingEnd,
+		&i.RequiredCourse,
+		&i.SanctionedOnly,
+		&i.FamilyID,
+		&i.Version,
+		&i.EffectiveFrom,
+		&i.OwnerID,
 	)
 	return i, err
 }
@@ -54,6 +54,22 @@ func (q *Queries) DeleteStandardTime(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteStandardTimeForEventAndAge = `-- name: DeleteStandardTimeForEventAndAge :exec
+DELETE FROM standard_times
+WHERE standard_id = $1 AND event = $2 AND age_group = $3
+`
+
+type DeleteStandardTimeForEventAndAgeParams struct {
+	StandardID uuid.UUID `json:"standard_id"`
+	Event      string    `json:"event"`
+	AgeGroup   string    `json:"age_group"`
+}
+
+func (q *Queries) DeleteStandardTimeForEventAndAge(ctx context.Context, arg DeleteStandardTimeForEventAndAgeParams) error {
+	_, err := q.db.Exec(ctx, deleteStandardTimeForEventAndAge, arg.StandardID, arg.Event, arg.AgeGroup)
+	return err
+}
+
 const deleteStandardTimesByStandardID = `-- name: DeleteStandardTimesByStandardID :exec
 DELETE FROM standard_times
 WHERE standard_id = $1
@@ -159,6 +175,47 @@ func (q *Queries) ListStandardTimes(ctx context.Context, standardID uuid.UUID) (
 	return items, nil
 }
 
+const listStandardTimesForCourse = `-- name: ListStandardTimesForCourse :many
+SELECT st.id, st.standard_id, st.event, st.age_group, st.time_ms, st.created_at, st.updated_at
+FROM standard_times st
+JOIN time_standards ts ON ts.id = st.standard_id
+WHERE ts.course_type = $1 AND ts.gender = $2
+ORDER BY st.standard_id, st.event, st.age_group
+`
+
+type ListStandardTimesForCourseParams struct {
+	CourseType string `json:"course_type"`
+	Gender     string `json:"gender"`
+}
+
+func (q *Queries) ListStandardTimesForCourse(ctx context.Context, arg ListStandardTimesForCourseParams) ([]StandardTime, error) {
+	rows, err := q.db.Query(ctx, listStandardTimesForCourse, arg.CourseType, arg.Gender)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StandardTime{}
+	for rows.Next() {
+		var i StandardTime
+		if err := rows.Scan(
+			&i.ID,
+			&i.StandardID,
+			&i.Event,
+			&i.AgeGroup,
+			&i.TimeMs,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateStandardTime = `-- name: UpdateStandardTime :one
 UPDATE standard_times
 SET event = $2, age_group = $3, time_ms = $4
@@ -13,6 +13,21 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimUnownedSwimmers = `-- name: ClaimUnownedSwimmers :execrows
+UPDATE swimmers
+SET owner_id = $1
+WHERE owner_id = ''
+`
+
+// Assigns swimmers created before multi-swimmer support to an owner
+func (q *Queries) ClaimUnownedSwimmers(ctx context.Context, ownerID string) (int64, error) {
+	result, err := q.db.Exec(ctx, claimUnownedSwimmers, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const countSwimmers = `-- name: CountSwimmers :one
 SELECT COUNT(*) FROM swimmers
 `
@@ -25,9 +40,10 @@ func (q *Queries) CountSwimmers(ctx context.Context) (int64, error) {
 }
 
 const createSwimmer = `-- name: CreateSwimmer :one
-INSERT INTO swimmers (name, birth_date, gender, threshold_percent)
-VALUES ($1, $2, $3, $4)
-RETURNING id, name, birth_date, gender, threshold_percent, created_at, updated_at
+INSERT INTO swimmers (name, birth_date, gender, threshold_percent, owner_id, season_start_month, season_start_day)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+          season_start_month, season_start_day
 `
 
 type CreateSwimmerParams struct {
@@ -35,6 +51,9 @@ type CreateSwimmerParams struct {
 	BirthDate        pgtype.Date    `json:"birth_date"`
 	Gender           string         `json:"gender"`
 	ThresholdPercent pgtype.Numeric `json:"threshold_percent"`
+	OwnerID          string         `json:"owner_id"`
+	SeasonStartMonth int16          `json:"season_start_month"`
+	SeasonStartDay   int16          `json:"season_start_day"`
 }
 
 type CreateSwimmerRow struct {
@@ -43,8 +62,11 @@ type CreateSwimmerRow struct {
 	BirthDate        pgtype.Date    `json:"birth_date"`
 	Gender           string         `json:"gender"`
 	ThresholdPercent pgtype.Numeric `json:"threshold_percent"`
+	OwnerID          string         `json:"owner_id"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
+	SeasonStartMonth int16          `json:"season_start_month"`
+	SeasonStartDay   int16          `json:"season_start_day"`
 }
 
 func (q *Queries) CreateSwimmer(ctx context.Context, arg CreateSwimmerParams) (CreateSwimmerRow, error) {
@@ -53,6 +75,9 @@ func (q *Queries) CreateSwimmer(ctx context.Context, arg CreateSwimmerParams) (C
 		arg.BirthDate,
 		arg.Gender,
 		arg.ThresholdPercent,
+		arg.OwnerID,
+		arg.SeasonStartMonth,
+		arg.SeasonStartDay,
 	)
 	var i CreateSwimmerRow
 	err := row.Scan(
@@ -61,8 +86,11 @@ func (q *Queries) CreateSwimmer(ctx context.Context, arg CreateSwimmerParams) (C
 		&i.BirthDate,
 		&i.Gender,
 		&i.ThresholdPercent,
+		&i.OwnerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SeasonStartMonth,
+		&i.SeasonStartDay,
 	)
 	return i, err
 }
@@ -78,7 +106,8 @@ func (q *Queries) DeleteSwimmer(ctx context.Context, id uuid.UUID) error {
 }
 
 const getSwimmer = `-- name: GetSwimmer :one
-SELECT id, name, birth_date, gender, threshold_percent, created_at, updated_at
+SELECT id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+       season_start_month, season_start_day
 FROM swimmers
 WHERE id = $1
 `
@@ -89,8 +118,11 @@ type GetSwimmerRow struct {
 	BirthDate        pgtype.Date    `json:"birth_date"`
 	Gender           string         `json:"gender"`
 	ThresholdPercent pgtype.Numeric `json:"threshold_percent"`
+	OwnerID          string         `json:"owner_id"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
+	SeasonStartMonth int16          `json:"season_start_month"`
+	SeasonStartDay   int16          `json:"season_start_day"`
 }
 
 func (q *Queries) GetSwimmer(ctx context.Context, id uuid.UUID) (GetSwimmerRow, error) {
@@ -102,15 +134,21 @@ func (q *Queries) GetSwimmer(ctx context.Context, id uuid.UUID) (GetSwimmerRow,
 		&i.BirthDate,
 		&i.Gender,
 		&i.ThresholdPercent,
+		&i.OwnerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SeasonStartMonth,
+		&i.SeasonStartDay,
 	)
 	return i, err
 }
 
 const getSwimmerByUserID = `-- name: GetSwimmerByUserID :one
-SELECT id, name, birth_date, gender, threshold_percent, created_at, updated_at
+SELECT id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+       season_start_month, season_start_day
 FROM swimmers
+WHERE owner_id = $1
+ORDER BY created_at, name
 LIMIT 1
 `
 
@@ -120,14 +158,16 @@ type GetSwimmerByUserIDRow struct {
 	BirthDate        pgtype.Date    `json:"birth_date"`
 	Gender           string         `json:"gender"`
 	ThresholdPercent pgtype.Numeric `json:"threshold_percent"`
+	OwnerID          string         `json:"owner_id"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
+	SeasonStartMonth int16          `json:"season_start_month"`
+	SeasonStartDay   int16          `json:"season_start_day"`
 }
 
-// In a multi-user scenario, this would filter by user_id
-// For single-user MVP, just return the first swimmer
-func (q *Queries) GetSwimmerByUserID(ctx context.Context) (GetSwimmerByUserIDRow, error) {
-	row := q.db.QueryRow(ctx, getSwimmerByUserID)
+// Returns the owner's default swimmer (the first one created)
+func (q *Queries) GetSwimmerByUserID(ctx context.Context, ownerID string) (GetSwimmerByUserIDRow, error) {
+	row := q.db.QueryRow(ctx, getSwimmerByUserID, ownerID)
 	var i GetSwimmerByUserIDRow
 	err := row.Scan(
 		&i.ID,
@@ -135,15 +175,115 @@ func (q *Queries) GetSwimmerByUserID(ctx context.Context) (GetSwimmerByUserIDRow
 		&i.BirthDate,
 		&i.Gender,
 		&i.ThresholdPercent,
+		&i.OwnerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SeasonStartMonth,
+		&i.SeasonStartDay,
 	)
 	return i, err
 }
 
+const getSwimmerForOwner = `-- name: GetSwimmerForOwner :one
+SELECT id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+       season_start_month, season_start_day
+FROM swimmers
+WHERE id = $1 AND owner_id = $2
+`
+
+type GetSwimmerForOwnerParams struct {
+	ID      uuid.UUID `json:"id"`
+	OwnerID string    `json:"owner_id"`
+}
+
+type GetSwimmerForOwnerRow struct {
+	ID               uuid.UUID      `json:"id"`
+	Name             string         `json:"name"`
+	BirthDate        pgtype.Date    `json:"birth_date"`
+	Gender           string         `json:"gender"`
+	ThresholdPercent pgtype.Numeric `json:"threshold_percent"`
+	OwnerID          string         `json:"owner_id"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	SeasonStartMonth int16          `json:"season_start_month"`
+	SeasonStartDay   int16          `json:"season_start_day"`
+}
+
+func (q *Queries) GetSwimmerForOwner(ctx context.Context, arg GetSwimmerForOwnerParams) (GetSwimmerForOwnerRow, error) {
+	row := q.db.QueryRow(ctx, getSwimmerForOwner, arg.ID, arg.OwnerID)
+	var i GetSwimmerForOwnerRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.BirthDate,
+		&i.Gender,
+		&i.ThresholdPercent,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SeasonStartMonth,
+		&i.SeasonStartDay,
+	)
+	return i, err
+}
+
+const listAllSwimmers = `-- name: ListAllSwimmers :many
+SELECT id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+       season_start_month, season_start_day
+FROM swimmers
+ORDER BY owner_id, created_at, name
+`
+
+type ListAllSwimmersRow struct {
+	ID               uuid.UUID      `json:"id"`
+	Name             string         `json:"name"`
+	BirthDate        pgtype.Date    `json:"birth_date"`
+	Gender           string         `json:"gender"`
+	ThresholdPercent pgtype.Numeric `json:"threshold_percent"`
+	OwnerID          string         `json:"owner_id"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	SeasonStartMonth int16          `json:"season_start_month"`
+	SeasonStartDay   int16          `json:"season_start_day"`
+}
+
+// Lists the swimmers of all owners, each owner's default swimmer first
+func (q *Queries) ListAllSwimmers(ctx context.Context) ([]ListAllSwimmersRow, error) {
+	rows, err := q.db.Query(ctx, listAllSwimmers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAllSwimmersRow{}
+	for rows.Next() {
+		var i ListAllSwimmersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.BirthDate,
+			&i.Gender,
+			&i.ThresholdPercent,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SeasonStartMonth,
+			&i.SeasonStartDay,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSwimmers = `-- name: ListSwimmers :many
-SELECT id, name, birth_date, gender, threshold_percent, created_at, updated_at
+SELECT id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+       season_start_month, season_start_day
 FROM swimmers
+WHERE owner_id = $1
 ORDER BY name
 `
 
@@ -153,12 +293,15 @@ type ListSwimmersRow struct {
 	BirthDate        pgtype.Date    `json:"birth_date"`
 	Gender           string         `json:"gender"`
 	ThresholdPercent pgtype.Numeric `json:"threshold_percent"`
+	OwnerID          string         `json:"owner_id"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
+	SeasonStartMonth int16          `json:"season_start_month"`
+	SeasonStartDay   int16          `json:"season_start_day"`
 }
 
-func (q *Queries) ListSwimmers(ctx context.Context) ([]ListSwimmersRow, error) {
-	rows, err := q.db.Query(ctx, listSwimmers)
+func (q *Queries) ListSwimmers(ctx context.Context, ownerID string) ([]ListSwimmersRow, error) {
+	rows, err := q.db.Query(ctx, listSwimmers, ownerID)
 	if err != nil {
 		return nil, err
 	}
@@ -172,8 +315,11 @@ func (q *Queries) ListSwimmers(ctx context.Context) ([]ListSwimmersRow, error) {
 			&i.BirthDate,
 			&i.Gender,
 			&i.ThresholdPercent,
+			&i.OwnerID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SeasonStartMonth,
+			&i.SeasonStartDay,
 		); err != nil {
 			return nil, err
 		}
@@ -185,11 +331,23 @@ func (q *Queries) ListSwimmers(ctx context.Context) ([]ListSwimmersRow, error) {
 	return items, nil
 }
 
+const lockUnownedClaims = `-- name: LockUnownedClaims :exec
+SELECT pg_advisory_xact_lock(hashtext('swimstats.claim_unowned'))
+`
+
+// Serializes claims of data without an owner until the transaction ends
+func (q *Queries) LockUnownedClaims(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockUnownedClaims)
+	return err
+}
+
 const updateSwimmer = `-- name: UpdateSwimmer :one
 UPDATE swimmers
-SET name = $2, birth_date = $3, gender = $4, threshold_percent = $5
+SET name = $2, birth_date = $3, gender = $4, threshold_percent = $5,
+    season_start_month = $6, season_start_day = $7
 WHERE id = $1
-RETURNING id, name, birth_date, gender, threshold_percent, created_at, updated_at
+RETURNING id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+          season_start_month, season_start_day
 `
 
 type UpdateSwimmerParams struct {
@@ -198,6 +356,8 @@ type UpdateSwimmerParams struct {
 	BirthDate        pgtype.Date    `json:"birth_date"`
 	Gender           string         `json:"gender"`
 	ThresholdPercent pgtype.Numeric `json:"threshold_percent"`
+	SeasonStartMonth int16          `json:"season_start_month"`
+	SeasonStartDay   int16          `json:"season_start_day"`
 }
 
 type UpdateSwimmerRow struct {
@@ -206,8 +366,11 @@ type UpdateSwimmerRow struct {
 	BirthDate        pgtype.Date    `json:"birth_date"`
 	Gender           string         `json:"gender"`
 	ThresholdPercent pgtype.Numeric `json:"threshold_percent"`
+	OwnerID          string         `json:"owner_id"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
+	SeasonStartMonth int16          `json:"season_start_month"`
+	SeasonStartDay   int16          `json:"season_start_day"`
 }
 
 func (q *Queries) UpdateSwimmer(ctx context.Context, arg UpdateSwimmerParams) (UpdateSwimmerRow, error) {
@@ -217,6 +380,8 @@ func (q *Queries) UpdateSwimmer(ctx context.Context, arg UpdateSwimmerParams) (U
 		arg.BirthDate,
 		arg.Gender,
 		arg.ThresholdPercent,
+		arg.SeasonStartMonth,
+		arg.SeasonStartDay,
 	)
 	var i UpdateSwimmerRow
 	err := row.Scan(
@@ -225,8 +390,11 @@ func (q *Queries) UpdateSwimmer(ctx context.Context, arg UpdateSwimmerParams) (U
 		&i.BirthDate,
 		&i.Gender,
 		&i.ThresholdPercent,
+		&i.OwnerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SeasonStartMonth,
+		&i.SeasonStartDay,
 	)
 	return i, err
 }
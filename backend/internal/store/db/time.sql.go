@@ -13,6 +13,56 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countResultsByStatus = `-- name: CountResultsByStatus :many
+SELECT t.status, COUNT(*)::int AS count
+FROM times t
+JOIN meets m ON m.id = t.meet_id
+WHERE t.swimmer_id = $1
+  AND ($2::varchar = '' OR m.course_type = $2)
+  AND ($3::date IS NULL OR COALESCE(t.event_date, m.start_date) >= $3)
+  AND ($4::date IS NULL OR COALESCE(t.event_date, m.start_date) <= $4)
+GROUP BY t.status
+ORDER BY t.status
+`
+
+type CountResultsByStatusParams struct {
+	SwimmerID uuid.UUID   `json:"swimmer_id"`
+	Column2   string      `json:"column_2"`
+	Column3   pgtype.Date `json:"column_3"`
+	Column4   pgtype.Date `json:"column_4"`
+}
+
+type CountResultsByStatusRow struct {
+	Status string `json:"status"`
+	Count  int32  `json:"count"`
+}
+
+// Returns count of results per status for a swimmer, optionally within a course and date range
+func (q *Queries) CountResultsByStatus(ctx context.Context, arg CountResultsByStatusParams) ([]CountResultsByStatusRow, error) {
+	rows, err := q.db.Query(ctx, countResultsByStatus,
+		arg.SwimmerID,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountResultsByStatusRow{}
+	for rows.Next() {
+		var i CountResultsByStatusRow
+		if err := rows.Scan(&i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countTimes = `-- name: CountTimes :one
 SELECT COUNT(*) FROM times t
 JOIN meets m ON m.id = t.meet_id
@@ -83,18 +133,25 @@ func (q *Queries) CountTimesByEvent(ctx context.Context, arg CountTimesByEventPa
 }
 
 const createTime = `-- name: CreateTime :one
-INSERT INTO times (swimmer_id, meet_id, event, time_ms, event_date, notes)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, swimmer_id, meet_id, event, time_ms, event_date, notes, created_at, updated_at
+INSERT INTO times (swimmer_id, meet_id, event, time_ms, event_date, notes, relay_leg, relay_stroke, official_event, status, dq_code, dq_reason, grade)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING id, swimmer_id, meet_id, event, time_ms, event_date, notes, created_at, updated_at, relay_leg, relay_stroke, official_event, status, dq_code, dq_reason, grade
 `
 
 type CreateTimeParams struct {
-	SwimmerID uuid.UUID   `json:"swimmer_id"`
-	MeetID    uuid.UUID   `json:"meet_id"`
-	Event     string      `json:"event"`
-	TimeMs    int32       `json:"time_ms"`
-	EventDate pgtype.Date `json:"event_date"`
-	Notes     pgtype.Text `json:"notes"`
+	SwimmerID     uuid.UUID   `json:"swimmer_id"`
+	MeetID        uuid.UUID   `json:"meet_id"`
+	Event         string      `json:"event"`
+	TimeMs        int32       `json:"time_ms"`
+	EventDate     pgtype.Date `json:"event_date"`
+	Notes         pgtype.Text `json:"notes"`
+	RelayLeg      pgtype.Int2 `json:"relay_leg"`
+	RelayStroke   pgtype.Text `json:"relay_stroke"`
+	OfficialEvent string      `json:"official_event"`
+	Status        string      `json:"status"`
+	DqCode        pgtype.Text `json:"dq_code"`
+	DqReason      pgtype.Text `json:"dq_reason"`
+	Grade         pgtype.Text `json:"grade"`
 }
 
 func (q *Queries) CreateTime(ctx context.Context, arg CreateTimeParams) (Time, error) {
@@ -105,6 +162,13 @@ func (q *Queries) CreateTime(ctx context.Context, arg CreateTimeParams) (Time, e
 		arg.TimeMs,
 		arg.EventDate,
 		arg.Notes,
+		arg.RelayLeg,
+		arg.RelayStroke,
+		arg.OfficialEvent,
+		arg.Status,
+		arg.DqCode,
+		arg.DqReason,
+		arg.Grade,
 	)
 	var i Time
 	err := row.Scan(
@@ -117,6 +181,13 @@ func (q *Queries) CreateTime(ctx context.Context, arg CreateTimeParams) (Time, e
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RelayLeg,
+		&i.RelayStroke,
+		&i.OfficialEvent,
+		&i.Status,
+		&i.DqCode,
+		&i.DqReason,
+		&i.Grade,
 	)
 	return i, err
 }
@@ -141,6 +212,16 @@ func (q *Queries) DeleteTimesByMeet(ctx context.Context, meetID uuid.UUID) error
 	return err
 }
 
+const deleteTimesBySwimmer = `-- name: DeleteTimesBySwimmer :exec
+DELETE FROM times
+WHERE swimmer_id = $1
+`
+
+func (q *Queries) DeleteTimesBySwimmer(ctx context.Context, swimmerID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteTimesBySwimmer, swimmerID)
+	return err
+}
+
 const eventExistsForMeet = `-- name: EventExistsForMeet :one
 SELECT EXISTS (
     SELECT 1 FROM times
@@ -169,7 +250,7 @@ SELECT
     t.id,
     t.swimmer_id,
     t.meet_id,
-    t.event,
+    t.official_event AS event,
     t.time_ms,
     t.event_date,
     t.notes,
@@ -181,15 +262,16 @@ FROM times t
 JOIN meets m ON m.id = t.meet_id
 WHERE t.swimmer_id = $1
   AND m.course_type = $2
-  AND t.event = $3
+  AND t.official_event = $3
+  AND t.status = 'ok'
 ORDER BY t.time_ms ASC, COALESCE(t.event_date, m.start_date) DESC
 LIMIT 1
 `
 
 type GetPersonalBestForEventParams struct {
-	SwimmerID  uuid.UUID `json:"swimmer_id"`
-	CourseType string    `json:"course_type"`
-	Event      string    `json:"event"`
+	SwimmerID     uuid.UUID `json:"swimmer_id"`
+	CourseType    string    `json:"course_type"`
+	OfficialEvent string    `json:"official_event"`
 }
 
 type GetPersonalBestForEventRow struct {
@@ -206,9 +288,9 @@ type GetPersonalBestForEventRow struct {
 	MeetDate  pgtype.Date `json:"meet_date"`
 }
 
-// Returns the fastest time for a specific event
+// Returns the fastest time for a specific event, including relay lead-off legs
 func (q *Queries) GetPersonalBestForEvent(ctx context.Context, arg GetPersonalBestForEventParams) (GetPersonalBestForEventRow, error) {
-	row := q.db.QueryRow(ctx, getPersonalBestForEvent, arg.SwimmerID, arg.CourseType, arg.Event)
+	row := q.db.QueryRow(ctx, getPersonalBestForEvent, arg.SwimmerID, arg.CourseType, arg.OfficialEvent)
 	var i GetPersonalBestForEventRow
 	err := row.Scan(
 		&i.ID,
@@ -227,28 +309,38 @@ func (q *Queries) GetPersonalBestForEvent(ctx context.Context, arg GetPersonalBe
 }
 
 const getPersonalBests = `-- name: GetPersonalBests :many
-SELECT DISTINCT ON (t.event)
+SELECT DISTINCT ON (t.official_event)
     t.id,
     t.swimmer_id,
     t.meet_id,
-    t.event,
+    t.official_event AS event,
     t.time_ms,
     t.event_date,
     t.notes,
     t.created_at,
     t.updated_at,
+    t.event AS swum_event,
+    t.relay_leg,
     m.name AS meet_name,
     m.start_date AS meet_date
 FROM times t
 JOIN meets m ON m.id = t.meet_id
 WHERE t.swimmer_id = $1
   AND m.course_type = $2
-ORDER BY t.event, t.time_ms ASC, COALESCE(t.event_date, m.start_date) DESC
+  AND t.official_event <> ''
+  AND t.status = 'ok'
+  AND ($3::date IS NULL OR COALESCE(t.event_date, m.start_date) >= $3)
+  AND ($4::date IS NULL OR COALESCE(t.event_date, m.start_date) <= $4)
+  AND (NOT $5::boolean OR m.sanctioned)
+ORDER BY t.official_event, t.time_ms ASC, COALESCE(t.event_date, m.start_date) DESC
 `
 
 type GetPersonalBestsParams struct {
-	SwimmerID  uuid.UUID `json:"swimmer_id"`
-	CourseType string    `json:"course_type"`
+	SwimmerID  uuid.UUID   `json:"swimmer_id"`
+	CourseType string      `json:"course_type"`
+	Column3    pgtype.Date `json:"column_3"`
+	Column4    pgtype.Date `json:"column_4"`
+	Column5    bool        `json:"column_5"`
 }
 
 type GetPersonalBestsRow struct {
@@ -261,13 +353,23 @@ type GetPersonalBestsRow struct {
 	Notes     pgtype.Text `json:"notes"`
 	CreatedAt time.Time   `json:"created_at"`
 	UpdatedAt time.Time   `json:"updated_at"`
+	SwumEvent string      `json:"swum_event"`
+	RelayLeg  pgtype.Int2 `json:"relay_leg"`
 	MeetName  string      `json:"meet_name"`
 	MeetDate  pgtype.Date `json:"meet_date"`
 }
 
 // Returns the fastest time for each event for a swimmer in a specific course type
+// Relay lead-off legs count toward the equivalent individual event; DQ, DNS, DNF and scratches never do
+// Swims may be restricted to a date window ($3, $4; NULL is open) and to sanctioned meets ($5)
 func (q *Queries) GetPersonalBests(ctx context.Context, arg GetPersonalBestsParams) ([]GetPersonalBestsRow, error) {
-	rows, err := q.db.Query(ctx, getPersonalBests, arg.SwimmerID, arg.CourseType)
+	rows, err := q.db.Query(ctx, getPersonalBests,
+		arg.SwimmerID,
+		arg.CourseType,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -285,6 +387,8 @@ func (q *Queries) GetPersonalBests(ctx context.Context, arg GetPersonalBestsPara
 			&i.Notes,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SwumEvent,
+			&i.RelayLeg,
 			&i.MeetName,
 			&i.MeetDate,
 		); err != nil {
@@ -313,24 +417,26 @@ SELECT
         JOIN meets m2 ON m2.id = t2.meet_id
         WHERE t2.swimmer_id = t.swimmer_id
           AND m2.course_type = m.course_type
-          AND t2.event = t.event
+          AND t2.official_event = t.official_event
+          AND t2.status = 'ok'
     )) AS is_pb
 FROM times t
 JOIN meets m ON m.id = t.meet_id
 WHERE t.swimmer_id = $1
   AND m.course_type = $2
-  AND t.event = $3
+  AND t.official_event = $3
+  AND t.status = 'ok'
   AND ($4::date IS NULL OR COALESCE(t.event_date, m.start_date) >= $4)
   AND ($5::date IS NULL OR COALESCE(t.event_date, m.start_date) <= $5)
 ORDER BY COALESCE(t.event_date, m.start_date) ASC, t.time_ms ASC
 `
 
 type GetProgressDataParams struct {
-	SwimmerID  uuid.UUID   `json:"swimmer_id"`
-	CourseType string      `json:"course_type"`
-	Event      string      `json:"event"`
-	Column4    pgtype.Date `json:"column_4"`
-	Column5    pgtype.Date `json:"column_5"`
+	SwimmerID     uuid.UUID   `json:"swimmer_id"`
+	CourseType    string      `json:"course_type"`
+	OfficialEvent string      `json:"official_event"`
+	Column4       pgtype.Date `json:"column_4"`
+	Column5       pgtype.Date `json:"column_5"`
 }
 
 type GetProgressDataRow struct {
@@ -343,13 +449,13 @@ type GetProgressDataRow struct {
 	IsPb     bool        `json:"is_pb"`
 }
 
-// Returns time progression for a specific event over time
+// Returns time progression for a specific event over time, including relay lead-off legs
 // Used for progress charts visualization
 func (q *Queries) GetProgressData(ctx context.Context, arg GetProgressDataParams) ([]GetProgressDataRow, error) {
 	rows, err := q.db.Query(ctx, getProgressData,
 		arg.SwimmerID,
 		arg.CourseType,
-		arg.Event,
+		arg.OfficialEvent,
 		arg.Column4,
 		arg.Column5,
 	)
@@ -389,7 +495,14 @@ SELECT
     t.event_date,
     t.notes, 
     t.created_at, 
-    t.updated_at
+    t.updated_at,
+    t.relay_leg,
+    t.relay_stroke,
+    t.official_event,
+    t.status,
+    t.dq_code,
+    t.dq_reason,
+    t.grade
 FROM times t
 WHERE t.id = $1
 `
@@ -407,6 +520,13 @@ func (q *Queries) GetTime(ctx context.Context, id uuid.UUID) (Time, error) {
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RelayLeg,
+		&i.RelayStroke,
+		&i.OfficialEvent,
+		&i.Status,
+		&i.DqCode,
+		&i.DqReason,
+		&i.Grade,
 	)
 	return i, err
 }
@@ -422,6 +542,13 @@ SELECT
     t.notes, 
     t.created_at, 
     t.updated_at,
+    t.relay_leg,
+    t.relay_stroke,
+    t.official_event,
+    t.status,
+    t.dq_code,
+    t.dq_reason,
+    t.grade,
     m.name AS meet_name,
     m.city AS meet_city,
     m.start_date AS meet_start_date,
@@ -442,6 +569,13 @@ type GetTimeWithMeetRow struct {
 	Notes          pgtype.Text `json:"notes"`
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
+	RelayLeg       pgtype.Int2 `json:"relay_leg"`
+	RelayStroke    pgtype.Text `json:"relay_stroke"`
+	OfficialEvent  string      `json:"official_event"`
+	Status         string      `json:"status"`
+	DqCode         pgtype.Text `json:"dq_code"`
+	DqReason       pgtype.Text `json:"dq_reason"`
+	Grade          pgtype.Text `json:"grade"`
 	MeetName       string      `json:"meet_name"`
 	MeetCity       string      `json:"meet_city"`
 	MeetStartDate  pgtype.Date `json:"meet_start_date"`
@@ -462,6 +596,13 @@ func (q *Queries) GetTimeWithMeet(ctx context.Context, id uuid.UUID) (GetTimeWit
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RelayLeg,
+		&i.RelayStroke,
+		&i.OfficialEvent,
+		&i.Status,
+		&i.DqCode,
+		&i.DqReason,
+		&i.Grade,
 		&i.MeetName,
 		&i.MeetCity,
 		&i.MeetStartDate,
@@ -501,18 +642,19 @@ SELECT NOT EXISTS (
     JOIN meets m ON m.id = t.meet_id
     WHERE t.swimmer_id = $1
       AND m.course_type = $2
-      AND t.event = $3
+      AND t.official_event = $3
+      AND t.status = 'ok'
       AND t.time_ms <= $4
       AND t.id != $5
 ) AS is_pb
 `
 
 type IsPersonalBestParams struct {
-	SwimmerID  uuid.UUID `json:"swimmer_id"`
-	CourseType string    `json:"course_type"`
-	Event      string    `json:"event"`
-	TimeMs     int32     `json:"time_ms"`
-	ID         uuid.UUID `json:"id"`
+	SwimmerID     uuid.UUID `json:"swimmer_id"`
+	CourseType    string    `json:"course_type"`
+	OfficialEvent string    `json:"official_event"`
+	TimeMs        int32     `json:"time_ms"`
+	ID            uuid.UUID `json:"id"`
 }
 
 // Check if a given time is faster than all existing times for this event/course
@@ -520,7 +662,7 @@ func (q *Queries) IsPersonalBest(ctx context.Context, arg IsPersonalBestParams)
 	row := q.db.QueryRow(ctx, isPersonalBest,
 		arg.SwimmerID,
 		arg.CourseType,
-		arg.Event,
+		arg.OfficialEvent,
 		arg.TimeMs,
 		arg.ID,
 	)
@@ -529,6 +671,121 @@ func (q *Queries) IsPersonalBest(ctx context.Context, arg IsPersonalBestParams)
 	return is_pb, err
 }
 
+const listOfficialSwims = `-- name: ListOfficialSwims :many
+SELECT
+    t.id,
+    t.official_event AS event,
+    t.time_ms,
+    COALESCE(t.event_date, m.start_date) AS date,
+    m.name AS meet_name,
+    m.start_date AS meet_date,
+    t.meet_id
+FROM times t
+JOIN meets m ON m.id = t.meet_id
+WHERE t.swimmer_id = $1
+  AND m.course_type = $2
+  AND t.official_event <> ''
+  AND t.status = 'ok'
+  AND ($3::date IS NULL OR COALESCE(t.event_date, m.start_date) >= $3)
+  AND ($4::date IS NULL OR COALESCE(t.event_date, m.start_date) <= $4)
+  AND (NOT $5::boolean OR m.sanctioned)
+ORDER BY t.official_event, COALESCE(t.event_date, m.start_date) DESC, t.time_ms ASC
+`
+
+type ListOfficialSwimsParams struct {
+	SwimmerID  uuid.UUID   `json:"swimmer_id"`
+	CourseType string      `json:"course_type"`
+	Column3    pgtype.Date `json:"column_3"`
+	Column4    pgtype.Date `json:"column_4"`
+	Column5    bool        `json:"column_5"`
+}
+
+type ListOfficialSwimsRow struct {
+	ID       uuid.UUID   `json:"id"`
+	Event    string      `json:"event"`
+	TimeMs   int32       `json:"time_ms"`
+	Date     pgtype.Date `json:"date"`
+	MeetName string      `json:"meet_name"`
+	MeetDate pgtype.Date `json:"meet_date"`
+	MeetID   uuid.UUID   `json:"meet_id"`
+}
+
+// Returns all official swims of a swimmer in a course type, including relay lead-off legs
+// Used to evaluate each swim against the standard of the swimmer's age group at the time
+// Swims may be restricted to a date window ($3, $4; NULL is open) and to sanctioned meets ($5)
+func (q *Queries) ListOfficialSwims(ctx context.Context, arg ListOfficialSwimsParams) ([]ListOfficialSwimsRow, error) {
+	rows, err := q.db.Query(ctx, listOfficialSwims,
+		arg.SwimmerID,
+		arg.CourseType,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListOfficialSwimsRow{}
+	for rows.Next() {
+		var i ListOfficialSwimsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Event,
+			&i.TimeMs,
+			&i.Date,
+			&i.MeetName,
+			&i.MeetDate,
+			&i.MeetID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResultDates = `-- name: ListResultDates :many
+SELECT
+    COALESCE(t.event_date, m.start_date) AS date,
+    t.meet_id,
+    t.status
+FROM times t
+JOIN meets m ON m.id = t.meet_id
+WHERE t.swimmer_id = $1
+ORDER BY COALESCE(t.event_date, m.start_date)
+`
+
+type ListResultDatesRow struct {
+	Date   pgtype.Date `json:"date"`
+	MeetID uuid.UUID   `json:"meet_id"`
+	Status string      `json:"status"`
+}
+
+// Returns the date, meet and status of every result of a swimmer in all courses
+// Used to assign meets and times to seasons
+func (q *Queries) ListResultDates(ctx context.Context, swimmerID uuid.UUID) ([]ListResultDatesRow, error) {
+	rows, err := q.db.Query(ctx, listResultDates, swimmerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListResultDatesRow{}
+	for rows.Next() {
+		var i ListResultDatesRow
+		if err := rows.Scan(&i.Date, &i.MeetID, &i.Status); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTimes = `-- name: ListTimes :many
 SELECT 
     t.id, 
@@ -540,6 +797,13 @@ SELECT
     t.notes, 
     t.created_at, 
     t.updated_at,
+    t.relay_leg,
+    t.relay_stroke,
+    t.official_event,
+    t.status,
+    t.dq_code,
+    t.dq_reason,
+    t.grade,
     m.name AS meet_name,
     m.city AS meet_city,
     m.start_date AS meet_start_date,
@@ -551,7 +815,7 @@ WHERE t.swimmer_id = $1
   AND ($2::varchar = '' OR m.course_type = $2)
   AND ($3::varchar = '' OR t.event = $3)
   AND ($4::uuid = '00000000-0000-0000-0000-000000000000' OR t.meet_id = $4)
-ORDER BY COALESCE(t.event_date, m.start_date) DESC, t.event
+ORDER BY COALESCE(t.event_date, m.start_date) DESC, t.event, t.id
 LIMIT $5 OFFSET $6
 `
 
@@ -574,6 +838,13 @@ type ListTimesRow struct {
 	Notes          pgtype.Text `json:"notes"`
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
+	RelayLeg       pgtype.Int2 `json:"relay_leg"`
+	RelayStroke    pgtype.Text `json:"relay_stroke"`
+	OfficialEvent  string      `json:"official_event"`
+	Status         string      `json:"status"`
+	DqCode         pgtype.Text `json:"dq_code"`
+	DqReason       pgtype.Text `json:"dq_reason"`
+	Grade          pgtype.Text `json:"grade"`
 	MeetName       string      `json:"meet_name"`
 	MeetCity       string      `json:"meet_city"`
 	MeetStartDate  pgtype.Date `json:"meet_start_date"`
@@ -607,6 +878,13 @@ func (q *Queries) ListTimes(ctx context.Context, arg ListTimesParams) ([]ListTim
 			&i.Notes,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.RelayLeg,
+			&i.RelayStroke,
+			&i.OfficialEvent,
+			&i.Status,
+			&i.DqCode,
+			&i.DqReason,
+			&i.Grade,
 			&i.MeetName,
 			&i.MeetCity,
 			&i.MeetStartDate,
@@ -633,7 +911,14 @@ SELECT
     t.event_date,
     t.notes, 
     t.created_at, 
-    t.updated_at
+    t.updated_at,
+    t.relay_leg,
+    t.relay_stroke,
+    t.official_event,
+    t.status,
+    t.dq_code,
+    t.dq_reason,
+    t.grade
 FROM times t
 WHERE t.meet_id = $1
 ORDER BY COALESCE(t.event_date, (SELECT start_date FROM meets WHERE id = t.meet_id)), t.event, t.time_ms
@@ -658,6 +943,79 @@ func (q *Queries) ListTimesByMeet(ctx context.Context, meetID uuid.UUID) ([]Time
 			&i.Notes,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.RelayLeg,
+			&i.RelayStroke,
+			&i.OfficialEvent,
+			&i.Status,
+			&i.DqCode,
+			&i.DqReason,
+			&i.Grade,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTimesForGrading = `-- name: ListTimesForGrading :many
+SELECT
+    t.id,
+    t.official_event,
+    t.time_ms,
+    t.status,
+    t.grade,
+    m.start_date AS meet_start_date,
+    s.birth_date,
+    s.owner_id
+FROM times t
+JOIN meets m ON m.id = t.meet_id
+JOIN swimmers s ON s.id = t.swimmer_id
+WHERE m.course_type = $1 AND s.gender = $2
+  AND ($3::varchar = '' OR s.owner_id = $3)
+`
+
+type ListTimesForGradingParams struct {
+	CourseType string `json:"course_type"`
+	Gender     string `json:"gender"`
+	Column3    string `json:"column_3"`
+}
+
+type ListTimesForGradingRow struct {
+	ID            uuid.UUID   `json:"id"`
+	OfficialEvent string      `json:"official_event"`
+	TimeMs        int32       `json:"time_ms"`
+	Status        string      `json:"status"`
+	Grade         pgtype.Text `json:"grade"`
+	MeetStartDate pgtype.Date `json:"meet_start_date"`
+	BirthDate     pgtype.Date `json:"birth_date"`
+	OwnerID       string      `json:"owner_id"`
+}
+
+// Returns the times of the owner's swimmers of a gender in a course with what their grade depends on,
+// or those of all swimmers for an empty owner
+// Used to regrade swims when a grading ladder changes
+func (q *Queries) ListTimesForGrading(ctx context.Context, arg ListTimesForGradingParams) ([]ListTimesForGradingRow, error) {
+	rows, err := q.db.Query(ctx, listTimesForGrading, arg.CourseType, arg.Gender, arg.Column3)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTimesForGradingRow{}
+	for rows.Next() {
+		var i ListTimesForGradingRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OfficialEvent,
+			&i.TimeMs,
+			&i.Status,
+			&i.Grade,
+			&i.MeetStartDate,
+			&i.BirthDate,
+			&i.OwnerID,
 		); err != nil {
 			return nil, err
 		}
@@ -671,18 +1029,27 @@ func (q *Queries) ListTimesByMeet(ctx context.Context, meetID uuid.UUID) ([]Time
 
 const updateTime = `-- name: UpdateTime :one
 UPDATE times
-SET meet_id = $2, event = $3, time_ms = $4, event_date = $5, notes = $6
+SET meet_id = $2, event = $3, time_ms = $4, event_date = $5, notes = $6,
+    relay_leg = $7, relay_stroke = $8, official_event = $9,
+    status = $10, dq_code = $11, dq_reason = $12, grade = $13
 WHERE id = $1
-RETURNING id, swimmer_id, meet_id, event, time_ms, event_date, notes, created_at, updated_at
+RETURNING id, swimmer_id, meet_id, event, time_ms, event_date, notes, created_at, updated_at, relay_leg, relay_stroke, official_event, status, dq_code, dq_reason, grade
 `
 
 type UpdateTimeParams struct {
-	ID        uuid.UUID   `json:"id"`
-	MeetID    uuid.UUID   `json:"meet_id"`
-	Event     string      `json:"event"`
-	TimeMs    int32       `json:"time_ms"`
-	EventDate pgtype.Date `json:"event_date"`
-	Notes     pgtype.Text `json:"notes"`
+	ID            uuid.UUID   `json:"id"`
+	MeetID        uuid.UUID   `json:"meet_id"`
+	Event         string      `json:"event"`
+	TimeMs        int32       `json:"time_ms"`
+	EventDate     pgtype.Date `json:"event_date"`
+	Notes         pgtype.Text `json:"notes"`
+	RelayLeg      pgtype.Int2 `json:"relay_leg"`
+	RelayStroke   pgtype.Text `json:"relay_stroke"`
+	OfficialEvent string      `json:"official_event"`
+	Status        string      `json:"status"`
+	DqCode        pgtype.Text `json:"dq_code"`
+	DqReason      pgtype.Text `json:"dq_reason"`
+	Grade         pgtype.Text `json:"grade"`
 }
 
 func (q *Queries) UpdateTime(ctx context.Context, arg UpdateTimeParams) (Time, error) {
@@ -693,6 +1060,13 @@ func (q *Queries) UpdateTime(ctx context.Context, arg UpdateTimeParams) (Time, e
 		arg.TimeMs,
 		arg.EventDate,
 		arg.Notes,
+		arg.RelayLeg,
+		arg.RelayStroke,
+		arg.OfficialEvent,
+		arg.Status,
+		arg.DqCode,
+		arg.DqReason,
+		arg.Grade,
 	)
 	var i Time
 	err := row.Scan(
@@ -705,6 +1079,29 @@ func (q *Queries) UpdateTime(ctx context.Context, arg UpdateTimeParams) (Time, e
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RelayLeg,
+		&i.RelayStroke,
+		&i.OfficialEvent,
+		&i.Status,
+		&i.DqCode,
+		&i.DqReason,
+		&i.Grade,
 	)
 	return i, err
 }
+
+const updateTimeGrade = `-- name: UpdateTimeGrade :exec
+UPDATE times
+SET grade = $2
+WHERE id = $1
+`
+
+type UpdateTimeGradeParams struct {
+	ID    uuid.UUID   `json:"id"`
+	Grade pgtype.Text `json:"grade"`
+}
+
+func (q *Queries) UpdateTimeGrade(ctx context.Context, arg UpdateTimeGradeParams) error {
+	_, err := q.db.Exec(ctx, updateTimeGrade, arg.ID, arg.Grade)
+	return err
+}
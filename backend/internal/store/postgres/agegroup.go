@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/bpg/swimstats/backend/internal/store/db"
+)
+
+// AgeGroupRepository provides age-group scheme data access.
+type AgeGroupRepository struct {
+	queries *db.Queries
+}
+
+// NewAgeGroupRepository creates a new age-group scheme repository.
+func NewAgeGroupRepository(queries *db.Queries) *AgeGroupRepository {
+	return &AgeGroupRepository{queries: queries}
+}
+
+// WithTx returns a repository that runs its queries in the transaction.
+func (r *AgeGroupRepository) WithTx(tx pgx.Tx) *AgeGroupRepository {
+	return &AgeGroupRepository{queries: r.queries.WithTx(tx)}
+}
+
+// Get retrieves a scheme by ID.
+func (r *AgeGroupRepository) Get(ctx context.Context, id uuid.UUID) (*db.AgeGroupScheme, error) {
+	scheme, err := r.queries.GetAgeGroupScheme(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get age group scheme: %w", err)
+	}
+	return &scheme, nil
+}
+
+// GetByName retrieves a scheme by its name.
+func (r *AgeGroupRepository) GetByName(ctx context.Context, name string) (*db.AgeGroupScheme, error) {
+	scheme, err := r.queries.GetAgeGroupSchemeByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get age group scheme by name: %w", err)
+	}
+	return &scheme, nil
+}
+
+// List lists all schemes.
+func (r *AgeGroupRepository) List(ctx context.Context) ([]db.AgeGroupScheme, error) {
+	schemes, err := r.queries.ListAgeGroupSchemes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list age group schemes: %w", err)
+	}
+	return schemes, nil
+}
+
+// Create creates a new scheme.
+func (r *AgeGroupRepository) Create(ctx context.Context, params db.CreateAgeGroupSchemeParams) (*db.AgeGroupScheme, error) {
+	scheme, err := r.queries.CreateAgeGroupScheme(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create age group scheme: %w", err)
+	}
+	return &scheme, nil
+}
+
+// Delete deletes a scheme and its age groups.
+func (r *AgeGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	deleted, err := r.queries.DeleteAgeGroupScheme(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete age group scheme: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// NameExists checks if a scheme with the given name exists.
+func (r *AgeGroupRepository) NameExists(ctx context.Context, name string) (bool, error) {
+	exists, err := r.queries.AgeGroupSchemeNameExists(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("check age group scheme name exists: %w", err)
+	}
+	return exists, nil
+}
+
+// InUse checks if any time standard uses the scheme.
+func (r *AgeGroupRepository) InUse(ctx context.Context, id uuid.UUID) (bool, error) {
+	inUse, err := r.queries.AgeGroupSchemeInUse(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("check age group scheme in use: %w", err)
+	}
+	return inUse, nil
+}
+
+// ListRanges lists the age groups of a scheme, youngest first.
+func (r *AgeGroupRepository) ListRanges(ctx context.Context, schemeID uuid.UUID) ([]db.AgeGroupRange, error) {
+	ranges, err := r.queries.ListAgeGroupRanges(ctx, schemeID)
+	if err != nil {
+		return nil, fmt.Errorf("list age group ranges: %w", err)
+	}
+	return ranges, nil
+}
+
+// ListAllRanges lists the age groups of all schemes.
+func (r *AgeGroupRepository) ListAllRanges(ctx context.Context) ([]db.AgeGroupRange, error) {
+	ranges, err := r.queries.ListAllAgeGroupRanges(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list age group ranges: %w", err)
+	}
+	return ranges, nil
+}
+
+// CreateRange adds an age group to a scheme.
+func (r *AgeGroupRepository) CreateRange(ctx context.Context, params db.CreateAgeGroupRangeParams) (*db.AgeGroupRange, error) {
+	ageRange, err := r.queries.CreateAgeGroupRange(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create age group range: %w", err)
+	}
+	return &ageRange, nil
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/bpg/swimstats/backend/internal/store/db"
+)
+
+// ConversionRepository provides course conversion factor data access.
+type ConversionRepository struct {
+	queries *db.Queries
+}
+
+// NewConversionRepository creates a new conversion repository.
+func NewConversionRepository(queries *db.Queries) *ConversionRepository {
+	return &ConversionRepository{queries: queries}
+}
+
+// WithTx returns a repository that runs its queries in the transaction.
+func (r *ConversionRepository) WithTx(tx pgx.Tx) *ConversionRepository {
+	return &ConversionRepository{queries: r.queries.WithTx(tx)}
+}
+
+// ListFactors lists all course conversion factors.
+func (r *ConversionRepository) ListFactors(ctx context.Context) ([]db.CourseConversionFactor, error) {
+	factors, err := r.queries.ListConversionFactors(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list conversion factors: %w", err)
+	}
+	return factors, nil
+}
+
+// UpsertFactor creates or replaces the factor for a course pair and event.
+func (r *ConversionRepository) UpsertFactor(ctx context.Context, params db.UpsertConversionFactorParams) (*db.CourseConversionFactor, error) {
+	factor, err := r.queries.UpsertConversionFactor(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("upsert conversion factor: %w", err)
+	}
+	return &factor, nil
+}
+
+// DeleteFactor deletes a conversion factor.
+func (r *ConversionRepository) DeleteFactor(ctx context.Context, id uuid.UUID) error {
+	deleted, err := r.queries.DeleteConversionFactor(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete conversion factor: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
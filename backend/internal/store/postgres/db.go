@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -107,6 +108,56 @@ func NewFromDSN(ctx context.Context, dsn string) (*DB, error) {
 	return &DB{Pool: pool}, nil
 }
 
+// TxBeginner starts transactions, e.g. a *pgxpool.Pool.
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// SnapshotBeginner starts transactions with options, e.g. a *pgxpool.Pool.
+type SnapshotBeginner interface {
+	TxBeginner
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// InSnapshot runs fn in a read-only, repeatable read transaction, so that
+// everything fn reads comes from a single consistent snapshot.
+func InSnapshot(ctx context.Context, txs SnapshotBeginner, fn func(tx pgx.Tx) error) error {
+	tx, err := txs.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// InTx runs fn in a transaction, committed if fn succeeds and rolled back
+// otherwise.
+func InTx(ctx context.Context, txs TxBeginner, fn func(tx pgx.Tx) error) error {
+	tx, err := txs.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
 // Close closes the database connection pool.
 func (db *DB) Close() {
 	if db.Pool != nil {
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/bpg/swimstats/backend/internal/store/db"
+)
+
+// LadderRepository provides standard ladder data access.
+type LadderRepository struct {
+	queries *db.Queries
+}
+
+// NewLadderRepository creates a new ladder repository.
+func NewLadderRepository(queries *db.Queries) *LadderRepository {
+	return &LadderRepository{queries: queries}
+}
+
+// WithTx returns a repository that runs its queries in the transaction.
+func (r *LadderRepository) WithTx(tx pgx.Tx) *LadderRepository {
+	return &LadderRepository{queries: r.queries.WithTx(tx)}
+}
+
+// Get retrieves a ladder by ID.
+func (r *LadderRepository) Get(ctx context.Context, id uuid.UUID) (*db.StandardLadder, error) {
+	ladder, err := r.queries.GetLadder(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get ladder: %w", err)
+	}
+	return &ladder, nil
+}
+
+// ListLaddersParams contains parameters for listing ladders. With an
+// OwnerID, only the shared ladders and those of the owner are listed.
+type ListLaddersParams struct {
+	CourseType string
+	Gender     string
+	OwnerID    string
+}
+
+// List lists ladders, optionally of a course and gender.
+func (r *LadderRepository) List(ctx context.Context, params ListLaddersParams) ([]db.StandardLadder, error) {
+	ladders, err := r.queries.ListLadders(ctx, db.ListLaddersParams{
+		Column1: params.CourseType,
+		Column2: params.Gender,
+		Column3: params.OwnerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list ladders: %w", err)
+	}
+	return ladders, nil
+}
+
+// GetGrading retrieves the owner's grading ladder of a course and gender,
+// falling back to the shared one.
+func (r *LadderRepository) GetGrading(ctx context.Context, ownerID, courseType, gender string) (*db.StandardLadder, error) {
+	ladder, err := r.queries.GetGradingLadder(ctx, db.GetGradingLadderParams{
+		CourseType: courseType,
+		Gender:     gender,
+		Column3:    ownerID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get grading ladder: %w", err)
+	}
+	return &ladder, nil
+}
+
+// ListGradingByStandard lists the grading ladders with a standard as a rung.
+func (r *LadderRepository) ListGradingByStandard(ctx context.Context, standardID uuid.UUID) ([]db.StandardLadder, error) {
+	ladders, err := r.queries.ListGradingLaddersByStandard(ctx, standardID)
+	if err != nil {
+		return nil, fmt.Errorf("list grading ladders: %w", err)
+	}
+	return ladders, nil
+}
+
+// Create creates a new ladder.
+func (r *LadderRepository) Create(ctx context.Context, params db.CreateLadderParams) (*db.StandardLadder, error) {
+	ladder, err := r.queries.CreateLadder(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create ladder: %w", err)
+	}
+	return &ladder, nil
+}
+
+// Update updates an existing ladder.
+func (r *LadderRepository) Update(ctx context.Context, params db.UpdateLadderParams) (*db.StandardLadder, error) {
+	ladder, err := r.queries.UpdateLadder(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("update ladder: %w", err)
+	}
+	return &ladder, nil
+}
+
+// Delete deletes a ladder and its rungs.
+func (r *LadderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	deleted, err := r.queries.DeleteLadder(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete ladder: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// NameExists checks if the owner already has a ladder of that name
+// (excluding the given ID).
+func (r *LadderRepository) NameExists(ctx context.Context, ownerID, name string, excludeID uuid.UUID) (bool, error) {
+	exists, err := r.queries.LadderNameExists(ctx, db.LadderNameExistsParams{
+		OwnerID: ownerID,
+		Name:    name,
+		ID:      excludeID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("check ladder name exists: %w", err)
+	}
+	return exists, nil
+}
+
+// GradingExists checks if the owner already has a grading ladder of a course
+// and gender (excluding the given ID).
+func (r *LadderRepository) GradingExists(ctx context.Context, ownerID, courseType, gender string, excludeID uuid.UUID) (bool, error) {
+	exists, err := r.queries.GradingLadderExists(ctx, db.GradingLadderExistsParams{
+		OwnerID:    ownerID,
+		CourseType: courseType,
+		Gender:     gender,
+		ID:         excludeID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("check grading ladder exists: %w", err)
+	}
+	return exists, nil
+}
+
+// ListRungs lists the rungs of a ladder from the lowest tier up.
+func (r *LadderRepository) ListRungs(ctx context.Context, ladderID uuid.UUID) ([]db.ListLadderRungsRow, error) {
+	rungs, err := r.queries.ListLadderRungs(ctx, ladderID)
+	if err != nil {
+		return nil, fmt.Errorf("list ladder rungs: %w", err)
+	}
+	return rungs, nil
+}
+
+// ListAllRungs lists the rungs of all ladders.
+func (r *LadderRepository) ListAllRungs(ctx context.Context) ([]db.ListAllLadderRungsRow, error) {
+	rungs, err := r.queries.ListAllLadderRungs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list ladder rungs: %w", err)
+	}
+	return rungs, nil
+}
+
+// CreateRung adds a rung to a ladder.
+func (r *LadderRepository) CreateRung(ctx context.Context, params db.CreateLadderRungParams) error {
+	if err := r.queries.CreateLadderRung(ctx, params); err != nil {
+		return fmt.Errorf("create ladder rung: %w", err)
+	}
+	return nil
+}
+
+// DeleteRungs deletes all rungs of a ladder.
+func (r *LadderRepository) DeleteRungs(ctx context.Context, ladderID uuid.UUID) error {
+	if err := r.queries.DeleteLadderRungs(ctx, ladderID); err != nil {
+		return fmt.Errorf("delete ladder rungs: %w", err)
+	}
+	return nil
+}
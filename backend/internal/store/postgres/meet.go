@@ -21,9 +21,17 @@ func NewMeetRepository(queries *db.Queries) *MeetRepository {
 	return &MeetRepository{queries: queries}
 }
 
-// Get retrieves a meet by ID.
-func (r *MeetRepository) Get(ctx context.Context, id uuid.UUID) (*db.Meet, error) {
-	meet, err := r.queries.GetMeet(ctx, id)
+// WithTx returns a repository that runs its queries in the transaction.
+func (r *MeetRepository) WithTx(tx pgx.Tx) *MeetRepository {
+	return &MeetRepository{queries: r.queries.WithTx(tx)}
+}
+
+// Get retrieves an owner's meet by ID.
+func (r *MeetRepository) Get(ctx context.Context, id uuid.UUID, ownerID string) (*db.Meet, error) {
+	meet, err := r.queries.GetMeet(ctx, db.GetMeetParams{
+		ID:      id,
+		OwnerID: ownerID,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -33,9 +41,12 @@ func (r *MeetRepository) Get(ctx context.Context, id uuid.UUID) (*db.Meet, error
 	return &meet, nil
 }
 
-// GetWithTimeCount retrieves a meet with its time count.
-func (r *MeetRepository) GetWithTimeCount(ctx context.Context, id uuid.UUID) (*db.GetMeetWithTimeCountRow, error) {
-	meet, err := r.queries.GetMeetWithTimeCount(ctx, id)
+// GetWithTimeCount retrieves an owner's meet with its time count.
+func (r *MeetRepository) GetWithTimeCount(ctx context.Context, id uuid.UUID, ownerID string) (*db.GetMeetWithTimeCountRow, error) {
+	meet, err := r.queries.GetMeetWithTimeCount(ctx, db.GetMeetWithTimeCountParams{
+		ID:      id,
+		OwnerID: ownerID,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -45,8 +56,21 @@ func (r *MeetRepository) GetWithTimeCount(ctx context.Context, id uuid.UUID) (*d
 	return &meet, nil
 }
 
+// Find retrieves an owner's meet by name, start date and course type.
+func (r *MeetRepository) Find(ctx context.Context, params db.FindMeetParams) (*db.Meet, error) {
+	meet, err := r.queries.FindMeet(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("find meet: %w", err)
+	}
+	return &meet, nil
+}
+
 // ListMeetsParams contains parameters for listing meets.
 type ListMeetsParams struct {
+	OwnerID    string
 	CourseType *string
 	Limit      int32
 	Offset     int32
@@ -66,7 +90,8 @@ func (r *MeetRepository) List(ctx context.Context, params ListMeetsParams) ([]db
 	}
 
 	meets, err := r.queries.ListMeets(ctx, db.ListMeetsParams{
-		Column1: courseType,
+		OwnerID: params.OwnerID,
+		Column2: courseType,
 		Limit:   limit,
 		Offset:  params.Offset,
 	})
@@ -76,14 +101,17 @@ func (r *MeetRepository) List(ctx context.Context, params ListMeetsParams) ([]db
 	return meets, nil
 }
 
-// Count returns the total number of meets matching the filter.
-func (r *MeetRepository) Count(ctx context.Context, courseType *string) (int64, error) {
+// Count returns the total number of an owner's meets matching the filter.
+func (r *MeetRepository) Count(ctx context.Context, ownerID string, courseType *string) (int64, error) {
 	ct := ""
 	if courseType != nil {
 		ct = *courseType
 	}
 
-	count, err := r.queries.CountMeets(ctx, ct)
+	count, err := r.queries.CountMeets(ctx, db.CountMeetsParams{
+		OwnerID: ownerID,
+		Column2: ct,
+	})
 	if err != nil {
 		return 0, fmt.Errorf("count meets: %w", err)
 	}
@@ -119,8 +147,26 @@ func (r *MeetRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// GetRecent retrieves the most recent meets.
-func (r *MeetRepository) GetRecent(ctx context.Context, courseType *string, limit int32) ([]db.GetRecentMeetsRow, error) {
+// DeleteEmpty deletes an owner's meets that have no times and returns how many were removed.
+func (r *MeetRepository) DeleteEmpty(ctx context.Context, ownerID string) (int64, error) {
+	count, err := r.queries.DeleteEmptyMeets(ctx, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("delete empty meets: %w", err)
+	}
+	return count, nil
+}
+
+// ListEmpty lists the meets of all owners that have no times.
+func (r *MeetRepository) ListEmpty(ctx context.Context) ([]db.Meet, error) {
+	meets, err := r.queries.ListEmptyMeets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list empty meets: %w", err)
+	}
+	return meets, nil
+}
+
+// GetRecent retrieves an owner's most recent meets.
+func (r *MeetRepository) GetRecent(ctx context.Context, ownerID string, courseType *string, limit int32) ([]db.GetRecentMeetsRow, error) {
 	ct := ""
 	if courseType != nil {
 		ct = *courseType
@@ -131,7 +177,8 @@ func (r *MeetRepository) GetRecent(ctx context.Context, courseType *string, limi
 	}
 
 	meets, err := r.queries.GetRecentMeets(ctx, db.GetRecentMeetsParams{
-		Column1: ct,
+		OwnerID: ownerID,
+		Column2: ct,
 		Limit:   limit,
 	})
 	if err != nil {
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/bpg/swimstats/backend/internal/store/db"
+)
+
+// PointsRepository provides points base time data access.
+type PointsRepository struct {
+	queries *db.Queries
+}
+
+// NewPointsRepository creates a new points repository.
+func NewPointsRepository(queries *db.Queries) *PointsRepository {
+	return &PointsRepository{queries: queries}
+}
+
+// WithTx returns a repository that runs its queries in the transaction.
+func (r *PointsRepository) WithTx(tx pgx.Tx) *PointsRepository {
+	return &PointsRepository{queries: r.queries.WithTx(tx)}
+}
+
+// ListBaseTimes lists the base times of all tables.
+func (r *PointsRepository) ListBaseTimes(ctx context.Context) ([]db.PointsBaseTime, error) {
+	baseTimes, err := r.queries.ListPointsBaseTimes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list points base times: %w", err)
+	}
+	return baseTimes, nil
+}
+
+// ListBaseTimesByGender lists the base times of all years and courses for a gender.
+func (r *PointsRepository) ListBaseTimesByGender(ctx context.Context, gender string) ([]db.PointsBaseTime, error) {
+	baseTimes, err := r.queries.ListPointsBaseTimesByGender(ctx, gender)
+	if err != nil {
+		return nil, fmt.Errorf("list points base times: %w", err)
+	}
+	return baseTimes, nil
+}
+
+// UpsertBaseTime creates or replaces the base time of an event in a table.
+func (r *PointsRepository) UpsertBaseTime(ctx context.Context, params db.UpsertPointsBaseTimeParams) (*db.PointsBaseTime, error) {
+	baseTime, err := r.queries.UpsertPointsBaseTime(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("upsert points base time: %w", err)
+	}
+	return &baseTime, nil
+}
+
+// DeleteTable deletes the base times of a year, course and gender.
+func (r *PointsRepository) DeleteTable(ctx context.Context, params db.DeletePointsBaseTimesParams) error {
+	deleted, err := r.queries.DeletePointsBaseTimes(ctx, params)
+	if err != nil {
+		return fmt.Errorf("delete points base times: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
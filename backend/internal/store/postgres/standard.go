@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/bpg/swimstats/backend/internal/store/db"
 )
@@ -21,6 +22,11 @@ func NewStandardRepository(queries *db.Queries) *StandardRepository {
 	return &StandardRepository{queries: queries}
 }
 
+// WithTx returns a repository that runs its queries in the transaction.
+func (r *StandardRepository) WithTx(tx pgx.Tx) *StandardRepository {
+	return &StandardRepository{queries: r.queries.WithTx(tx)}
+}
+
 // Get retrieves a standard by ID.
 func (r *StandardRepository) Get(ctx context.Context, id uuid.UUID) (*db.TimeStandard, error) {
 	standard, err := r.queries.GetStandard(ctx, id)
@@ -33,10 +39,42 @@ func (r *StandardRepository) Get(ctx context.Context, id uuid.UUID) (*db.TimeSta
 	return &standard, nil
 }
 
-// ListStandardsParams contains parameters for listing standards.
+// GetForOwner retrieves a standard the owner sees: a shared one or their
+// own. Standards of other owners are reported as ErrNotFound. Without an
+// owner, all standards are seen.
+func (r *StandardRepository) GetForOwner(ctx context.Context, ownerID string, id uuid.UUID) (*db.TimeStandard, error) {
+	standard, err := r.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != "" && standard.OwnerID != "" && standard.OwnerID != ownerID {
+		return nil, ErrNotFound
+	}
+	return standard, nil
+}
+
+// GetByName retrieves a standard of the owner by its name.
+func (r *StandardRepository) GetByName(ctx context.Context, ownerID, name string) (*db.TimeStandard, error) {
+	standard, err := r.queries.GetStandardByName(ctx, db.GetStandardByNameParams{
+		OwnerID: ownerID,
+		Name:    name,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get standard by name: %w", err)
+	}
+	return &standard, nil
+}
+
+// ListStandardsParams contains parameters for listing standards and
+// families. With an OwnerID, only the shared ones and those of the owner are
+// listed.
 type ListStandardsParams struct {
 	CourseType *string
 	Gender     *string
+	OwnerID    string
 }
 
 // List lists standards with optional filtering.
@@ -54,6 +92,7 @@ func (r *StandardRepository) List(ctx context.Context, params ListStandardsParam
 	standards, err := r.queries.ListStandards(ctx, db.ListStandardsParams{
 		Column1: courseType,
 		Column2: gender,
+		Column3: params.OwnerID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("list standards: %w", err)
@@ -99,11 +138,13 @@ func (r *StandardRepository) Exists(ctx context.Context, id uuid.UUID) (bool, er
 	return exists, nil
 }
 
-// NameExists checks if a standard name is already taken (excluding the given ID).
-func (r *StandardRepository) NameExists(ctx context.Context, name string, excludeID uuid.UUID) (bool, error) {
+// NameExists checks if the owner already has a standard of that name
+// (excluding the given ID).
+func (r *StandardRepository) NameExists(ctx context.Context, ownerID, name string, excludeID uuid.UUID) (bool, error) {
 	exists, err := r.queries.StandardNameExists(ctx, db.StandardNameExistsParams{
-		Name: name,
-		ID:   excludeID,
+		OwnerID: ownerID,
+		Name:    name,
+		ID:      excludeID,
 	})
 	if err != nil {
 		return false, fmt.Errorf("check standard name exists: %w", err)
@@ -111,6 +152,141 @@ func (r *StandardRepository) NameExists(ctx context.Context, name string, exclud
 	return exists, nil
 }
 
+// ListOwners lists the users that own standards.
+func (r *StandardRepository) ListOwners(ctx context.Context) ([]string, error) {
+	owners, err := r.queries.ListStandardOwners(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list standard owners: %w", err)
+	}
+	return owners, nil
+}
+
+// ListVersions lists the versions of a standard family, earliest first.
+func (r *StandardRepository) ListVersions(ctx context.Context, familyID uuid.UUID) ([]db.TimeStandard, error) {
+	versions, err := r.queries.ListStandardVersions(ctx, pgtype.UUID{Bytes: familyID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("list standard versions: %w", err)
+	}
+	return versions, nil
+}
+
+// VersionExists checks if a version of the family is already effective from
+// the given date (excluding the given ID).
+func (r *StandardRepository) VersionExists(ctx context.Context, familyID uuid.UUID, effectiveFrom pgtype.Date, excludeID uuid.UUID) (bool, error) {
+	exists, err := r.queries.StandardVersionExists(ctx, db.StandardVersionExistsParams{
+		FamilyID:      pgtype.UUID{Bytes: familyID, Valid: true},
+		EffectiveFrom: effectiveFrom,
+		ID:            excludeID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("check standard version exists: %w", err)
+	}
+	return exists, nil
+}
+
+// GetFamily retrieves a standard family by ID.
+func (r *StandardRepository) GetFamily(ctx context.Context, id uuid.UUID) (*db.StandardFamily, error) {
+	family, err := r.queries.GetStandardFamily(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get standard family: %w", err)
+	}
+	return &family, nil
+}
+
+// GetFamilyByName retrieves the owner's standard family of a course and
+// gender by name.
+func (r *StandardRepository) GetFamilyByName(ctx context.Context, ownerID, name, courseType, gender string) (*db.StandardFamily, error) {
+	family, err := r.queries.GetStandardFamilyByName(ctx, db.GetStandardFamilyByNameParams{
+		OwnerID:    ownerID,
+		Name:       name,
+		CourseType: courseType,
+		Gender:     gender,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get standard family: %w", err)
+	}
+	return &family, nil
+}
+
+// ListFamilies lists standard families with optional filtering.
+func (r *StandardRepository) ListFamilies(ctx context.Context, params ListStandardsParams) ([]db.StandardFamily, error) {
+	courseType := ""
+	if params.CourseType != nil {
+		courseType = *params.CourseType
+	}
+
+	gender := ""
+	if params.Gender != nil {
+		gender = *params.Gender
+	}
+
+	families, err := r.queries.ListStandardFamilies(ctx, db.ListStandardFamiliesParams{
+		Column1: courseType,
+		Column2: gender,
+		Column3: params.OwnerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list standard families: %w", err)
+	}
+	return families, nil
+}
+
+// CreateFamily creates a new standard family.
+func (r *StandardRepository) CreateFamily(ctx context.Context, params db.CreateStandardFamilyParams) (*db.StandardFamily, error) {
+	family, err := r.queries.CreateStandardFamily(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create standard family: %w", err)
+	}
+	return &family, nil
+}
+
+// UpdateFamily updates the name and description of a standard family.
+func (r *StandardRepository) UpdateFamily(ctx context.Context, params db.UpdateStandardFamilyParams) (*db.StandardFamily, error) {
+	family, err := r.queries.UpdateStandardFamily(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("update standard family: %w", err)
+	}
+	return &family, nil
+}
+
+// DeleteFamily deletes a standard family. Its versions are kept as
+// standalone standards.
+func (r *StandardRepository) DeleteFamily(ctx context.Context, id uuid.UUID) error {
+	rows, err := r.queries.DeleteStandardFamily(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete standard family: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// FamilyNameExists checks if the owner already has a family of that name in
+// a course and gender (excluding the given ID).
+func (r *StandardRepository) FamilyNameExists(ctx context.Context, ownerID, name, courseType, gender string, excludeID uuid.UUID) (bool, error) {
+	exists, err := r.queries.StandardFamilyNameExists(ctx, db.StandardFamilyNameExistsParams{
+		OwnerID:    ownerID,
+		Name:       name,
+		CourseType: courseType,
+		Gender:     gender,
+		ID:         excludeID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("check standard family name exists: %w", err)
+	}
+	return exists, nil
+}
+
 // ListTimes lists all times for a standard.
 func (r *StandardRepository) ListTimes(ctx context.Context, standardID uuid.UUID) ([]db.StandardTime, error) {
 	times, err := r.queries.ListStandardTimes(ctx, standardID)
@@ -120,6 +296,18 @@ func (r *StandardRepository) ListTimes(ctx context.Context, standardID uuid.UUID
 	return times, nil
 }
 
+// ListTimesForCourse lists the times of all standards of a course and gender.
+func (r *StandardRepository) ListTimesForCourse(ctx context.Context, courseType, gender string) ([]db.StandardTime, error) {
+	times, err := r.queries.ListStandardTimesForCourse(ctx, db.ListStandardTimesForCourseParams{
+		CourseType: courseType,
+		Gender:     gender,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list standard times for course: %w", err)
+	}
+	return times, nil
+}
+
 // UpsertTime creates or updates a standard time.
 func (r *StandardRepository) UpsertTime(ctx context.Context, params db.UpsertStandardTimeParams) (*db.StandardTime, error) {
 	st, err := r.queries.UpsertStandardTime(ctx, params)
@@ -137,6 +325,19 @@ func (r *StandardRepository) DeleteTimes(ctx context.Context, standardID uuid.UU
 	return nil
 }
 
+// DeleteTime deletes the time of an event and age group of a standard.
+func (r *StandardRepository) DeleteTime(ctx context.Context, standardID uuid.UUID, event, ageGroup string) error {
+	err := r.queries.DeleteStandardTimeForEventAndAge(ctx, db.DeleteStandardTimeForEventAndAgeParams{
+		StandardID: standardID,
+		Event:      event,
+		AgeGroup:   ageGroup,
+	})
+	if err != nil {
+		return fmt.Errorf("delete standard time: %w", err)
+	}
+	return nil
+}
+
 // GetTimeForEventAndAge retrieves a specific standard time.
 func (r *StandardRepository) GetTimeForEventAndAge(ctx context.Context, standardID uuid.UUID, event, ageGroup string) (*db.StandardTime, error) {
 	st, err := r.queries.GetStandardTimeForEventAndAge(ctx, db.GetStandardTimeForEventAndAgeParams{
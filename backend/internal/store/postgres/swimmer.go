@@ -21,6 +21,11 @@ func NewSwimmerRepository(queries *db.Queries) *SwimmerRepository {
 	return &SwimmerRepository{queries: queries}
 }
 
+// WithTx returns a repository that runs its queries in the transaction.
+func (r *SwimmerRepository) WithTx(tx pgx.Tx) *SwimmerRepository {
+	return &SwimmerRepository{queries: r.queries.WithTx(tx)}
+}
+
 // Get retrieves a swimmer by ID.
 func (r *SwimmerRepository) Get(ctx context.Context, id uuid.UUID) (*db.Swimmer, error) {
 	row, err := r.queries.GetSwimmer(ctx, id)
@@ -36,14 +41,17 @@ func (r *SwimmerRepository) Get(ctx context.Context, id uuid.UUID) (*db.Swimmer,
 		BirthDate:        row.BirthDate,
 		Gender:           row.Gender,
 		ThresholdPercent: row.ThresholdPercent,
+		OwnerID:          row.OwnerID,
 		CreatedAt:        row.CreatedAt,
 		UpdatedAt:        row.UpdatedAt,
+		SeasonStartMonth: row.SeasonStartMonth,
+		SeasonStartDay:   row.SeasonStartDay,
 	}, nil
 }
 
-// GetFirst retrieves the first swimmer (for single-user mode).
-func (r *SwimmerRepository) GetFirst(ctx context.Context) (*db.Swimmer, error) {
-	row, err := r.queries.GetSwimmerByUserID(ctx)
+// GetFirst retrieves the owner's first swimmer.
+func (r *SwimmerRepository) GetFirst(ctx context.Context, ownerID string) (*db.Swimmer, error) {
+	row, err := r.queries.GetSwimmerByUserID(ctx, ownerID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -56,8 +64,37 @@ func (r *SwimmerRepository) GetFirst(ctx context.Context) (*db.Swimmer, error) {
 		BirthDate:        row.BirthDate,
 		Gender:           row.Gender,
 		ThresholdPercent: row.ThresholdPercent,
+		OwnerID:          row.OwnerID,
 		CreatedAt:        row.CreatedAt,
 		UpdatedAt:        row.UpdatedAt,
+		SeasonStartMonth: row.SeasonStartMonth,
+		SeasonStartDay:   row.SeasonStartDay,
+	}, nil
+}
+
+// GetForOwner retrieves a swimmer by ID if it belongs to the given owner.
+func (r *SwimmerRepository) GetForOwner(ctx context.Context, id uuid.UUID, ownerID string) (*db.Swimmer, error) {
+	row, err := r.queries.GetSwimmerForOwner(ctx, db.GetSwimmerForOwnerParams{
+		ID:      id,
+		OwnerID: ownerID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get swimmer for owner: %w", err)
+	}
+	return &db.Swimmer{
+		ID:               row.ID,
+		Name:             row.Name,
+		BirthDate:        row.BirthDate,
+		Gender:           row.Gender,
+		ThresholdPercent: row.ThresholdPercent,
+		OwnerID:          row.OwnerID,
+		CreatedAt:        row.CreatedAt,
+		UpdatedAt:        row.UpdatedAt,
+		SeasonStartMonth: row.SeasonStartMonth,
+		SeasonStartDay:   row.SeasonStartDay,
 	}, nil
 }
 
@@ -73,8 +110,11 @@ func (r *SwimmerRepository) Create(ctx context.Context, params db.CreateSwimmerP
 		BirthDate:        row.BirthDate,
 		Gender:           row.Gender,
 		ThresholdPercent: row.ThresholdPercent,
+		OwnerID:          row.OwnerID,
 		CreatedAt:        row.CreatedAt,
 		UpdatedAt:        row.UpdatedAt,
+		SeasonStartMonth: row.SeasonStartMonth,
+		SeasonStartDay:   row.SeasonStartDay,
 	}, nil
 }
 
@@ -93,8 +133,11 @@ func (r *SwimmerRepository) Update(ctx context.Context, params db.UpdateSwimmerP
 		BirthDate:        row.BirthDate,
 		Gender:           row.Gender,
 		ThresholdPercent: row.ThresholdPercent,
+		OwnerID:          row.OwnerID,
 		CreatedAt:        row.CreatedAt,
 		UpdatedAt:        row.UpdatedAt,
+		SeasonStartMonth: row.SeasonStartMonth,
+		SeasonStartDay:   row.SeasonStartDay,
 	}, nil
 }
 
@@ -106,9 +149,9 @@ func (r *SwimmerRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// List lists all swimmers.
-func (r *SwimmerRepository) List(ctx context.Context) ([]db.Swimmer, error) {
-	rows, err := r.queries.ListSwimmers(ctx)
+// List lists all swimmers of an owner.
+func (r *SwimmerRepository) List(ctx context.Context, ownerID string) ([]db.Swimmer, error) {
+	rows, err := r.queries.ListSwimmers(ctx, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("list swimmers: %w", err)
 	}
@@ -120,8 +163,35 @@ func (r *SwimmerRepository) List(ctx context.Context) ([]db.Swimmer, error) {
 			BirthDate:        row.BirthDate,
 			Gender:           row.Gender,
 			ThresholdPercent: row.ThresholdPercent,
+			OwnerID:          row.OwnerID,
+			CreatedAt:        row.CreatedAt,
+			UpdatedAt:        row.UpdatedAt,
+			SeasonStartMonth: row.SeasonStartMonth,
+			SeasonStartDay:   row.SeasonStartDay,
+		}
+	}
+	return swimmers, nil
+}
+
+// ListAll lists the swimmers of all owners, each owner's default swimmer first.
+func (r *SwimmerRepository) ListAll(ctx context.Context) ([]db.Swimmer, error) {
+	rows, err := r.queries.ListAllSwimmers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list all swimmers: %w", err)
+	}
+	swimmers := make([]db.Swimmer, len(rows))
+	for i, row := range rows {
+		swimmers[i] = db.Swimmer{
+			ID:               row.ID,
+			Name:             row.Name,
+			BirthDate:        row.BirthDate,
+			Gender:           row.Gender,
+			ThresholdPercent: row.ThresholdPercent,
+			OwnerID:          row.OwnerID,
 			CreatedAt:        row.CreatedAt,
 			UpdatedAt:        row.UpdatedAt,
+			SeasonStartMonth: row.SeasonStartMonth,
+			SeasonStartDay:   row.SeasonStartDay,
 		}
 	}
 	return swimmers, nil
@@ -135,3 +205,21 @@ func (r *SwimmerRepository) Count(ctx context.Context) (int64, error) {
 	}
 	return count, nil
 }
+
+// ClaimUnowned assigns swimmers and meets without an owner to the given owner
+// and returns how many of each were assigned. It must run in a transaction,
+// which holds a lock against concurrent claims until it ends.
+func (r *SwimmerRepository) ClaimUnowned(ctx context.Context, ownerID string) (swimmers, meets int64, err error) {
+	if err := r.queries.LockUnownedClaims(ctx); err != nil {
+		return 0, 0, fmt.Errorf("lock unowned claims: %w", err)
+	}
+	swimmers, err = r.queries.ClaimUnownedSwimmers(ctx, ownerID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("claim unowned swimmers: %w", err)
+	}
+	meets, err = r.queries.ClaimUnownedMeets(ctx, ownerID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("claim unowned meets: %w", err)
+	}
+	return swimmers, meets, nil
+}
@@ -23,6 +23,11 @@ func NewTimeRepository(queries *db.Queries) *TimeRepository {
 	return &TimeRepository{queries: queries}
 }
 
+// WithTx returns a repository that runs its queries in the transaction.
+func (r *TimeRepository) WithTx(tx pgx.Tx) *TimeRepository {
+	return &TimeRepository{queries: r.queries.WithTx(tx)}
+}
+
 // Get retrieves a time by ID.
 func (r *TimeRepository) Get(ctx context.Context, id uuid.UUID) (*db.Time, error) {
 	time, err := r.queries.GetTime(ctx, id)
@@ -150,6 +155,14 @@ func (r *TimeRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// DeleteBySwimmer deletes all times of a swimmer.
+func (r *TimeRepository) DeleteBySwimmer(ctx context.Context, swimmerID uuid.UUID) error {
+	if err := r.queries.DeleteTimesBySwimmer(ctx, swimmerID); err != nil {
+		return fmt.Errorf("delete times by swimmer: %w", err)
+	}
+	return nil
+}
+
 // ListByMeet lists all times for a specific meet.
 func (r *TimeRepository) ListByMeet(ctx context.Context, meetID uuid.UUID) ([]db.Time, error) {
 	times, err := r.queries.ListTimesByMeet(ctx, meetID)
@@ -159,11 +172,75 @@ func (r *TimeRepository) ListByMeet(ctx context.Context, meetID uuid.UUID) ([]db
 	return times, nil
 }
 
+// UpdateGrade sets the grade of a time.
+func (r *TimeRepository) UpdateGrade(ctx context.Context, id uuid.UUID, grade pgtype.Text) error {
+	if err := r.queries.UpdateTimeGrade(ctx, db.UpdateTimeGradeParams{ID: id, Grade: grade}); err != nil {
+		return fmt.Errorf("update time grade: %w", err)
+	}
+	return nil
+}
+
+// ListForGrading lists the times of the owner's swimmers of a gender in a
+// course, or those of all swimmers for an empty owner.
+func (r *TimeRepository) ListForGrading(ctx context.Context, ownerID, courseType, gender string) ([]db.ListTimesForGradingRow, error) {
+	times, err := r.queries.ListTimesForGrading(ctx, db.ListTimesForGradingParams{
+		CourseType: courseType,
+		Gender:     gender,
+		Column3:    ownerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list times for grading: %w", err)
+	}
+	return times, nil
+}
+
+// SwimWindow restricts the swims that count toward a standard. An invalid
+// (NULL) date leaves that end of the window open.
+type SwimWindow struct {
+	Start          pgtype.Date
+	End            pgtype.Date
+	SanctionedOnly bool
+}
+
+// ListOfficialSwims retrieves all official swims of a swimmer in a course type
+// within the window, grouped by event with the most recent first.
+func (r *TimeRepository) ListOfficialSwims(ctx context.Context, swimmerID uuid.UUID, courseType string, window SwimWindow) ([]db.ListOfficialSwimsRow, error) {
+	swims, err := r.queries.ListOfficialSwims(ctx, db.ListOfficialSwimsParams{
+		SwimmerID:  swimmerID,
+		CourseType: courseType,
+		Column3:    window.Start,
+		Column4:    window.End,
+		Column5:    window.SanctionedOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list official swims: %w", err)
+	}
+	return swims, nil
+}
+
+// ListResultDates retrieves the date, meet and status of all results of a swimmer.
+func (r *TimeRepository) ListResultDates(ctx context.Context, swimmerID uuid.UUID) ([]db.ListResultDatesRow, error) {
+	rows, err := r.queries.ListResultDates(ctx, swimmerID)
+	if err != nil {
+		return nil, fmt.Errorf("list result dates: %w", err)
+	}
+	return rows, nil
+}
+
 // GetPersonalBests retrieves personal bests for a swimmer in a course type.
 func (r *TimeRepository) GetPersonalBests(ctx context.Context, swimmerID uuid.UUID, courseType string) ([]db.GetPersonalBestsRow, error) {
+	return r.GetPersonalBestsInWindow(ctx, swimmerID, courseType, SwimWindow{})
+}
+
+// GetPersonalBestsInWindow retrieves the best times of a swimmer in a course
+// type counting only swims within the window.
+func (r *TimeRepository) GetPersonalBestsInWindow(ctx context.Context, swimmerID uuid.UUID, courseType string, window SwimWindow) ([]db.GetPersonalBestsRow, error) {
 	pbs, err := r.queries.GetPersonalBests(ctx, db.GetPersonalBestsParams{
 		SwimmerID:  swimmerID,
 		CourseType: courseType,
+		Column3:    window.Start,
+		Column4:    window.End,
+		Column5:    window.SanctionedOnly,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("get personal bests: %w", err)
@@ -171,12 +248,12 @@ func (r *TimeRepository) GetPersonalBests(ctx context.Context, swimmerID uuid.UU
 	return pbs, nil
 }
 
-// GetPersonalBestForEvent retrieves the personal best for a specific event.
+// GetPersonalBestForEvent retrieves the personal best for a specific individual event.
 func (r *TimeRepository) GetPersonalBestForEvent(ctx context.Context, swimmerID uuid.UUID, courseType, event string) (*db.GetPersonalBestForEventRow, error) {
 	pb, err := r.queries.GetPersonalBestForEvent(ctx, db.GetPersonalBestForEventParams{
-		SwimmerID:  swimmerID,
-		CourseType: courseType,
-		Event:      event,
+		SwimmerID:     swimmerID,
+		CourseType:    courseType,
+		OfficialEvent: event,
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -195,11 +272,11 @@ func (r *TimeRepository) IsPersonalBest(ctx context.Context, swimmerID uuid.UUID
 	}
 
 	result, err := r.queries.IsPersonalBest(ctx, db.IsPersonalBestParams{
-		SwimmerID:  swimmerID,
-		CourseType: courseType,
-		Event:      event,
-		TimeMs:     timeMS,
-		ID:         excludeUUID,
+		SwimmerID:     swimmerID,
+		CourseType:    courseType,
+		OfficialEvent: event,
+		TimeMs:        timeMS,
+		ID:            excludeUUID,
 	})
 	if err != nil {
 		return false, fmt.Errorf("check is personal best: %w", err)
@@ -207,6 +284,33 @@ func (r *TimeRepository) IsPersonalBest(ctx context.Context, swimmerID uuid.UUID
 	return result, nil
 }
 
+// CountResultsByStatus counts a swimmer's results per status, optionally
+// filtered by course type and date range.
+func (r *TimeRepository) CountResultsByStatus(ctx context.Context, swimmerID uuid.UUID, courseType string, startDate, endDate *time.Time) ([]db.CountResultsByStatusRow, error) {
+	var column3, column4 pgtype.Date
+
+	if startDate != nil {
+		column3.Time = *startDate
+		column3.Valid = true
+	}
+
+	if endDate != nil {
+		column4.Time = *endDate
+		column4.Valid = true
+	}
+
+	rows, err := r.queries.CountResultsByStatus(ctx, db.CountResultsByStatusParams{
+		SwimmerID: swimmerID,
+		Column2:   courseType,
+		Column3:   column3,
+		Column4:   column4,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("count results by status: %w", err)
+	}
+	return rows, nil
+}
+
 // GetTotalTimeCount returns the total number of times for a swimmer.
 func (r *TimeRepository) GetTotalTimeCount(ctx context.Context, swimmerID uuid.UUID) (int32, error) {
 	count, err := r.queries.GetTotalTimeCount(ctx, swimmerID)
@@ -253,14 +357,49 @@ func (r *TimeRepository) GetProgressData(ctx context.Context, swimmerID uuid.UUI
 	}
 
 	rows, err := r.queries.GetProgressData(ctx, db.GetProgressDataParams{
-		SwimmerID:  swimmerID,
-		CourseType: courseType,
-		Event:      event,
-		Column4:    column4,
-		Column5:    column5,
+		SwimmerID:     swimmerID,
+		CourseType:    courseType,
+		OfficialEvent: event,
+		Column4:       column4,
+		Column5:       column5,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("get progress data: %w", err)
 	}
 	return rows, nil
 }
+
+// ListSplits lists the splits of a time ordered by distance.
+func (r *TimeRepository) ListSplits(ctx context.Context, timeID uuid.UUID) ([]db.Split, error) {
+	splits, err := r.queries.ListSplits(ctx, timeID)
+	if err != nil {
+		return nil, fmt.Errorf("list splits: %w", err)
+	}
+	return splits, nil
+}
+
+// ListSplitsBySwimmer lists the splits of all times of a swimmer.
+func (r *TimeRepository) ListSplitsBySwimmer(ctx context.Context, swimmerID uuid.UUID) ([]db.Split, error) {
+	splits, err := r.queries.ListSplitsBySwimmer(ctx, swimmerID)
+	if err != nil {
+		return nil, fmt.Errorf("list splits by swimmer: %w", err)
+	}
+	return splits, nil
+}
+
+// CreateSplit creates a split of a time.
+func (r *TimeRepository) CreateSplit(ctx context.Context, params db.CreateSplitParams) (*db.Split, error) {
+	split, err := r.queries.CreateSplit(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create split: %w", err)
+	}
+	return &split, nil
+}
+
+// DeleteSplits deletes all splits of a time.
+func (r *TimeRepository) DeleteSplits(ctx context.Context, timeID uuid.UUID) error {
+	if err := r.queries.DeleteSplits(ctx, timeID); err != nil {
+		return fmt.Errorf("delete splits: %w", err)
+	}
+	return nil
+}
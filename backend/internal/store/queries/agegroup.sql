@@ -0,0 +1,46 @@
+-- name: GetAgeGroupScheme :one
+SELECT id, name, description, age_rule, is_preloaded, created_at, updated_at
+FROM age_group_schemes
+WHERE id = $1;
+
+-- name: GetAgeGroupSchemeByName :one
+SELECT id, name, description, age_rule, is_preloaded, created_at, updated_at
+FROM age_group_schemes
+WHERE name = $1;
+
+-- name: ListAgeGroupSchemes :many
+SELECT id, name, description, age_rule, is_preloaded, created_at, updated_at
+FROM age_group_schemes
+ORDER BY is_preloaded DESC, name ASC;
+
+-- name: CreateAgeGroupScheme :one
+INSERT INTO age_group_schemes (name, description, age_rule)
+VALUES ($1, $2, $3)
+RETURNING id, name, description, age_rule, is_preloaded, created_at, updated_at;
+
+-- name: DeleteAgeGroupScheme :execrows
+DELETE FROM age_group_schemes
+WHERE id = $1;
+
+-- name: AgeGroupSchemeNameExists :one
+SELECT EXISTS(SELECT 1 FROM age_group_schemes WHERE name = $1);
+
+-- name: AgeGroupSchemeInUse :one
+-- Reports whether any time standard uses the scheme
+SELECT EXISTS(SELECT 1 FROM time_standards WHERE age_group_scheme_id = $1);
+
+-- name: ListAgeGroupRanges :many
+SELECT id, scheme_id, code, min_age, max_age
+FROM age_group_ranges
+WHERE scheme_id = $1
+ORDER BY min_age;
+
+-- name: ListAllAgeGroupRanges :many
+SELECT id, scheme_id, code, min_age, max_age
+FROM age_group_ranges
+ORDER BY scheme_id, min_age;
+
+-- name: CreateAgeGroupRange :one
+INSERT INTO age_group_ranges (scheme_id, code, min_age, max_age)
+VALUES ($1, $2, $3, $4)
+RETURNING id, scheme_id, code, min_age, max_age;
@@ -0,0 +1,14 @@
+-- name: ListConversionFactors :many
+SELECT id, from_course, to_course, event, factor, created_at, updated_at
+FROM course_conversion_factors
+ORDER BY from_course, to_course, event;
+
+-- name: UpsertConversionFactor :one
+INSERT INTO course_conversion_factors (from_course, to_course, event, factor)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (from_course, to_course, event) DO UPDATE SET factor = EXCLUDED.factor
+RETURNING id, from_course, to_course, event, factor, created_at, updated_at;
+
+-- name: DeleteConversionFactor :execrows
+DELETE FROM course_conversion_factors
+WHERE id = $1;
@@ -0,0 +1,72 @@
+-- name: GetLadder :one
+SELECT id, name, description, course_type, gender, grading, created_at, updated_at, owner_id
+FROM standard_ladders
+WHERE id = $1;
+
+-- name: ListLadders :many
+-- Lists the shared ladders and those of the owner, or all ladders for an empty owner
+SELECT id, name, description, course_type, gender, grading, created_at, updated_at, owner_id
+FROM standard_ladders
+WHERE ($1::varchar = '' OR course_type = $1)
+  AND ($2::varchar = '' OR gender = $2)
+  AND ($3::varchar = '' OR owner_id IN ('', $3))
+ORDER BY name ASC;
+
+-- name: GetGradingLadder :one
+-- Returns the owner's grading ladder of a course and gender, or the shared one
+SELECT id, name, description, course_type, gender, grading, created_at, updated_at, owner_id
+FROM standard_ladders
+WHERE course_type = $1 AND gender = $2 AND grading AND owner_id IN ('', $3::varchar)
+ORDER BY owner_id DESC
+LIMIT 1;
+
+-- name: ListGradingLaddersByStandard :many
+-- Returns the grading ladders with a standard as a rung
+SELECT l.id, l.name, l.description, l.course_type, l.gender, l.grading, l.created_at, l.updated_at, l.owner_id
+FROM standard_ladders l
+JOIN standard_ladder_rungs r ON r.ladder_id = l.id
+WHERE r.standard_id = $1 AND l.grading
+ORDER BY l.name ASC;
+
+-- name: CreateLadder :one
+INSERT INTO standard_ladders (name, description, course_type, gender, grading, owner_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, description, course_type, gender, grading, created_at, updated_at, owner_id;
+
+-- name: UpdateLadder :one
+UPDATE standard_ladders
+SET name = $2, description = $3, course_type = $4, gender = $5, grading = $6
+WHERE id = $1
+RETURNING id, name, description, course_type, gender, grading, created_at, updated_at, owner_id;
+
+-- name: DeleteLadder :execrows
+DELETE FROM standard_ladders
+WHERE id = $1;
+
+-- name: LadderNameExists :one
+SELECT EXISTS(SELECT 1 FROM standard_ladders WHERE owner_id = $1 AND name = $2 AND id != $3);
+
+-- name: GradingLadderExists :one
+SELECT EXISTS(SELECT 1 FROM standard_ladders WHERE owner_id = $1 AND course_type = $2 AND gender = $3 AND grading AND id != $4);
+
+-- name: ListLadderRungs :many
+-- Returns the rungs of a ladder from the lowest tier up
+SELECT r.ladder_id, r.position, r.standard_id, r.label, ts.name AS standard_name
+FROM standard_ladder_rungs r
+JOIN time_standards ts ON ts.id = r.standard_id
+WHERE r.ladder_id = $1
+ORDER BY r.position;
+
+-- name: ListAllLadderRungs :many
+SELECT r.ladder_id, r.position, r.standard_id, r.label, ts.name AS standard_name
+FROM standard_ladder_rungs r
+JOIN time_standards ts ON ts.id = r.standard_id
+ORDER BY r.ladder_id, r.position;
+
+-- name: CreateLadderRung :exec
+INSERT INTO standard_ladder_rungs (ladder_id, position, standard_id, label)
+VALUES ($1, $2, $3, $4);
+
+-- name: DeleteLadderRungs :exec
+DELETE FROM standard_ladder_rungs
+WHERE ladder_id = $1;
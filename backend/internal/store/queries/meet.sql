@@ -1,7 +1,17 @@
 -- name: GetMeet :one
-SELECT id, name, city, country, start_date, end_date, course_type, created_at, updated_at
+SELECT id, name, city, country, start_date, end_date, course_type, created_at, updated_at, owner_id, sanctioned
 FROM meets
-WHERE id = $1;
+WHERE id = $1 AND owner_id = $2;
+
+-- name: FindMeet :one
+-- Finds an owner's meet by its natural key (name, start date and course)
+SELECT id, name, city, country, start_date, end_date, course_type, created_at, updated_at, owner_id, sanctioned
+FROM meets
+WHERE owner_id = $1
+  AND name = $2
+  AND start_date = $3
+  AND course_type = $4
+LIMIT 1;
 
 -- name: ListMeets :many
 SELECT 
@@ -14,33 +24,55 @@ SELECT
     m.course_type, 
     m.created_at, 
     m.updated_at,
+    m.sanctioned,
     COUNT(t.id)::int AS time_count
 FROM meets m
 LEFT JOIN times t ON t.meet_id = m.id
-WHERE ($1::varchar = '' OR m.course_type = $1)
+WHERE m.owner_id = $1
+  AND ($2::varchar = '' OR m.course_type = $2)
 GROUP BY m.id
 ORDER BY m.start_date DESC
-LIMIT $2 OFFSET $3;
+LIMIT $3 OFFSET $4;
 
 -- name: CountMeets :one
 SELECT COUNT(*) FROM meets
-WHERE ($1::varchar = '' OR course_type = $1);
+WHERE owner_id = $1
+  AND ($2::varchar = '' OR course_type = $2);
 
 -- name: CreateMeet :one
-INSERT INTO meets (name, city, country, start_date, end_date, course_type)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, name, city, country, start_date, end_date, course_type, created_at, updated_at;
+INSERT INTO meets (name, city, country, start_date, end_date, course_type, owner_id, sanctioned)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, name, city, country, start_date, end_date, course_type, created_at, updated_at, owner_id, sanctioned;
 
 -- name: UpdateMeet :one
 UPDATE meets
-SET name = $2, city = $3, country = $4, start_date = $5, end_date = $6, course_type = $7
+SET name = $2, city = $3, country = $4, start_date = $5, end_date = $6, course_type = $7, sanctioned = $8
 WHERE id = $1
-RETURNING id, name, city, country, start_date, end_date, course_type, created_at, updated_at;
+RETURNING id, name, city, country, start_date, end_date, course_type, created_at, updated_at, owner_id, sanctioned;
 
 -- name: DeleteMeet :exec
 DELETE FROM meets
 WHERE id = $1;
 
+-- name: DeleteEmptyMeets :execrows
+-- Removes an owner's meets that no longer have any recorded times
+DELETE FROM meets m
+WHERE m.owner_id = $1
+  AND NOT EXISTS (SELECT 1 FROM times t WHERE t.meet_id = m.id);
+
+-- name: ListEmptyMeets :many
+-- Lists the meets of all owners that have no recorded times
+SELECT m.id, m.name, m.city, m.country, m.start_date, m.end_date, m.course_type, m.created_at, m.updated_at, m.owner_id, m.sanctioned
+FROM meets m
+WHERE NOT EXISTS (SELECT 1 FROM times t WHERE t.meet_id = m.id)
+ORDER BY m.owner_id, m.start_date, m.name;
+
+-- name: ClaimUnownedMeets :execrows
+-- Assigns meets created before multi-swimmer support to an owner
+UPDATE meets
+SET owner_id = $1
+WHERE owner_id = '';
+
 -- name: GetMeetWithTimeCount :one
 SELECT 
     m.id, 
@@ -52,10 +84,11 @@ SELECT
     m.course_type, 
     m.created_at, 
     m.updated_at,
+    m.sanctioned,
     COUNT(t.id)::int AS time_count
 FROM meets m
 LEFT JOIN times t ON t.meet_id = m.id
-WHERE m.id = $1
+WHERE m.id = $1 AND m.owner_id = $2
 GROUP BY m.id;
 
 -- name: GetRecentMeets :many
@@ -69,10 +102,12 @@ SELECT
     m.course_type, 
     m.created_at, 
     m.updated_at,
+    m.sanctioned,
     COUNT(t.id)::int AS time_count
 FROM meets m
 LEFT JOIN times t ON t.meet_id = m.id
-WHERE ($1::varchar = '' OR m.course_type = $1)
+WHERE m.owner_id = $1
+  AND ($2::varchar = '' OR m.course_type = $2)
 GROUP BY m.id
 ORDER BY m.start_date DESC
-LIMIT $2;
+LIMIT $3;
@@ -0,0 +1,22 @@
+-- name: ListPointsBaseTimes :many
+SELECT id, year, course_type, gender, event, time_ms, created_at, updated_at
+FROM points_base_times
+ORDER BY year DESC, course_type, gender, event;
+
+-- name: ListPointsBaseTimesByGender :many
+-- Returns the base times of all years and courses for a gender
+-- Used to compute the points of many swims at once
+SELECT id, year, course_type, gender, event, time_ms, created_at, updated_at
+FROM points_base_times
+WHERE gender = $1
+ORDER BY year DESC, course_type, event;
+
+-- name: UpsertPointsBaseTime :one
+INSERT INTO points_base_times (year, course_type, gender, event, time_ms)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (year, course_type, gender, event) DO UPDATE SET time_ms = EXCLUDED.time_ms
+RETURNING id, year, course_type, gender, event, time_ms, created_at, updated_at;
+
+-- name: DeletePointsBaseTimes :execrows
+DELETE FROM points_base_times
+WHERE year = $1 AND course_type = $2 AND gender = $3;
@@ -0,0 +1,22 @@
+-- name: ListSplits :many
+SELECT id, time_id, distance, time_ms, created_at
+FROM splits
+WHERE time_id = $1
+ORDER BY distance;
+
+-- name: ListSplitsBySwimmer :many
+-- Returns the splits of all times of a swimmer, ordered by time and distance
+SELECT s.id, s.time_id, s.distance, s.time_ms, s.created_at
+FROM splits s
+JOIN times t ON t.id = s.time_id
+WHERE t.swimmer_id = $1
+ORDER BY s.time_id, s.distance;
+
+-- name: CreateSplit :one
+INSERT INTO splits (time_id, distance, time_ms)
+VALUES ($1, $2, $3)
+RETURNING id, time_id, distance, time_ms, created_at;
+
+-- name: DeleteSplits :exec
+DELETE FROM splits
+WHERE time_id = $1;
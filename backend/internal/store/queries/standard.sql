@@ -1,25 +1,41 @@
 -- name: GetStandard :one
-SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at
+SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+       qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id
 FROM time_standards
 WHERE id = $1;
 
+-- name: GetStandardByName :one
+SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+       qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id
+FROM time_standards
+WHERE owner_id = $1 AND name = $2;
+
 -- name: ListStandards :many
-SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at
+-- Lists the shared standards and those of the owner, or all standards for an empty owner
+SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+       qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id
 FROM time_standards
 WHERE ($1::varchar = '' OR course_type = $1)
   AND ($2::varchar = '' OR gender = $2)
+  AND ($3::varchar = '' OR owner_id IN ('', $3))
 ORDER BY is_preloaded DESC, name ASC;
 
 -- name: CreateStandard :one
-INSERT INTO time_standards (name, description, course_type, gender, is_preloaded)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, name, description, course_type, gender, is_preloaded, created_at, updated_at;
+INSERT INTO time_standards (name, description, course_type, gender, is_preloaded, age_group_scheme_id,
+                            qualifying_start, qualifying_end, required_course, sanctioned_only,
+                            family_id, version, effective_from, owner_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+          qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id;
 
 -- name: UpdateStandard :one
 UPDATE time_standards
-SET name = $2, description = $3, course_type = $4, gender = $5
+SET name = $2, description = $3, course_type = $4, gender = $5, age_group_scheme_id = $6,
+    qualifying_start = $7, qualifying_end = $8, required_course = $9, sanctioned_only = $10,
+    family_id = $11, version = $12, effective_from = $13
 WHERE id = $1
-RETURNING id, name, description, course_type, gender, is_preloaded, created_at, updated_at;
+RETURNING id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+          qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id;
 
 -- name: DeleteStandard :exec
 DELETE FROM time_standards
@@ -29,4 +45,21 @@ WHERE id = $1;
 SELECT EXISTS(SELECT 1 FROM time_standards WHERE id = $1);
 
 -- name: StandardNameExists :one
-SELECT EXISTS(SELECT 1 FROM time_standards WHERE name = $1 AND id != $2);
+SELECT EXISTS(SELECT 1 FROM time_standards WHERE owner_id = $1 AND name = $2 AND id != $3);
+
+-- name: ListStandardVersions :many
+SELECT id, name, description, course_type, gender, is_preloaded, created_at, updated_at, age_group_scheme_id,
+       qualifying_start, qualifying_end, required_course, sanctioned_only, family_id, version, effective_from, owner_id
+FROM time_standards
+WHERE family_id = $1
+ORDER BY effective_from ASC;
+
+-- name: StandardVersionExists :one
+SELECT EXISTS(SELECT 1 FROM time_standards WHERE family_id = $1 AND effective_from = $2 AND id != $3);
+
+-- name: ListStandardOwners :many
+-- Lists the users that own standards
+SELECT DISTINCT owner_id
+FROM time_standards
+WHERE owner_id != ''
+ORDER BY owner_id;
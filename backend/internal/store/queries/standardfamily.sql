@@ -0,0 +1,36 @@
+-- name: GetStandardFamily :one
+SELECT id, name, description, course_type, gender, created_at, updated_at, owner_id
+FROM standard_families
+WHERE id = $1;
+
+-- name: GetStandardFamilyByName :one
+SELECT id, name, description, course_type, gender, created_at, updated_at, owner_id
+FROM standard_families
+WHERE owner_id = $1 AND name = $2 AND course_type = $3 AND gender = $4;
+
+-- name: ListStandardFamilies :many
+-- Lists the shared families and those of the owner, or all families for an empty owner
+SELECT id, name, description, course_type, gender, created_at, updated_at, owner_id
+FROM standard_families
+WHERE ($1::varchar = '' OR course_type = $1)
+  AND ($2::varchar = '' OR gender = $2)
+  AND ($3::varchar = '' OR owner_id IN ('', $3))
+ORDER BY name ASC;
+
+-- name: CreateStandardFamily :one
+INSERT INTO standard_families (name, description, course_type, gender, owner_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, description, course_type, gender, created_at, updated_at, owner_id;
+
+-- name: UpdateStandardFamily :one
+UPDATE standard_families
+SET name = $2, description = $3
+WHERE id = $1
+RETURNING id, name, description, course_type, gender, created_at, updated_at, owner_id;
+
+-- name: DeleteStandardFamily :execrows
+DELETE FROM standard_families
+WHERE id = $1;
+
+-- name: StandardFamilyNameExists :one
+SELECT EXISTS(SELECT 1 FROM standard_families WHERE owner_id = $1 AND name = $2 AND course_type = $3 AND gender = $4 AND id != $5);
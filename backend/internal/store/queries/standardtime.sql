@@ -21,6 +21,13 @@ ORDER BY
         ELSE 99
     END;
 
+-- name: ListStandardTimesForCourse :many
+SELECT st.id, st.standard_id, st.event, st.age_group, st.time_ms, st.created_at, st.updated_at
+FROM standard_times st
+JOIN time_standards ts ON ts.id = st.standard_id
+WHERE ts.course_type = $1 AND ts.gender = $2
+ORDER BY st.standard_id, st.event, st.age_group;
+
 -- name: GetStandardTimeForEventAndAge :one
 SELECT id, standard_id, event, age_group, time_ms, created_at, updated_at
 FROM standard_times
@@ -41,6 +48,10 @@ RETURNING id, standard_id, event, age_group, time_ms, created_at, updated_at;
 DELETE FROM standard_times
 WHERE id = $1;
 
+-- name: DeleteStandardTimeForEventAndAge :exec
+DELETE FROM standard_times
+WHERE standard_id = $1 AND event = $2 AND age_group = $3;
+
 -- name: DeleteStandardTimesByStandardID :exec
 DELETE FROM standard_times
 WHERE standard_id = $1;
@@ -1,34 +1,65 @@
 -- name: GetSwimmer :one
-SELECT id, name, birth_date, gender, threshold_percent, created_at, updated_at
+SELECT id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+       season_start_month, season_start_day
 FROM swimmers
 WHERE id = $1;
 
 -- name: GetSwimmerByUserID :one
--- In a multi-user scenario, this would filter by user_id
--- For single-user MVP, just return the first swimmer
-SELECT id, name, birth_date, gender, threshold_percent, created_at, updated_at
+-- Returns the owner's default swimmer (the first one created)
+SELECT id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+       season_start_month, season_start_day
 FROM swimmers
+WHERE owner_id = $1
+ORDER BY created_at, name
 LIMIT 1;
 
+-- name: GetSwimmerForOwner :one
+SELECT id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+       season_start_month, season_start_day
+FROM swimmers
+WHERE id = $1 AND owner_id = $2;
+
 -- name: CreateSwimmer :one
-INSERT INTO swimmers (name, birth_date, gender, threshold_percent)
-VALUES ($1, $2, $3, $4)
-RETURNING id, name, birth_date, gender, threshold_percent, created_at, updated_at;
+INSERT INTO swimmers (name, birth_date, gender, threshold_percent, owner_id, season_start_month, season_start_day)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+          season_start_month, season_start_day;
 
 -- name: UpdateSwimmer :one
 UPDATE swimmers
-SET name = $2, birth_date = $3, gender = $4, threshold_percent = $5
+SET name = $2, birth_date = $3, gender = $4, threshold_percent = $5,
+    season_start_month = $6, season_start_day = $7
 WHERE id = $1
-RETURNING id, name, birth_date, gender, threshold_percent, created_at, updated_at;
+RETURNING id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+          season_start_month, season_start_day;
 
 -- name: DeleteSwimmer :exec
 DELETE FROM swimmers
 WHERE id = $1;
 
 -- name: ListSwimmers :many
-SELECT id, name, birth_date, gender, threshold_percent, created_at, updated_at
+SELECT id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+       season_start_month, season_start_day
 FROM swimmers
+WHERE owner_id = $1
 ORDER BY name;
 
 -- name: CountSwimmers :one
 SELECT COUNT(*) FROM swimmers;
+
+-- name: LockUnownedClaims :exec
+-- Serializes claims of data without an owner until the transaction ends
+SELECT pg_advisory_xact_lock(hashtext('swimstats.claim_unowned'));
+
+-- name: ClaimUnownedSwimmers :execrows
+-- Assigns swimmers created before multi-swimmer support to an owner
+UPDATE swimmers
+SET owner_id = $1
+WHERE owner_id = '';
+
+-- name: ListAllSwimmers :many
+-- Lists the swimmers of all owners, each owner's default swimmer first
+SELECT id, name, birth_date, gender, threshold_percent, owner_id, created_at, updated_at,
+       season_start_month, season_start_day
+FROM swimmers
+ORDER BY owner_id, created_at, name;
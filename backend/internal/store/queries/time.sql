@@ -8,7 +8,14 @@ SELECT
     t.event_date,
     t.notes, 
     t.created_at, 
-    t.updated_at
+    t.updated_at,
+    t.relay_leg,
+    t.relay_stroke,
+    t.official_event,
+    t.status,
+    t.dq_code,
+    t.dq_reason,
+    t.grade
 FROM times t
 WHERE t.id = $1;
 
@@ -23,6 +30,13 @@ SELECT
     t.notes, 
     t.created_at, 
     t.updated_at,
+    t.relay_leg,
+    t.relay_stroke,
+    t.official_event,
+    t.status,
+    t.dq_code,
+    t.dq_reason,
+    t.grade,
     m.name AS meet_name,
     m.city AS meet_city,
     m.start_date AS meet_start_date,
@@ -43,6 +57,13 @@ SELECT
     t.notes, 
     t.created_at, 
     t.updated_at,
+    t.relay_leg,
+    t.relay_stroke,
+    t.official_event,
+    t.status,
+    t.dq_code,
+    t.dq_reason,
+    t.grade,
     m.name AS meet_name,
     m.city AS meet_city,
     m.start_date AS meet_start_date,
@@ -54,7 +75,7 @@ WHERE t.swimmer_id = $1
   AND ($2::varchar = '' OR m.course_type = $2)
   AND ($3::varchar = '' OR t.event = $3)
   AND ($4::uuid = '00000000-0000-0000-0000-000000000000' OR t.meet_id = $4)
-ORDER BY COALESCE(t.event_date, m.start_date) DESC, t.event
+ORDER BY COALESCE(t.event_date, m.start_date) DESC, t.event, t.id
 LIMIT $5 OFFSET $6;
 
 -- name: CountTimes :one
@@ -66,15 +87,41 @@ WHERE t.swimmer_id = $1
   AND ($4::uuid = '00000000-0000-0000-0000-000000000000' OR t.meet_id = $4);
 
 -- name: CreateTime :one
-INSERT INTO times (swimmer_id, meet_id, event, time_ms, event_date, notes)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, swimmer_id, meet_id, event, time_ms, event_date, notes, created_at, updated_at;
+INSERT INTO times (swimmer_id, meet_id, event, time_ms, event_date, notes, relay_leg, relay_stroke, official_event, status, dq_code, dq_reason, grade)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING id, swimmer_id, meet_id, event, time_ms, event_date, notes, created_at, updated_at, relay_leg, relay_stroke, official_event, status, dq_code, dq_reason, grade;
 
 -- name: UpdateTime :one
 UPDATE times
-SET meet_id = $2, event = $3, time_ms = $4, event_date = $5, notes = $6
+SET meet_id = $2, event = $3, time_ms = $4, event_date = $5, notes = $6,
+    relay_leg = $7, relay_stroke = $8, official_event = $9,
+    status = $10, dq_code = $11, dq_reason = $12, grade = $13
 WHERE id = $1
-RETURNING id, swimmer_id, meet_id, event, time_ms, event_date, notes, created_at, updated_at;
+RETURNING id, swimmer_id, meet_id, event, time_ms, event_date, notes, created_at, updated_at, relay_leg, relay_stroke, official_event, status, dq_code, dq_reason, grade;
+
+-- name: UpdateTimeGrade :exec
+UPDATE times
+SET grade = $2
+WHERE id = $1;
+
+-- name: ListTimesForGrading :many
+-- Returns the times of the owner's swimmers of a gender in a course with what their grade depends on,
+-- or those of all swimmers for an empty owner
+-- Used to regrade swims when a grading ladder changes
+SELECT
+    t.id,
+    t.official_event,
+    t.time_ms,
+    t.status,
+    t.grade,
+    m.start_date AS meet_start_date,
+    s.birth_date,
+    s.owner_id
+FROM times t
+JOIN meets m ON m.id = t.meet_id
+JOIN swimmers s ON s.id = t.swimmer_id
+WHERE m.course_type = $1 AND s.gender = $2
+  AND ($3::varchar = '' OR s.owner_id = $3);
 
 -- name: DeleteTime :exec
 DELETE FROM times
@@ -84,6 +131,10 @@ WHERE id = $1;
 DELETE FROM times
 WHERE meet_id = $1;
 
+-- name: DeleteTimesBySwimmer :exec
+DELETE FROM times
+WHERE swimmer_id = $1;
+
 -- name: ListTimesByMeet :many
 SELECT 
     t.id, 
@@ -94,38 +145,89 @@ SELECT
     t.event_date,
     t.notes, 
     t.created_at, 
-    t.updated_at
+    t.updated_at,
+    t.relay_leg,
+    t.relay_stroke,
+    t.official_event,
+    t.status,
+    t.dq_code,
+    t.dq_reason,
+    t.grade
 FROM times t
 WHERE t.meet_id = $1
 ORDER BY COALESCE(t.event_date, (SELECT start_date FROM meets WHERE id = t.meet_id)), t.event, t.time_ms;
 
 -- name: GetPersonalBests :many
 -- Returns the fastest time for each event for a swimmer in a specific course type
-SELECT DISTINCT ON (t.event)
+-- Relay lead-off legs count toward the equivalent individual event; DQ, DNS, DNF and scratches never do
+-- Swims may be restricted to a date window ($3, $4; NULL is open) and to sanctioned meets ($5)
+SELECT DISTINCT ON (t.official_event)
     t.id,
     t.swimmer_id,
     t.meet_id,
-    t.event,
+    t.official_event AS event,
     t.time_ms,
     t.event_date,
     t.notes,
     t.created_at,
     t.updated_at,
+    t.event AS swum_event,
+    t.relay_leg,
     m.name AS meet_name,
     m.start_date AS meet_date
 FROM times t
 JOIN meets m ON m.id = t.meet_id
 WHERE t.swimmer_id = $1
   AND m.course_type = $2
-ORDER BY t.event, t.time_ms ASC, COALESCE(t.event_date, m.start_date) DESC;
+  AND t.official_event <> ''
+  AND t.status = 'ok'
+  AND ($3::date IS NULL OR COALESCE(t.event_date, m.start_date) >= $3)
+  AND ($4::date IS NULL OR COALESCE(t.event_date, m.start_date) <= $4)
+  AND (NOT $5::boolean OR m.sanctioned)
+ORDER BY t.official_event, t.time_ms ASC, COALESCE(t.event_date, m.start_date) DESC;
+
+-- name: ListOfficialSwims :many
+-- Returns all official swims of a swimmer in a course type, including relay lead-off legs
+-- Used to evaluate each swim against the standard of the swimmer's age group at the time
+-- Swims may be restricted to a date window ($3, $4; NULL is open) and to sanctioned meets ($5)
+SELECT
+    t.id,
+    t.official_event AS event,
+    t.time_ms,
+    COALESCE(t.event_date, m.start_date) AS date,
+    m.name AS meet_name,
+    m.start_date AS meet_date,
+    t.meet_id
+FROM times t
+JOIN meets m ON m.id = t.meet_id
+WHERE t.swimmer_id = $1
+  AND m.course_type = $2
+  AND t.official_event <> ''
+  AND t.status = 'ok'
+  AND ($3::date IS NULL OR COALESCE(t.event_date, m.start_date) >= $3)
+  AND ($4::date IS NULL OR COALESCE(t.event_date, m.start_date) <= $4)
+  AND (NOT $5::boolean OR m.sanctioned)
+ORDER BY t.official_event, COALESCE(t.event_date, m.start_date) DESC, t.time_ms ASC;
+
+-- name: ListResultDates :many
+-- Returns the date, meet and status of every result of a swimmer in all courses
+-- Used to assign meets and times to seasons
+SELECT
+    COALESCE(t.event_date, m.start_date) AS date,
+    t.meet_id,
+    t.status
+FROM times t
+JOIN meets m ON m.id = t.meet_id
+WHERE t.swimmer_id = $1
+ORDER BY COALESCE(t.event_date, m.start_date);
 
 -- name: GetPersonalBestForEvent :one
--- Returns the fastest time for a specific event
+-- Returns the fastest time for a specific event, including relay lead-off legs
 SELECT 
     t.id,
     t.swimmer_id,
     t.meet_id,
-    t.event,
+    t.official_event AS event,
     t.time_ms,
     t.event_date,
     t.notes,
@@ -137,7 +239,8 @@ FROM times t
 JOIN meets m ON m.id = t.meet_id
 WHERE t.swimmer_id = $1
   AND m.course_type = $2
-  AND t.event = $3
+  AND t.official_event = $3
+  AND t.status = 'ok'
 ORDER BY t.time_ms ASC, COALESCE(t.event_date, m.start_date) DESC
 LIMIT 1;
 
@@ -148,7 +251,8 @@ SELECT NOT EXISTS (
     JOIN meets m ON m.id = t.meet_id
     WHERE t.swimmer_id = $1
       AND m.course_type = $2
-      AND t.event = $3
+      AND t.official_event = $3
+      AND t.status = 'ok'
       AND t.time_ms <= $4
       AND t.id != $5
 ) AS is_pb;
@@ -163,6 +267,18 @@ WHERE t.swimmer_id = $1
 GROUP BY event
 ORDER BY event;
 
+-- name: CountResultsByStatus :many
+-- Returns count of results per status for a swimmer, optionally within a course and date range
+SELECT t.status, COUNT(*)::int AS count
+FROM times t
+JOIN meets m ON m.id = t.meet_id
+WHERE t.swimmer_id = $1
+  AND ($2::varchar = '' OR m.course_type = $2)
+  AND ($3::date IS NULL OR COALESCE(t.event_date, m.start_date) >= $3)
+  AND ($4::date IS NULL OR COALESCE(t.event_date, m.start_date) <= $4)
+GROUP BY t.status
+ORDER BY t.status;
+
 -- name: GetTotalTimeCount :one
 SELECT COUNT(*)::int FROM times
 WHERE swimmer_id = $1;
@@ -181,7 +297,7 @@ SELECT EXISTS (
 ) AS exists;
 
 -- name: GetProgressData :many
--- Returns time progression for a specific event over time
+-- Returns time progression for a specific event over time, including relay lead-off legs
 -- Used for progress charts visualization
 SELECT
     t.id,
@@ -197,13 +313,15 @@ SELECT
         JOIN meets m2 ON m2.id = t2.meet_id
         WHERE t2.swimmer_id = t.swimmer_id
           AND m2.course_type = m.course_type
-          AND t2.event = t.event
+          AND t2.official_event = t.official_event
+          AND t2.status = 'ok'
     )) AS is_pb
 FROM times t
 JOIN meets m ON m.id = t.meet_id
 WHERE t.swimmer_id = $1
   AND m.course_type = $2
-  AND t.event = $3
+  AND t.official_event = $3
+  AND t.status = 'ok'
   AND ($4::date IS NULL OR COALESCE(t.event_date, m.start_date) >= $4)
   AND ($5::date IS NULL OR COALESCE(t.event_date, m.start_date) <= $5)
 ORDER BY COALESCE(t.event_date, m.start_date) ASC, t.time_ms ASC;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_meets_owner_id;
+DROP INDEX IF EXISTS idx_swimmers_owner_id;
+
+ALTER TABLE meets DROP COLUMN owner_id;
+ALTER TABLE swimmers DROP COLUMN owner_id;
@@ -0,0 +1,9 @@
+-- Link swimmers and meets to the OIDC user that owns them.
+-- Meets are shared between all swimmers of the same owner (siblings at one meet).
+-- Rows created before multi-swimmer support keep an empty owner and are not
+-- visible to anyone until an administrator assigns them with the claim command.
+ALTER TABLE swimmers ADD COLUMN owner_id VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE meets ADD COLUMN owner_id VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_swimmers_owner_id ON swimmers(owner_id);
+CREATE INDEX idx_meets_owner_id ON meets(owner_id);
@@ -0,0 +1,10 @@
+-- Cumulative split times recorded during a swim (e.g. every 50m of a 200).
+-- The last split of a time is at the event distance and equals the final time.
+CREATE TABLE splits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    time_id UUID NOT NULL REFERENCES times(id) ON DELETE CASCADE,
+    distance INTEGER NOT NULL CHECK (distance > 0),
+    time_ms INTEGER NOT NULL CHECK (time_ms > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (time_id, distance)
+);
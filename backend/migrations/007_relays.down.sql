@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_times_swimmer_official_event;
+
+ALTER TABLE times DROP COLUMN IF EXISTS official_event;
+ALTER TABLE times DROP COLUMN IF EXISTS relay_stroke;
+ALTER TABLE times DROP COLUMN IF EXISTS relay_leg;
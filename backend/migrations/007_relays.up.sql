@@ -0,0 +1,12 @@
+-- Relay swims: the swimmer's leg position and stroke within a relay event.
+-- official_event is the individual event a time counts toward for personal
+-- bests and progress: the event itself for individual swims, the individual
+-- equivalent of a relay lead-off leg (e.g. 100FR for the first leg of a
+-- 4X100FR) and empty for the other relay legs.
+ALTER TABLE times ADD COLUMN relay_leg SMALLINT CHECK (relay_leg BETWEEN 1 AND 4);
+ALTER TABLE times ADD COLUMN relay_stroke VARCHAR(2);
+ALTER TABLE times ADD COLUMN official_event VARCHAR(50) NOT NULL DEFAULT '';
+
+UPDATE times SET official_event = event;
+
+CREATE INDEX idx_times_swimmer_official_event ON times(swimmer_id, official_event);
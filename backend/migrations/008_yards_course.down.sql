@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS course_conversion_factors;
+
+DELETE FROM times WHERE meet_id IN (SELECT id FROM meets WHERE course_type = '25y');
+DELETE FROM meets WHERE course_type = '25y';
+DELETE FROM time_standards WHERE course_type = '25y';
+
+ALTER TABLE time_standards DROP CONSTRAINT IF EXISTS time_standards_course_type_check;
+ALTER TABLE time_standards ADD CONSTRAINT time_standards_course_type_check
+    CHECK (course_type IN ('25m', '50m'));
+
+ALTER TABLE meets DROP CONSTRAINT IF EXISTS meets_course_type_check;
+ALTER TABLE meets ADD CONSTRAINT meets_course_type_check
+    CHECK (course_type IN ('25m', '50m'));
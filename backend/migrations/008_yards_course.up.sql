@@ -0,0 +1,38 @@
+-- Short-course yards (25y) pools, used by US meets.
+ALTER TABLE meets DROP CONSTRAINT IF EXISTS meets_course_type_check;
+ALTER TABLE meets ADD CONSTRAINT meets_course_type_check
+    CHECK (course_type IN ('25m', '50m', '25y'));
+
+ALTER TABLE time_standards DROP CONSTRAINT IF EXISTS time_standards_course_type_check;
+ALTER TABLE time_standards ADD CONSTRAINT time_standards_course_type_check
+    CHECK (course_type IN ('25m', '50m', '25y'));
+
+-- Multipliers estimating the equivalent time of a swim in another course.
+-- An empty event applies to all events without a specific factor. The
+-- reverse conversion uses the inverse factor when no direct one exists.
+CREATE TABLE course_conversion_factors (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_course VARCHAR(3) NOT NULL CHECK (from_course IN ('25m', '50m', '25y')),
+    to_course VARCHAR(3) NOT NULL CHECK (to_course IN ('25m', '50m', '25y')),
+    event VARCHAR(50) NOT NULL DEFAULT '',
+    factor NUMERIC(6,4) NOT NULL CHECK (factor > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (from_course, to_course, event),
+    CONSTRAINT course_conversion_factors_courses_differ CHECK (from_course <> to_course)
+);
+
+CREATE TRIGGER course_conversion_factors_updated_at BEFORE UPDATE ON course_conversion_factors
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+-- Commonly used approximations. Distance freestyle converts between the
+-- yards and metric equivalents (500y/400m, 1000y/800m, 1650y/1500m).
+INSERT INTO course_conversion_factors (from_course, to_course, event, factor) VALUES
+    ('25y', '25m', '', 1.1100),
+    ('25y', '25m', '500FR', 0.8750),
+    ('25y', '25m', '1000FR', 0.8750),
+    ('25y', '25m', '1650FR', 0.9750),
+    ('25y', '50m', '', 1.1300),
+    ('25y', '50m', '500FR', 0.8925),
+    ('25y', '50m', '1000FR', 0.8925),
+    ('25y', '50m', '1650FR', 1.0200);
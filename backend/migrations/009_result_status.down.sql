@@ -0,0 +1,8 @@
+DELETE FROM times WHERE status <> 'ok';
+
+ALTER TABLE times DROP CONSTRAINT times_time_ms_check;
+ALTER TABLE times ADD CONSTRAINT times_time_ms_check CHECK (time_ms > 0);
+
+ALTER TABLE times DROP COLUMN IF EXISTS dq_reason;
+ALTER TABLE times DROP COLUMN IF EXISTS dq_code;
+ALTER TABLE times DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+-- Result status: a swim may be disqualified (dq), not started (dns), not
+-- finished (dnf) or scratched (scr). Only 'ok' results are official times;
+-- the others keep time_ms = 0 unless a time was recorded (DQ, DNF).
+ALTER TABLE times ADD COLUMN status VARCHAR(3) NOT NULL DEFAULT 'ok'
+    CHECK (status IN ('ok', 'dq', 'dns', 'dnf', 'scr'));
+ALTER TABLE times ADD COLUMN dq_code VARCHAR(20);
+ALTER TABLE times ADD COLUMN dq_reason TEXT;
+
+ALTER TABLE times DROP CONSTRAINT times_time_ms_check;
+ALTER TABLE times ADD CONSTRAINT times_time_ms_check
+    CHECK (time_ms > 0 OR (status <> 'ok' AND time_ms = 0));
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_standards_age_group_scheme_id;
+ALTER TABLE time_standards DROP COLUMN IF EXISTS age_group_scheme_id;
+
+DROP TABLE IF EXISTS age_group_ranges;
+DROP TABLE IF EXISTS age_group_schemes;
@@ -0,0 +1,82 @@
+-- Age-group schemes: a governing body's age groups and the rule used to
+-- determine a swimmer's competition age. Each time standard uses one scheme.
+CREATE TABLE age_group_schemes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    age_rule VARCHAR(20) NOT NULL CHECK (age_rule IN ('dec31', 'meet_start')),
+    is_preloaded BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Age groups of a scheme. A NULL max_age is open-ended (e.g. 17 & over).
+CREATE TABLE age_group_ranges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    scheme_id UUID NOT NULL REFERENCES age_group_schemes(id) ON DELETE CASCADE,
+    code VARCHAR(20) NOT NULL,
+    min_age SMALLINT NOT NULL CHECK (min_age >= 0),
+    max_age SMALLINT CHECK (max_age >= min_age),
+    UNIQUE (scheme_id, code)
+);
+
+CREATE INDEX idx_age_group_ranges_scheme_id ON age_group_ranges(scheme_id);
+
+CREATE TRIGGER age_group_schemes_updated_at BEFORE UPDATE ON age_group_schemes
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+WITH scheme AS (
+    INSERT INTO age_group_schemes (name, description, age_rule, is_preloaded)
+    VALUES ('Swimming Canada', 'National age groups, age as of December 31', 'dec31', TRUE)
+    RETURNING id
+)
+INSERT INTO age_group_ranges (scheme_id, code, min_age, max_age)
+SELECT scheme.id, r.code, r.min_age, r.max_age
+FROM scheme, (VALUES
+    ('10U', 0, 10),
+    ('11-12', 11, 12),
+    ('13-14', 13, 14),
+    ('15-17', 15, 17),
+    ('OPEN', 18, NULL)
+) AS r(code, min_age, max_age);
+
+WITH scheme AS (
+    INSERT INTO age_group_schemes (name, description, age_rule, is_preloaded)
+    VALUES ('Swim Ontario', 'Provincial single-year age groups, age as of December 31', 'dec31', TRUE)
+    RETURNING id
+)
+INSERT INTO age_group_ranges (scheme_id, code, min_age, max_age)
+SELECT scheme.id, r.code, r.min_age, r.max_age
+FROM scheme, (VALUES
+    ('11U', 0, 11),
+    ('12', 12, 12),
+    ('13', 13, 13),
+    ('14', 14, 14),
+    ('15', 15, 15),
+    ('16', 16, 16),
+    ('17O', 17, NULL)
+) AS r(code, min_age, max_age);
+
+WITH scheme AS (
+    INSERT INTO age_group_schemes (name, description, age_rule, is_preloaded)
+    VALUES ('USA Swimming', 'Age on the first day of the meet', 'meet_start', TRUE)
+    RETURNING id
+)
+INSERT INTO age_group_ranges (scheme_id, code, min_age, max_age)
+SELECT scheme.id, r.code, r.min_age, r.max_age
+FROM scheme, (VALUES
+    ('10U', 0, 10),
+    ('11-12', 11, 12),
+    ('13-14', 13, 14),
+    ('15-16', 15, 16),
+    ('17-18', 17, 18),
+    ('OPEN', 19, NULL)
+) AS r(code, min_age, max_age);
+
+-- Existing standards were entered with the Swimming Canada age groups.
+ALTER TABLE time_standards ADD COLUMN age_group_scheme_id UUID REFERENCES age_group_schemes(id);
+UPDATE time_standards
+SET age_group_scheme_id = (SELECT id FROM age_group_schemes WHERE name = 'Swimming Canada');
+ALTER TABLE time_standards ALTER COLUMN age_group_scheme_id SET NOT NULL;
+
+CREATE INDEX idx_standards_age_group_scheme_id ON time_standards(age_group_scheme_id);
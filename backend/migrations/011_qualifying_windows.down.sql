@@ -0,0 +1,7 @@
+ALTER TABLE meets DROP COLUMN IF EXISTS sanctioned;
+
+ALTER TABLE time_standards DROP CONSTRAINT IF EXISTS time_standards_qualifying_window_valid;
+ALTER TABLE time_standards DROP COLUMN IF EXISTS sanctioned_only;
+ALTER TABLE time_standards DROP COLUMN IF EXISTS required_course;
+ALTER TABLE time_standards DROP COLUMN IF EXISTS qualifying_end;
+ALTER TABLE time_standards DROP COLUMN IF EXISTS qualifying_start;
@@ -0,0 +1,13 @@
+-- Qualifying windows: a standard may only count swims done between
+-- qualifying_start and qualifying_end (inclusive, either end open), in a
+-- required course without conversion, or at sanctioned meets only.
+ALTER TABLE time_standards ADD COLUMN qualifying_start DATE;
+ALTER TABLE time_standards ADD COLUMN qualifying_end DATE;
+ALTER TABLE time_standards ADD COLUMN required_course VARCHAR(3)
+    CHECK (required_course IN ('25m', '50m', '25y'));
+ALTER TABLE time_standards ADD COLUMN sanctioned_only BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE time_standards ADD CONSTRAINT time_standards_qualifying_window_valid
+    CHECK (qualifying_end >= qualifying_start);
+
+-- Meets are sanctioned unless marked otherwise (e.g. time trials, club meets)
+ALTER TABLE meets ADD COLUMN sanctioned BOOLEAN NOT NULL DEFAULT TRUE;
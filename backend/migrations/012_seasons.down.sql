@@ -0,0 +1,2 @@
+ALTER TABLE swimmers DROP COLUMN IF EXISTS season_start_day;
+ALTER TABLE swimmers DROP COLUMN IF EXISTS season_start_month;
@@ -0,0 +1,7 @@
+-- Swim seasons: each swimmer's seasons start on the same day every year
+-- (September 1 by default) and last one year. Meets and times belong to the
+-- season containing their date.
+ALTER TABLE swimmers ADD COLUMN season_start_month SMALLINT NOT NULL DEFAULT 9
+    CHECK (season_start_month BETWEEN 1 AND 12);
+ALTER TABLE swimmers ADD COLUMN season_start_day SMALLINT NOT NULL DEFAULT 1
+    CHECK (season_start_day BETWEEN 1 AND 31);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS points_base_times;
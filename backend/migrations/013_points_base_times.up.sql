@@ -0,0 +1,19 @@
+-- World Aquatics points base times: the reference time worth 1000 points
+-- for an event, per year, course and gender. Points are
+-- 1000 * (base time / swim time)^3, truncated.
+CREATE TABLE points_base_times (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    year SMALLINT NOT NULL CHECK (year BETWEEN 1900 AND 9999),
+    course_type VARCHAR(3) NOT NULL CHECK (course_type IN ('25m', '50m', '25y')),
+    gender VARCHAR(10) NOT NULL CHECK (gender IN ('female', 'male')),
+    event VARCHAR(50) NOT NULL,
+    time_ms INTEGER NOT NULL CHECK (time_ms > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (year, course_type, gender, event)
+);
+
+CREATE INDEX idx_points_base_times_gender ON points_base_times(gender);
+
+CREATE TRIGGER points_base_times_updated_at BEFORE UPDATE ON points_base_times
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at();
@@ -0,0 +1,3 @@
+ALTER TABLE times DROP COLUMN IF EXISTS grade;
+DROP TABLE IF EXISTS standard_ladder_rungs;
+DROP TABLE IF EXISTS standard_ladders;
@@ -0,0 +1,34 @@
+-- Standard ladders: standards of one course and gender ordered into tiers,
+-- lowest first (e.g. OAG < OSC < Canadian Open < Trials). A grading ladder
+-- (at most one per course and gender) gives every official swim the label of
+-- the highest rung it achieved, e.g. USA Swimming motivational times B-AAAA.
+CREATE TABLE standard_ladders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    course_type VARCHAR(3) NOT NULL CHECK (course_type IN ('25m', '50m', '25y')),
+    gender VARCHAR(10) NOT NULL CHECK (gender IN ('female', 'male')),
+    grading BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_standard_ladders_grading ON standard_ladders(course_type, gender) WHERE grading;
+
+CREATE TRIGGER standard_ladders_updated_at BEFORE UPDATE ON standard_ladders
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+-- Rungs are ordered by position, 1 being the lowest tier
+CREATE TABLE standard_ladder_rungs (
+    ladder_id UUID NOT NULL REFERENCES standard_ladders(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL CHECK (position > 0),
+    standard_id UUID NOT NULL REFERENCES time_standards(id) ON DELETE CASCADE,
+    label VARCHAR(20),
+    PRIMARY KEY (ladder_id, position),
+    UNIQUE (ladder_id, standard_id)
+);
+
+CREATE INDEX idx_standard_ladder_rungs_standard_id ON standard_ladder_rungs(standard_id);
+
+-- The grade of a swim on the grading ladder of its course and the swimmer's gender
+ALTER TABLE times ADD COLUMN grade VARCHAR(100);
@@ -0,0 +1,6 @@
+ALTER TABLE time_standards DROP CONSTRAINT IF EXISTS time_standards_family_effective_unique;
+ALTER TABLE time_standards DROP CONSTRAINT IF EXISTS time_standards_version_effective;
+ALTER TABLE time_standards DROP COLUMN IF EXISTS effective_from;
+ALTER TABLE time_standards DROP COLUMN IF EXISTS version;
+ALTER TABLE time_standards DROP COLUMN IF EXISTS family_id;
+DROP TABLE IF EXISTS standard_families;
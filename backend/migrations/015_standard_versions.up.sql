@@ -0,0 +1,29 @@
+-- Standard families group the versions of a standard published over the
+-- seasons (e.g. the OSC standards of 2024-2026 and 2026-2028). Each version
+-- is a standard of its own, effective from its effective date until the next
+-- version's. Family names are unique per course and gender, as the same
+-- standard is published for each.
+CREATE TABLE standard_families (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    course_type VARCHAR(3) NOT NULL CHECK (course_type IN ('25m', '50m', '25y')),
+    gender VARCHAR(10) NOT NULL CHECK (gender IN ('female', 'male')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (name, course_type, gender)
+);
+
+CREATE TRIGGER standard_families_updated_at BEFORE UPDATE ON standard_families
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+-- Deleting a family keeps its versions as standalone standards
+ALTER TABLE time_standards ADD COLUMN family_id UUID REFERENCES standard_families(id) ON DELETE SET NULL;
+ALTER TABLE time_standards ADD COLUMN version VARCHAR(50);
+ALTER TABLE time_standards ADD COLUMN effective_from DATE;
+ALTER TABLE time_standards ADD CONSTRAINT time_standards_version_effective
+    CHECK (family_id IS NULL OR effective_from IS NOT NULL);
+ALTER TABLE time_standards ADD CONSTRAINT time_standards_family_effective_unique
+    UNIQUE (family_id, effective_from);
+
+CREATE INDEX idx_standards_family_id ON time_standards(family_id);
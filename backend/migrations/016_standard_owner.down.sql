@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_standard_families_owner_id;
+DROP INDEX IF EXISTS idx_standards_owner_id;
+
+ALTER TABLE standard_families DROP CONSTRAINT IF EXISTS standard_families_owner_name_key;
+ALTER TABLE standard_families ADD CONSTRAINT standard_families_name_course_type_gender_key
+    UNIQUE (name, course_type, gender);
+ALTER TABLE time_standards DROP CONSTRAINT IF EXISTS time_standards_owner_name_key;
+ALTER TABLE time_standards ADD CONSTRAINT time_standards_name_key UNIQUE (name);
+
+ALTER TABLE standard_families DROP COLUMN owner_id;
+ALTER TABLE time_standards DROP COLUMN owner_id;
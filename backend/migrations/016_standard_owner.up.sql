@@ -0,0 +1,16 @@
+-- Link custom standards and standard families to the OIDC user that owns
+-- them, as swimmers and meets are. Names are unique per owner. Standards and
+-- families without an owner are shared by all users: the preloaded standards,
+-- those imported with the administrative commands and the custom standards
+-- created before standards had owners.
+ALTER TABLE time_standards ADD COLUMN owner_id VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE standard_families ADD COLUMN owner_id VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE time_standards DROP CONSTRAINT time_standards_name_key;
+ALTER TABLE time_standards ADD CONSTRAINT time_standards_owner_name_key UNIQUE (owner_id, name);
+ALTER TABLE standard_families DROP CONSTRAINT standard_families_name_course_type_gender_key;
+ALTER TABLE standard_families ADD CONSTRAINT standard_families_owner_name_key
+    UNIQUE (owner_id, name, course_type, gender);
+
+CREATE INDEX idx_standards_owner_id ON time_standards(owner_id);
+CREATE INDEX idx_standard_families_owner_id ON standard_families(owner_id);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_standard_ladders_owner_id;
+
+DROP INDEX IF EXISTS idx_standard_ladders_grading;
+CREATE UNIQUE INDEX idx_standard_ladders_grading ON standard_ladders(course_type, gender) WHERE grading;
+
+ALTER TABLE standard_ladders DROP CONSTRAINT IF EXISTS standard_ladders_owner_name_key;
+ALTER TABLE standard_ladders ADD CONSTRAINT standard_ladders_name_key UNIQUE (name);
+
+ALTER TABLE standard_ladders DROP COLUMN owner_id;
@@ -0,0 +1,36 @@
+-- Link standard ladders to the OIDC user that owns them, as standards are.
+-- Names are unique per owner and each owner has at most one grading ladder
+-- per course and gender. Ladders without an owner are shared by all users:
+-- a shared grading ladder grades the swims of users without a grading ladder
+-- of their own.
+ALTER TABLE standard_ladders ADD COLUMN owner_id VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Existing ladders go to the owner of their custom standards, and lose the
+-- rungs of standards that owner does not see. Ladders of shared standards
+-- only stay shared.
+UPDATE standard_ladders l
+SET owner_id = (
+    SELECT MIN(ts.owner_id)
+    FROM standard_ladder_rungs r
+    JOIN time_standards ts ON ts.id = r.standard_id
+    WHERE r.ladder_id = l.id AND ts.owner_id != ''
+)
+WHERE EXISTS (
+    SELECT 1
+    FROM standard_ladder_rungs r
+    JOIN time_standards ts ON ts.id = r.standard_id
+    WHERE r.ladder_id = l.id AND ts.owner_id != ''
+);
+
+DELETE FROM standard_ladder_rungs r
+USING standard_ladders l, time_standards ts
+WHERE l.id = r.ladder_id AND ts.id = r.standard_id
+  AND ts.owner_id NOT IN ('', l.owner_id);
+
+ALTER TABLE standard_ladders DROP CONSTRAINT standard_ladders_name_key;
+ALTER TABLE standard_ladders ADD CONSTRAINT standard_ladders_owner_name_key UNIQUE (owner_id, name);
+
+DROP INDEX idx_standard_ladders_grading;
+CREATE UNIQUE INDEX idx_standard_ladders_grading ON standard_ladders(owner_id, course_type, gender) WHERE grading;
+
+CREATE INDEX idx_standard_ladders_owner_id ON standard_ladders(owner_id);
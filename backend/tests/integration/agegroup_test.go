@@ -0,0 +1,309 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type AgeRange struct {
+	Code   string `json:"code"`
+	MinAge int    `json:"min_age"`
+	MaxAge *int   `json:"max_age,omitempty"`
+}
+
+type AgeGroupScheme struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	AgeRule     string     `json:"age_rule"`
+	IsPreloaded bool       `json:"is_preloaded"`
+	Groups      []AgeRange `json:"groups"`
+}
+
+type AgeGroupSchemeList struct {
+	Schemes []AgeGroupScheme `json:"schemes"`
+}
+
+type SchemeStandard struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	AgeGroupSchemeID string `json:"age_group_scheme_id"`
+}
+
+type JSONImportResult struct {
+	Standards []SchemeStandard `json:"standards"`
+	Imported  int              `json:"imported"`
+	Skipped   int              `json:"skipped"`
+	Errors    []string         `json:"errors"`
+}
+
+type AgeGroupComparison struct {
+	Event          string  `json:"event"`
+	AgeGroup       string  `json:"age_group"`
+	StandardTimeMS *int    `json:"standard_time_ms"`
+	PrevAgeGroup   *string `json:"prev_age_group"`
+	NextAgeGroup   *string `json:"next_age_group"`
+}
+
+type AgeGroupComparisonResult struct {
+	SwimmerAge      int                  `json:"swimmer_age"`
+	SwimmerAgeGroup string               `json:"swimmer_age_group"`
+	AgeGroupScheme  string               `json:"age_group_scheme"`
+	Comparisons     []AgeGroupComparison `json:"comparisons"`
+}
+
+func TestAgeGroupSchemeAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	schemes := make(map[string]AgeGroupScheme)
+
+	t.Run("GET /age-group-schemes lists the preloaded schemes", func(t *testing.T) {
+		rr := client.Get("/api/v1/age-group-schemes")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var list AgeGroupSchemeList
+		AssertJSONBody(t, rr, &list)
+		for _, s := range list.Schemes {
+			schemes[s.Name] = s
+		}
+
+		canada := schemes["Swimming Canada"]
+		assert.True(t, canada.IsPreloaded)
+		assert.Equal(t, "dec31", canada.AgeRule)
+		require.Len(t, canada.Groups, 5)
+		assert.Equal(t, "10U", canada.Groups[0].Code)
+		assert.Nil(t, canada.Groups[4].MaxAge)
+
+		ontario := schemes["Swim Ontario"]
+		require.Len(t, ontario.Groups, 7)
+		assert.Equal(t, "14", ontario.Groups[3].Code)
+
+		assert.Equal(t, "meet_start", schemes["USA Swimming"].AgeRule)
+	})
+
+	t.Run("POST /age-group-schemes creates a scheme", func(t *testing.T) {
+		twelve := 12
+		rr := client.Post("/api/v1/age-group-schemes", map[string]any{
+			"name":     "Club Groups",
+			"age_rule": "meet_start",
+			"groups": []AgeRange{
+				{Code: "12U", MinAge: 0, MaxAge: &twelve},
+				{Code: "13O", MinAge: 13},
+			},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var scheme AgeGroupScheme
+		AssertJSONBody(t, rr, &scheme)
+		assert.False(t, scheme.IsPreloaded)
+		assert.Len(t, scheme.Groups, 2)
+
+		rr = client.Delete("/api/v1/age-group-schemes/" + scheme.ID)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("POST /age-group-schemes validates input", func(t *testing.T) {
+		twelve := 12
+		rr := client.Post("/api/v1/age-group-schemes", map[string]any{
+			"name":     "Overlapping",
+			"age_rule": "dec31",
+			"groups": []AgeRange{
+				{Code: "12U", MinAge: 0, MaxAge: &twelve},
+				{Code: "12O", MinAge: 12},
+			},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = client.Post("/api/v1/age-group-schemes", map[string]any{
+			"name":     "Bad Rule",
+			"age_rule": "birthday",
+			"groups":   []AgeRange{{Code: "OPEN", MinAge: 0}},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("changing schemes requires an operator", func(t *testing.T) {
+		client.SetMockUser("view_only")
+		defer client.SetMockUser("full")
+
+		rr := client.Post("/api/v1/age-group-schemes", map[string]any{
+			"name":     "Read Only",
+			"age_rule": "dec31",
+			"groups":   []AgeRange{{Code: "OPEN", MinAge: 0}},
+		})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		// Schemes are shared, so full access to one's own data is not enough
+		client.SetMockUser("full")
+		client.SetMockEmail("other@swimstats.local")
+		defer client.SetMockEmail("test@swimstats.local")
+
+		rr = client.Post("/api/v1/age-group-schemes", map[string]any{
+			"name":     "Other Family",
+			"age_rule": "dec31",
+			"groups":   []AgeRange{{Code: "OPEN", MinAge: 0}},
+		})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = client.Delete("/api/v1/age-group-schemes/" + schemes["Swim Ontario"].ID)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("DELETE /age-group-schemes rejects preloaded schemes", func(t *testing.T) {
+		rr := client.Delete("/api/v1/age-group-schemes/" + schemes["Swim Ontario"].ID)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("standards default to the Swimming Canada scheme", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import", StandardImportInput{
+			Name:       "Default Scheme Standard",
+			CourseType: "25m",
+			Gender:     "female",
+			Times:      []StandardTimeInput{{Event: "50FR", AgeGroup: "13-14", TimeMs: 30000}},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var std SchemeStandard
+		AssertJSONBody(t, rr, &std)
+		assert.Equal(t, schemes["Swimming Canada"].ID, std.AgeGroupSchemeID)
+
+		rr = client.Put("/api/v1/standards/"+std.ID+"/times", map[string]any{
+			"times": []StandardTimeInput{{Event: "50FR", AgeGroup: "14", TimeMs: 30000}},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("JSON import keeps single-year Ontario age groups", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import/json", map[string]any{
+			"season":           "2025-2026",
+			"source":           "Swim Ontario",
+			"course_type":      "25m",
+			"gender":           "female",
+			"age_group_scheme": "Swim Ontario",
+			"standards": map[string]any{
+				"OAG": map[string]string{"name": "Ontario Age Group Test"},
+			},
+			"times": map[string]any{
+				"50FR": map[string]any{
+					"11U": map[string]string{"OAG": "0:32.16"},
+					"13":  map[string]string{"OAG": "0:29.06"},
+					"14":  map[string]string{"OAG": "0:28.77"},
+					"15":  map[string]string{"OAG": "0:28.39"},
+					"17+": map[string]string{"OAG": "0:27.90"},
+				},
+			},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var result JSONImportResult
+		AssertJSONBody(t, rr, &result)
+		require.Equal(t, 1, result.Imported, result.Errors)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, schemes["Swim Ontario"].ID, result.Standards[0].AgeGroupSchemeID)
+
+		rr = client.Get("/api/v1/standards/" + result.Standards[0].ID)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var std StandardWithTimes
+		AssertJSONBody(t, rr, &std)
+		byAgeGroup := make(map[string]int)
+		for _, st := range std.Times {
+			byAgeGroup[st.AgeGroup] = st.TimeMs
+		}
+		assert.Equal(t, map[string]int{
+			"11U": 32160,
+			"13":  29060,
+			"14":  28770,
+			"15":  28390,
+			"17O": 27900,
+		}, byAgeGroup)
+	})
+
+	t.Run("JSON import reports age groups outside the scheme", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import/json", map[string]any{
+			"season":      "2025-2026",
+			"source":      "Test",
+			"course_type": "25m",
+			"gender":      "female",
+			"standards": map[string]any{
+				"SC": map[string]string{"name": "Canada Single Year Test"},
+			},
+			"times": map[string]any{
+				"50FR": map[string]any{
+					"10&U": map[string]string{"SC": "0:33.00"},
+					"12":   map[string]string{"SC": "0:31.00"},
+				},
+			},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var result JSONImportResult
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, 1, result.Imported)
+		require.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors[0], "'12'")
+	})
+
+	t.Run("GET /comparisons uses the standard's age groups", func(t *testing.T) {
+		// Turns 14 this year, so 14 on December 31
+		birthDate := fmt.Sprintf("%d-06-15", time.Now().Year()-14)
+		rr := client.Put("/api/v1/swimmer", SwimmerInput{
+			Name:      "Ontario Swimmer",
+			BirthDate: birthDate,
+			Gender:    "female",
+		})
+		require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK, rr.Body.String())
+
+		rr = client.Get("/api/v1/standards?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var list StandardList
+		AssertJSONBody(t, rr, &list)
+		var standardID string
+		for _, s := range list.Standards {
+			if s.Name == "Ontario Age Group Test" {
+				standardID = s.ID
+			}
+		}
+		require.NotEmpty(t, standardID)
+
+		rr = client.Get("/api/v1/comparisons?standard_id=" + standardID + "&course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result AgeGroupComparisonResult
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, "Swim Ontario", result.AgeGroupScheme)
+		assert.Equal(t, 14, result.SwimmerAge)
+		assert.Equal(t, "14", result.SwimmerAgeGroup)
+
+		for _, c := range result.Comparisons {
+			if c.Event != "50FR" {
+				continue
+			}
+			assert.Equal(t, "14", c.AgeGroup)
+			require.NotNil(t, c.StandardTimeMS)
+			assert.Equal(t, 28770, *c.StandardTimeMS)
+			require.NotNil(t, c.PrevAgeGroup)
+			assert.Equal(t, "13", *c.PrevAgeGroup)
+			require.NotNil(t, c.NextAgeGroup)
+			assert.Equal(t, "15", *c.NextAgeGroup)
+		}
+	})
+}
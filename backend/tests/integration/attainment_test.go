@@ -0,0 +1,192 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type StandardLevel struct {
+	StandardID   string `json:"standard_id"`
+	StandardName string `json:"standard_name"`
+	AgeGroup     string `json:"age_group"`
+	TimeMS       int    `json:"time_ms"`
+	Achieved     bool   `json:"achieved"`
+}
+
+type NextStandard struct {
+	StandardLevel
+	GapMS      *int     `json:"gap_ms"`
+	GapPercent *float64 `json:"gap_percent"`
+}
+
+type EventAttainment struct {
+	Event           string          `json:"event"`
+	SwimmerTimeMS   *int            `json:"swimmer_time_ms"`
+	Standards       []StandardLevel `json:"standards"`
+	HighestAchieved *StandardLevel  `json:"highest_achieved"`
+	Next            *NextStandard   `json:"next"`
+}
+
+type AttainmentStandard struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	AgeGroup string `json:"age_group"`
+}
+
+type AttainmentMatrix struct {
+	CourseType string               `json:"course_type"`
+	Standards  []AttainmentStandard `json:"standards"`
+	Events     []EventAttainment    `json:"events"`
+}
+
+func TestAttainmentAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	rr := client.Put("/api/v1/swimmer", SwimmerInput{
+		Name:      "Attainment Swimmer",
+		BirthDate: "2012-05-15",
+		Gender:    "female",
+	})
+	require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK, rr.Body.String())
+	var swimmer Swimmer
+	AssertJSONBody(t, rr, &swimmer)
+
+	rr = client.Post("/api/v1/meets", MeetInput{
+		Name:       "Attainment Meet",
+		City:       "Toronto",
+		Country:    "Canada",
+		StartDate:  "2025-03-01",
+		EndDate:    "2025-03-01",
+		CourseType: "25m",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var meet Meet
+	AssertJSONBody(t, rr, &meet)
+
+	rr = client.Post("/api/v1/times", TimeInput{MeetID: meet.ID, Event: "100FR", TimeMS: 70000, EventDate: "2025-03-01"})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	rr = client.Post("/api/v1/times", TimeInput{MeetID: meet.ID, Event: "50BK", TimeMS: 40000, EventDate: "2025-03-01"})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	importStandard := func(t *testing.T, input StandardImportInput) string {
+		t.Helper()
+		rr := client.Post("/api/v1/standards/import", input)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var std StandardWithTimes
+		AssertJSONBody(t, rr, &std)
+		return std.ID
+	}
+
+	// Four tiers of 100FR times, plus a standard of another course and gender
+	regional := importStandard(t, StandardImportInput{
+		Name: "Attainment Regional", CourseType: "25m", Gender: "female",
+		Times: []StandardTimeInput{
+			{Event: "100FR", AgeGroup: "OPEN", TimeMs: 75000},
+			{Event: "50BK", AgeGroup: "OPEN", TimeMs: 38000},
+		},
+	})
+	provincial := importStandard(t, StandardImportInput{
+		Name: "Attainment Provincial", CourseType: "25m", Gender: "female",
+		Times: []StandardTimeInput{{Event: "100FR", AgeGroup: "OPEN", TimeMs: 71000}},
+	})
+	national := importStandard(t, StandardImportInput{
+		Name: "Attainment National", CourseType: "25m", Gender: "female",
+		Times: []StandardTimeInput{
+			{Event: "100FR", AgeGroup: "OPEN", TimeMs: 66000},
+			{Event: "200FR", AgeGroup: "OPEN", TimeMs: 140000},
+		},
+	})
+	elite := importStandard(t, StandardImportInput{
+		Name: "Attainment Elite", CourseType: "25m", Gender: "female",
+		Times: []StandardTimeInput{{Event: "100FR", AgeGroup: "OPEN", TimeMs: 62000}},
+	})
+	importStandard(t, StandardImportInput{
+		Name: "Attainment Long Course", CourseType: "50m", Gender: "female",
+		Times: []StandardTimeInput{{Event: "100FR", AgeGroup: "OPEN", TimeMs: 60000}},
+	})
+	importStandard(t, StandardImportInput{
+		Name: "Attainment Boys", CourseType: "25m", Gender: "male",
+		Times: []StandardTimeInput{{Event: "100FR", AgeGroup: "OPEN", TimeMs: 80000}},
+	})
+
+	findEvent := func(t *testing.T, m AttainmentMatrix, event string) EventAttainment {
+		t.Helper()
+		for _, e := range m.Events {
+			if e.Event == event {
+				return e
+			}
+		}
+		t.Fatalf("event %s not found", event)
+		return EventAttainment{}
+	}
+
+	t.Run("GET /attainment evaluates every standard of the course", func(t *testing.T) {
+		rr := client.Get("/api/v1/attainment?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var matrix AttainmentMatrix
+		AssertJSONBody(t, rr, &matrix)
+		assert.Equal(t, "25m", matrix.CourseType)
+		assert.Len(t, matrix.Standards, 4, "only standards of the course and gender")
+
+		fr := findEvent(t, matrix, "100FR")
+		require.NotNil(t, fr.SwimmerTimeMS)
+		assert.Len(t, fr.Standards, 4)
+
+		require.NotNil(t, fr.HighestAchieved)
+		assert.Equal(t, provincial, fr.HighestAchieved.StandardID)
+		assert.Equal(t, "OPEN", fr.HighestAchieved.AgeGroup)
+
+		require.NotNil(t, fr.Next)
+		assert.Equal(t, national, fr.Next.StandardID)
+		assert.NotEqual(t, elite, fr.Next.StandardID)
+		require.NotNil(t, fr.Next.GapMS)
+		assert.Equal(t, 4000, *fr.Next.GapMS)
+		require.NotNil(t, fr.Next.GapPercent)
+		assert.InDelta(t, 6.06, *fr.Next.GapPercent, 0.01)
+
+		bk := findEvent(t, matrix, "50BK")
+		assert.Nil(t, bk.HighestAchieved)
+		require.NotNil(t, bk.Next)
+		assert.Equal(t, regional, bk.Next.StandardID)
+		require.NotNil(t, bk.Next.GapMS)
+		assert.Equal(t, 2000, *bk.Next.GapMS)
+	})
+
+	t.Run("GET /attainment lists events without a swim", func(t *testing.T) {
+		rr := client.Get("/api/v1/swimmers/" + swimmer.ID + "/attainment?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var matrix AttainmentMatrix
+		AssertJSONBody(t, rr, &matrix)
+		assert.Len(t, matrix.Events, 3, "only events with a swim or a standard time")
+
+		fr := findEvent(t, matrix, "200FR")
+		assert.Nil(t, fr.SwimmerTimeMS)
+		assert.Nil(t, fr.HighestAchieved)
+		require.NotNil(t, fr.Next)
+		assert.Equal(t, national, fr.Next.StandardID)
+		assert.Nil(t, fr.Next.GapMS)
+	})
+
+	t.Run("GET /attainment validates the course type", func(t *testing.T) {
+		rr := client.Get("/api/v1/attainment?course_type=10m")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
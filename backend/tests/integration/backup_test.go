@@ -0,0 +1,301 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type BackupInfo struct {
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	Size      int64  `json:"size"`
+}
+
+type BackupList struct {
+	Backups []BackupInfo `json:"backups"`
+	Total   int          `json:"total"`
+}
+
+func TestBackupAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	original := ImportData{
+		Swimmer: &SwimmerExport{Name: "Backup Swimmer", BirthDate: "2012-05-15", Gender: "female", ThresholdPercent: 3.0},
+		Meets: []MeetExport{{
+			Name: "Backup Meet", City: "Toronto", Country: "Canada",
+			StartDate: "2026-01-10", EndDate: "2026-01-11", CourseType: "25m",
+			Times: []TimeExport{
+				{Event: "50FR", Time: "30.10", EventDate: "2026-01-10"},
+				{Event: "100FR", Time: "1:05.20", EventDate: "2026-01-11"},
+			},
+		}},
+		Standards: []StandardExport{{
+			Name: "Backup Standard", CourseType: "25m", Gender: "female",
+			Times: map[string][]string{"50FR": {"10&U:32.00"}},
+		}},
+	}
+	rr := client.Post("/api/v1/data/import", ImportRequest{Data: original, Confirmed: true})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var backupName string
+
+	t.Run("POST /admin/backups takes a snapshot", func(t *testing.T) {
+		rr := client.Post("/api/v1/admin/backups", nil)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var info BackupInfo
+		AssertJSONBody(t, rr, &info)
+		assert.Regexp(t, `^swimstats-\d{8}T\d{6}\.\d{3}Z\.json\.gz$`, info.Name)
+		assert.Positive(t, info.Size)
+		backupName = info.Name
+
+		rr = client.Get("/api/v1/admin/backups")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var list BackupList
+		AssertJSONBody(t, rr, &list)
+		require.Equal(t, 1, list.Total)
+		assert.Equal(t, backupName, list.Backups[0].Name)
+	})
+
+	t.Run("retention keeps the newest snapshots", func(t *testing.T) {
+		for range 4 {
+			rr := client.Post("/api/v1/admin/backups", nil)
+			require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		}
+
+		rr := client.Get("/api/v1/admin/backups")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var list BackupList
+		AssertJSONBody(t, rr, &list)
+		assert.Equal(t, 3, list.Total)
+		assert.NotContains(t, []string{list.Backups[0].Name, list.Backups[1].Name, list.Backups[2].Name}, backupName)
+		backupName = list.Backups[0].Name
+	})
+
+	t.Run("POST /admin/backups/{name}/restore restores the snapshot", func(t *testing.T) {
+		changed := ImportData{
+			Swimmer: original.Swimmer,
+			Meets: []MeetExport{{
+				Name: "Other Meet", City: "Ottawa", Country: "Canada",
+				StartDate: "2026-02-07", EndDate: "2026-02-07", CourseType: "50m",
+				Times: []TimeExport{{Event: "50FR", Time: "31.00", EventDate: "2026-02-07"}},
+			}},
+		}
+		rr := client.Post("/api/v1/data/import", ImportRequest{Data: changed, Confirmed: true})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		// Restoring needs confirmation
+		rr = client.Post("/api/v1/admin/backups/"+backupName+"/restore", map[string]any{})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		// A dry run changes nothing
+		rr = client.Post("/api/v1/admin/backups/"+backupName+"/restore", map[string]any{"dry_run": true})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/data/export")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var export ExportData
+		AssertJSONBody(t, rr, &export)
+		require.Len(t, export.Meets, 1)
+		assert.Equal(t, "Other Meet", export.Meets[0].Name)
+
+		rr = client.Post("/api/v1/admin/backups/"+backupName+"/restore", map[string]any{"confirmed": true})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/data/export")
+		require.Equal(t, http.StatusOK, rr.Code)
+		export = ExportData{}
+		AssertJSONBody(t, rr, &export)
+		assert.Equal(t, "Backup Swimmer", export.Swimmer.Name)
+		require.Len(t, export.Meets, 1)
+		assert.Equal(t, "Backup Meet", export.Meets[0].Name)
+		assert.Len(t, export.Meets[0].Times, 2)
+		require.Len(t, export.Standards, 1)
+		assert.Equal(t, "Backup Standard", export.Standards[0].Name)
+	})
+
+	t.Run("restoring into an emptied database recreates schemes, ladders and settings", func(t *testing.T) {
+		twelve := 12
+		rr := client.Post("/api/v1/age-group-schemes", map[string]any{
+			"name":     "Backup Groups",
+			"age_rule": "meet_start",
+			"groups": []AgeRange{
+				{Code: "12U", MinAge: 0, MaxAge: &twelve},
+				{Code: "13O", MinAge: 13},
+			},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		rr = client.Post("/api/v1/standards/import/json", map[string]any{
+			"season":           "2025-2026",
+			"source":           "Backup Club",
+			"course_type":      "25m",
+			"gender":           "female",
+			"age_group_scheme": "Backup Groups",
+			"standards": map[string]any{
+				"BG": map[string]string{"name": "Backup Groups Standard"},
+			},
+			"times": map[string]any{
+				"50FR": map[string]any{"13O": map[string]string{"BG": "0:31.00"}},
+			},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var imported JSONImportResult
+		AssertJSONBody(t, rr, &imported)
+		require.Equal(t, 1, imported.Imported, imported.Errors)
+
+		rr = client.Post("/api/v1/standard-ladders", LadderInput{
+			Name: "Backup Ladder", CourseType: "25m", Gender: "female", Grading: true,
+			Rungs: []LadderRungInput{{StandardID: imported.Standards[0].ID, Label: "BG"}},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		rr = client.Put("/api/v1/points/base-times", PointsTable{
+			Year: 2026, CourseType: "25m", Gender: "female",
+			Times: []PointsBaseTime{{Event: "50FR", TimeMS: 23000}},
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = client.Put("/api/v1/conversions/factors", ConversionFactor{
+			FromCourse: "25y", ToCourse: "25m", Event: "200FR", Factor: 1.13,
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = client.Post("/api/v1/meets", MeetInput{
+			Name: "Empty Meet", City: "Toronto", StartDate: "2026-03-14", EndDate: "2026-03-14", CourseType: "25m",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		rr = client.Post("/api/v1/admin/backups", nil)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var info BackupInfo
+		AssertJSONBody(t, rr, &info)
+
+		testDB.CleanTables(t)
+		_, err := testDB.Pool.Exec(ctx, "DELETE FROM course_conversion_factors WHERE event = '200FR'")
+		require.NoError(t, err)
+
+		rr = client.Post("/api/v1/admin/backups/"+info.Name+"/restore", map[string]any{"confirmed": true})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/age-group-schemes")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var schemes AgeGroupSchemeList
+		AssertJSONBody(t, rr, &schemes)
+		var scheme *AgeGroupScheme
+		for i := range schemes.Schemes {
+			if schemes.Schemes[i].Name == "Backup Groups" {
+				scheme = &schemes.Schemes[i]
+			}
+		}
+		require.NotNil(t, scheme, "the custom scheme is restored")
+		assert.Equal(t, "meet_start", scheme.AgeRule)
+		assert.Len(t, scheme.Groups, 2)
+
+		rr = client.Get("/api/v1/standard-ladders")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var ladders LadderList
+		AssertJSONBody(t, rr, &ladders)
+		require.Len(t, ladders.Ladders, 1)
+		restored := ladders.Ladders[0]
+		assert.Equal(t, "Backup Ladder", restored.Name)
+		assert.True(t, restored.Grading)
+		require.Len(t, restored.Rungs, 1)
+		assert.Equal(t, "Backup Groups Standard", restored.Rungs[0].StandardName)
+
+		rr = client.Get("/api/v1/standards/" + restored.Rungs[0].StandardID)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var std SchemeStandard
+		AssertJSONBody(t, rr, &std)
+		assert.Equal(t, scheme.ID, std.AgeGroupSchemeID)
+
+		rr = client.Get("/api/v1/times?course_type=25m&event=50FR")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var times TimeList
+		AssertJSONBody(t, rr, &times)
+		require.Len(t, times.Times, 1)
+		assert.Equal(t, "BG", times.Times[0].Grade, "the restored ladder grades the restored swims")
+
+		rr = client.Get("/api/v1/points/base-times")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var tables PointsTableList
+		AssertJSONBody(t, rr, &tables)
+		require.Len(t, tables.Tables, 1)
+		assert.Equal(t, 2026, tables.Tables[0].Year)
+
+		rr = client.Get("/api/v1/conversions/factors")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var factors ConversionFactorList
+		AssertJSONBody(t, rr, &factors)
+		var factor *ConversionFactor
+		for i := range factors.Factors {
+			if factors.Factors[i].Event == "200FR" && factors.Factors[i].FromCourse == "25y" {
+				factor = &factors.Factors[i]
+			}
+		}
+		require.NotNil(t, factor, "the conversion factor is restored")
+		assert.InDelta(t, 1.13, factor.Factor, 0.0001)
+		defer client.Delete("/api/v1/conversions/factors/" + factor.ID)
+
+		rr = client.Get("/api/v1/meets")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var meets MeetList
+		AssertJSONBody(t, rr, &meets)
+		names := make([]string, 0, len(meets.Meets))
+		for _, m := range meets.Meets {
+			names = append(names, m.Name)
+		}
+		assert.ElementsMatch(t, []string{"Backup Meet", "Empty Meet"}, names)
+	})
+
+	t.Run("restoring an unknown snapshot returns 404", func(t *testing.T) {
+		rr := client.Post("/api/v1/admin/backups/swimstats-20000101T000000.000Z.json.gz/restore", map[string]any{"confirmed": true})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = client.Post("/api/v1/admin/backups/..%2Fsecrets/restore", map[string]any{"confirmed": true})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("backups require an operator", func(t *testing.T) {
+		client.SetMockUser("view_only")
+		defer client.SetMockUser("full")
+
+		rr := client.Get("/api/v1/admin/backups")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = client.Post("/api/v1/admin/backups", nil)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		// Full access to one's own data is not enough
+		client.SetMockUser("full")
+		client.SetMockEmail("other@swimstats.local")
+		defer client.SetMockEmail("test@swimstats.local")
+
+		rr = client.Get("/api/v1/admin/backups")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = client.Post("/api/v1/admin/backups", nil)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = client.Post("/api/v1/admin/backups/"+backupName+"/restore", map[string]any{"confirmed": true})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
@@ -0,0 +1,388 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type QualifyingWindow struct {
+	AgeGroup  string  `json:"age_group"`
+	StartDate string  `json:"start_date"`
+	EndDate   *string `json:"end_date"`
+}
+
+type AtSwimComparison struct {
+	Event            string            `json:"event"`
+	Status           string            `json:"status"`
+	AgeGroup         string            `json:"age_group"`
+	SwimmerTimeMS    *int              `json:"swimmer_time_ms"`
+	StandardTimeMS   *int              `json:"standard_time_ms"`
+	AchievedAtTime   *bool             `json:"achieved_at_time"`
+	SwimAge          *int              `json:"swim_age"`
+	QualifyingWindow *QualifyingWindow `json:"qualifying_window"`
+}
+
+type AtSwimComparisonResult struct {
+	Mode            string             `json:"mode"`
+	SwimmerAgeGroup string             `json:"swimmer_age_group"`
+	Comparisons     []AtSwimComparison `json:"comparisons"`
+	Summary         struct {
+		Achieved    int `json:"achieved"`
+		NotAchieved int `json:"not_achieved"`
+	} `json:"summary"`
+}
+
+func TestComparisonAtSwimMode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	// 14 this year (13-14), 12 two years ago (11-12)
+	year := time.Now().Year()
+	rr := client.Put("/api/v1/swimmer", SwimmerInput{
+		Name:      "Qualifier",
+		BirthDate: fmt.Sprintf("%d-06-15", year-14),
+		Gender:    "female",
+	})
+	require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK, rr.Body.String())
+
+	meetDate := fmt.Sprintf("%d-03-10", year-2)
+	rr = client.Post("/api/v1/meets", MeetInput{
+		Name:       "Age Group Championships",
+		City:       "Toronto",
+		Country:    "Canada",
+		StartDate:  meetDate,
+		EndDate:    meetDate,
+		CourseType: "25m",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var meet Meet
+	AssertJSONBody(t, rr, &meet)
+
+	rr = client.Post("/api/v1/times", TimeInput{
+		MeetID:    meet.ID,
+		Event:     "50FR",
+		TimeMS:    34000,
+		EventDate: meetDate,
+	})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	rr = client.Post("/api/v1/standards/import", StandardImportInput{
+		Name:       "Championship Standard",
+		CourseType: "25m",
+		Gender:     "female",
+		Times: []StandardTimeInput{
+			{Event: "50FR", AgeGroup: "11-12", TimeMs: 35000},
+			{Event: "50FR", AgeGroup: "13-14", TimeMs: 32000},
+		},
+	})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var std StandardWithTimes
+	AssertJSONBody(t, rr, &std)
+
+	find := func(t *testing.T, result AtSwimComparisonResult) AtSwimComparison {
+		t.Helper()
+		for _, c := range result.Comparisons {
+			if c.Event == "50FR" {
+				return c
+			}
+		}
+		t.Fatal("50FR comparison not found")
+		return AtSwimComparison{}
+	}
+
+	t.Run("current mode compares against the current age group", func(t *testing.T) {
+		rr := client.Get("/api/v1/comparisons?standard_id=" + std.ID + "&course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result AtSwimComparisonResult
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, "current", result.Mode)
+
+		free50 := find(t, result)
+		assert.Equal(t, "13-14", free50.AgeGroup)
+		assert.Equal(t, "not_achieved", free50.Status)
+		assert.Nil(t, free50.AchievedAtTime)
+	})
+
+	t.Run("at_swim mode compares against the age group on the meet date", func(t *testing.T) {
+		rr := client.Get("/api/v1/comparisons?standard_id=" + std.ID + "&course_type=25m&mode=at_swim")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result AtSwimComparisonResult
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, "at_swim", result.Mode)
+		assert.Equal(t, "13-14", result.SwimmerAgeGroup)
+		assert.Equal(t, 1, result.Summary.Achieved)
+
+		free50 := find(t, result)
+		assert.Equal(t, "achieved", free50.Status)
+		assert.Equal(t, "11-12", free50.AgeGroup)
+		require.NotNil(t, free50.StandardTimeMS)
+		assert.Equal(t, 35000, *free50.StandardTimeMS)
+		require.NotNil(t, free50.AchievedAtTime)
+		assert.True(t, *free50.AchievedAtTime)
+		require.NotNil(t, free50.SwimAge)
+		assert.Equal(t, 12, *free50.SwimAge)
+
+		require.NotNil(t, free50.QualifyingWindow)
+		assert.Equal(t, "11-12", free50.QualifyingWindow.AgeGroup)
+		assert.Equal(t, fmt.Sprintf("%d-01-01", year-3), free50.QualifyingWindow.StartDate)
+		require.NotNil(t, free50.QualifyingWindow.EndDate)
+		assert.Equal(t, fmt.Sprintf("%d-12-31", year-2), *free50.QualifyingWindow.EndDate)
+	})
+
+	t.Run("current mode on a past date compares against the age group then", func(t *testing.T) {
+		rr := client.Get("/api/v1/comparisons?standard_id=" + std.ID + "&course_type=25m&date=" + fmt.Sprintf("%d-09-01", year-2))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result AtSwimComparisonResult
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, "11-12", result.SwimmerAgeGroup)
+
+		free50 := find(t, result)
+		assert.Equal(t, "11-12", free50.AgeGroup)
+		assert.Equal(t, "achieved", free50.Status)
+		require.NotNil(t, free50.StandardTimeMS)
+		assert.Equal(t, 35000, *free50.StandardTimeMS)
+	})
+
+	t.Run("other owners' standards are not found", func(t *testing.T) {
+		client.SetMockEmail("other@swimstats.local")
+		defer client.SetMockEmail("test@swimstats.local")
+
+		rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "Other Qualifier", BirthDate: "2012-05-15", Gender: "female"})
+		require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK, rr.Body.String())
+
+		rr = client.Get("/api/v1/comparisons?standard_id=" + std.ID + "&course_type=25m")
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), "standard not found")
+	})
+
+	t.Run("GET /comparisons validates mode", func(t *testing.T) {
+		rr := client.Get("/api/v1/comparisons?standard_id=" + std.ID + "&mode=best")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+type QualifyingPeriod struct {
+	StartDate      *string `json:"start_date"`
+	EndDate        *string `json:"end_date"`
+	RequiredCourse *string `json:"required_course"`
+	SanctionedOnly bool    `json:"sanctioned_only"`
+	Closed         bool    `json:"closed"`
+}
+
+type WindowComparison struct {
+	Event         string `json:"event"`
+	Status        string `json:"status"`
+	SwimmerTimeMS *int   `json:"swimmer_time_ms"`
+	Qualification string `json:"qualification"`
+}
+
+type WindowComparisonResult struct {
+	QualifyingPeriod *QualifyingPeriod  `json:"qualifying_period"`
+	Comparisons      []WindowComparison `json:"comparisons"`
+}
+
+func TestComparisonQualifyingWindow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	rr := client.Put("/api/v1/swimmer", SwimmerInput{
+		Name:      "Window Swimmer",
+		BirthDate: "2000-06-15",
+		Gender:    "female",
+	})
+	require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK, rr.Body.String())
+
+	daysAgo := func(days int) string {
+		return time.Now().AddDate(0, 0, -days).Format("2006-01-02")
+	}
+
+	addSwims := func(t *testing.T, name, date string, sanctioned bool, times map[string]int) {
+		t.Helper()
+		rr := client.Post("/api/v1/meets", MeetInput{
+			Name:       name,
+			City:       "Toronto",
+			StartDate:  date,
+			CourseType: "25m",
+			Sanctioned: &sanctioned,
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var meet Meet
+		AssertJSONBody(t, rr, &meet)
+		assert.Equal(t, sanctioned, meet.Sanctioned)
+
+		for event, timeMS := range times {
+			rr := client.Post("/api/v1/times", TimeInput{
+				MeetID:    meet.ID,
+				Event:     event,
+				TimeMS:    timeMS,
+				EventDate: date,
+			})
+			require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		}
+	}
+
+	// The all-time best is outside the open window, the fastest swim in the
+	// window is at an unsanctioned time trial
+	addSwims(t, "Last Season Finals", daysAgo(200), true, map[string]int{"50FR": 30000})
+	addSwims(t, "Winter Invitational", daysAgo(30), true, map[string]int{"50FR": 33000, "100FR": 69000})
+	addSwims(t, "Club Time Trial", daysAgo(20), false, map[string]int{"50FR": 31000})
+
+	createStandard := func(t *testing.T, input StandardImportInput) StandardWithTimes {
+		t.Helper()
+		input.CourseType = "25m"
+		input.Gender = "female"
+		input.Times = []StandardTimeInput{
+			{Event: "50FR", AgeGroup: "OPEN", TimeMs: 32000},
+			{Event: "100FR", AgeGroup: "OPEN", TimeMs: 70000},
+		}
+		rr := client.Post("/api/v1/standards/import", input)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var std StandardWithTimes
+		AssertJSONBody(t, rr, &std)
+		return std
+	}
+
+	compare := func(t *testing.T, std StandardWithTimes) (WindowComparisonResult, map[string]WindowComparison) {
+		t.Helper()
+		rr := client.Get("/api/v1/comparisons?standard_id=" + std.ID + "&course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result WindowComparisonResult
+		AssertJSONBody(t, rr, &result)
+		byEvent := make(map[string]WindowComparison)
+		for _, c := range result.Comparisons {
+			byEvent[c.Event] = c
+		}
+		return result, byEvent
+	}
+
+	t.Run("only sanctioned swims inside an open window count", func(t *testing.T) {
+		std := createStandard(t, StandardImportInput{
+			Name:            "Provincials Current Season",
+			QualifyingStart: daysAgo(60),
+			QualifyingEnd:   daysAgo(-60),
+			SanctionedOnly:  true,
+		})
+		assert.Equal(t, daysAgo(60), std.QualifyingStart)
+		assert.True(t, std.SanctionedOnly)
+
+		result, byEvent := compare(t, std)
+		require.NotNil(t, result.QualifyingPeriod)
+		assert.False(t, result.QualifyingPeriod.Closed)
+		assert.True(t, result.QualifyingPeriod.SanctionedOnly)
+
+		free50 := byEvent["50FR"]
+		require.NotNil(t, free50.SwimmerTimeMS)
+		assert.Equal(t, 33000, *free50.SwimmerTimeMS)
+		assert.Equal(t, "not_achieved", free50.Status)
+		assert.Equal(t, "not_yet", free50.Qualification)
+
+		assert.Equal(t, "qualified", byEvent["100FR"].Qualification)
+		assert.Equal(t, "not_yet", byEvent["200FR"].Qualification)
+	})
+
+	t.Run("events not achieved in a past window are closed", func(t *testing.T) {
+		std := createStandard(t, StandardImportInput{
+			Name:            "Provincials Last Season",
+			QualifyingStart: daysAgo(400),
+			QualifyingEnd:   daysAgo(100),
+		})
+
+		result, byEvent := compare(t, std)
+		require.NotNil(t, result.QualifyingPeriod)
+		assert.True(t, result.QualifyingPeriod.Closed)
+
+		free50 := byEvent["50FR"]
+		require.NotNil(t, free50.SwimmerTimeMS)
+		assert.Equal(t, 30000, *free50.SwimmerTimeMS)
+		assert.Equal(t, "qualified", free50.Qualification)
+
+		assert.Equal(t, "no_time", byEvent["100FR"].Status)
+		assert.Equal(t, "window_closed", byEvent["100FR"].Qualification)
+
+		// The window was still open on a date before its end
+		rr := client.Get("/api/v1/comparisons?standard_id=" + std.ID + "&course_type=25m&date=" + daysAgo(150))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var past WindowComparisonResult
+		AssertJSONBody(t, rr, &past)
+		require.NotNil(t, past.QualifyingPeriod)
+		assert.False(t, past.QualifyingPeriod.Closed)
+	})
+
+	t.Run("swims in another course than the required one do not count", func(t *testing.T) {
+		std := createStandard(t, StandardImportInput{
+			Name:           "Long Course Only",
+			RequiredCourse: "50m",
+		})
+
+		_, byEvent := compare(t, std)
+		assert.Equal(t, "no_time", byEvent["50FR"].Status)
+		assert.Equal(t, "not_yet", byEvent["50FR"].Qualification)
+	})
+
+	t.Run("standards without rules report no qualification", func(t *testing.T) {
+		std := createStandard(t, StandardImportInput{Name: "Any Time Standard"})
+
+		result, byEvent := compare(t, std)
+		assert.Nil(t, result.QualifyingPeriod)
+		assert.Equal(t, "achieved", byEvent["50FR"].Status)
+		assert.Empty(t, byEvent["50FR"].Qualification)
+	})
+
+	t.Run("POST /standards/import validates the window", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import", StandardImportInput{
+			Name:            "Backwards Window",
+			CourseType:      "25m",
+			Gender:          "female",
+			QualifyingStart: daysAgo(10),
+			QualifyingEnd:   daysAgo(20),
+			Times:           []StandardTimeInput{{Event: "50FR", AgeGroup: "OPEN", TimeMs: 32000}},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = client.Post("/api/v1/standards/import", StandardImportInput{
+			Name:           "Bad Course",
+			CourseType:     "25m",
+			Gender:         "female",
+			RequiredCourse: "33m",
+			Times:          []StandardTimeInput{{Event: "50FR", AgeGroup: "OPEN", TimeMs: 32000}},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,265 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ConversionFactor struct {
+	ID         string  `json:"id"`
+	FromCourse string  `json:"from_course"`
+	ToCourse   string  `json:"to_course"`
+	Event      string  `json:"event,omitempty"`
+	Factor     float64 `json:"factor"`
+}
+
+type ConversionFactorList struct {
+	Factors []ConversionFactor `json:"factors"`
+}
+
+type Conversion struct {
+	Event           string  `json:"event"`
+	FromCourse      string  `json:"from_course"`
+	TimeMS          int     `json:"time_ms"`
+	ToCourse        string  `json:"to_course"`
+	ConvertedEvent  string  `json:"converted_event"`
+	ConvertedTimeMS int     `json:"converted_time_ms"`
+	Factor          float64 `json:"factor"`
+	Estimated       bool    `json:"estimated"`
+}
+
+type EventComparison struct {
+	Event          string  `json:"event"`
+	Status         string  `json:"status"`
+	SwimmerTimeMS  *int    `json:"swimmer_time_ms"`
+	Converted      bool    `json:"converted"`
+	ConvertedFrom  *string `json:"converted_from"`
+	OriginalEvent  *string `json:"original_event"`
+	OriginalTimeMS *int    `json:"original_time_ms"`
+}
+
+type ComparisonResult struct {
+	CourseType         string            `json:"course_type"`
+	StandardCourseType string            `json:"standard_course_type"`
+	Converted          bool              `json:"converted"`
+	Comparisons        []EventComparison `json:"comparisons"`
+}
+
+func TestConversionAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	swimmerInput := SwimmerInput{
+		Name:      "Yards Swimmer",
+		BirthDate: "2012-05-15",
+		Gender:    "female",
+	}
+	rr := client.Put("/api/v1/swimmer", swimmerInput)
+	require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK)
+
+	createMeet := func(t *testing.T, name, courseType string) string {
+		t.Helper()
+		rr := client.Post("/api/v1/meets", MeetInput{
+			Name:       name,
+			City:       "Seattle",
+			Country:    "USA",
+			StartDate:  "2026-01-15",
+			EndDate:    "2026-01-15",
+			CourseType: courseType,
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var meet Meet
+		AssertJSONBody(t, rr, &meet)
+		return meet.ID
+	}
+
+	yardsMeetID := createMeet(t, "Yards Invitational", "25y")
+
+	t.Run("POST /times accepts yard events at a 25y meet", func(t *testing.T) {
+		rr := client.Post("/api/v1/times", TimeInput{
+			MeetID:    yardsMeetID,
+			Event:     "500FR",
+			TimeMS:    300000,
+			EventDate: "2026-01-15",
+		})
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		rr = client.Post("/api/v1/times", TimeInput{
+			MeetID:    yardsMeetID,
+			Event:     "100FR",
+			TimeMS:    50000,
+			EventDate: "2026-01-15",
+		})
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+
+	t.Run("POST /times rejects events not swum in the meet's course", func(t *testing.T) {
+		metricMeetID := createMeet(t, "Metric Meet", "25m")
+
+		rr := client.Post("/api/v1/times", TimeInput{
+			MeetID:    metricMeetID,
+			Event:     "500FR",
+			TimeMS:    300000,
+			EventDate: "2026-01-15",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = client.Post("/api/v1/times", TimeInput{
+			MeetID:    yardsMeetID,
+			Event:     "400FR",
+			TimeMS:    300000,
+			EventDate: "2026-01-15",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("GET /conversions estimates the time in another course", func(t *testing.T) {
+		rr := client.Get("/api/v1/conversions?event=100FR&time_ms=50000&from=25y&to=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var conv Conversion
+		AssertJSONBody(t, rr, &conv)
+		assert.Equal(t, "100FR", conv.ConvertedEvent)
+		assert.Equal(t, 55500, conv.ConvertedTimeMS)
+		assert.True(t, conv.Estimated)
+
+		rr = client.Get("/api/v1/conversions?event=500FR&time_ms=300000&from=25y&to=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &conv)
+		assert.Equal(t, "400FR", conv.ConvertedEvent)
+		assert.Equal(t, 262500, conv.ConvertedTimeMS)
+	})
+
+	t.Run("GET /conversions validates input", func(t *testing.T) {
+		rr := client.Get("/api/v1/conversions?event=500FR&time_ms=300000&from=25m&to=50m")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = client.Get("/api/v1/conversions?event=100FR&time_ms=abc&from=25y&to=25m")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("PUT /conversions/factors sets a factor", func(t *testing.T) {
+		rr := client.Put("/api/v1/conversions/factors", ConversionFactor{
+			FromCourse: "25y",
+			ToCourse:   "25m",
+			Event:      "100FR",
+			Factor:     1.12,
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var factor ConversionFactor
+		AssertJSONBody(t, rr, &factor)
+		assert.Equal(t, 1.12, factor.Factor)
+		defer client.Delete("/api/v1/conversions/factors/" + factor.ID)
+
+		rr = client.Get("/api/v1/conversions?event=100FR&time_ms=50000&from=25y&to=25m")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var conv Conversion
+		AssertJSONBody(t, rr, &conv)
+		assert.Equal(t, 56000, conv.ConvertedTimeMS)
+
+		rr = client.Get("/api/v1/conversions/factors")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var list ConversionFactorList
+		AssertJSONBody(t, rr, &list)
+		assert.NotEmpty(t, list.Factors)
+	})
+
+	t.Run("changing factors requires an operator", func(t *testing.T) {
+		client.SetMockUser("view_only")
+		defer client.SetMockUser("full")
+
+		rr := client.Put("/api/v1/conversions/factors", ConversionFactor{
+			FromCourse: "25y",
+			ToCourse:   "25m",
+			Factor:     1.2,
+		})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		// Factors are shared, so full access to one's own data is not enough
+		client.SetMockUser("full")
+		client.SetMockEmail("other@swimstats.local")
+		defer client.SetMockEmail("test@swimstats.local")
+
+		rr = client.Put("/api/v1/conversions/factors", ConversionFactor{
+			FromCourse: "25y",
+			ToCourse:   "25m",
+			Factor:     1.2,
+		})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = client.Get("/api/v1/conversions/factors")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list ConversionFactorList
+		AssertJSONBody(t, rr, &list)
+		require.NotEmpty(t, list.Factors)
+
+		rr = client.Delete("/api/v1/conversions/factors/" + list.Factors[0].ID)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("GET /comparisons flags yards times converted to a metric standard", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import", StandardImportInput{
+			Name:       "Metric Conversion Standard",
+			CourseType: "25m",
+			Gender:     "female",
+			Times: []StandardTimeInput{
+				{Event: "100FR", AgeGroup: "OPEN", TimeMs: 56000},
+				{Event: "400FR", AgeGroup: "OPEN", TimeMs: 260000},
+			},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var std StandardWithTimes
+		AssertJSONBody(t, rr, &std)
+
+		rr = client.Get("/api/v1/comparisons?standard_id=" + std.ID + "&course_type=25y")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result ComparisonResult
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, "25y", result.CourseType)
+		assert.Equal(t, "25m", result.StandardCourseType)
+		assert.True(t, result.Converted)
+
+		byEvent := make(map[string]EventComparison)
+		for _, c := range result.Comparisons {
+			byEvent[c.Event] = c
+		}
+
+		free100 := byEvent["100FR"]
+		assert.True(t, free100.Converted)
+		require.NotNil(t, free100.SwimmerTimeMS)
+		assert.Equal(t, 55500, *free100.SwimmerTimeMS)
+		require.NotNil(t, free100.OriginalTimeMS)
+		assert.Equal(t, 50000, *free100.OriginalTimeMS)
+		assert.Equal(t, "achieved", free100.Status)
+
+		free400 := byEvent["400FR"]
+		assert.True(t, free400.Converted)
+		require.NotNil(t, free400.OriginalEvent)
+		assert.Equal(t, "500FR", *free400.OriginalEvent)
+		assert.Equal(t, "not_achieved", free400.Status)
+
+		_, hasYardEvent := byEvent["500FR"]
+		assert.False(t, hasYardEvent)
+	})
+}
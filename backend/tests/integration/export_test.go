@@ -1,7 +1,11 @@
 package integration
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/csv"
+	"io"
 	"net/http"
 	"testing"
 
@@ -37,6 +41,7 @@ type TimeExport struct {
 	Event     string `json:"event"`
 	Time      string `json:"time"`
 	EventDate string `json:"event_date"`
+	Status    string `json:"status,omitempty"`
 	Notes     string `json:"notes"`
 }
 
@@ -46,6 +51,9 @@ type StandardExport struct {
 	CourseType  string              `json:"course_type"`
 	Gender      string              `json:"gender"`
 	Times       map[string][]string `json:"times"`
+
+	QualifyingStart string `json:"qualifying_start,omitempty"`
+	SanctionedOnly  bool   `json:"sanctioned_only,omitempty"`
 }
 
 func TestExportAPI(t *testing.T) {
@@ -209,7 +217,7 @@ func TestExportAPI(t *testing.T) {
 		AssertJSONBody(t, rr, &exportData)
 
 		// Verify export has format version
-		assert.Equal(t, "1.0", exportData.FormatVersion)
+		assert.Equal(t, "1.1", exportData.FormatVersion)
 
 		// Verify export contains expected swimmer data including threshold
 		assert.Equal(t, "Round Trip Swimmer", exportData.Swimmer.Name)
@@ -305,3 +313,101 @@ func TestExportAPI(t *testing.T) {
 		}
 	})
 }
+
+func TestExportFormats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "Format Swimmer", BirthDate: "2012-05-15", Gender: "female"})
+	require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK)
+
+	for i, meetInput := range []MeetInput{
+		{Name: "Format Winter Meet", City: "Toronto", StartDate: "2026-01-15", EndDate: "2026-01-15", CourseType: "25m"},
+		{Name: "Format Spring Meet", City: "Ottawa", StartDate: "2026-03-20", EndDate: "2026-03-21", CourseType: "25m"},
+	} {
+		rr = client.Post("/api/v1/meets", meetInput)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var meet Meet
+		AssertJSONBody(t, rr, &meet)
+
+		times := []TimeInput{{MeetID: meet.ID, Event: "50FR", TimeMS: 30000 - i*500, EventDate: meetInput.StartDate}}
+		if i == 1 {
+			times = append(times, TimeInput{MeetID: meet.ID, Event: "100FR", TimeMS: 65000, EventDate: meetInput.EndDate, Status: "dq", DQCode: "SW 10.2"})
+		}
+		for _, timeInput := range times {
+			rr = client.Post("/api/v1/times", timeInput)
+			require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		}
+	}
+
+	t.Run("GET /data/export?format=csv returns one row per swim", func(t *testing.T) {
+		rr := client.Get("/api/v1/data/export?format=csv")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Header().Get("Content-Type"), "text/csv")
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "swimstats-export.csv")
+
+		records, err := csv.NewReader(rr.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		assert.Equal(t, []string{
+			"Date", "Meet", "City", "Course", "Event", "Time", "Seconds", "Status",
+			"PB", "Age", "Age Group", "Relay Leg", "Notes",
+		}, records[0])
+
+		assert.Equal(t, []string{"2026-01-15", "Format Winter Meet", "Toronto", "25m", "50FR", "30.00", "30"}, records[1][:7])
+		assert.Equal(t, "false", records[1][8], "a slower time is not a PB")
+		assert.Equal(t, "13-14", records[1][10])
+
+		assert.Equal(t, []string{"2026-03-20", "Format Spring Meet", "Ottawa", "25m", "50FR", "29.50", "29.5"}, records[2][:7])
+		assert.Equal(t, "true", records[2][8])
+
+		assert.Equal(t, "100FR", records[3][4])
+		assert.Equal(t, "dq", records[3][7])
+		assert.Equal(t, "false", records[3][8], "disqualified swims are never PBs")
+	})
+
+	t.Run("GET /data/export?format=xlsx returns a workbook", func(t *testing.T) {
+		rr := client.Get("/api/v1/data/export?format=xlsx")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Header().Get("Content-Type"), "spreadsheetml")
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "swimstats-export.xlsx")
+
+		zr, err := zip.NewReader(bytes.NewReader(rr.Body.Bytes()), int64(rr.Body.Len()))
+		require.NoError(t, err)
+		parts := make(map[string]string)
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			rc.Close()
+			parts[f.Name] = string(content)
+		}
+
+		require.Contains(t, parts, "xl/workbook.xml")
+		for _, name := range []string{"Meets", "Times", "Personal Bests", "Comparisons"} {
+			assert.Contains(t, parts["xl/workbook.xml"], `name="`+name+`"`)
+		}
+		require.Contains(t, parts, "xl/worksheets/sheet1.xml")
+		assert.Contains(t, parts["xl/worksheets/sheet1.xml"], "Format Spring Meet")
+		require.Contains(t, parts, "xl/worksheets/sheet3.xml")
+		assert.Contains(t, parts["xl/worksheets/sheet3.xml"], "29.50", "the PB sheet has the fastest 50FR")
+		assert.Contains(t, parts, "xl/worksheets/sheet4.xml")
+	})
+
+	t.Run("GET /data/export rejects unknown formats", func(t *testing.T) {
+		rr := client.Get("/api/v1/data/export?format=pdf")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,201 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ForecastTrend struct {
+	Model             string  `json:"model"`
+	Slope             float64 `json:"slope"`
+	RSquared          float64 `json:"r_squared"`
+	ConfidenceLevel   float64 `json:"confidence_level"`
+	CurrentEstimateMS int     `json:"current_estimate_ms"`
+}
+
+type ForecastProjection struct {
+	Date   string `json:"date"`
+	TimeMS int    `json:"time_ms"`
+	LowMS  int    `json:"low_ms"`
+	HighMS int    `json:"high_ms"`
+}
+
+type StandardForecast struct {
+	StandardID     string  `json:"standard_id"`
+	AgeGroup       string  `json:"age_group"`
+	StandardTimeMS int     `json:"standard_time_ms"`
+	GapMS          *int    `json:"gap_ms"`
+	Status         string  `json:"status"`
+	ETA            *string `json:"eta"`
+	ETAEarliest    *string `json:"eta_earliest"`
+	ETALatest      *string `json:"eta_latest"`
+}
+
+type Forecast struct {
+	Event          string               `json:"event"`
+	CourseType     string               `json:"course_type"`
+	Season         string               `json:"season"`
+	Swims          int                  `json:"swims"`
+	ThinHistory    bool                 `json:"thin_history"`
+	PersonalBestMS *int                 `json:"personal_best_ms"`
+	Trend          *ForecastTrend       `json:"trend"`
+	Projection     []ForecastProjection `json:"projection"`
+	Forecasts      []StandardForecast   `json:"forecasts"`
+}
+
+func TestForecastAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	rr := client.Put("/api/v1/swimmer", SwimmerInput{
+		Name:      "Forecast Swimmer",
+		BirthDate: "2012-05-15",
+		Gender:    "female",
+	})
+	require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK, rr.Body.String())
+
+	// Two years of steadily improving 100FR swims, ending a month ago
+	start := time.Now().AddDate(-2, -1, 0)
+	times := []int{80000, 77500, 76000, 74800, 74000, 73300, 72800, 72400, 72000}
+	for i, timeMS := range times {
+		date := start.AddDate(0, 3*i, 0).Format("2006-01-02")
+		rr := client.Post("/api/v1/meets", MeetInput{
+			Name:       fmt.Sprintf("Forecast Meet %d", i+1),
+			City:       "Toronto",
+			Country:    "Canada",
+			StartDate:  date,
+			EndDate:    date,
+			CourseType: "25m",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var meet Meet
+		AssertJSONBody(t, rr, &meet)
+
+		rr = client.Post("/api/v1/times", TimeInput{MeetID: meet.ID, Event: "100FR", TimeMS: timeMS, EventDate: date})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		if i >= len(times)-2 {
+			// Two recent 50FR swims are not enough for a trend
+			rr = client.Post("/api/v1/times", TimeInput{MeetID: meet.ID, Event: "50FR", TimeMS: 34000 - i*100, EventDate: date})
+			require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		}
+	}
+
+	rr = client.Post("/api/v1/standards/import", StandardImportInput{
+		Name:       "Forecast Standard",
+		CourseType: "25m",
+		Gender:     "female",
+		Times: []StandardTimeInput{
+			{Event: "100FR", AgeGroup: "OPEN", TimeMs: 71500},
+			{Event: "50FR", AgeGroup: "OPEN", TimeMs: 32000},
+		},
+	})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var target StandardWithTimes
+	AssertJSONBody(t, rr, &target)
+
+	rr = client.Post("/api/v1/standards/import", StandardImportInput{
+		Name:       "Forecast Achieved Standard",
+		CourseType: "25m",
+		Gender:     "female",
+		Times:      []StandardTimeInput{{Event: "100FR", AgeGroup: "OPEN", TimeMs: 75000}},
+	})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var achieved StandardWithTimes
+	AssertJSONBody(t, rr, &achieved)
+
+	findForecast := func(t *testing.T, f Forecast, standardID string) StandardForecast {
+		t.Helper()
+		for _, sf := range f.Forecasts {
+			if sf.StandardID == standardID {
+				return sf
+			}
+		}
+		t.Fatalf("forecast for standard %s not found", standardID)
+		return StandardForecast{}
+	}
+
+	t.Run("GET /forecast/{event} fits an improving trend", func(t *testing.T) {
+		rr := client.Get("/api/v1/forecast/100FR?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var forecast Forecast
+		AssertJSONBody(t, rr, &forecast)
+		assert.Equal(t, "100FR", forecast.Event)
+		assert.Equal(t, len(times), forecast.Swims)
+		assert.False(t, forecast.ThinHistory)
+		require.NotNil(t, forecast.PersonalBestMS)
+		assert.Equal(t, 72000, *forecast.PersonalBestMS)
+
+		require.NotNil(t, forecast.Trend)
+		assert.Equal(t, "logarithmic", forecast.Trend.Model)
+		assert.Less(t, forecast.Trend.Slope, 0.0)
+		assert.Greater(t, forecast.Trend.RSquared, 0.9)
+		assert.Less(t, forecast.Trend.CurrentEstimateMS, 73000)
+
+		require.NotEmpty(t, forecast.Projection)
+		for _, p := range forecast.Projection {
+			assert.LessOrEqual(t, p.LowMS, p.TimeMS)
+			assert.GreaterOrEqual(t, p.HighMS, p.TimeMS)
+		}
+
+		sf := findForecast(t, forecast, target.ID)
+		assert.Equal(t, "OPEN", sf.AgeGroup)
+		require.NotNil(t, sf.GapMS)
+		assert.Equal(t, 500, *sf.GapMS)
+		assert.Contains(t, []string{"projected", "beyond_horizon"}, sf.Status)
+		require.NotNil(t, sf.ETAEarliest, "the faster edge of the band reaches a close standard")
+		if sf.Status == "projected" {
+			require.NotNil(t, sf.ETA)
+			assert.LessOrEqual(t, *sf.ETAEarliest, *sf.ETA)
+			if sf.ETALatest != nil {
+				assert.LessOrEqual(t, *sf.ETA, *sf.ETALatest)
+			}
+		}
+
+		sf = findForecast(t, forecast, achieved.ID)
+		assert.Equal(t, "achieved", sf.Status)
+		assert.Nil(t, sf.ETA)
+	})
+
+	t.Run("GET /forecast/{event} flags thin history", func(t *testing.T) {
+		rr := client.Get("/api/v1/forecast/50FR?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var forecast Forecast
+		AssertJSONBody(t, rr, &forecast)
+		assert.Equal(t, 2, forecast.Swims)
+		assert.True(t, forecast.ThinHistory)
+		assert.Nil(t, forecast.Trend)
+		assert.Empty(t, forecast.Projection)
+
+		sf := findForecast(t, forecast, target.ID)
+		assert.Equal(t, "insufficient_data", sf.Status)
+	})
+
+	t.Run("GET /forecast/{event} validates parameters", func(t *testing.T) {
+		rr := client.Get("/api/v1/forecast/100FR")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = client.Get("/api/v1/forecast/99XX?course_type=25m")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
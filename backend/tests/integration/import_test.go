@@ -3,6 +3,7 @@ package integration
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -243,3 +244,810 @@ func TestImportAPI(t *testing.T) {
 		assert.Equal(t, "female", customStandard.Gender)
 	})
 }
+
+const lenexResults = `<?xml version="1.0" encoding="UTF-8"?>
+<LENEX version="3.0">
+  <MEETS>
+    <MEET name="Spring Open" city="Toronto" nation="CAN" course="LCM">
+      <SESSIONS>
+        <SESSION number="1" date="2026-03-14">
+          <EVENTS>
+            <EVENT eventid="1" number="1" gender="F"><SWIMSTYLE distance="100" relaycount="1" stroke="FREE"/></EVENT>
+            <EVENT eventid="2" number="2" gender="F"><SWIMSTYLE distance="100" relaycount="4" stroke="FREE"/></EVENT>
+          </EVENTS>
+        </SESSION>
+        <SESSION number="2" date="2026-03-15">
+          <EVENTS>
+            <EVENT eventid="3" number="3" gender="F"><SWIMSTYLE distance="200" relaycount="1" stroke="MEDLEY"/></EVENT>
+          </EVENTS>
+        </SESSION>
+      </SESSIONS>
+      <CLUBS>
+        <CLUB name="Test Club">
+          <ATHLETES>
+            <ATHLETE athleteid="1" firstname="Lenex" lastname="Swimmer" birthdate="2012-05-15" gender="F">
+              <RESULTS>
+                <RESULT resultid="1" eventid="1" swimtime="00:01:05.32"/>
+                <RESULT resultid="2" eventid="3" swimtime="00:02:40.01"/>
+              </RESULTS>
+            </ATHLETE>
+            <ATHLETE athleteid="2" firstname="Other" lastname="Athlete" birthdate="2011-01-01" gender="F">
+              <RESULTS>
+                <RESULT resultid="3" eventid="1" swimtime="00:01:01.00"/>
+              </RESULTS>
+            </ATHLETE>
+          </ATHLETES>
+        </CLUB>
+      </CLUBS>
+    </MEET>
+  </MEETS>
+</LENEX>`
+
+type ImportResult struct {
+	Success          bool     `json:"success"`
+	DryRun           bool     `json:"dry_run"`
+	MeetsDeleted     int      `json:"meets_deleted"`
+	MeetsCreated     int      `json:"meets_created"`
+	TimesCreated     int      `json:"times_created"`
+	StandardsCreated int      `json:"standards_created"`
+	Errors           []string `json:"errors"`
+}
+
+func TestImportTransactionAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "Atomic Swimmer", BirthDate: "2012-05-15", Gender: "female"})
+	require.Equal(t, http.StatusCreated, rr.Code)
+	rr = client.Post("/api/v1/meets", MeetInput{Name: "Kept Meet", City: "Toronto", StartDate: "2026-01-10", CourseType: "25m"})
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var kept Meet
+	AssertJSONBody(t, rr, &kept)
+	rr = client.Post("/api/v1/times", TimeInput{MeetID: kept.ID, Event: "50FR", TimeMS: 30000, EventDate: "2026-01-10"})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	newMeets := []MeetExport{
+		{
+			Name: "Imported Meet 1", City: "Ottawa", StartDate: "2026-02-07", EndDate: "2026-02-08", CourseType: "25m",
+			Times: []TimeExport{
+				{Event: "50FR", Time: "29.80", EventDate: "2026-02-07"},
+				{Event: "100FR", Time: "1:05.10", EventDate: "2026-02-08"},
+			},
+		},
+		{
+			Name: "Imported Meet 2", City: "Montreal", StartDate: "2026-03-14", EndDate: "2026-03-14", CourseType: "50m",
+			Times: []TimeExport{{Event: "50FR", Time: "30.40", EventDate: "2026-03-14"}},
+		},
+	}
+
+	assertUnchanged := func(t *testing.T) {
+		t.Helper()
+		rr := client.Get("/api/v1/meets")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var meets MeetList
+		AssertJSONBody(t, rr, &meets)
+		require.Len(t, meets.Meets, 1)
+		assert.Equal(t, "Kept Meet", meets.Meets[0].Name)
+
+		rr = client.Get("/api/v1/times")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var times TimeList
+		AssertJSONBody(t, rr, &times)
+		assert.Equal(t, 1, times.Total)
+	}
+
+	t.Run("an invalid meet fails the import before anything is deleted", func(t *testing.T) {
+		invalid := append([]MeetExport{}, newMeets...)
+		invalid = append(invalid, MeetExport{
+			Name: "Broken Meet", StartDate: "2026-04-01", EndDate: "2026-04-01", CourseType: "25m",
+			Times: []TimeExport{{Event: "75FR", Time: "45.00", EventDate: "2026-04-01"}},
+		})
+		rr := client.Post("/api/v1/data/import", ImportRequest{Data: ImportData{Meets: invalid}, Confirmed: true})
+		require.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		var result ImportResult
+		AssertJSONBody(t, rr, &result)
+		assert.False(t, result.Success)
+		require.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors[0], "Broken Meet")
+		assertUnchanged(t)
+	})
+
+	t.Run("a failure after meets were replaced rolls everything back", func(t *testing.T) {
+		rr := client.Post("/api/v1/data/import", ImportRequest{
+			Data: ImportData{
+				Meets: newMeets,
+				Standards: []StandardExport{{
+					Name: "Atomic Standard", CourseType: "25m", Gender: "female",
+					Times: map[string][]string{"50FR": {"99-100:30.00"}},
+				}},
+			},
+			Confirmed: true,
+		})
+		require.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		var result ImportResult
+		AssertJSONBody(t, rr, &result)
+		assert.False(t, result.Success)
+		assert.Zero(t, result.MeetsCreated)
+		assertUnchanged(t)
+	})
+
+	t.Run("a dry run reports the result of the import without keeping it", func(t *testing.T) {
+		request := map[string]any{
+			"data":    ImportData{Meets: newMeets},
+			"dry_run": true,
+		}
+		rr := client.Post("/api/v1/data/import", request)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var dryRun ImportResult
+		AssertJSONBody(t, rr, &dryRun)
+		assert.True(t, dryRun.Success)
+		assert.True(t, dryRun.DryRun)
+		assert.Equal(t, 1, dryRun.MeetsDeleted)
+		assert.Equal(t, 2, dryRun.MeetsCreated)
+		assert.Equal(t, 3, dryRun.TimesCreated)
+		assertUnchanged(t)
+
+		rr = client.Post("/api/v1/data/import", ImportRequest{Data: ImportData{Meets: newMeets}, Confirmed: true})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result ImportResult
+		AssertJSONBody(t, rr, &result)
+		assert.False(t, result.DryRun)
+		dryRun.DryRun = false
+		assert.Equal(t, dryRun, result, "the dry run predicts the import exactly")
+	})
+}
+
+type MergeCounts struct {
+	Matched     int `json:"matched"`
+	Inserted    int `json:"inserted"`
+	Updated     int `json:"updated"`
+	Conflicting int `json:"conflicting"`
+}
+
+type MergeImportResult struct {
+	Success      bool `json:"success"`
+	MeetsCreated int  `json:"meets_created"`
+	TimesCreated int  `json:"times_created"`
+	Merge        struct {
+		Meets     MergeCounts `json:"meets"`
+		Times     MergeCounts `json:"times"`
+		Standards MergeCounts `json:"standards"`
+		Conflicts []string    `json:"conflicts"`
+	} `json:"merge"`
+}
+
+func TestMergeImportAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "Merge Swimmer", BirthDate: "2012-05-15", Gender: "female"})
+	require.Equal(t, http.StatusCreated, rr.Code)
+	for _, m := range []MeetInput{
+		{Name: "Fall Open", City: "Toronto", StartDate: "2026-01-10", CourseType: "25m"},
+		{Name: "Untouched Meet", City: "Toronto", StartDate: "2025-12-06", CourseType: "25m"},
+	} {
+		rr = client.Post("/api/v1/meets", m)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var created Meet
+		AssertJSONBody(t, rr, &created)
+		rr = client.Post("/api/v1/times", TimeInput{MeetID: created.ID, Event: "50FR", TimeMS: 30000, EventDate: m.StartDate})
+		require.Equal(t, http.StatusCreated, rr.Code)
+	}
+
+	data := ImportData{
+		Meets: []MeetExport{
+			{
+				Name: "Fall Open", City: "Toronto", StartDate: "2026-01-10", EndDate: "2026-01-10", CourseType: "25m",
+				Times: []TimeExport{
+					{Event: "50FR", Time: "29.50", EventDate: "2026-01-10"},
+					{Event: "100FR", Time: "1:05.00", EventDate: "2026-01-10"},
+				},
+			},
+			{
+				Name: "Winter Invitational", City: "Ottawa", StartDate: "2026-02-07", EndDate: "2026-02-08", CourseType: "25m",
+				Times: []TimeExport{{Event: "50FR", Time: "29.80", EventDate: "2026-02-07"}},
+			},
+		},
+		Standards: []StandardExport{{
+			Name: "Merge Standard", CourseType: "25m", Gender: "female",
+			Times: map[string][]string{"50FR": {"13-14:30.00"}},
+		}},
+	}
+
+	merge := func(t *testing.T, update bool) MergeImportResult {
+		t.Helper()
+		rr := client.Post("/api/v1/data/import", map[string]any{
+			"data":      data,
+			"confirmed": true,
+			"mode":      "merge",
+			"update":    update,
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var result MergeImportResult
+		AssertJSONBody(t, rr, &result)
+		require.True(t, result.Success)
+		return result
+	}
+
+	timesByEvent := func(t *testing.T) map[string][]int {
+		t.Helper()
+		rr := client.Get("/api/v1/times")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var times TimeList
+		AssertJSONBody(t, rr, &times)
+		byEvent := make(map[string][]int)
+		for _, tr := range times.Times {
+			byEvent[tr.Event] = append(byEvent[tr.Event], tr.TimeMS)
+		}
+		return byEvent
+	}
+
+	t.Run("merge inserts new records and reports changed ones as conflicts", func(t *testing.T) {
+		result := merge(t, false)
+		assert.Equal(t, 1, result.MeetsCreated)
+		assert.Equal(t, 2, result.TimesCreated)
+		assert.Equal(t, MergeCounts{Matched: 1, Inserted: 1}, result.Merge.Meets)
+		assert.Equal(t, MergeCounts{Inserted: 2, Conflicting: 1}, result.Merge.Times)
+		assert.Equal(t, MergeCounts{Inserted: 1}, result.Merge.Standards)
+		require.Len(t, result.Merge.Conflicts, 1)
+		assert.Contains(t, result.Merge.Conflicts[0], "50FR at Fall Open")
+
+		times := timesByEvent(t)
+		assert.ElementsMatch(t, []int{30000, 30000, 29800}, times["50FR"], "conflicting times are left unchanged")
+		assert.Equal(t, []int{65000}, times["100FR"])
+	})
+
+	t.Run("merge with update updates changed records", func(t *testing.T) {
+		data.Standards[0].Times["50FR"] = []string{"13-14:29.00"}
+		data.Standards[0].Times["100FR"] = []string{"13-14:1:04.00"}
+
+		result := merge(t, true)
+		assert.Zero(t, result.MeetsCreated)
+		assert.Zero(t, result.TimesCreated)
+		assert.Equal(t, MergeCounts{Matched: 2}, result.Merge.Meets)
+		assert.Equal(t, MergeCounts{Matched: 2, Updated: 1}, result.Merge.Times)
+		assert.Equal(t, MergeCounts{Updated: 1}, result.Merge.Standards)
+		assert.Empty(t, result.Merge.Conflicts)
+
+		times := timesByEvent(t)
+		assert.ElementsMatch(t, []int{29500, 30000, 29800}, times["50FR"])
+	})
+
+	t.Run("merging the same data again matches everything and deletes nothing", func(t *testing.T) {
+		data.Meets = data.Meets[:1]
+		result := merge(t, false)
+		assert.Equal(t, MergeCounts{Matched: 1}, result.Merge.Meets)
+		assert.Equal(t, MergeCounts{Matched: 2}, result.Merge.Times)
+		assert.Equal(t, MergeCounts{Matched: 1}, result.Merge.Standards)
+		assert.Empty(t, result.Merge.Conflicts)
+
+		rr := client.Get("/api/v1/meets")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var meets MeetList
+		AssertJSONBody(t, rr, &meets)
+		assert.Len(t, meets.Meets, 3)
+		assert.Len(t, timesByEvent(t)["50FR"], 3)
+	})
+
+	t.Run("merge updates the description and qualifying rules of standards", func(t *testing.T) {
+		data.Standards[0].Description = "Merged description"
+		data.Standards[0].QualifyingStart = "2025-09-01"
+		data.Standards[0].SanctionedOnly = true
+
+		result := merge(t, false)
+		assert.Equal(t, MergeCounts{Conflicting: 1}, result.Merge.Standards)
+		require.Len(t, result.Merge.Conflicts, 1)
+		assert.Contains(t, result.Merge.Conflicts[0], "description, qualifying rules")
+
+		result = merge(t, true)
+		assert.Equal(t, MergeCounts{Updated: 1}, result.Merge.Standards)
+		assert.Empty(t, result.Merge.Conflicts)
+
+		rr := client.Get("/api/v1/standards")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list StandardList
+		AssertJSONBody(t, rr, &list)
+		var std Standard
+		for _, s := range list.Standards {
+			if s.Name == "Merge Standard" {
+				std = s
+			}
+		}
+		assert.Equal(t, "Merged description", std.Description)
+		assert.Equal(t, "2025-09-01", std.QualifyingStart)
+		assert.True(t, std.SanctionedOnly)
+	})
+}
+
+func TestDataFormatAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	t.Run("GET /data/schema lists the format versions", func(t *testing.T) {
+		rr := client.Get("/api/v1/data/schema")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var versions struct {
+			Current  string   `json:"current"`
+			Versions []string `json:"versions"`
+		}
+		AssertJSONBody(t, rr, &versions)
+		assert.Equal(t, "1.1", versions.Current)
+		assert.Equal(t, []string{"1.0", "1.1"}, versions.Versions)
+	})
+
+	t.Run("GET /data/schema/{version} returns the JSON Schema", func(t *testing.T) {
+		for _, version := range []string{"1.0", "1.1"} {
+			rr := client.Get("/api/v1/data/schema/" + version)
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "application/schema+json", rr.Header().Get("Content-Type"))
+
+			var schema map[string]any
+			AssertJSONBody(t, rr, &schema)
+			assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+			assert.Equal(t, "SwimStats data "+version, schema["title"])
+		}
+
+		rr := client.Get("/api/v1/data/schema/9.9")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("1.0 documents are upgraded on import", func(t *testing.T) {
+		rr := client.Post("/api/v1/data/import", map[string]any{
+			"data": map[string]any{
+				"format_version": "1.0",
+				"swimmer":        map[string]any{"name": "Legacy Swimmer", "birth_date": "2012-05-15", "gender": "female", "threshold_percent": 3.0},
+				"meets": []map[string]any{{
+					"name": "Legacy Meet", "city": "Toronto", "country": "Canada",
+					"start_date": "2019-11-02", "end_date": "2019-11-03", "course_type": "25m",
+					"times": []map[string]any{{"event": "50FR", "time": "35.10", "event_date": "2019-11-02", "notes": ""}},
+				}},
+				"standards": []map[string]any{{
+					"name": "Legacy Standard", "description": "", "course_type": "25m", "gender": "female",
+					"times": map[string][]string{"50FR": {"10&U:36.00"}},
+				}},
+			},
+			"confirmed": true,
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var result ImportResult
+		AssertJSONBody(t, rr, &result)
+		assert.True(t, result.Success)
+		assert.Equal(t, 1, result.MeetsCreated)
+		assert.Equal(t, 1, result.TimesCreated)
+		assert.Equal(t, 1, result.StandardsCreated)
+
+		rr = client.Get("/api/v1/meets")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var meets MeetList
+		AssertJSONBody(t, rr, &meets)
+		require.Len(t, meets.Meets, 1)
+		assert.True(t, meets.Meets[0].Sanctioned)
+
+		rr = client.Get("/api/v1/data/export")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var exported ExportData
+		AssertJSONBody(t, rr, &exported)
+		assert.Equal(t, "1.1", exported.FormatVersion)
+		require.Len(t, exported.Meets, 1)
+		assert.Len(t, exported.Meets[0].Times, 1)
+	})
+
+	t.Run("future and unknown versions are rejected", func(t *testing.T) {
+		rr := client.Post("/api/v1/data/import", map[string]any{
+			"data":    map[string]any{"format_version": "2.0", "meets": []any{}},
+			"dry_run": true,
+		})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "format version 2.0 is newer than the supported version 1.1")
+
+		rr = client.Post("/api/v1/data/import/preview", map[string]any{"format_version": "0.9"})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `unknown format version "0.9"`)
+	})
+}
+
+type LenexPreview struct {
+	Mode          string     `json:"mode"`
+	NewMeetsCount int        `json:"new_meets_count"`
+	NewTimesCount int        `json:"new_times_count"`
+	Data          ImportData `json:"data"`
+}
+
+func TestLenexImportAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	t.Run("Lenex preview extracts the swimmer's results and appends them", func(t *testing.T) {
+		testDB.CleanTables(t)
+
+		rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "Lenex Swimmer", BirthDate: "2012-05-15", Gender: "female"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		rr = client.Post("/api/v1/meets", MeetInput{Name: "Existing Meet", City: "Ottawa", StartDate: "2026-01-10", CourseType: "25m"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var existing Meet
+		AssertJSONBody(t, rr, &existing)
+		rr = client.Post("/api/v1/times", TimeInput{MeetID: existing.ID, Event: "50FR", TimeMS: 30000, EventDate: "2026-01-10"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		rr = client.PostRaw("/api/v1/data/import/preview?format=lenex", "application/xml", []byte(lenexResults))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var preview LenexPreview
+		AssertJSONBody(t, rr, &preview)
+		assert.Equal(t, "append", preview.Mode)
+		assert.Equal(t, 1, preview.NewMeetsCount)
+		assert.Equal(t, 2, preview.NewTimesCount)
+		require.Len(t, preview.Data.Meets, 1)
+		assert.Equal(t, "Spring Open", preview.Data.Meets[0].Name)
+		assert.Equal(t, "50m", preview.Data.Meets[0].CourseType)
+		assert.Equal(t, "2026-03-14", preview.Data.Meets[0].StartDate)
+		assert.Equal(t, "2026-03-15", preview.Data.Meets[0].EndDate)
+
+		rr = client.Post("/api/v1/data/import", map[string]interface{}{
+			"data":      preview.Data,
+			"confirmed": true,
+			"mode":      preview.Mode,
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		// Existing meet is kept alongside the imported one
+		rr = client.Get("/api/v1/meets")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var meets MeetList
+		AssertJSONBody(t, rr, &meets)
+		assert.Equal(t, 2, meets.Total)
+
+		rr = client.Get("/api/v1/times")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var times TimeList
+		AssertJSONBody(t, rr, &times)
+		assert.Equal(t, 3, times.Total)
+	})
+
+	t.Run("Lenex preview keeps the fastest official swim of an event", func(t *testing.T) {
+		testDB.CleanTables(t)
+
+		rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "Lenex Swimmer", BirthDate: "2012-05-15", Gender: "female"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		// Prelims and finals, in Windows-1252 as labelled ISO-8859-1 ("\x92" is a
+		// right single quote there, a control character in ISO-8859-1)
+		results := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>" + `
+<LENEX version="3.0">
+  <MEETS>
+    <MEET name="Swimmer` + "\x92" + `s Cup" city="Montr` + "\xe9" + `al" nation="CAN" course="LCM">
+      <SESSIONS>
+        <SESSION number="1" date="2026-03-14">
+          <EVENTS>
+            <EVENT eventid="1" number="1" gender="F" round="PRE"><SWIMSTYLE distance="100" relaycount="1" stroke="FREE"/></EVENT>
+            <EVENT eventid="2" number="2" gender="F" round="PRE"><SWIMSTYLE distance="200" relaycount="1" stroke="MEDLEY"/></EVENT>
+          </EVENTS>
+        </SESSION>
+        <SESSION number="2" date="2026-03-15">
+          <EVENTS>
+            <EVENT eventid="3" number="3" gender="F" round="FIN"><SWIMSTYLE distance="100" relaycount="1" stroke="FREE"/></EVENT>
+            <EVENT eventid="4" number="4" gender="F" round="FIN"><SWIMSTYLE distance="200" relaycount="1" stroke="MEDLEY"/></EVENT>
+          </EVENTS>
+        </SESSION>
+      </SESSIONS>
+      <CLUBS>
+        <CLUB name="Test Club">
+          <ATHLETES>
+            <ATHLETE athleteid="1" firstname="Lenex" lastname="Swimmer" birthdate="2012-05-15" gender="F">
+              <RESULTS>
+                <RESULT resultid="1" eventid="1" swimtime="00:01:05.32"/>
+                <RESULT resultid="2" eventid="3" swimtime="00:01:04.90"/>
+                <RESULT resultid="3" eventid="2" swimtime="00:02:40.01"/>
+                <RESULT resultid="4" eventid="4" swimtime="00:02:39.50" status="DSQ"/>
+              </RESULTS>
+            </ATHLETE>
+          </ATHLETES>
+        </CLUB>
+      </CLUBS>
+    </MEET>
+  </MEETS>
+</LENEX>`
+
+		rr = client.PostRaw("/api/v1/data/import/preview?format=lenex", "application/xml", []byte(results))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var preview LenexPreview
+		AssertJSONBody(t, rr, &preview)
+		require.Len(t, preview.Data.Meets, 1)
+		meet := preview.Data.Meets[0]
+		assert.Equal(t, "Swimmer\u2019s Cup", meet.Name)
+		assert.Equal(t, "Montr\u00e9al", meet.City)
+
+		times := make(map[string]TimeExport)
+		for _, tm := range meet.Times {
+			times[tm.Event] = tm
+		}
+		require.Len(t, times, 2)
+		assert.Equal(t, "1:04.90", times["100FR"].Time)
+		assert.Equal(t, "2026-03-15", times["100FR"].EventDate)
+		// The disqualified final does not replace the official prelim swim
+		assert.Equal(t, "2:40.01", times["200IM"].Time)
+		assert.Empty(t, times["200IM"].Status)
+	})
+
+	t.Run("Lenex preview rejects files without the swimmer", func(t *testing.T) {
+		testDB.CleanTables(t)
+
+		rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "Someone Else", BirthDate: "2010-02-02", Gender: "female"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		rr = client.PostRaw("/api/v1/data/import/preview?format=lenex", "application/xml", []byte(lenexResults))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+type CSVPreview struct {
+	LenexPreview
+	RowErrors []struct {
+		Row   int    `json:"row"`
+		Error string `json:"error"`
+	} `json:"row_errors"`
+}
+
+func TestCSVImportAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	t.Run("CSV preview groups rows into meets and reports invalid rows", func(t *testing.T) {
+		testDB.CleanTables(t)
+
+		rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "CSV Swimmer", BirthDate: "2012-05-15", Gender: "female"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		results := strings.Join([]string{
+			"Meet,City,Start Date,End Date,Course,Event,Date,Time,Status,Notes",
+			"Fall Classic,Toronto,2025-10-04,2025-10-05,SCM,50FR,2025-10-04,31.20,,",
+			"Fall Classic,Toronto,2025-10-04,2025-10-05,SCM,100 FR,2025-10-05,1:09.80,,final",
+			"Fall Classic,Toronto,2025-10-04,2025-10-05,SCM,100BK,2025-10-05,,dq,",
+			"Fall Classic,Toronto,2025-10-04,2025-10-05,SCM,50FR,2025-10-09,30.00,,",
+			"Spring Open,Ottawa,2026-03-14,2026-03-14,50m,50FR,2026-03-14,30.90,,",
+			"Spring Open,Ottawa,2026-03-14,2026-03-14,50m,25FR,2026-03-14,14.00,,",
+			"Spring Open,Ottawa,2026-03-14,2026-03-14,50m,100FR,2026-03-14,fast,,",
+			"Spring Open,Ottawa,2026-03-14,2026-03-14,pond,100FR,2026-03-14,1:08.00,,",
+		}, "\n")
+		rr = client.PostRaw("/api/v1/data/import/preview?format=csv", "text/csv", []byte(results))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var preview CSVPreview
+		AssertJSONBody(t, rr, &preview)
+		assert.Equal(t, "append", preview.Mode)
+		assert.Equal(t, 2, preview.NewMeetsCount)
+		assert.Equal(t, 4, preview.NewTimesCount)
+		require.Len(t, preview.Data.Meets, 2)
+		assert.Equal(t, "Fall Classic", preview.Data.Meets[0].Name)
+		assert.Equal(t, "25m", preview.Data.Meets[0].CourseType)
+		assert.Len(t, preview.Data.Meets[0].Times, 3)
+		assert.Equal(t, "Spring Open", preview.Data.Meets[1].Name)
+
+		rows := make(map[int]string)
+		for _, rowErr := range preview.RowErrors {
+			rows[rowErr.Row] = rowErr.Error
+		}
+		require.Len(t, rows, 4, preview.RowErrors)
+		assert.Contains(t, rows[5], "outside meet date range")
+		assert.Contains(t, rows[7], "25FR")
+		assert.Contains(t, rows[8], "time")
+		assert.Contains(t, rows[9], "course")
+
+		rr = client.Post("/api/v1/data/import", map[string]interface{}{
+			"data":      preview.Data,
+			"confirmed": true,
+			"mode":      preview.Mode,
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/times")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var times TimeList
+		AssertJSONBody(t, rr, &times)
+		assert.Equal(t, 4, times.Total)
+	})
+
+	t.Run("CSV export imports back", func(t *testing.T) {
+		rr := client.Get("/api/v1/data/export?format=csv")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		rr = client.PostRaw("/api/v1/data/import/preview?format=csv", "text/csv", rr.Body.Bytes())
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var preview CSVPreview
+		AssertJSONBody(t, rr, &preview)
+		assert.Empty(t, preview.RowErrors)
+		assert.Equal(t, 2, preview.NewMeetsCount)
+		assert.Equal(t, 4, preview.NewTimesCount)
+	})
+
+	t.Run("CSV preview rejects files without required columns", func(t *testing.T) {
+		rr := client.PostRaw("/api/v1/data/import/preview?format=csv", "text/csv", []byte("Meet,Course,Event\nFall Classic,25m,50FR\n"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+// sdifRecord builds a fixed-width SDIF record with values at 1-based positions.
+func sdifRecord(code string, fields map[int]string) string {
+	record := []byte(strings.Repeat(" ", 160))
+	copy(record, code)
+	for pos, value := range fields {
+		copy(record[pos-1:], value)
+	}
+	return string(record)
+}
+
+func TestSDIFImportAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	results := strings.Join([]string{
+		sdifRecord("A0", map[int]string{12: "3", 14: "Meet Results"}),
+		sdifRecord("B1", map[int]string{12: "Winter Invitational", 86: "Ottawa", 118: "CAN", 122: "02142026", 130: "02152026", 150: "S"}),
+		// 100 free: prelims 1:06.10, finals 1:05.32
+		sdifRecord("D0", map[int]string{12: "Swimmer, Sdif", 56: "05152012", 66: "F", 68: "100", 72: "1", 81: "02142026", 98: "1:06.10", 106: "S", 116: "1:05.32", 124: "S"}),
+		// 50 back: disqualified, recorded without a time
+		sdifRecord("D0", map[int]string{12: "Swimmer, Sdif", 56: "05152012", 66: "F", 68: "50", 72: "2", 81: "02152026", 116: "DQ", 124: "X"}),
+		// 200 IM
+		sdifRecord("D0", map[int]string{12: "Swimmer, Sdif", 56: "05152012", 66: "F", 68: "200", 72: "5", 81: "02152026", 116: "2:40.01", 124: "S"}),
+		// Another swimmer
+		sdifRecord("D0", map[int]string{12: "Other, Athlete", 56: "01012011", 66: "F", 68: "100", 72: "1", 81: "02142026", 116: "1:01.00", 124: "S"}),
+		sdifRecord("Z0", nil),
+	}, "\r\n")
+
+	t.Run("POST /data/import/sdif imports the swimmer's results", func(t *testing.T) {
+		testDB.CleanTables(t)
+
+		rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "Sdif Swimmer", BirthDate: "2012-05-15", Gender: "female"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		rr = client.PostRaw("/api/v1/data/import/sdif", "text/plain", []byte(results))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result struct {
+			MeetsCreated int      `json:"meets_created"`
+			TimesCreated int      `json:"times_created"`
+			SkippedTimes int      `json:"skipped_times"`
+			Warnings     []string `json:"warnings"`
+		}
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, 1, result.MeetsCreated)
+		assert.Equal(t, 3, result.TimesCreated)
+		assert.Empty(t, result.Warnings)
+
+		rr = client.Get("/api/v1/times")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var times TimeList
+		AssertJSONBody(t, rr, &times)
+		require.Equal(t, 3, times.Total)
+		for _, tr := range times.Times {
+			switch tr.Event {
+			case "100FR":
+				assert.Equal(t, 65320, tr.TimeMS)
+				assert.Equal(t, "ok", tr.Status)
+			case "50BK":
+				assert.Equal(t, 0, tr.TimeMS)
+				assert.Equal(t, "dq", tr.Status)
+			}
+		}
+
+		// Importing the same file again skips duplicate events
+		rr = client.PostRaw("/api/v1/data/import/sdif", "text/plain", []byte(results))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, 0, result.MeetsCreated)
+		assert.Equal(t, 0, result.TimesCreated)
+		assert.Equal(t, 3, result.SkippedTimes)
+	})
+
+	t.Run("POST /data/import/sdif rejects files without the swimmer", func(t *testing.T) {
+		testDB.CleanTables(t)
+
+		rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "Sdif Swimmer", BirthDate: "2010-01-01", Gender: "female"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		rr = client.PostRaw("/api/v1/data/import/sdif", "text/plain", []byte(results))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("POST /data/import/sdif imports all meets or none", func(t *testing.T) {
+		testDB.CleanTables(t)
+
+		rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "Sdif Swimmer", BirthDate: "2012-05-15", Gender: "female"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		// The second meet is in yards, where the 400 free is not swum
+		failing := strings.Join([]string{
+			sdifRecord("A0", map[int]string{12: "3", 14: "Meet Results"}),
+			sdifRecord("B1", map[int]string{12: "Winter Invitational", 86: "Ottawa", 118: "CAN", 122: "02142026", 130: "02152026", 150: "S"}),
+			sdifRecord("D0", map[int]string{12: "Swimmer, Sdif", 56: "05152012", 66: "F", 68: "100", 72: "1", 81: "02142026", 116: "1:05.32", 124: "S"}),
+			sdifRecord("B1", map[int]string{12: "Yards Classic", 86: "Buffalo", 118: "USA", 122: "03072026", 130: "03082026", 150: "Y"}),
+			sdifRecord("D0", map[int]string{12: "Swimmer, Sdif", 56: "05152012", 66: "F", 68: "400", 72: "1", 81: "03072026", 116: "4:50.00", 124: "Y"}),
+			sdifRecord("Z0", nil),
+		}, "\r\n")
+
+		rr = client.PostRaw("/api/v1/data/import/sdif", "text/plain", []byte(failing))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/meets")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var meets MeetList
+		AssertJSONBody(t, rr, &meets)
+		assert.Equal(t, 0, meets.Total)
+	})
+
+	t.Run("view-only access cannot import SDIF files", func(t *testing.T) {
+		client.SetMockUser("view_only")
+		defer client.SetMockUser("full")
+
+		rr := client.PostRaw("/api/v1/data/import/sdif", "text/plain", []byte(results))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
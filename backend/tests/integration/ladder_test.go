@@ -0,0 +1,351 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type LadderRungInput struct {
+	StandardID string `json:"standard_id"`
+	Label      string `json:"label,omitempty"`
+}
+
+type LadderInput struct {
+	Name       string            `json:"name"`
+	CourseType string            `json:"course_type"`
+	Gender     string            `json:"gender"`
+	Grading    bool              `json:"grading"`
+	Rungs      []LadderRungInput `json:"rungs"`
+}
+
+type LadderRung struct {
+	Position     int    `json:"position"`
+	StandardID   string `json:"standard_id"`
+	StandardName string `json:"standard_name"`
+	Label        string `json:"label"`
+}
+
+type Ladder struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	CourseType string       `json:"course_type"`
+	Gender     string       `json:"gender"`
+	Grading    bool         `json:"grading"`
+	Rungs      []LadderRung `json:"rungs"`
+}
+
+type LadderList struct {
+	Ladders []Ladder `json:"ladders"`
+}
+
+type RegradeResult struct {
+	Updated int `json:"updated"`
+}
+
+type EventLadderRung struct {
+	Position   int    `json:"position"`
+	Label      string `json:"label"`
+	StandardID string `json:"standard_id"`
+	TimeMS     int    `json:"time_ms"`
+	Achieved   bool   `json:"achieved"`
+}
+
+type NextLadderRung struct {
+	EventLadderRung
+	GapMS *int `json:"gap_ms"`
+}
+
+type EventLadder struct {
+	Event       string            `json:"event"`
+	Rungs       []EventLadderRung `json:"rungs"`
+	CurrentRung *EventLadderRung  `json:"current_rung"`
+	NextRung    *NextLadderRung   `json:"next_rung"`
+}
+
+type LadderComparison struct {
+	LadderID string        `json:"ladder_id"`
+	Events   []EventLadder `json:"events"`
+}
+
+func TestLadderAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	rr := client.Put("/api/v1/swimmer", SwimmerInput{
+		Name:      "Ladder Swimmer",
+		BirthDate: "2012-05-15",
+		Gender:    "female",
+	})
+	require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK, rr.Body.String())
+
+	createMeet := func(t *testing.T, name, date string) string {
+		t.Helper()
+		rr := client.Post("/api/v1/meets", MeetInput{
+			Name:       name,
+			City:       "Toronto",
+			Country:    "Canada",
+			StartDate:  date,
+			EndDate:    date,
+			CourseType: "25m",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var meet Meet
+		AssertJSONBody(t, rr, &meet)
+		return meet.ID
+	}
+
+	importStandard := func(t *testing.T, name, courseType string, timeMS int) string {
+		t.Helper()
+		rr := client.Post("/api/v1/standards/import", StandardImportInput{
+			Name:       name,
+			CourseType: courseType,
+			Gender:     "female",
+			Times:      []StandardTimeInput{{Event: "100FR", AgeGroup: "OPEN", TimeMs: timeMS}},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var std StandardWithTimes
+		AssertJSONBody(t, rr, &std)
+		return std.ID
+	}
+
+	b := importStandard(t, "Ladder B", "25m", 80000)
+	bb := importStandard(t, "Ladder BB", "25m", 75000)
+	a := importStandard(t, "Ladder A", "25m", 70000)
+	aa := importStandard(t, "Ladder AA", "25m", 65000)
+	longCourse := importStandard(t, "Ladder Long Course", "50m", 70000)
+
+	// A swim recorded before any grading ladder exists
+	rr = client.Post("/api/v1/times", TimeInput{MeetID: createMeet(t, "Ladder Meet 1", "2025-01-15"), Event: "100FR", TimeMS: 72000, EventDate: "2025-01-15"})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var first TimeRecord
+	AssertJSONBody(t, rr, &first)
+	assert.Empty(t, first.Grade)
+
+	motivational := LadderInput{
+		Name:       "Motivational",
+		CourseType: "25m",
+		Gender:     "female",
+		Grading:    true,
+		Rungs: []LadderRungInput{
+			{StandardID: b, Label: "B"},
+			{StandardID: bb, Label: "BB"},
+			{StandardID: a, Label: "A"},
+			{StandardID: aa, Label: "AA"},
+		},
+	}
+
+	var ladder Ladder
+	t.Run("POST /standard-ladders validates the ladder", func(t *testing.T) {
+		rr := client.Post("/api/v1/standard-ladders", LadderInput{Name: "Empty", CourseType: "25m", Gender: "female"})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = client.Post("/api/v1/standard-ladders", LadderInput{
+			Name: "Mixed Courses", CourseType: "25m", Gender: "female",
+			Rungs: []LadderRungInput{{StandardID: b}, {StandardID: longCourse}},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "rungs must be standards of the ladder's course")
+
+		rr = client.Post("/api/v1/standard-ladders", LadderInput{
+			Name: "Duplicate Rungs", CourseType: "25m", Gender: "female",
+			Rungs: []LadderRungInput{{StandardID: b}, {StandardID: b}},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("POST /standard-ladders creates a grading ladder and grades swims", func(t *testing.T) {
+		rr := client.Post("/api/v1/standard-ladders", motivational)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &ladder)
+		assert.True(t, ladder.Grading)
+		require.Len(t, ladder.Rungs, 4)
+		assert.Equal(t, 1, ladder.Rungs[0].Position)
+		assert.Equal(t, "B", ladder.Rungs[0].Label)
+		assert.Equal(t, "Ladder AA", ladder.Rungs[3].StandardName)
+
+		rr = client.Get("/api/v1/times/" + first.ID)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var record TimeRecord
+		AssertJSONBody(t, rr, &record)
+		assert.Equal(t, "BB", record.Grade, "existing swims are graded")
+
+		// Only one grading ladder per course and gender
+		rr = client.Post("/api/v1/standard-ladders", LadderInput{
+			Name: "Second Grading", CourseType: "25m", Gender: "female", Grading: true,
+			Rungs: []LadderRungInput{{StandardID: a}},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("new swims are graded when recorded", func(t *testing.T) {
+		meetID := createMeet(t, "Ladder Meet 2", "2025-03-01")
+		rr := client.Post("/api/v1/times", TimeInput{MeetID: meetID, Event: "100FR", TimeMS: 69000, EventDate: "2025-03-01"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var record TimeRecord
+		AssertJSONBody(t, rr, &record)
+		assert.Equal(t, "A", record.Grade)
+
+		// Unofficial results have no grade
+		rr = client.Post("/api/v1/times", TimeInput{
+			MeetID: meetID, Event: "200FR", TimeMS: 150000, EventDate: "2025-03-01",
+			Status: "dq", DQCode: "FR-3.1", DQReason: "False start",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &record)
+		assert.Empty(t, record.Grade)
+
+		rr = client.Get("/api/v1/times?course_type=25m&event=100FR")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var list TimeList
+		AssertJSONBody(t, rr, &list)
+		require.Len(t, list.Times, 2)
+		for _, tr := range list.Times {
+			assert.NotEmpty(t, tr.Grade, tr.ID)
+		}
+	})
+
+	t.Run("ladders are private to their owner", func(t *testing.T) {
+		client.SetMockEmail("other@swimstats.local")
+		defer client.SetMockEmail("test@swimstats.local")
+
+		rr := client.Put("/api/v1/swimmer", SwimmerInput{Name: "Other Ladder Swimmer", BirthDate: "2012-05-15", Gender: "female"})
+		require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK, rr.Body.String())
+
+		rr = client.Post("/api/v1/times", TimeInput{MeetID: createMeet(t, "Other Ladder Meet", "2025-03-01"), Event: "100FR", TimeMS: 60000, EventDate: "2025-03-01"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var record TimeRecord
+		AssertJSONBody(t, rr, &record)
+		assert.Empty(t, record.Grade, "other users' ladders do not grade the swim")
+
+		rr = client.Get("/api/v1/standard-ladders/" + ladder.ID)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		rr = client.Put("/api/v1/standard-ladders/"+ladder.ID, motivational)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		rr = client.Get("/api/v1/standard-ladders")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var list LadderList
+		AssertJSONBody(t, rr, &list)
+		assert.Empty(t, list.Ladders)
+
+		// Other users' standards are not rungs; the name is free per user
+		rr = client.Post("/api/v1/standard-ladders", LadderInput{
+			Name: "Motivational", CourseType: "25m", Gender: "female", Grading: true,
+			Rungs: []LadderRungInput{{StandardID: b, Label: "B"}},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "standard not found")
+
+		own := importStandard(t, "Other Ladder B", "25m", 80000)
+		rr = client.Post("/api/v1/standard-ladders", LadderInput{
+			Name: "Motivational", CourseType: "25m", Gender: "female", Grading: true,
+			Rungs: []LadderRungInput{{StandardID: own, Label: "B"}},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/times/" + record.ID)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &record)
+		assert.Equal(t, "B", record.Grade, "the user's own grading ladder grades the swim")
+	})
+
+	t.Run("GET /ladder-comparisons reports the current and next rung", func(t *testing.T) {
+		rr := client.Get("/api/v1/ladder-comparisons?ladder_id=" + ladder.ID)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var comparison LadderComparison
+		AssertJSONBody(t, rr, &comparison)
+		require.Len(t, comparison.Events, 1, "only events with a time on a rung")
+
+		fr := comparison.Events[0]
+		assert.Equal(t, "100FR", fr.Event)
+		assert.Len(t, fr.Rungs, 4)
+		require.NotNil(t, fr.CurrentRung)
+		assert.Equal(t, 3, fr.CurrentRung.Position)
+		assert.Equal(t, a, fr.CurrentRung.StandardID)
+		require.NotNil(t, fr.NextRung)
+		assert.Equal(t, "AA", fr.NextRung.Label)
+		require.NotNil(t, fr.NextRung.GapMS)
+		assert.Equal(t, 4000, *fr.NextRung.GapMS)
+
+		rr = client.Get("/api/v1/ladder-comparisons")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = client.Get("/api/v1/ladder-comparisons?ladder_id=00000000-0000-0000-0000-000000000001")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("regrading follows changes to the standards", func(t *testing.T) {
+		rr := client.Put("/api/v1/standards/"+aa+"/times", map[string]any{
+			"times": []StandardTimeInput{{Event: "100FR", AgeGroup: "OPEN", TimeMs: 71000}},
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/times?course_type=25m&event=100FR")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var list TimeList
+		AssertJSONBody(t, rr, &list)
+		for _, tr := range list.Times {
+			if tr.TimeMS == 69000 {
+				assert.Equal(t, "AA", tr.Grade)
+			}
+		}
+
+		// The swims were regraded with the new times
+		rr = client.Post("/api/v1/standard-ladders/"+ladder.ID+"/regrade", nil)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var result RegradeResult
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, 0, result.Updated)
+	})
+
+	t.Run("grades are cleared when the ladder stops grading", func(t *testing.T) {
+		update := motivational
+		update.Grading = false
+		rr := client.Put("/api/v1/standard-ladders/"+ladder.ID, update)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/times/" + first.ID)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var record TimeRecord
+		AssertJSONBody(t, rr, &record)
+		assert.Empty(t, record.Grade)
+
+		rr = client.Post("/api/v1/standard-ladders/"+ladder.ID+"/regrade", nil)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("ladders can be listed and deleted", func(t *testing.T) {
+		rr := client.Get("/api/v1/standard-ladders?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var list LadderList
+		AssertJSONBody(t, rr, &list)
+		require.Len(t, list.Ladders, 1)
+		assert.Len(t, list.Ladders[0].Rungs, 4)
+
+		rr = client.Get("/api/v1/standard-ladders?course_type=50m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &list)
+		assert.Empty(t, list.Ladders)
+
+		rr = client.Delete("/api/v1/standard-ladders/" + ladder.ID)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+
+		rr = client.Get("/api/v1/standard-ladders/" + ladder.ID)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -17,6 +17,7 @@ type MeetInput struct {
 	StartDate  string `json:"start_date"`
 	EndDate    string `json:"end_date,omitempty"`
 	CourseType string `json:"course_type"`
+	Sanctioned *bool  `json:"sanctioned,omitempty"`
 }
 
 type Meet struct {
@@ -27,6 +28,7 @@ type Meet struct {
 	StartDate  string `json:"start_date"`
 	EndDate    string `json:"end_date"`
 	CourseType string `json:"course_type"`
+	Sanctioned bool   `json:"sanctioned"`
 	TimeCount  int    `json:"time_count,omitempty"`
 }
 
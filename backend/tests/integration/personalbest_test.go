@@ -3,6 +3,7 @@ package integration
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,6 +17,8 @@ type PersonalBest struct {
 	TimeID        string `json:"time_id"`
 	MeetName      string `json:"meet"`
 	Date          string `json:"date"`
+	RelayEvent    string `json:"relay_event,omitempty"`
+	Points        *int   `json:"points,omitempty"`
 }
 
 type PersonalBestList struct {
@@ -216,6 +219,182 @@ func TestPersonalBestsAPI(t *testing.T) {
 		assert.True(t, found, "200FR PB not found in 50m results")
 	})
 
+	t.Run("GET /personal-bests counts relay lead-off legs", func(t *testing.T) {
+		meet1 := createMeet(t, "Relay Meet 1", "2026-03-07", "50m")
+		meet2 := createMeet(t, "Relay Meet 2", "2026-03-14", "50m")
+
+		// Lead-off leg of a medley relay is an official 100BK time
+		rr := client.Post("/api/v1/times", TimeInput{MeetID: meet1, Event: "4X100MR", TimeMS: 72500, EventDate: "2026-03-07", RelayLeg: 1})
+		require.Equal(t, http.StatusCreated, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+
+		var leadOff TimeRecord
+		AssertJSONBody(t, rr, &leadOff)
+		assert.Equal(t, 1, leadOff.RelayLeg)
+		assert.Equal(t, "BK", leadOff.RelayStroke)
+		assert.Equal(t, "100BK", leadOff.OfficialEvent)
+		assert.True(t, leadOff.IsPB)
+
+		// Other legs start on a relay takeover and do not count
+		rr = client.Post("/api/v1/times", TimeInput{MeetID: meet2, Event: "4X100FR", TimeMS: 58000, EventDate: "2026-03-14", RelayLeg: 2, RelayStroke: "FR"})
+		require.Equal(t, http.StatusCreated, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+
+		var secondLeg TimeRecord
+		AssertJSONBody(t, rr, &secondLeg)
+		assert.Empty(t, secondLeg.OfficialEvent)
+		assert.False(t, secondLeg.IsPB)
+
+		rr = client.Get("/api/v1/personal-bests?course_type=50m")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var pbs PersonalBestList
+		AssertJSONBody(t, rr, &pbs)
+
+		var pb100BK *PersonalBest
+		for i := range pbs.PersonalBests {
+			assert.NotEqual(t, "100FR", pbs.PersonalBests[i].Event, "non lead-off leg must not count")
+			assert.NotEqual(t, "4X100MR", pbs.PersonalBests[i].Event)
+			if pbs.PersonalBests[i].Event == "100BK" {
+				pb100BK = &pbs.PersonalBests[i]
+			}
+		}
+		require.NotNil(t, pb100BK, "lead-off leg should count as 100BK")
+		assert.Equal(t, 72500, pb100BK.TimeMS)
+		assert.Equal(t, "4X100MR", pb100BK.RelayEvent)
+	})
+
+	t.Run("POST /times validates relay legs", func(t *testing.T) {
+		meetID := createMeet(t, "Relay Validation Meet", "2026-03-21", "50m")
+
+		testCases := []struct {
+			name  string
+			input TimeInput
+		}{
+			{"relay without leg", TimeInput{Event: "4X50FR", TimeMS: 30000}},
+			{"leg out of range", TimeInput{Event: "4X50FR", TimeMS: 30000, RelayLeg: 5}},
+			{"stroke does not match medley leg", TimeInput{Event: "4X50MR", TimeMS: 30000, RelayLeg: 2, RelayStroke: "FL"}},
+			{"leg on individual event", TimeInput{Event: "50FR", TimeMS: 30000, RelayLeg: 1}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.input.MeetID = meetID
+				tc.input.EventDate = "2026-03-21"
+				rr := client.Post("/api/v1/times", tc.input)
+				assert.Equal(t, http.StatusBadRequest, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+			})
+		}
+	})
+
+	t.Run("GET /personal-bests excludes DQ, DNS, DNF and scratches", func(t *testing.T) {
+		meet1 := createMeet(t, "Status Meet 1", "2026-04-04", "25m")
+		meet2 := createMeet(t, "Status Meet 2", "2026-04-11", "25m")
+
+		rr := client.Post("/api/v1/times", TimeInput{MeetID: meet1, Event: "200FL", TimeMS: 150000, EventDate: "2026-04-04"})
+		require.Equal(t, http.StatusCreated, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+
+		// A faster disqualified swim is not an official time
+		rr = client.Post("/api/v1/times", TimeInput{
+			MeetID: meet2, Event: "200FL", TimeMS: 145000, EventDate: "2026-04-11",
+			Status: "dq", DQCode: "FL-7.4", DQReason: "Alternating kick",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+
+		var dq TimeRecord
+		AssertJSONBody(t, rr, &dq)
+		assert.Equal(t, "dq", dq.Status)
+		assert.Equal(t, "FL-7.4", dq.DQCode)
+		assert.Equal(t, "Alternating kick", dq.DQReason)
+		assert.False(t, dq.IsPB)
+
+		rr = client.Post("/api/v1/times/batch", map[string]interface{}{
+			"meet_id": meet2,
+			"times": []map[string]interface{}{
+				{"event": "100FL", "time_ms": 0, "event_date": "2026-04-11", "status": "dns"},
+				{"event": "200IM", "time_ms": 0, "event_date": "2026-04-11", "status": "scr"},
+				{"event": "400IM", "time_ms": 0, "event_date": "2026-04-11", "status": "dnf"},
+			},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+
+		var batch BatchResponse
+		AssertJSONBody(t, rr, &batch)
+		assert.Empty(t, batch.NewPBs)
+		for _, tr := range batch.Times {
+			assert.Equal(t, strings.ToUpper(tr.Status), tr.TimeFormatted)
+		}
+
+		rr = client.Get("/api/v1/personal-bests?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var pbs PersonalBestList
+		AssertJSONBody(t, rr, &pbs)
+		for _, pb := range pbs.PersonalBests {
+			assert.NotContains(t, []string{"100FL", "200IM", "400IM"}, pb.Event)
+			if pb.Event == "200FL" {
+				assert.Equal(t, 150000, pb.TimeMS)
+			}
+		}
+
+		rr = client.Get("/api/v1/progress/200FL?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var progress ProgressData
+		AssertJSONBody(t, rr, &progress)
+		require.Len(t, progress.DataPoints, 1)
+		assert.Equal(t, 150000, progress.DataPoints[0].TimeMS)
+
+		// The meet's event list still shows every result
+		rr = client.Get("/api/v1/times?meet_id=" + meet2)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var meetTimes TimeList
+		AssertJSONBody(t, rr, &meetTimes)
+		assert.Equal(t, 4, meetTimes.Total)
+
+		rr = client.Get("/api/v1/stats?start_date=2026-04-01&end_date=2026-04-30")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var stats struct {
+			Total        int `json:"total"`
+			Swims        int `json:"swims"`
+			Disqualified int `json:"disqualified"`
+			DidNotStart  int `json:"did_not_start"`
+			DidNotFinish int `json:"did_not_finish"`
+			Scratched    int `json:"scratched"`
+		}
+		AssertJSONBody(t, rr, &stats)
+		assert.Equal(t, 5, stats.Total)
+		assert.Equal(t, 1, stats.Swims)
+		assert.Equal(t, 1, stats.Disqualified)
+		assert.Equal(t, 1, stats.DidNotStart)
+		assert.Equal(t, 1, stats.DidNotFinish)
+		assert.Equal(t, 1, stats.Scratched)
+	})
+
+	t.Run("POST /times validates result status", func(t *testing.T) {
+		meetID := createMeet(t, "Status Validation Meet", "2026-04-18", "25m")
+
+		testCases := []struct {
+			name  string
+			input TimeInput
+		}{
+			{"unknown status", TimeInput{Event: "50FR", TimeMS: 30000, Status: "dsq"}},
+			{"official swim without time", TimeInput{Event: "50FR", Status: "ok"}},
+			{"DNS with time", TimeInput{Event: "50FR", TimeMS: 30000, Status: "dns"}},
+			{"DQ code on official swim", TimeInput{Event: "50FR", TimeMS: 30000, DQCode: "FR-1"}},
+			{"splits without time", TimeInput{Event: "50FR", Status: "dnf", Splits: []Split{{Distance: 25, TimeMS: 15000}}}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.input.MeetID = meetID
+				tc.input.EventDate = "2026-04-18"
+				rr := client.Post("/api/v1/times", tc.input)
+				assert.Equal(t, http.StatusBadRequest, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+			})
+		}
+	})
+
 	t.Run("GET /personal-bests requires authentication", func(t *testing.T) {
 		client.ClearMockUser()
 		rr := client.Get("/api/v1/personal-bests?course_type=25m")
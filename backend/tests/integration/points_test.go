@@ -0,0 +1,267 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type PointsBaseTime struct {
+	Event  string `json:"event"`
+	TimeMS int    `json:"time_ms"`
+}
+
+type PointsTable struct {
+	Year       int              `json:"year"`
+	CourseType string           `json:"course_type"`
+	Gender     string           `json:"gender"`
+	Times      []PointsBaseTime `json:"times"`
+}
+
+type PointsTableList struct {
+	Tables []PointsTable `json:"tables"`
+}
+
+type BestEvent struct {
+	Rank       int    `json:"rank"`
+	CourseType string `json:"course_type"`
+	Event      string `json:"event"`
+	TimeMS     int    `json:"time_ms"`
+	Points     *int   `json:"points"`
+}
+
+type BestEventList struct {
+	Events []BestEvent `json:"events"`
+}
+
+func TestPointsAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	t.Run("PUT /points/base-times validates the table", func(t *testing.T) {
+		rr := client.Put("/api/v1/points/base-times", PointsTable{
+			Year: 2025, CourseType: "50m", Gender: "female",
+			Times: []PointsBaseTime{{Event: "4X100FR", TimeMS: 210000}},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "relays have no individual base time")
+
+		rr = client.Put("/api/v1/points/base-times", PointsTable{
+			Year: 2025, CourseType: "50m", Gender: "mixed",
+			Times: []PointsBaseTime{{Event: "50FR", TimeMS: 23610}},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	// Base times of two years; swims use the latest year not after the swim
+	rr := client.Put("/api/v1/points/base-times", PointsTable{
+		Year: 2020, CourseType: "50m", Gender: "female",
+		Times: []PointsBaseTime{{Event: "50FR", TimeMS: 24000}},
+	})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	rr = client.Put("/api/v1/points/base-times", PointsTable{
+		Year: 2025, CourseType: "50m", Gender: "female",
+		Times: []PointsBaseTime{
+			{Event: "50FR", TimeMS: 23610},
+			{Event: "100FR", TimeMS: 51710},
+		},
+	})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	rr = client.Put("/api/v1/swimmer", SwimmerInput{
+		Name:      "Points Swimmer",
+		BirthDate: "2010-03-01",
+		Gender:    "female",
+	})
+	require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK, rr.Body.String())
+
+	createMeet := func(t *testing.T, name, date string) string {
+		t.Helper()
+		rr := client.Post("/api/v1/meets", MeetInput{
+			Name:       name,
+			City:       "Toronto",
+			Country:    "Canada",
+			StartDate:  date,
+			EndDate:    date,
+			CourseType: "50m",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var meet Meet
+		AssertJSONBody(t, rr, &meet)
+		return meet.ID
+	}
+
+	meet2024 := createMeet(t, "Points Meet 2024", "2024-06-01")
+	meet2025 := createMeet(t, "Points Meet 2025", "2025-06-01")
+
+	t.Run("times have points from the base times of their year", func(t *testing.T) {
+		rr := client.Post("/api/v1/times", TimeInput{MeetID: meet2024, Event: "50FR", TimeMS: 29000, EventDate: "2024-06-01"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var record TimeRecord
+		AssertJSONBody(t, rr, &record)
+		require.NotNil(t, record.Points)
+		assert.Equal(t, 566, *record.Points, "1000 * (24.00 / 29.00)^3 with the 2020 base time")
+
+		rr = client.Post("/api/v1/times", TimeInput{MeetID: meet2025, Event: "50FR", TimeMS: 28500, EventDate: "2025-06-01"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &record)
+		require.NotNil(t, record.Points)
+		assert.Equal(t, 568, *record.Points, "1000 * (23.61 / 28.50)^3 with the 2025 base time")
+
+		rr = client.Post("/api/v1/times", TimeInput{MeetID: meet2025, Event: "100FR", TimeMS: 63000, EventDate: "2025-06-01"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &record)
+		require.NotNil(t, record.Points)
+		assert.Equal(t, 552, *record.Points)
+
+		// No base time for the event
+		rr = client.Post("/api/v1/times", TimeInput{MeetID: meet2025, Event: "200FL", TimeMS: 170000, EventDate: "2025-06-01"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &record)
+		assert.Nil(t, record.Points)
+
+		// Unofficial results have no points
+		rr = client.Post("/api/v1/times", TimeInput{
+			MeetID: meet2024, Event: "100FR", TimeMS: 62000, EventDate: "2024-06-01",
+			Status: "dq", DQCode: "FR-3.1", DQReason: "False start",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &record)
+		assert.Nil(t, record.Points)
+
+		rr = client.Get("/api/v1/times?course_type=50m&event=50FR")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var list TimeList
+		AssertJSONBody(t, rr, &list)
+		require.Len(t, list.Times, 2)
+		for _, tr := range list.Times {
+			assert.NotNil(t, tr.Points, tr.ID)
+		}
+	})
+
+	t.Run("personal bests and progress have points", func(t *testing.T) {
+		rr := client.Get("/api/v1/personal-bests?course_type=50m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var pbs PersonalBestList
+		AssertJSONBody(t, rr, &pbs)
+		for _, pb := range pbs.PersonalBests {
+			switch pb.Event {
+			case "50FR":
+				require.NotNil(t, pb.Points)
+				assert.Equal(t, 568, *pb.Points)
+			case "200FL":
+				assert.Nil(t, pb.Points)
+			}
+		}
+
+		rr = client.Get("/api/v1/progress/50FR?course_type=50m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var progress ProgressData
+		AssertJSONBody(t, rr, &progress)
+		require.Len(t, progress.DataPoints, 2)
+		for _, p := range progress.DataPoints {
+			assert.NotNil(t, p.Points, p.Date)
+		}
+	})
+
+	t.Run("GET /best-events ranks personal bests by points", func(t *testing.T) {
+		rr := client.Get("/api/v1/best-events")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var ranking BestEventList
+		AssertJSONBody(t, rr, &ranking)
+		require.Len(t, ranking.Events, 2, "events without base times are left out")
+		assert.Equal(t, 1, ranking.Events[0].Rank)
+		assert.Equal(t, "50FR", ranking.Events[0].Event)
+		assert.Equal(t, "50m", ranking.Events[0].CourseType)
+		assert.Equal(t, 2, ranking.Events[1].Rank)
+		assert.Equal(t, "100FR", ranking.Events[1].Event)
+
+		rr = client.Get("/api/v1/best-events?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &ranking)
+		assert.Empty(t, ranking.Events)
+
+		rr = client.Get("/api/v1/best-events?course_type=10m")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("base time tables can be listed, replaced and deleted", func(t *testing.T) {
+		rr := client.Get("/api/v1/points/base-times")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var list PointsTableList
+		AssertJSONBody(t, rr, &list)
+		require.Len(t, list.Tables, 2)
+		assert.Equal(t, 2025, list.Tables[0].Year, "most recent first")
+		assert.Len(t, list.Tables[0].Times, 2)
+
+		// Replacing a table removes the events left out
+		rr = client.Put("/api/v1/points/base-times", PointsTable{
+			Year: 2025, CourseType: "50m", Gender: "female",
+			Times: []PointsBaseTime{{Event: "50FR", TimeMS: 23610}},
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var table PointsTable
+		AssertJSONBody(t, rr, &table)
+		assert.Len(t, table.Times, 1)
+
+		rr = client.Delete("/api/v1/points/base-times/2020/50m/female")
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+
+		rr = client.Delete("/api/v1/points/base-times/2020/50m/female")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		// The 2024 swim now uses the earliest table left
+		rr = client.Get("/api/v1/times?course_type=50m&event=50FR")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var times TimeList
+		AssertJSONBody(t, rr, &times)
+		for _, tr := range times.Times {
+			require.NotNil(t, tr.Points)
+			if tr.TimeMS == 29000 {
+				assert.Equal(t, 539, *tr.Points)
+			}
+		}
+	})
+
+	t.Run("changing base times requires an operator", func(t *testing.T) {
+		// Base times are shared, so full access to one's own data is not enough
+		client.SetMockEmail("other@swimstats.local")
+		defer client.SetMockEmail("test@swimstats.local")
+
+		rr := client.Put("/api/v1/points/base-times", PointsTable{
+			Year: 2025, CourseType: "50m", Gender: "female",
+			Times: []PointsBaseTime{{Event: "50FR", TimeMS: 20000}},
+		})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = client.Delete("/api/v1/points/base-times/2025/50m/female")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = client.Get("/api/v1/points/base-times")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var list PointsTableList
+		AssertJSONBody(t, rr, &list)
+		require.NotEmpty(t, list.Tables)
+		assert.Equal(t, 23610, list.Tables[0].Times[0].TimeMS)
+	})
+}
@@ -17,6 +17,7 @@ type ProgressDataPoint struct {
 	MeetName       string `json:"meet_name"`
 	Event          string `json:"event"`
 	IsPersonalBest bool   `json:"is_pb"`
+	Points         *int   `json:"points,omitempty"`
 }
 
 type ProgressData struct {
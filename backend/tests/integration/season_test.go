@@ -0,0 +1,278 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type SeasonInfo struct {
+	Season    string `json:"season"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Current   bool   `json:"current"`
+	Meets     int    `json:"meets"`
+	Swims     int    `json:"swims"`
+}
+
+type SeasonList struct {
+	SeasonStart string       `json:"season_start"`
+	Seasons     []SeasonInfo `json:"seasons"`
+}
+
+type SeasonBest struct {
+	Event                string   `json:"event"`
+	TimeMS               int      `json:"time_ms"`
+	IsPersonalBest       bool     `json:"is_personal_best"`
+	PreviousSeasonTimeMS *int     `json:"previous_season_time_ms"`
+	ImprovementMS        *int     `json:"improvement_ms"`
+	ImprovementPercent   *float64 `json:"improvement_percent"`
+}
+
+type SeasonBestList struct {
+	Season         string       `json:"season"`
+	StartDate      string       `json:"start_date"`
+	EndDate        string       `json:"end_date"`
+	PreviousSeason string       `json:"previous_season"`
+	CourseType     string       `json:"course_type"`
+	SeasonBests    []SeasonBest `json:"season_bests"`
+}
+
+type StandardAchievement struct {
+	StandardID   string   `json:"standard_id"`
+	StandardName string   `json:"standard_name"`
+	AgeGroup     string   `json:"age_group"`
+	Events       []string `json:"events"`
+}
+
+type SeasonSummary struct {
+	Season            string                `json:"season"`
+	Meets             int                   `json:"meets"`
+	Swims             int                   `json:"swims"`
+	Events            int                   `json:"events"`
+	PersonalBests     int                   `json:"personal_bests"`
+	EventsImproved    int                   `json:"events_improved"`
+	StandardsAchieved []StandardAchievement `json:"standards_achieved"`
+}
+
+func TestSeasonsAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	rr := client.Put("/api/v1/swimmer", SwimmerInput{
+		Name:      "Season Swimmer",
+		BirthDate: "2012-05-15",
+		Gender:    "female",
+	})
+	require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK, rr.Body.String())
+
+	var sw Swimmer
+	AssertJSONBody(t, rr, &sw)
+	assert.Equal(t, "09-01", sw.SeasonStart, "seasons start on September 1 by default")
+
+	createMeet := func(t *testing.T, name, date string) string {
+		t.Helper()
+		rr := client.Post("/api/v1/meets", MeetInput{
+			Name:       name,
+			City:       "Toronto",
+			Country:    "Canada",
+			StartDate:  date,
+			EndDate:    date,
+			CourseType: "25m",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var meet Meet
+		AssertJSONBody(t, rr, &meet)
+		return meet.ID
+	}
+
+	addTime := func(t *testing.T, input TimeInput) {
+		t.Helper()
+		rr := client.Post("/api/v1/times", input)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	// 2024-2025 season
+	fallMeet := createMeet(t, "Fall Invitational 2024", "2024-10-05")
+	addTime(t, TimeInput{MeetID: fallMeet, Event: "50FR", TimeMS: 36000, EventDate: "2024-10-05"})
+	addTime(t, TimeInput{MeetID: fallMeet, Event: "100FR", TimeMS: 80000, EventDate: "2024-10-05"})
+	winterMeet := createMeet(t, "Winter Classic 2025", "2025-02-10")
+	addTime(t, TimeInput{MeetID: winterMeet, Event: "50FR", TimeMS: 35000, EventDate: "2025-02-10"})
+
+	// 2025-2026 season
+	openerMeet := createMeet(t, "Season Opener 2025", "2025-10-04")
+	addTime(t, TimeInput{MeetID: openerMeet, Event: "50FR", TimeMS: 34500, EventDate: "2025-10-04"})
+	addTime(t, TimeInput{MeetID: openerMeet, Event: "100FR", TimeMS: 81000, EventDate: "2025-10-04"})
+	januaryMeet := createMeet(t, "January Meet 2026", "2026-01-20")
+	addTime(t, TimeInput{MeetID: januaryMeet, Event: "50FR", TimeMS: 35500, EventDate: "2026-01-20"})
+	addTime(t, TimeInput{
+		MeetID: januaryMeet, Event: "100FR", TimeMS: 79000, EventDate: "2026-01-20",
+		Status: "dq", DQCode: "SW-3.2", DQReason: "Did not touch wall",
+	})
+
+	t.Run("GET /seasons lists seasons with activity", func(t *testing.T) {
+		rr := client.Get("/api/v1/seasons")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var list SeasonList
+		AssertJSONBody(t, rr, &list)
+		assert.Equal(t, "09-01", list.SeasonStart)
+
+		byLabel := make(map[string]SeasonInfo)
+		for _, s := range list.Seasons {
+			byLabel[s.Season] = s
+		}
+
+		require.Contains(t, byLabel, "2024-2025")
+		assert.Equal(t, "2024-09-01", byLabel["2024-2025"].StartDate)
+		assert.Equal(t, "2025-08-31", byLabel["2024-2025"].EndDate)
+		assert.Equal(t, 2, byLabel["2024-2025"].Meets)
+		assert.Equal(t, 3, byLabel["2024-2025"].Swims)
+
+		require.Contains(t, byLabel, "2025-2026")
+		assert.Equal(t, 2, byLabel["2025-2026"].Meets)
+		assert.Equal(t, 3, byLabel["2025-2026"].Swims, "the disqualified swim is not counted")
+
+		// The current season is always listed, most recent first
+		require.NotEmpty(t, list.Seasons)
+		assert.True(t, list.Seasons[0].Current)
+	})
+
+	t.Run("GET /season-bests compares with the previous season", func(t *testing.T) {
+		rr := client.Get("/api/v1/season-bests?course_type=25m&season=2025-2026")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result SeasonBestList
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, "2025-2026", result.Season)
+		assert.Equal(t, "2024-2025", result.PreviousSeason)
+		assert.Equal(t, "25m", result.CourseType)
+		require.Len(t, result.SeasonBests, 2)
+
+		for _, best := range result.SeasonBests {
+			require.NotNil(t, best.PreviousSeasonTimeMS, best.Event)
+			require.NotNil(t, best.ImprovementMS, best.Event)
+			switch best.Event {
+			case "50FR":
+				assert.Equal(t, 34500, best.TimeMS)
+				assert.True(t, best.IsPersonalBest)
+				assert.Equal(t, 35000, *best.PreviousSeasonTimeMS)
+				assert.Equal(t, 500, *best.ImprovementMS)
+				require.NotNil(t, best.ImprovementPercent)
+				assert.InDelta(t, 1.43, *best.ImprovementPercent, 0.01)
+			case "100FR":
+				assert.Equal(t, 81000, best.TimeMS)
+				assert.False(t, best.IsPersonalBest)
+				assert.Equal(t, 80000, *best.PreviousSeasonTimeMS)
+				assert.Equal(t, -1000, *best.ImprovementMS)
+			default:
+				t.Errorf("unexpected event %s", best.Event)
+			}
+		}
+	})
+
+	t.Run("GET /season-bests of the first season has no previous times", func(t *testing.T) {
+		rr := client.Get("/api/v1/season-bests?course_type=25m&season=2024-2025")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result SeasonBestList
+		AssertJSONBody(t, rr, &result)
+		require.Len(t, result.SeasonBests, 2)
+		for _, best := range result.SeasonBests {
+			assert.True(t, best.IsPersonalBest, best.Event)
+			assert.Nil(t, best.PreviousSeasonTimeMS, best.Event)
+			assert.Nil(t, best.ImprovementMS, best.Event)
+		}
+	})
+
+	t.Run("GET /season-summary summarizes the season", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import", StandardImportInput{
+			Name:       "Season Summary Standard",
+			CourseType: "25m",
+			Gender:     "female",
+			Times: []StandardTimeInput{
+				{Event: "50FR", AgeGroup: "13-14", TimeMs: 35000},
+				{Event: "100FR", AgeGroup: "OPEN", TimeMs: 78000},
+			},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var std StandardWithTimes
+		AssertJSONBody(t, rr, &std)
+
+		rr = client.Get("/api/v1/season-summary?course_type=25m&season=2025-2026")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var summary SeasonSummary
+		AssertJSONBody(t, rr, &summary)
+		assert.Equal(t, "2025-2026", summary.Season)
+		assert.Equal(t, 2, summary.Meets)
+		assert.Equal(t, 3, summary.Swims)
+		assert.Equal(t, 2, summary.Events)
+		assert.Equal(t, 1, summary.PersonalBests)
+		assert.Equal(t, 1, summary.EventsImproved)
+
+		var achievement *StandardAchievement
+		for i := range summary.StandardsAchieved {
+			if summary.StandardsAchieved[i].StandardID == std.ID {
+				achievement = &summary.StandardsAchieved[i]
+			}
+		}
+		require.NotNil(t, achievement, "standard achieved by the 50FR season best")
+		assert.Equal(t, "13-14", achievement.AgeGroup)
+		assert.Equal(t, []string{"50FR"}, achievement.Events)
+	})
+
+	t.Run("season endpoints validate parameters", func(t *testing.T) {
+		rr := client.Get("/api/v1/season-bests")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = client.Get("/api/v1/season-bests?course_type=25m&season=2025")
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "seasons starting in September span two years")
+
+		rr = client.Get("/api/v1/season-summary?course_type=10m")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("calendar-year seasons", func(t *testing.T) {
+		rr := client.Put("/api/v1/swimmer", SwimmerInput{
+			Name:        "Season Swimmer",
+			BirthDate:   "2012-05-15",
+			Gender:      "female",
+			SeasonStart: "01-01",
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/season-bests?course_type=25m&season=2025")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result SeasonBestList
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, "2025-01-01", result.StartDate)
+		assert.Equal(t, "2025-12-31", result.EndDate)
+		assert.Equal(t, "2024", result.PreviousSeason)
+
+		rr = client.Put("/api/v1/swimmer", SwimmerInput{
+			Name:        "Season Swimmer",
+			BirthDate:   "2012-05-15",
+			Gender:      "female",
+			SeasonStart: "02-30",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
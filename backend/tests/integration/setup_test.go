@@ -12,6 +12,7 @@ import (
 
 	"github.com/bpg/swimstats/backend/internal/api"
 	"github.com/bpg/swimstats/backend/internal/auth"
+	"github.com/bpg/swimstats/backend/internal/domain/backup"
 )
 
 // APIClient is a helper for making API requests in tests.
@@ -19,6 +20,7 @@ type APIClient struct {
 	t           *testing.T
 	handler     http.Handler
 	accessLevel string
+	email       string
 }
 
 // NewAPIClient creates a new API test client.
@@ -27,6 +29,7 @@ func NewAPIClient(t *testing.T, handler http.Handler) *APIClient {
 		t:           t,
 		handler:     handler,
 		accessLevel: "full",
+		email:       "test@swimstats.local",
 	}
 }
 
@@ -35,6 +38,11 @@ func (c *APIClient) SetMockUser(accessLevel string) {
 	c.accessLevel = accessLevel
 }
 
+// SetMockEmail sets the mock user email, which determines the user ID that owns data.
+func (c *APIClient) SetMockEmail(email string) {
+	c.email = email
+}
+
 // ClearMockUser removes the mock user (for testing unauthenticated access).
 func (c *APIClient) ClearMockUser() {
 	c.accessLevel = ""
@@ -60,6 +68,19 @@ func (c *APIClient) Delete(path string) *httptest.ResponseRecorder {
 	return c.doRequest("DELETE", path, nil)
 }
 
+// PostRaw performs a POST request with a raw (non-JSON) body.
+func (c *APIClient) PostRaw(path, contentType string, body []byte) *httptest.ResponseRecorder {
+	c.t.Helper()
+
+	req, err := http.NewRequest("POST", path, bytes.NewReader(body))
+	if err != nil {
+		c.t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	return c.serve(req)
+}
+
 func (c *APIClient) doRequest(method, path string, body interface{}) *httptest.ResponseRecorder {
 	c.t.Helper()
 
@@ -84,10 +105,14 @@ func (c *APIClient) doRequest(method, path string, body interface{}) *httptest.R
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	return c.serve(req)
+}
+
+func (c *APIClient) serve(req *http.Request) *httptest.ResponseRecorder {
 	// Send mock user as JSON so the auth provider can parse it (unless cleared)
 	if c.accessLevel != "" {
 		mockUserJSON, _ := json.Marshal(map[string]string{
-			"email":  "test@swimstats.local",
+			"email":  c.email,
 			"name":   "Test User",
 			"access": c.accessLevel,
 		})
@@ -149,9 +174,12 @@ func setupTestHandler(t *testing.T, testDB *TestDB) http.Handler {
 		Level: slog.LevelWarn, // Only show warnings and errors during tests
 	}))
 
-	// Create auth provider in dev mode (skips real OIDC validation)
+	// Create auth provider in dev mode (skips real OIDC validation); the
+	// viewer sees the data of the default test user, who is an operator
 	authCfg := auth.Config{
 		SkipValidation: true,
+		ViewerOwners:   map[string]string{"mock-viewer@swimstats.local": "mock-test@swimstats.local"},
+		AdminSubjects:  []string{"mock-test@swimstats.local"},
 	}
 	authProvider, err := auth.NewProvider(context.Background(), authCfg, logger)
 	if err != nil {
@@ -159,7 +187,10 @@ func setupTestHandler(t *testing.T, testDB *TestDB) http.Handler {
 	}
 
 	// Create the router with all dependencies
-	router := api.NewRouter(logger, authProvider, testDB.Pool)
+	router := api.NewRouter(logger, authProvider, testDB.Pool, backup.Config{
+		Dir:      t.TempDir(),
+		KeepLast: 3,
+	})
 
 	return router.Handler()
 }
@@ -7,6 +7,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/swimstats/backend/internal/api"
+	"github.com/bpg/swimstats/backend/internal/domain/backup"
+	"github.com/bpg/swimstats/backend/internal/domain/standard"
 )
 
 type StandardInput struct {
@@ -23,11 +27,15 @@ type StandardTimeInput struct {
 }
 
 type StandardImportInput struct {
-	Name        string              `json:"name"`
-	Description string              `json:"description,omitempty"`
-	CourseType  string              `json:"course_type"`
-	Gender      string              `json:"gender"`
-	Times       []StandardTimeInput `json:"times"`
+	Name            string              `json:"name"`
+	Description     string              `json:"description,omitempty"`
+	CourseType      string              `json:"course_type"`
+	Gender          string              `json:"gender"`
+	Times           []StandardTimeInput `json:"times"`
+	QualifyingStart string              `json:"qualifying_start,omitempty"`
+	QualifyingEnd   string              `json:"qualifying_end,omitempty"`
+	RequiredCourse  string              `json:"required_course,omitempty"`
+	SanctionedOnly  bool                `json:"sanctioned_only,omitempty"`
 }
 
 type Standard struct {
@@ -37,6 +45,12 @@ type Standard struct {
 	CourseType  string `json:"course_type"`
 	Gender      string `json:"gender"`
 	IsPreloaded bool   `json:"is_preloaded"`
+	Shared      bool   `json:"shared"`
+
+	QualifyingStart string `json:"qualifying_start"`
+	QualifyingEnd   string `json:"qualifying_end"`
+	RequiredCourse  string `json:"required_course"`
+	SanctionedOnly  bool   `json:"sanctioned_only"`
 }
 
 type StandardTime struct {
@@ -379,4 +393,63 @@ func TestStandardAPI(t *testing.T) {
 		rr = client.Post("/api/v1/standards", input)
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
 	})
+
+	t.Run("standards belong to their owner", func(t *testing.T) {
+		testDB.ClearTables(ctx, t)
+
+		rr := client.Post("/api/v1/standards", StandardInput{Name: "Mine", CourseType: "25m", Gender: "female"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var mine Standard
+		AssertJSONBody(t, rr, &mine)
+		assert.False(t, mine.Shared)
+
+		services := api.NewServices(testDB.Pool, backup.Config{})
+		shared, err := services.Standards.Create(ctx, "", standard.Input{Name: "Shared", CourseType: "25m", Gender: "female"})
+		require.NoError(t, err)
+
+		client.SetMockEmail("other@swimstats.local")
+		defer client.SetMockEmail("test@swimstats.local")
+
+		// Other users see only the shared standard
+		rr = client.Get("/api/v1/standards")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list StandardList
+		AssertJSONBody(t, rr, &list)
+		require.Len(t, list.Standards, 1)
+		assert.Equal(t, "Shared", list.Standards[0].Name)
+		assert.True(t, list.Standards[0].Shared)
+
+		rr = client.Get("/api/v1/standards/" + mine.ID)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		rr = client.Put("/api/v1/standards/"+mine.ID, StandardInput{Name: "Taken", CourseType: "25m", Gender: "female"})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		rr = client.Delete("/api/v1/standards/" + mine.ID)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		// Shared standards are read-only
+		rr = client.Put("/api/v1/standards/"+shared.ID.String(), StandardInput{Name: "Changed", CourseType: "25m", Gender: "female"})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		rr = client.Delete("/api/v1/standards/" + shared.ID.String())
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		// Names are unique per owner
+		rr = client.Post("/api/v1/standards", StandardInput{Name: "Mine", CourseType: "25m", Gender: "female"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		// Replace imports only delete the caller's standards
+		rr = client.Post("/api/v1/data/import", ImportRequest{Data: ImportData{
+			Swimmer: &SwimmerExport{Name: "Other Swimmer", BirthDate: "2012-05-15", Gender: "female"},
+		}, Confirmed: true})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rr = client.Get("/api/v1/standards")
+		require.Equal(t, http.StatusOK, rr.Code)
+		list = StandardList{}
+		AssertJSONBody(t, rr, &list)
+		require.Len(t, list.Standards, 1)
+		assert.Equal(t, "Shared", list.Standards[0].Name)
+
+		client.SetMockEmail("test@swimstats.local")
+		rr = client.Get("/api/v1/standards/" + mine.ID)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
 }
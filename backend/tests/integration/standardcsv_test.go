@@ -0,0 +1,160 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CSVImportResult struct {
+	Standards []StandardWithTimes `json:"standards"`
+	Imported  int                 `json:"imported"`
+	Errors    []string            `json:"errors"`
+}
+
+func TestStandardCSVAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	timesOf := func(std StandardWithTimes) map[string]int {
+		times := make(map[string]int)
+		for _, st := range std.Times {
+			times[st.Event+"/"+st.AgeGroup] = st.TimeMs
+		}
+		return times
+	}
+
+	var shortCourse StandardWithTimes
+	t.Run("POST /standards/import/csv creates a standard per gender and course", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import/csv", map[string]any{
+			"name": "CSV Provincial",
+			"mapping": map[string]any{
+				"gender_column": "Gender",
+				"course_column": "Course",
+			},
+			"csv": strings.Join([]string{
+				"Event,Gender,Course,10&U,11-12,13-14,Open",
+				"50 Free,F,SCM,0:35.00,0:32.00,0:30.00,0:28.00",
+				"100 Freestyle,F,SCM,1:15.00,-,,1:01.00",
+				"100 Back,Girls,LCM,1:20.00,,1:10.00,",
+				"75 Free,F,SCM,1:00.00,,,",
+				"50 Free,X,SCM,0:35.00,,,",
+				"50 Fly,F,SCM,fast,,,",
+			}, "\n"),
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var result CSVImportResult
+		AssertJSONBody(t, rr, &result)
+		require.Equal(t, 2, result.Imported)
+		assert.Len(t, result.Errors, 3, result.Errors)
+
+		byName := make(map[string]StandardWithTimes)
+		for _, std := range result.Standards {
+			byName[std.Name] = std
+		}
+		require.Contains(t, byName, "CSV Provincial (female, 25m)")
+		require.Contains(t, byName, "CSV Provincial (female, 50m)")
+
+		shortCourse = byName["CSV Provincial (female, 25m)"]
+		assert.Equal(t, "25m", shortCourse.CourseType)
+		assert.Equal(t, "female", shortCourse.Gender)
+		assert.Equal(t, map[string]int{
+			"50FR/10U":   35000,
+			"50FR/11-12": 32000,
+			"50FR/13-14": 30000,
+			"50FR/OPEN":  28000,
+			"100FR/10U":  75000,
+			"100FR/OPEN": 61000,
+		}, timesOf(shortCourse))
+		assert.Equal(t, map[string]int{
+			"100BK/10U":   80000,
+			"100BK/13-14": 70000,
+		}, timesOf(byName["CSV Provincial (female, 50m)"]))
+	})
+
+	t.Run("POST /standards/import/csv maps columns and time formats", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import/csv", map[string]any{
+			"name":        "CSV Club",
+			"course_type": "25m",
+			"gender":      "male",
+			"mapping": map[string]any{
+				"event_column":      "Stroke",
+				"age_group_columns": map[string]string{"Age 13-14": "13-14", "Age 15-17": "15-17"},
+				"time_format":       "seconds",
+				"delimiter":         ";",
+			},
+			"csv": "Stroke;Notes;Age 13-14;Age 15-17\n50FR;club record;30.5;29.25\n",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var result CSVImportResult
+		AssertJSONBody(t, rr, &result)
+		require.Equal(t, 1, result.Imported)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, "CSV Club", result.Standards[0].Name)
+		assert.Equal(t, map[string]int{"50FR/13-14": 30500, "50FR/15-17": 29250}, timesOf(result.Standards[0]))
+	})
+
+	t.Run("POST /standards/import/csv validates the mapping", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import/csv", map[string]any{
+			"name": "CSV Invalid", "course_type": "25m", "gender": "female",
+			"mapping": map[string]any{"event_column": "Race"},
+			"csv":     "Event,Open\n50FR,0:28.00\n",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "the event column must exist")
+
+		rr = client.Post("/api/v1/standards/import/csv", map[string]any{
+			"name": "CSV Invalid", "course_type": "25m",
+			"csv": "Event,Open\n50FR,0:28.00\n",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "gender is required without a gender column")
+
+		rr = client.Post("/api/v1/standards/import/csv", map[string]any{
+			"name": "CSV Provincial (female, 25m)", "course_type": "25m", "gender": "female",
+			"csv": "Event,Open\n50FR,0:28.00\n",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "names must be unique")
+	})
+
+	t.Run("GET /standards/{id}/csv exports a standard that imports back", func(t *testing.T) {
+		rr := client.Get("/api/v1/standards/" + shortCourse.ID + "/csv")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Header().Get("Content-Type"), "text/csv")
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "csv-provincial-female-25m.csv")
+
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "Event,10U,11-12,13-14,OPEN", lines[0])
+		assert.Equal(t, "50FR,35.00,32.00,30.00,28.00", lines[1])
+		assert.Equal(t, "100FR,1:15.00,,,1:01.00", lines[2])
+
+		rr = client.Post("/api/v1/standards/import/csv", map[string]any{
+			"name": "CSV Round Trip", "course_type": "25m", "gender": "female",
+			"csv": rr.Body.String(),
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var result CSVImportResult
+		AssertJSONBody(t, rr, &result)
+		require.Equal(t, 1, result.Imported)
+		assert.Equal(t, timesOf(shortCourse), timesOf(result.Standards[0]))
+
+		rr = client.Get("/api/v1/standards/00000000-0000-0000-0000-000000000000/csv")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,201 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type StandardImportChange struct {
+	Code       string               `json:"code"`
+	Name       string               `json:"name"`
+	StandardID string               `json:"standard_id"`
+	Action     string               `json:"action"`
+	Inserts    []StandardTimeChange `json:"inserts"`
+	Updates    []StandardTimeChange `json:"updates"`
+	Removals   []StandardTimeChange `json:"removals"`
+}
+
+type JSONImportPlan struct {
+	Mode      string                 `json:"mode"`
+	DryRun    bool                   `json:"dry_run"`
+	Standards []Standard             `json:"standards"`
+	Changes   []StandardImportChange `json:"changes"`
+	Imported  int                    `json:"imported"`
+	Updated   int                    `json:"updated"`
+	Unchanged int                    `json:"unchanged"`
+	Skipped   int                    `json:"skipped"`
+	Errors    []string               `json:"errors"`
+}
+
+func TestStandardJSONImportModes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	file := func(standards map[string]any, times map[string]any) map[string]any {
+		return map[string]any{
+			"source":      "Mode Test",
+			"course_type": "25m",
+			"gender":      "female",
+			"standards":   standards,
+			"times":       times,
+		}
+	}
+	published := file(
+		map[string]any{"PROV": map[string]string{"name": "Mode Provincial"}},
+		map[string]any{
+			"50FR":  map[string]any{"OPEN": map[string]string{"PROV": "0:30.00"}},
+			"100FR": map[string]any{"OPEN": map[string]string{"PROV": "1:05.00"}},
+		},
+	)
+	corrected := file(
+		map[string]any{"PROV": map[string]string{"name": "Mode Provincial"}},
+		map[string]any{
+			"100FR": map[string]any{"OPEN": map[string]string{"PROV": "1:04.50"}},
+			"200FR": map[string]any{"OPEN": map[string]string{"PROV": "2:20.00"}},
+		},
+	)
+
+	timesOf := func(t *testing.T, id string) map[string]int {
+		t.Helper()
+		rr := client.Get("/api/v1/standards/" + id)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var std StandardWithTimes
+		AssertJSONBody(t, rr, &std)
+		times := make(map[string]int)
+		for _, st := range std.Times {
+			times[st.Event] = st.TimeMs
+		}
+		return times
+	}
+
+	rr := client.Post("/api/v1/standards/import/json", published)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var result JSONImportPlan
+	AssertJSONBody(t, rr, &result)
+	require.Equal(t, 1, result.Imported, result.Errors)
+	assert.Equal(t, "skip", result.Mode)
+	require.Len(t, result.Changes, 1)
+	assert.Equal(t, "create", result.Changes[0].Action)
+	assert.Len(t, result.Changes[0].Inserts, 2)
+	id := result.Standards[0].ID
+
+	t.Run("skip mode leaves existing standards untouched", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import/json", corrected)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var result JSONImportPlan
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, 1, result.Skipped)
+		require.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors[0], "already exists")
+		assert.Equal(t, map[string]int{"50FR": 30000, "100FR": 65000}, timesOf(t, id))
+	})
+
+	t.Run("invalid options are rejected", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import/json?mode=overwrite", corrected)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = client.Post("/api/v1/standards/import/json?dry_run=maybe", corrected)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("dry run lists the changes without making them", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import/json?mode=replace&dry_run=true", corrected)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var plan JSONImportPlan
+		AssertJSONBody(t, rr, &plan)
+		assert.True(t, plan.DryRun)
+		assert.Equal(t, 1, plan.Updated)
+		assert.Empty(t, plan.Standards)
+		require.Len(t, plan.Changes, 1)
+
+		change := plan.Changes[0]
+		assert.Equal(t, "update", change.Action)
+		assert.Equal(t, id, change.StandardID)
+		require.Len(t, change.Inserts, 1)
+		assert.Equal(t, "200FR", change.Inserts[0].Event)
+		require.Len(t, change.Updates, 1)
+		assert.Equal(t, "100FR", change.Updates[0].Event)
+		require.NotNil(t, change.Updates[0].ChangeMS)
+		assert.Equal(t, -500, *change.Updates[0].ChangeMS)
+		require.Len(t, change.Removals, 1)
+		assert.Equal(t, "50FR", change.Removals[0].Event)
+
+		assert.Equal(t, map[string]int{"50FR": 30000, "100FR": 65000}, timesOf(t, id))
+	})
+
+	t.Run("merge mode adds and updates times", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import/json?mode=merge", corrected)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var result JSONImportPlan
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, 1, result.Updated)
+		require.Len(t, result.Changes, 1)
+		assert.Empty(t, result.Changes[0].Removals)
+		require.Len(t, result.Standards, 1)
+		assert.Equal(t, id, result.Standards[0].ID, "the standard keeps its ID")
+		assert.Equal(t, map[string]int{"50FR": 30000, "100FR": 64500, "200FR": 140000}, timesOf(t, id))
+	})
+
+	t.Run("replace mode removes times missing from the file", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import/json?mode=replace", corrected)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.Equal(t, map[string]int{"100FR": 64500, "200FR": 140000}, timesOf(t, id))
+
+		rr = client.Post("/api/v1/standards/import/json?mode=replace", corrected)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var result JSONImportPlan
+		AssertJSONBody(t, rr, &result)
+		assert.Equal(t, 1, result.Unchanged)
+		assert.Equal(t, 0, result.Updated)
+	})
+
+	t.Run("a failing standard rolls back the whole file", func(t *testing.T) {
+		versioned := file(
+			map[string]any{"NAT": map[string]string{"name": "Mode National 2024", "family": "Mode National"}},
+			map[string]any{"100FR": map[string]any{"OPEN": map[string]string{"NAT": "1:00.00"}}},
+		)
+		versioned["effective_from"] = "2024-09-01"
+		rr := client.Post("/api/v1/standards/import/json", versioned)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		// The provincial times are updated before the second version, which
+		// conflicts with the first one's effective date, fails
+		conflicting := file(
+			map[string]any{
+				"A":   map[string]string{"name": "Mode Provincial"},
+				"NAT": map[string]string{"name": "Mode National 2024 Copy", "family": "Mode National"},
+			},
+			map[string]any{"100FR": map[string]any{"OPEN": map[string]string{"A": "1:03.00", "NAT": "0:59.00"}}},
+		)
+		conflicting["effective_from"] = "2024-09-01"
+		rr = client.Post("/api/v1/standards/import/json?mode=merge", conflicting)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		assert.Equal(t, 64500, timesOf(t, id)["100FR"], "no changes are kept")
+		rr = client.Get("/api/v1/standards")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list StandardList
+		AssertJSONBody(t, rr, &list)
+		for _, std := range list.Standards {
+			assert.NotEqual(t, "Mode National 2024 Copy", std.Name)
+		}
+	})
+}
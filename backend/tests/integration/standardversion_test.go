@@ -0,0 +1,350 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type StandardFamilyInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	CourseType  string `json:"course_type"`
+	Gender      string `json:"gender"`
+}
+
+type StandardVersion struct {
+	Standard
+	FamilyID      string `json:"family_id"`
+	Version       string `json:"version"`
+	EffectiveFrom string `json:"effective_from"`
+}
+
+type StandardFamily struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	CourseType string            `json:"course_type"`
+	Gender     string            `json:"gender"`
+	Versions   []StandardVersion `json:"versions"`
+}
+
+type StandardFamilyList struct {
+	Families []StandardFamily `json:"families"`
+}
+
+type StandardVersionImport struct {
+	StandardImportInput
+	FamilyID      string `json:"family_id,omitempty"`
+	Version       string `json:"version,omitempty"`
+	EffectiveFrom string `json:"effective_from,omitempty"`
+}
+
+type StandardTimeChange struct {
+	Event         string   `json:"event"`
+	AgeGroup      string   `json:"age_group"`
+	Status        string   `json:"status"`
+	FromTimeMS    *int     `json:"from_time_ms"`
+	ToTimeMS      *int     `json:"to_time_ms"`
+	ChangeMS      *int     `json:"change_ms"`
+	ChangePercent *float64 `json:"change_percent"`
+}
+
+type StandardDiff struct {
+	From    StandardVersion      `json:"from"`
+	To      StandardVersion      `json:"to"`
+	Changes []StandardTimeChange `json:"changes"`
+	Summary struct {
+		Faster    int `json:"faster"`
+		Slower    int `json:"slower"`
+		Unchanged int `json:"unchanged"`
+		Added     int `json:"added"`
+		Removed   int `json:"removed"`
+	} `json:"summary"`
+}
+
+type VersionedComparison struct {
+	StandardID     string            `json:"standard_id"`
+	Version        string            `json:"version"`
+	EffectiveFrom  string            `json:"effective_from"`
+	EvaluationDate string            `json:"evaluation_date"`
+	Comparisons    []EventComparison `json:"comparisons"`
+}
+
+func TestStandardVersionAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	testDB.CleanTables(t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	rr := client.Put("/api/v1/swimmer", SwimmerInput{
+		Name:      "Version Swimmer",
+		BirthDate: "2008-05-15",
+		Gender:    "female",
+	})
+	require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK, rr.Body.String())
+
+	rr = client.Post("/api/v1/meets", MeetInput{
+		Name:       "Version Meet",
+		City:       "Toronto",
+		Country:    "Canada",
+		StartDate:  "2025-01-15",
+		EndDate:    "2025-01-15",
+		CourseType: "25m",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var meet Meet
+	AssertJSONBody(t, rr, &meet)
+
+	rr = client.Post("/api/v1/times", TimeInput{MeetID: meet.ID, Event: "100FR", TimeMS: 69000, EventDate: "2025-01-15"})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var family StandardFamily
+	t.Run("POST /standard-families creates a family", func(t *testing.T) {
+		rr := client.Post("/api/v1/standard-families", StandardFamilyInput{
+			Name:       "Provincial",
+			CourseType: "25m",
+			Gender:     "female",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		AssertJSONBody(t, rr, &family)
+		assert.Equal(t, "Provincial", family.Name)
+		assert.Empty(t, family.Versions)
+
+		rr = client.Post("/api/v1/standard-families", StandardFamilyInput{
+			Name:       "Provincial",
+			CourseType: "25m",
+			Gender:     "female",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "family names are unique per course and gender")
+
+		rr = client.Post("/api/v1/standard-families", StandardFamilyInput{
+			Name:       "Provincial",
+			CourseType: "50m",
+			Gender:     "female",
+		})
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+
+	importVersion := func(t *testing.T, name, version, effectiveFrom string, times []StandardTimeInput) string {
+		t.Helper()
+		rr := client.Post("/api/v1/standards/import", StandardVersionImport{
+			StandardImportInput: StandardImportInput{
+				Name:       name,
+				CourseType: "25m",
+				Gender:     "female",
+				Times:      times,
+			},
+			FamilyID:      family.ID,
+			Version:       version,
+			EffectiveFrom: effectiveFrom,
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var std StandardVersion
+		AssertJSONBody(t, rr, &std)
+		assert.Equal(t, family.ID, std.FamilyID)
+		assert.Equal(t, effectiveFrom, std.EffectiveFrom)
+		return std.ID
+	}
+
+	v1 := importVersion(t, "Provincial 2024", "2024-2025", "2024-09-01", []StandardTimeInput{
+		{Event: "50FR", AgeGroup: "OPEN", TimeMs: 30000},
+		{Event: "100FR", AgeGroup: "OPEN", TimeMs: 70000},
+		{Event: "200FR", AgeGroup: "OPEN", TimeMs: 150000},
+	})
+	v2 := importVersion(t, "Provincial 2025", "2025-2026", "2025-09-01", []StandardTimeInput{
+		{Event: "50FR", AgeGroup: "OPEN", TimeMs: 30000},
+		{Event: "100FR", AgeGroup: "OPEN", TimeMs: 68000},
+		{Event: "400FR", AgeGroup: "OPEN", TimeMs: 300000},
+	})
+
+	t.Run("versions are validated against the family", func(t *testing.T) {
+		input := StandardVersionImport{
+			StandardImportInput: StandardImportInput{
+				Name:       "Provincial Duplicate",
+				CourseType: "25m",
+				Gender:     "female",
+				Times:      []StandardTimeInput{{Event: "100FR", AgeGroup: "OPEN", TimeMs: 68000}},
+			},
+			FamilyID:      family.ID,
+			EffectiveFrom: "2025-09-01",
+		}
+		rr := client.Post("/api/v1/standards/import", input)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "one version per effective date")
+
+		input.Name = "Provincial Long Course"
+		input.CourseType = "50m"
+		input.EffectiveFrom = "2026-09-01"
+		rr = client.Post("/api/v1/standards/import", input)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "versions must match the family's course")
+
+		input.Name = "Provincial Undated"
+		input.CourseType = "25m"
+		input.EffectiveFrom = ""
+		rr = client.Post("/api/v1/standards/import", input)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "versions need an effective date")
+	})
+
+	t.Run("GET /standard-families/{id} lists versions earliest first", func(t *testing.T) {
+		rr := client.Get("/api/v1/standard-families/" + family.ID)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var got StandardFamily
+		AssertJSONBody(t, rr, &got)
+		require.Len(t, got.Versions, 2)
+		assert.Equal(t, v1, got.Versions[0].ID)
+		assert.Equal(t, v2, got.Versions[1].ID)
+		assert.Equal(t, "2025-2026", got.Versions[1].Version)
+	})
+
+	t.Run("GET /standards/{id}/diff compares with the previous version", func(t *testing.T) {
+		rr := client.Get("/api/v1/standards/" + v2 + "/diff")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var diff StandardDiff
+		AssertJSONBody(t, rr, &diff)
+		assert.Equal(t, v1, diff.From.ID)
+		assert.Equal(t, v2, diff.To.ID)
+
+		changes := make(map[string]StandardTimeChange)
+		for _, c := range diff.Changes {
+			changes[c.Event] = c
+		}
+		require.Len(t, changes, 4)
+		assert.Equal(t, "unchanged", changes["50FR"].Status)
+		assert.Equal(t, "faster", changes["100FR"].Status)
+		require.NotNil(t, changes["100FR"].ChangeMS)
+		assert.Equal(t, -2000, *changes["100FR"].ChangeMS)
+		require.NotNil(t, changes["100FR"].ChangePercent)
+		assert.InDelta(t, -2.86, *changes["100FR"].ChangePercent, 0.01)
+		assert.Equal(t, "removed", changes["200FR"].Status)
+		assert.Nil(t, changes["200FR"].ToTimeMS)
+		assert.Equal(t, "added", changes["400FR"].Status)
+		assert.Nil(t, changes["400FR"].FromTimeMS)
+
+		assert.Equal(t, 1, diff.Summary.Faster)
+		assert.Equal(t, 1, diff.Summary.Unchanged)
+		assert.Equal(t, 1, diff.Summary.Added)
+		assert.Equal(t, 1, diff.Summary.Removed)
+	})
+
+	t.Run("GET /standards/{id}/diff compares with any standard", func(t *testing.T) {
+		rr := client.Get("/api/v1/standards/" + v1 + "/diff")
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "the first version has no previous version")
+
+		rr = client.Get("/api/v1/standards/" + v1 + "/diff?from=" + v2)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var diff StandardDiff
+		AssertJSONBody(t, rr, &diff)
+		assert.Equal(t, 1, diff.Summary.Slower)
+	})
+
+	t.Run("GET /comparisons uses the version effective on the date", func(t *testing.T) {
+		tests := []struct {
+			date       string
+			standardID string
+			status     string
+		}{
+			{"2025-01-15", v1, "achieved"},
+			{"2026-01-15", v2, "almost"},
+			{"2020-01-01", v1, "achieved"}, // before the first version
+		}
+		for _, tt := range tests {
+			rr := client.Get("/api/v1/comparisons?standard_id=" + v1 + "&course_type=25m&date=" + tt.date)
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+			var result VersionedComparison
+			AssertJSONBody(t, rr, &result)
+			assert.Equal(t, tt.standardID, result.StandardID, tt.date)
+			assert.Equal(t, tt.date, result.EvaluationDate)
+			for _, c := range result.Comparisons {
+				if c.Event == "100FR" {
+					assert.Equal(t, tt.status, c.Status, tt.date)
+				}
+			}
+		}
+
+		rr := client.Get("/api/v1/comparisons?standard_id=" + v1 + "&course_type=25m&date=2025-13-01")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("JSON import adds a version to the source's family", func(t *testing.T) {
+		rr := client.Post("/api/v1/standards/import/json", map[string]any{
+			"season":      "2099-2100",
+			"source":      "Test",
+			"course_type": "25m",
+			"gender":      "female",
+			"standards": map[string]any{
+				"PROV": map[string]string{"name": "Provincial 2099", "family": "Provincial"},
+				"NAT":  map[string]string{"name": "National 2099"},
+			},
+			"times": map[string]any{
+				"100FR": map[string]any{
+					"OPEN": map[string]string{"PROV": "1:07.00", "NAT": "1:00.00"},
+				},
+			},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var result JSONImportResult
+		AssertJSONBody(t, rr, &result)
+		require.Equal(t, 2, result.Imported, result.Errors)
+
+		rr = client.Get("/api/v1/standard-families?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var list StandardFamilyList
+		AssertJSONBody(t, rr, &list)
+
+		families := make(map[string]StandardFamily)
+		for _, f := range list.Families {
+			families[f.Name] = f
+		}
+		require.Contains(t, families, "Provincial")
+		require.Len(t, families["Provincial"].Versions, 3)
+		latest := families["Provincial"].Versions[2]
+		assert.Equal(t, "2099-2100", latest.Version)
+		assert.Equal(t, "2099-09-01", latest.EffectiveFrom)
+
+		require.Contains(t, families, "Test NAT", "a family is created from the source and code")
+		assert.Len(t, families["Test NAT"].Versions, 1)
+	})
+
+	t.Run("GET /attainment includes only the versions in effect", func(t *testing.T) {
+		rr := client.Get("/api/v1/attainment?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var matrix AttainmentMatrix
+		AssertJSONBody(t, rr, &matrix)
+		ids := make(map[string]bool)
+		for _, std := range matrix.Standards {
+			ids[std.ID] = true
+		}
+		assert.True(t, ids[v2])
+		assert.False(t, ids[v1], "superseded versions are left out")
+	})
+
+	t.Run("DELETE /standard-families/{id} keeps the versions", func(t *testing.T) {
+		rr := client.Delete("/api/v1/standard-families/" + family.ID)
+		require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/standard-families/" + family.ID)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = client.Get("/api/v1/standards/" + v1)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var std StandardVersion
+		AssertJSONBody(t, rr, &std)
+		assert.Empty(t, std.FamilyID)
+	})
+}
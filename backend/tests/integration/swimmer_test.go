@@ -3,17 +3,24 @@ package integration
 import (
 	"context"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/swimstats/backend/internal/api"
+	"github.com/bpg/swimstats/backend/internal/domain/backup"
+	"github.com/bpg/swimstats/backend/internal/domain/swimmer"
 )
 
 type SwimmerInput struct {
-	Name      string `json:"name"`
-	BirthDate string `json:"birth_date"`
-	Gender    string `json:"gender"`
+	Name        string `json:"name"`
+	BirthDate   string `json:"birth_date"`
+	Gender      string `json:"gender"`
+	SeasonStart string `json:"season_start,omitempty"`
 }
 
 type SwimmerInputWithThreshold struct {
@@ -30,6 +37,7 @@ type Swimmer struct {
 	Gender          string `json:"gender"`
 	CurrentAge      int    `json:"current_age,omitempty"`
 	CurrentAgeGroup string `json:"current_age_group,omitempty"`
+	SeasonStart     string `json:"season_start"`
 }
 
 func TestSwimmerAPI(t *testing.T) {
@@ -250,3 +258,230 @@ func TestSwimmerAgeCalculation(t *testing.T) {
 		})
 	}
 }
+
+type SwimmerList struct {
+	Swimmers []Swimmer `json:"swimmers"`
+	Total    int       `json:"total"`
+}
+
+func TestSwimmersAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := SetupTestDB(ctx, t)
+	defer testDB.TeardownTestDB(ctx, t)
+
+	handler := setupTestHandler(t, testDB)
+	client := NewAPIClient(t, handler)
+	client.SetMockUser("full")
+
+	createSwimmer := func(t *testing.T, name string) Swimmer {
+		rr := client.Post("/api/v1/swimmers", SwimmerInput{Name: name, BirthDate: "2012-05-15", Gender: "female"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var swimmer Swimmer
+		AssertJSONBody(t, rr, &swimmer)
+		return swimmer
+	}
+
+	t.Run("POST /swimmers creates additional swimmers", func(t *testing.T) {
+		testDB.ClearTables(ctx, t)
+
+		createSwimmer(t, "Alice")
+		createSwimmer(t, "Bob")
+
+		rr := client.Get("/api/v1/swimmers")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var list SwimmerList
+		AssertJSONBody(t, rr, &list)
+		assert.Equal(t, 2, list.Total)
+		require.Len(t, list.Swimmers, 2)
+		assert.Equal(t, "Alice", list.Swimmers[0].Name)
+		assert.Equal(t, "Bob", list.Swimmers[1].Name)
+
+		// The legacy route keeps returning the first swimmer
+		rr = client.Get("/api/v1/swimmer")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var swimmer Swimmer
+		AssertJSONBody(t, rr, &swimmer)
+		assert.Equal(t, "Alice", swimmer.Name)
+	})
+
+	t.Run("times are scoped to each swimmer", func(t *testing.T) {
+		testDB.ClearTables(ctx, t)
+
+		alice := createSwimmer(t, "Alice")
+		bob := createSwimmer(t, "Bob")
+
+		rr := client.Post("/api/v1/meets", MeetInput{Name: "Shared Meet", City: "Toronto", StartDate: "2026-03-15", CourseType: "25m"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var meet Meet
+		AssertJSONBody(t, rr, &meet)
+
+		rr = client.Post("/api/v1/swimmers/"+alice.ID+"/times", TimeInput{MeetID: meet.ID, Event: "100FR", TimeMS: 65320, EventDate: "2026-03-15"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var aliceTime TimeRecord
+		AssertJSONBody(t, rr, &aliceTime)
+
+		rr = client.Post("/api/v1/swimmers/"+bob.ID+"/times", TimeInput{MeetID: meet.ID, Event: "50FR", TimeMS: 30120, EventDate: "2026-03-15"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/swimmers/" + alice.ID + "/times")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list TimeList
+		AssertJSONBody(t, rr, &list)
+		require.Len(t, list.Times, 1)
+		assert.Equal(t, "100FR", list.Times[0].Event)
+
+		rr = client.Get("/api/v1/swimmers/" + bob.ID + "/personal-bests?course_type=25m")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var pbs PersonalBestList
+		AssertJSONBody(t, rr, &pbs)
+		require.Len(t, pbs.PersonalBests, 1)
+		assert.Equal(t, "50FR", pbs.PersonalBests[0].Event)
+
+		// A time cannot be read through another swimmer's route
+		rr = client.Get("/api/v1/swimmers/" + bob.ID + "/times/" + aliceTime.ID)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		// Swimmer IDs are compared as UUIDs, whatever their case
+		rr = client.Get("/api/v1/swimmers/" + strings.ToUpper(alice.ID) + "/times/" + aliceTime.ID)
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("swimmers and meets are private to their owner", func(t *testing.T) {
+		testDB.ClearTables(ctx, t)
+
+		alice := createSwimmer(t, "Alice")
+		rr := client.Post("/api/v1/meets", MeetInput{Name: "Private Meet", City: "Toronto", StartDate: "2026-03-15", CourseType: "25m"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		client.SetMockEmail("other@swimstats.local")
+		defer client.SetMockEmail("test@swimstats.local")
+
+		rr = client.Get("/api/v1/swimmers/" + alice.ID)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = client.Get("/api/v1/swimmers")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var swimmers SwimmerList
+		AssertJSONBody(t, rr, &swimmers)
+		assert.Equal(t, 0, swimmers.Total)
+
+		rr = client.Get("/api/v1/meets")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var meets MeetList
+		AssertJSONBody(t, rr, &meets)
+		assert.Equal(t, 0, meets.Total)
+	})
+
+	t.Run("view-only users see the data of the user they are mapped to", func(t *testing.T) {
+		testDB.ClearTables(ctx, t)
+
+		alice := createSwimmer(t, "Alice")
+		rr := client.Post("/api/v1/meets", MeetInput{Name: "Shared Meet", City: "Toronto", StartDate: "2026-03-15", CourseType: "25m"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		client.SetMockUser("view_only")
+		defer client.SetMockUser("full")
+		client.SetMockEmail("viewer@swimstats.local")
+		defer client.SetMockEmail("test@swimstats.local")
+
+		rr = client.Get("/api/v1/swimmers/" + alice.ID)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		rr = client.Get("/api/v1/meets")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var meets MeetList
+		AssertJSONBody(t, rr, &meets)
+		assert.Equal(t, 1, meets.Total)
+
+		// Viewers cannot change the data they see
+		rr = client.Post("/api/v1/meets", MeetInput{Name: "Viewer Meet", City: "Toronto", StartDate: "2026-03-16", CourseType: "25m"})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		// Unmapped view-only users see only their own, empty, data
+		client.SetMockEmail("stranger@swimstats.local")
+		rr = client.Get("/api/v1/swimmers/" + alice.ID)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		rr = client.Get("/api/v1/swimmers")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var swimmers SwimmerList
+		AssertJSONBody(t, rr, &swimmers)
+		assert.Equal(t, 0, swimmers.Total)
+	})
+
+	t.Run("legacy data is only assigned by claim", func(t *testing.T) {
+		testDB.ClearTables(ctx, t)
+
+		// Rows from before multi-swimmer support have no owner
+		testDB.ExecSQL(t, `INSERT INTO swimmers (name, birth_date, gender) VALUES ('Legacy', '2012-05-15', 'female')`)
+		testDB.ExecSQL(t, `INSERT INTO meets (name, city, start_date, end_date, course_type) VALUES ('Legacy Meet', 'Toronto', '2026-03-15', '2026-03-15', '25m')`)
+
+		// Reads never claim them
+		rr := client.Get("/api/v1/swimmer")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		rr = client.Get("/api/v1/swimmers")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var swimmers SwimmerList
+		AssertJSONBody(t, rr, &swimmers)
+		assert.Equal(t, 0, swimmers.Total)
+
+		// Concurrent claims assign everything to a single owner
+		services := api.NewServices(testDB.Pool, backup.Config{})
+		owners := []string{"mock-test@swimstats.local", "mock-other@swimstats.local"}
+		results := make([]*swimmer.ClaimResult, len(owners))
+		var wg sync.WaitGroup
+		for i, owner := range owners {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, err := services.Swimmers.ClaimUnowned(ctx, owner)
+				assert.NoError(t, err)
+				results[i] = result
+			}()
+		}
+		wg.Wait()
+		require.NotNil(t, results[0])
+		require.NotNil(t, results[1])
+		assert.Equal(t, int64(1), results[0].Swimmers+results[1].Swimmers)
+		assert.Equal(t, results[0].Swimmers, results[0].Meets)
+		assert.Equal(t, results[1].Swimmers, results[1].Meets)
+
+		for i, owner := range owners {
+			client.SetMockEmail(owner[len("mock-"):])
+			rr = client.Get("/api/v1/meets")
+			require.Equal(t, http.StatusOK, rr.Code)
+			var meets MeetList
+			AssertJSONBody(t, rr, &meets)
+			assert.Equal(t, int(results[i].Meets), meets.Total)
+		}
+		client.SetMockEmail("test@swimstats.local")
+
+		_, err := services.Swimmers.ClaimUnowned(ctx, " ")
+		assert.ErrorContains(t, err, "validation")
+	})
+
+	t.Run("DELETE /swimmers/{id} removes swimmer", func(t *testing.T) {
+		testDB.ClearTables(ctx, t)
+
+		alice := createSwimmer(t, "Alice")
+
+		rr := client.Delete("/api/v1/swimmers/" + alice.ID)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+
+		rr = client.Get("/api/v1/swimmers/" + alice.ID)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("view-only access cannot create swimmers", func(t *testing.T) {
+		testDB.ClearTables(ctx, t)
+
+		client.SetMockUser("view_only")
+		defer client.SetMockUser("full")
+
+		rr := client.Post("/api/v1/swimmers", SwimmerInput{Name: "Alice", BirthDate: "2012-05-15", Gender: "female"})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
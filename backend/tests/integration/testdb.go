@@ -90,11 +90,14 @@ func (db *TestDB) CleanTables(t *testing.T) {
 
 	// Tables in order respecting foreign key constraints
 	tables := []string{
+		"standard_ladders",
 		"standard_times",
 		"time_standards",
+		"standard_families",
 		"times",
 		"meets",
 		"swimmers",
+		"points_base_times",
 	}
 
 	for _, table := range tables {
@@ -103,6 +106,11 @@ func (db *TestDB) CleanTables(t *testing.T) {
 			t.Logf("Warning: Failed to truncate %s: %v", table, err)
 		}
 	}
+
+	// Custom age-group schemes; the preloaded ones are kept
+	if _, err := db.Pool.Exec(ctx, "DELETE FROM age_group_schemes WHERE NOT is_preloaded"); err != nil {
+		t.Logf("Warning: Failed to delete age group schemes: %v", err)
+	}
 }
 
 // ExecSQL executes raw SQL for test setup.
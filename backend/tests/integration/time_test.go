@@ -10,11 +10,23 @@ import (
 )
 
 type TimeInput struct {
-	MeetID    string `json:"meet_id"`
-	Event     string `json:"event"`
-	TimeMS    int    `json:"time_ms"`
-	Notes     string `json:"notes,omitempty"`
-	EventDate string `json:"event_date"`
+	MeetID      string  `json:"meet_id"`
+	Event       string  `json:"event"`
+	TimeMS      int     `json:"time_ms"`
+	Notes       string  `json:"notes,omitempty"`
+	EventDate   string  `json:"event_date"`
+	Status      string  `json:"status,omitempty"`
+	DQCode      string  `json:"dq_code,omitempty"`
+	DQReason    string  `json:"dq_reason,omitempty"`
+	RelayLeg    int     `json:"relay_leg,omitempty"`
+	RelayStroke string  `json:"relay_stroke,omitempty"`
+	Splits      []Split `json:"splits,omitempty"`
+}
+
+type Split struct {
+	Distance      int    `json:"distance"`
+	TimeMS        int    `json:"time_ms"`
+	TimeFormatted string `json:"time_formatted,omitempty"`
 }
 
 type TimeBatchInput struct {
@@ -28,14 +40,23 @@ type TimeBatchInput struct {
 }
 
 type TimeRecord struct {
-	ID            string `json:"id"`
-	MeetID        string `json:"meet_id"`
-	Event         string `json:"event"`
-	TimeMS        int    `json:"time_ms"`
-	TimeFormatted string `json:"time_formatted"`
-	Notes         string `json:"notes,omitempty"`
-	IsPB          bool   `json:"is_pb,omitempty"`
-	Meet          *Meet  `json:"meet,omitempty"`
+	ID            string  `json:"id"`
+	MeetID        string  `json:"meet_id"`
+	Event         string  `json:"event"`
+	TimeMS        int     `json:"time_ms"`
+	TimeFormatted string  `json:"time_formatted"`
+	Notes         string  `json:"notes,omitempty"`
+	Status        string  `json:"status"`
+	DQCode        string  `json:"dq_code,omitempty"`
+	DQReason      string  `json:"dq_reason,omitempty"`
+	RelayLeg      int     `json:"relay_leg,omitempty"`
+	RelayStroke   string  `json:"relay_stroke,omitempty"`
+	OfficialEvent string  `json:"official_event,omitempty"`
+	IsPB          bool    `json:"is_pb,omitempty"`
+	Points        *int    `json:"points,omitempty"`
+	Grade         string  `json:"grade,omitempty"`
+	Splits        []Split `json:"splits,omitempty"`
+	Meet          *Meet   `json:"meet,omitempty"`
 }
 
 type TimeList struct {
@@ -367,6 +388,79 @@ func TestTimeAPI(t *testing.T) {
 
 		assert.Equal(t, http.StatusCreated, rr.Code)
 	})
+
+	t.Run("POST /times stores splits", func(t *testing.T) {
+		testDB.ClearTables(ctx, t)
+		_, meetID := setupSwimmerAndMeet(t, "25m")
+
+		input := TimeInput{
+			MeetID:    meetID,
+			Event:     "200FR",
+			TimeMS:    145000,
+			EventDate: "2026-03-15",
+			Splits: []Split{
+				{Distance: 50, TimeMS: 34000},
+				{Distance: 100, TimeMS: 71000},
+				{Distance: 150, TimeMS: 108500},
+				{Distance: 200, TimeMS: 145000},
+			},
+		}
+		rr := client.Post("/api/v1/times", input)
+		require.Equal(t, http.StatusCreated, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+
+		var created TimeRecord
+		AssertJSONBody(t, rr, &created)
+		assert.Len(t, created.Splits, 4)
+
+		rr = client.Get("/api/v1/times/" + created.ID)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var time TimeRecord
+		AssertJSONBody(t, rr, &time)
+		require.Len(t, time.Splits, 4)
+		assert.Equal(t, 50, time.Splits[0].Distance)
+		assert.Equal(t, "34.00", time.Splits[0].TimeFormatted)
+		assert.Equal(t, 200, time.Splits[3].Distance)
+		assert.Equal(t, 145000, time.Splits[3].TimeMS)
+
+		// Updating without splits keeps them
+		input.Splits = nil
+		input.Notes = "Final"
+		rr = client.Put("/api/v1/times/"+created.ID, input)
+		require.Equal(t, http.StatusOK, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+
+		var updated TimeRecord
+		AssertJSONBody(t, rr, &updated)
+		assert.Len(t, updated.Splits, 4)
+
+		// Changing the final time requires matching splits
+		input.TimeMS = 144000
+		rr = client.Put("/api/v1/times/"+created.ID, input)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("POST /times validates splits", func(t *testing.T) {
+		testDB.ClearTables(ctx, t)
+		_, meetID := setupSwimmerAndMeet(t, "25m")
+
+		testCases := []struct {
+			name   string
+			splits []Split
+		}{
+			{"last split differs from final time", []Split{{Distance: 50, TimeMS: 31000}, {Distance: 100, TimeMS: 65000}}},
+			{"last split short of event distance", []Split{{Distance: 50, TimeMS: 31000}}},
+			{"distances not increasing", []Split{{Distance: 50, TimeMS: 31000}, {Distance: 50, TimeMS: 40000}, {Distance: 100, TimeMS: 65320}}},
+			{"times not increasing", []Split{{Distance: 50, TimeMS: 70000}, {Distance: 100, TimeMS: 65320}}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				input := TimeInput{MeetID: meetID, Event: "100FR", TimeMS: 65320, EventDate: "2026-03-15", Splits: tc.splits}
+				rr := client.Post("/api/v1/times", input)
+				assert.Equal(t, http.StatusBadRequest, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+			})
+		}
+	})
 }
 
 func TestTimeBatchAPI(t *testing.T) {
@@ -526,6 +620,54 @@ func TestTimeBatchAPI(t *testing.T) {
 		assert.Equal(t, http.StatusConflict, rr.Code)
 		AssertJSONError(t, rr, "DUPLICATE_EVENT")
 	})
+
+	t.Run("POST /times/batch stores splits", func(t *testing.T) {
+		testDB.ClearTables(ctx, t)
+
+		swimmerInput := SwimmerInput{Name: "Test", BirthDate: "2012-05-15", Gender: "female"}
+		rr := client.Put("/api/v1/swimmer", swimmerInput)
+		require.True(t, rr.Code == http.StatusCreated || rr.Code == http.StatusOK)
+
+		meetInput := MeetInput{Name: "Meet", City: "Toronto", StartDate: "2026-03-15", CourseType: "25m"}
+		rr = client.Post("/api/v1/meets", meetInput)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var meet Meet
+		AssertJSONBody(t, rr, &meet)
+
+		input := map[string]interface{}{
+			"meet_id": meet.ID,
+			"times": []map[string]interface{}{
+				{"event": "50FR", "time_ms": 30000, "event_date": "2026-03-15"},
+				{"event": "100FR", "time_ms": 65320, "event_date": "2026-03-15", "splits": []Split{
+					{Distance: 50, TimeMS: 31500},
+					{Distance: 100, TimeMS: 65000},
+				}},
+			},
+		}
+
+		// The last split must equal the final time; nothing is created otherwise
+		rr = client.Post("/api/v1/times/batch", input)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+
+		rr = client.Get("/api/v1/times")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list TimeList
+		AssertJSONBody(t, rr, &list)
+		assert.Equal(t, 0, list.Total)
+
+		input["times"].([]map[string]interface{})[1]["splits"] = []Split{
+			{Distance: 50, TimeMS: 31500},
+			{Distance: 100, TimeMS: 65320},
+		}
+		rr = client.Post("/api/v1/times/batch", input)
+		require.Equal(t, http.StatusCreated, rr.Code, "got %d: %s", rr.Code, rr.Body.String())
+
+		var response BatchResponse
+		AssertJSONBody(t, rr, &response)
+		require.Len(t, response.Times, 2)
+		assert.Empty(t, response.Times[0].Splits)
+		assert.Len(t, response.Times[1].Splits, 2)
+	})
 }
 
 func TestTimeFormatting(t *testing.T) {
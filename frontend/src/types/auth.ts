@@ -11,6 +11,8 @@ export interface User {
   email: string;
   name?: string;
   access_level: AccessLevel;
+  /** User whose data a view-only user sees, the user itself otherwise. */
+  owner_id?: string;
 }
 
 /**